@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// accessibleKey is the context key recording the accessible mode of a
+// command
+type accessibleKey struct{}
+
+// applyAccessible records on cmd whether --accessible or the accessible
+// setting asks for screen-reader friendly output, so the rendering helpers
+// can check it with accessible
+func applyAccessible(cmd *cobra.Command, cfg *config.Config) {
+	if resolveBool(cmd, cfg, "accessible", config.KeyAccessible) {
+		cmd.SetContext(context.WithValue(cmd.Context(), accessibleKey{}, true))
+	}
+}
+
+// accessible reports whether cmd prints screen-reader friendly output:
+// plain sentences without art, borders, emoji or color
+func accessible(cmd *cobra.Command) bool {
+	if cmd.Context() == nil {
+		return false
+	}
+	on, _ := cmd.Context().Value(accessibleKey{}).(bool)
+	return on
+}
+
+// suggestAccessible points at the accessible mode on a dumb terminal, as
+// screen readers and braille displays often use, unless it was turned on
+// or off explicitly
+func suggestAccessible(cmd *cobra.Command, cfg *config.Config) {
+	if cmd.Flags().Changed("accessible") || cfg.IsSet(config.KeyAccessible) {
+		return
+	}
+	if term, _ := lookupEnv(cmd, "TERM"); term != "dumb" {
+		return
+	}
+	fmt.Fprintln(cmd.ErrOrStderr(),
+		"Tip: for screen-reader friendly output, pass --accessible or run 'hello-gopher config set accessible true' (set it to false to hide this tip)")
+}
+
+// attribution names the author of a proverb beneath it
+func attribution(cmd *cobra.Command, author string) string {
+	if accessible(cmd) {
+		return fmt.Sprintf("By %s.", author)
+	}
+	return "— " + author
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestAccessibleGreeting(t *testing.T) {
+	appDirs(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--art", "--emoji", "--color", "always", "--accessible")
+	if code != ExitSuccess {
+		t.Fatalf("greet --accessible failed with exit code %d: %s", code, stderr)
+	}
+	if stdout != "Hello, Gopher!\n" {
+		t.Errorf("greet --art --emoji --accessible = %q, want the greeting alone without color", stdout)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "gopher", "--bubble", "--accessible")
+	if stdout != "Hello, Gopher!\n" {
+		t.Errorf("gopher --bubble --accessible = %q, want the greeting alone", stdout)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "--bubble", "--with-source", "--seed", "1", "--accessible")
+	if strings.Contains(stdout, "—") || strings.Contains(stdout, "\\") || !strings.Contains(stdout, "By ") {
+		t.Errorf("proverb --bubble --with-source --accessible = %q, want plain sentences", stdout)
+	}
+}
+
+func TestAccessibleCard(t *testing.T) {
+	appDirs(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "card", "-n", "Alice", "--from", "The Team", "--accessible")
+	if code != ExitSuccess {
+		t.Fatalf("card --accessible failed with exit code %d: %s", code, stderr)
+	}
+	want := "Happy Birthday card for Alice.\nHappy birthday, Alice! May your builds be fast and your tests green.\nFrom The Team.\n"
+	if stdout != want {
+		t.Errorf("card --accessible = %q, want %q", stdout, want)
+	}
+}
+
+func TestAccessibleSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("accessible: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": path, "TERM": "dumb"})
+
+	stdout, stderr, _ := testsupport.RunCommand(t, "greet", "--art")
+	if stdout != "Hello, Gopher!\n" {
+		t.Errorf("greet --art with accessible: true = %q, want the greeting alone", stdout)
+	}
+	if strings.Contains(stderr, "Tip:") {
+		t.Errorf("the accessible mode was suggested although it is configured: %q", stderr)
+	}
+
+	// The flag wins over the setting
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "--art", "--accessible=false")
+	if !strings.Contains(stdout, "(o)") {
+		t.Errorf("greet --art --accessible=false = %q, want the art", stdout)
+	}
+
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":     filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_ACCESSIBLE": "true",
+	})
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "--art")
+	if stdout != "Hello, Gopher!\n" {
+		t.Errorf("greet --art with HELLO_GOPHER_ACCESSIBLE = %q, want the greeting alone", stdout)
+	}
+}
+
+func TestAccessibleSuggestedOnDumbTerminal(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": config, "TERM": "dumb"})
+
+	_, stderr, code := testsupport.RunCommand(t, "greet")
+	if code != ExitSuccess || !strings.Contains(stderr, "--accessible") {
+		t.Errorf("greet on TERM=dumb exited with %d, want a tip about --accessible: %q", code, stderr)
+	}
+	if _, stderr, _ := testsupport.RunCommand(t, "greet", "--accessible=false"); strings.Contains(stderr, "Tip:") {
+		t.Errorf("the tip was shown with --accessible=false: %q", stderr)
+	}
+
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": config, "TERM": "xterm-256color"})
+	if _, stderr, _ := testsupport.RunCommand(t, "greet"); strings.Contains(stderr, "Tip:") {
+		t.Errorf("the tip was shown on a capable terminal: %q", stderr)
+	}
+}
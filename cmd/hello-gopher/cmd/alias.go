@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// newAliasCmd creates the alias command and its subcommands
+func newAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Define shortcuts for commands you run often",
+		Long: `Alias command manages command aliases, which are saved in the config file
+as "alias.<name>: <command>" and expanded before the command line is run.
+Arguments after an alias are appended to its command, and an alias may use
+another alias. Aliases can't replace built-in commands.`,
+		Example: `  hello-gopher alias add hi greet --style casual
+  hello-gopher hi -n Bob                # Runs 'greet --style casual -n Bob'
+  hello-gopher alias add wisdom proverb --daily
+  hello-gopher alias add al -- greet -n Al  # The -- isn't saved
+  hello-gopher alias list
+  hello-gopher alias rm hi`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown alias subcommand: %s", args[0]),
+					"Run 'hello-gopher alias --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newAliasListCmd(), newAliasAddCmd(), newAliasRmCmd())
+	return cmd
+}
+
+// newAliasListCmd creates the alias list command
+func newAliasListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print every alias and its command",
+		Args:  exactArgs(0, "alias list doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			for _, alias := range cfg.Aliases() {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", alias.Name, alias.Command)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newAliasAddCmd creates the alias add command
+func newAliasAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name> <command> [args...]",
+		Short: "Define or replace an alias",
+		// Flags belong to the aliased command
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Without flag parsing, flags such as --config before the name
+			// still have to be applied
+			flags, args := splitLeadingFlags(cmd, args)
+			if err := cmd.Flags().Parse(flags); err != nil {
+				return NewUsageError(err.Error(), "Run 'hello-gopher alias add --help' for usage information")
+			}
+			if help, _ := cmd.Flags().GetBool("help"); help {
+				return cmd.Help()
+			}
+			// 'alias add hi -- greet -n Al' separates the command like
+			// other commands taking one; the -- isn't part of it
+			if len(args) > 1 && args[1] == "--" {
+				args = append(args[:1], args[2:]...)
+			}
+			if len(args) < 2 {
+				return NewUsageError(
+					fmt.Sprintf("alias add requires a name and a command (got %d arguments)", len(args)),
+					"Run 'hello-gopher alias add hi greet --style casual'",
+				)
+			}
+
+			name, command := args[0], joinCommandLine(args[1:])
+			root := cmd.Root()
+			if isCommand(root, name) {
+				return NewUsageError(
+					fmt.Sprintf("Alias %q would hide the %s command", name, name),
+					"Pick another name",
+				)
+			}
+
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			if err := cfg.SetAlias(name, command); err != nil {
+				return NewUsageError(err.Error(), "Run 'hello-gopher alias add hi greet --style casual'")
+			}
+
+			// The alias must lead to a command, without going in circles
+			words, err := expandAlias(root, cfg, []string{name})
+			if err != nil {
+				return err
+			}
+			if !isCommand(root, words[0]) {
+				return NewUsageError(
+					fmt.Sprintf("Unknown command in alias %s: %s", name, words[0]),
+					"Run 'hello-gopher --help' to see available commands",
+				)
+			}
+
+			if err := cfg.Save(path); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to write configuration to %s", path),
+					err,
+					"Check that the config directory is writable",
+				)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newAliasRmCmd creates the alias rm command
+func newAliasRmCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <name>",
+		Aliases: []string{"remove"},
+		Short:   "Delete an alias",
+		Args:    exactArgs(1, "alias rm requires exactly one alias name"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			if !cfg.RemoveAlias(args[0]) {
+				return NewUsageError(
+					fmt.Sprintf("No alias named %s", args[0]),
+					"Run 'hello-gopher alias list' to see your aliases",
+				)
+			}
+			if err := cfg.Save(path); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to write configuration to %s", path),
+					err,
+					"Check that the config directory is writable",
+				)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// resolveAliases expands an alias used as the command in args, the command
+// line of root without the program name. Global flags before the alias are
+// kept, and the config file they select is the one aliases are read from.
+// Command lines without an alias are returned as they are.
+func resolveAliases(root *cobra.Command, args []string) ([]string, error) {
+	flags, rest := splitLeadingFlags(root, args)
+	if len(rest) == 0 || rest[0] == "--" || isCommand(root, rest[0]) {
+		return args, nil
+	}
+
+	path := ""
+	for i, flag := range flags {
+		if flag == "--config" && i+1 < len(flags) {
+			path = flags[i+1]
+		} else if value, ok := strings.CutPrefix(flag, "--config="); ok {
+			path = value
+		}
+	}
+	if path == "" {
+		var err error
+		if path, err = configPath(root); err != nil {
+			// The command reports it if it needs the file
+			return args, nil
+		}
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read configuration: %v", err),
+			err,
+			"Fix the file with 'hello-gopher config edit' or remove it to restore defaults",
+		)
+	}
+
+	words, err := expandAlias(root, cfg, rest)
+	if err != nil {
+		return nil, err
+	}
+	return append(flags, words...), nil
+}
+
+// splitLeadingFlags splits args into the flags of cmd before the first other
+// argument, together with their values, and the rest
+func splitLeadingFlags(cmd *cobra.Command, args []string) (flags, rest []string) {
+	i := 0
+	for ; i < len(args) && strings.HasPrefix(args[i], "-") && args[i] != "-" && args[i] != "--"; i++ {
+		name, _, hasValue := strings.Cut(args[i], "=")
+		var flag *pflag.Flag
+		if long, ok := strings.CutPrefix(name, "--"); ok {
+			flag = lookupFlag(cmd, long)
+		} else if len(name) == 2 {
+			flag = cmd.Flags().ShorthandLookup(name[1:])
+			if flag == nil {
+				flag = cmd.InheritedFlags().ShorthandLookup(name[1:])
+			}
+		}
+		if flag != nil && flag.NoOptDefVal == "" && !hasValue && i+1 < len(args) {
+			i++
+		}
+	}
+	return args[:i:i], args[i:]
+}
+
+// lookupFlag returns the flag of cmd called name, including the flags it
+// inherits
+func lookupFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	for _, fs := range []*pflag.FlagSet{cmd.Flags(), cmd.PersistentFlags(), cmd.InheritedFlags()} {
+		if flag := fs.Lookup(name); flag != nil {
+			return flag
+		}
+	}
+	return nil
+}
+
+// expandAlias replaces the alias at the start of words by its command, and
+// repeats this while the command starts with another alias. Words that
+// don't start with an alias are returned as they are.
+func expandAlias(root *cobra.Command, cfg *config.Config, words []string) ([]string, error) {
+	var chain []string
+	for !isCommand(root, words[0]) {
+		command, ok := cfg.Alias(words[0])
+		if !ok {
+			break
+		}
+		for _, seen := range chain {
+			if seen == words[0] {
+				return nil, NewUsageError(
+					fmt.Sprintf("Alias cycle: %s -> %s", strings.Join(chain, " -> "), words[0]),
+					"Change one of the aliases with 'hello-gopher alias add' or 'hello-gopher alias rm'",
+				)
+			}
+		}
+		chain = append(chain, words[0])
+
+		expansion, err := splitCommandLine(command)
+		if err != nil || len(expansion) == 0 {
+			if err == nil {
+				err = errors.New("no command")
+			}
+			return nil, NewUsageError(
+				fmt.Sprintf("Invalid alias %s: %v", words[0], err),
+				fmt.Sprintf("Redefine it with 'hello-gopher alias add %s <command>'", words[0]),
+			)
+		}
+		words = append(expansion, words[1:]...)
+	}
+	return words, nil
+}
+
+// isCommand reports whether name selects a command of root, including the
+// help and completion commands cobra adds when it runs
+func isCommand(root *cobra.Command, name string) bool {
+	if name == "help" || name == "completion" || strings.HasPrefix(name, "__") {
+		return true
+	}
+	// Find keeps the order of the commands, which Commands would sort
+	c, _, err := root.Find([]string{name})
+	return err == nil && c != root
+}
+
+// splitCommandLine splits s into words like a POSIX shell without
+// expansions: words are separated by spaces, single quotes keep everything
+// up to the closing quote, and double quotes and backslashes escape the next
+// character
+func splitCommandLine(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\' && (quote == 0 || quote == '"'):
+			if i+1 == len(runes) {
+				return nil, errors.New("trailing backslash")
+			}
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// joinCommandLine joins words into a command line that splitCommandLine
+// splits into the same words
+func joinCommandLine(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if w != "" && !strings.ContainsAny(w, " \t\n'\"\\") {
+			quoted[i] = w
+			continue
+		}
+		quoted[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(w) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+// aliasConfig writes a config file with content and points the CLI at it
+func aliasConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": path})
+	return path
+}
+
+func TestAliasAddListRm(t *testing.T) {
+	aliasConfig(t, "")
+
+	runConfig(t, "alias", "add", "hi", "greet", "--style", "casual")
+	runConfig(t, "alias", "add", "mary", "greet", "--name", "Mary Ann")
+	runConfig(t, "alias", "add", "wisdom", "proverb", "--daily")
+
+	out := runConfig(t, "alias", "list")
+	want := "hi: greet --style casual\nmary: greet --name \"Mary Ann\"\nwisdom: proverb --daily\n"
+	if out != want {
+		t.Errorf("alias list = %q, want %q", out, want)
+	}
+
+	runConfig(t, "alias", "rm", "wisdom")
+	if out := runConfig(t, "alias", "list"); strings.Contains(out, "wisdom") {
+		t.Errorf("alias rm didn't remove the alias:\n%s", out)
+	}
+	if _, _, code := testsupport.RunCommand(t, "alias", "rm", "wisdom"); code != ExitUsageError {
+		t.Errorf("alias rm of a missing alias exited with %d, want %d", code, ExitUsageError)
+	}
+}
+
+func TestAliasAddDashDash(t *testing.T) {
+	aliasConfig(t, "")
+
+	runConfig(t, "alias", "add", "hi", "--", "greet", "-n", "Al")
+	if out := runConfig(t, "alias", "list"); out != "hi: greet -n Al\n" {
+		t.Errorf("alias list = %q, want %q", out, "hi: greet -n Al\n")
+	}
+	if out := runConfig(t, "hi"); strings.TrimSpace(out) != "Hello, Al!" {
+		t.Errorf("hello-gopher hi = %q, want %q", out, "Hello, Al!")
+	}
+
+	// Only one -- is dropped, so the command would start with the other
+	for _, args := range [][]string{{"alias", "add", "hi", "--", "--", "greet"}, {"alias", "add", "hi", "--"}} {
+		if _, stderr, code := testsupport.RunCommand(t, args...); code != ExitUsageError {
+			t.Errorf("hello-gopher %s exited with %d, want %d: %s", strings.Join(args, " "), code, ExitUsageError, stderr)
+		}
+	}
+	if out := runConfig(t, "alias", "list"); out != "hi: greet -n Al\n" {
+		t.Errorf("a failed alias add changed the alias: %q", out)
+	}
+}
+
+func TestAliasChain(t *testing.T) {
+	aliasConfig(t, "alias.w: proverb\n")
+
+	// x expands through w to 'proverb --seed 1'
+	runConfig(t, "alias", "add", "x", "w", "--seed", "1")
+	want := runConfig(t, "proverb", "--seed", "1")
+	if out := runConfig(t, "x"); out != want {
+		t.Errorf("hello-gopher x = %q, want the output of proverb --seed 1 %q", out, want)
+	}
+	if out := runConfig(t, "alias", "list"); out != "w: proverb\nx: w --seed 1\n" {
+		t.Errorf("alias list = %q", out)
+	}
+}
+
+func TestAliasRun(t *testing.T) {
+	aliasConfig(t, "alias.hi: greet --style casual\nalias.yo: hi --lang es\nalias.mary: greet --name \"Mary Ann\"\n")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"hi", "-n", "Bob"}, "Hey, Bob"},
+		{[]string{"yo", "-n", "Bob"}, "Bob"},
+		{[]string{"mary"}, "Mary Ann"},
+		{[]string{"--verbose", "hi", "-n", "Bob"}, "Hey, Bob"},
+	}
+	for _, tt := range tests {
+		out := runConfig(t, tt.args...)
+		if !strings.Contains(out, tt.want) {
+			t.Errorf("hello-gopher %s = %q, want it to contain %q", strings.Join(tt.args, " "), out, tt.want)
+		}
+	}
+
+	// Built-in commands win over aliases in the file
+	if out := runConfig(t, "greet", "-n", "Bob"); strings.Contains(out, "Hey") {
+		t.Errorf("greet used an alias: %q", out)
+	}
+}
+
+func TestAliasCycle(t *testing.T) {
+	aliasConfig(t, "alias.a: b --x\nalias.b: a\n")
+
+	_, stderr, code := testsupport.RunCommand(t, "a")
+	if code != ExitUsageError || !strings.Contains(stderr, "Alias cycle: a -> b -> a") {
+		t.Errorf("alias cycle exited with %d: %s", code, stderr)
+	}
+
+	// Adding an alias that closes a cycle is refused before it's saved
+	path := aliasConfig(t, "alias.a: b\n")
+	if _, _, code := testsupport.RunCommand(t, "alias", "add", "b", "a"); code != ExitUsageError {
+		t.Errorf("alias add closing a cycle exited with %d, want %d", code, ExitUsageError)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "alias.b") {
+		t.Errorf("cyclic alias was saved:\n%s", data)
+	}
+}
+
+func TestAliasAddInvalid(t *testing.T) {
+	aliasConfig(t, "")
+
+	for _, args := range [][]string{
+		{"alias", "add", "greet", "proverb"},
+		{"alias", "add", "hi"},
+		{"alias", "add", "hi", "nosuchcommand"},
+		{"alias", "add", "two words", "greet"},
+	} {
+		if _, stderr, code := testsupport.RunCommand(t, args...); code != ExitUsageError {
+			t.Errorf("hello-gopher %s exited with %d, want %d: %s", strings.Join(args, " "), code, ExitUsageError, stderr)
+		}
+	}
+}
+
+func TestCommandLineRoundTrip(t *testing.T) {
+	for _, words := range [][]string{
+		{"greet", "--style", "casual"},
+		{"greet", "--name", "Mary Ann"},
+		{"greet", "-n", `it's "quoted" \ here`},
+		{"greet", ""},
+	} {
+		got, err := splitCommandLine(joinCommandLine(words))
+		if err != nil || !reflect.DeepEqual(got, words) {
+			t.Errorf("splitCommandLine(joinCommandLine(%q)) = %q, %v", words, got, err)
+		}
+	}
+
+	for _, line := range []string{`greet "open`, `greet 'open`, `greet \`} {
+		if _, err := splitCommandLine(line); err == nil {
+			t.Errorf("splitCommandLine(%q) accepted an invalid command line", line)
+		}
+	}
+}
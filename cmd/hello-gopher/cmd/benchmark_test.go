@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -14,7 +15,7 @@ func BenchmarkGreetCommand(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			cmd := &cobra.Command{
 				Use: "greet",
-				RunE: greetCmd.RunE,
+				RunE: newGreetCmd(Deps{}).RunE,
 			}
 			cmd.Flags().StringP("name", "n", "", "Name to greet")
 			cmd.SetOut(bytes.NewBuffer(nil))
@@ -28,7 +29,7 @@ func BenchmarkGreetCommand(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			cmd := &cobra.Command{
 				Use: "greet",
-				RunE: greetCmd.RunE,
+				RunE: newGreetCmd(Deps{}).RunE,
 			}
 			cmd.Flags().StringP("name", "n", "", "Name to greet")
 			cmd.SetOut(bytes.NewBuffer(nil))
@@ -42,7 +43,7 @@ func BenchmarkGreetCommand(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			cmd := &cobra.Command{
 				Use: "greet",
-				RunE: greetCmd.RunE,
+				RunE: newGreetCmd(Deps{}).RunE,
 			}
 			cmd.Flags().StringP("name", "n", "", "Name to greet")
 			cmd.SetOut(bytes.NewBuffer(nil))
@@ -58,7 +59,7 @@ func BenchmarkProverbCommand(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		cmd := &cobra.Command{
 			Use: "proverb",
-			RunE: proverbCmd.RunE,
+			RunE: newProverbCmd(Deps{}).RunE,
 		}
 		cmd.SetOut(bytes.NewBuffer(nil))
 		cmd.SetErr(bytes.NewBuffer(nil))
@@ -72,7 +73,7 @@ func BenchmarkRootCommandVersion(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		cmd := &cobra.Command{
 			Use: "hello-gopher",
-			RunE: rootCmd.RunE,
+			RunE: NewRootCmd(Deps{}).RunE,
 		}
 		cmd.Flags().BoolP("version", "v", false, "version info")
 		cmd.SetOut(bytes.NewBuffer(nil))
@@ -87,7 +88,7 @@ func BenchmarkRootCommandHelp(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		cmd := &cobra.Command{
 			Use: "hello-gopher",
-			RunE: rootCmd.RunE,
+			RunE: NewRootCmd(Deps{}).RunE,
 		}
 		cmd.SetOut(bytes.NewBuffer(nil))
 		cmd.SetErr(bytes.NewBuffer(nil))
@@ -126,7 +127,7 @@ func BenchmarkCommandCreation(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			cmd := &cobra.Command{
 				Use: "greet",
-				RunE: greetCmd.RunE,
+				RunE: newGreetCmd(Deps{}).RunE,
 			}
 			cmd.Flags().StringP("name", "n", "", "Name to greet")
 			_ = cmd
@@ -137,7 +138,7 @@ func BenchmarkCommandCreation(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			cmd := &cobra.Command{
 				Use: "proverb",
-				RunE: proverbCmd.RunE,
+				RunE: newProverbCmd(Deps{}).RunE,
 			}
 			_ = cmd
 		}
@@ -147,7 +148,7 @@ func BenchmarkCommandCreation(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			cmd := &cobra.Command{
 				Use: "version",
-				Run: versionCmd.Run,
+				RunE: newVersionCmd().RunE,
 			}
 			_ = cmd
 		}
@@ -202,16 +203,7 @@ func formatVersionInfo(version, buildDate, gitCommit string) string {
 func BenchmarkCommandExecution(b *testing.B) {
 	b.Run("FullGreetPipeline", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			rootCmd := &cobra.Command{Use: "hello-gopher"}
-			greetCmd := &cobra.Command{
-				Use: "greet",
-				RunE: greetCmd.RunE,
-			}
-			greetCmd.Flags().StringP("name", "n", "", "Name to greet")
-			rootCmd.AddCommand(greetCmd)
-			
-			rootCmd.SetOut(bytes.NewBuffer(nil))
-			rootCmd.SetErr(bytes.NewBuffer(nil))
+			rootCmd := NewRootCmd(Deps{Out: io.Discard, Err: io.Discard})
 			rootCmd.SetArgs([]string{"greet", "--name", "BenchUser"})
 			_ = rootCmd.Execute()
 		}
@@ -219,15 +211,7 @@ func BenchmarkCommandExecution(b *testing.B) {
 	
 	b.Run("FullProverbPipeline", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			rootCmd := &cobra.Command{Use: "hello-gopher"}
-			proverbCmd := &cobra.Command{
-				Use: "proverb",
-				RunE: proverbCmd.RunE,
-			}
-			rootCmd.AddCommand(proverbCmd)
-			
-			rootCmd.SetOut(bytes.NewBuffer(nil))
-			rootCmd.SetErr(bytes.NewBuffer(nil))
+			rootCmd := NewRootCmd(Deps{Out: io.Discard, Err: io.Discard})
 			rootCmd.SetArgs([]string{"proverb"})
 			_ = rootCmd.Execute()
 		}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newCacheCmd creates the cache command and its subcommands
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage cached files",
+		Long: `Cache command manages the files hello-gopher caches, such as the result of
+the last version --check. Cached files are fetched again when missing.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown cache subcommand: %s", args[0]),
+					"Run 'hello-gopher cache --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCacheClearCmd())
+	return cmd
+}
+
+// newCacheClearCmd creates the cache clear command
+func newCacheClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove every cached file",
+		Long: `Clear removes the files hello-gopher keeps in its cache directory, for
+example to force a fresh update check. Run 'hello-gopher data path cache' to
+see where the cache lives.`,
+		Args: exactArgs(0, "cache clear doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return removeAppFiles(cmd, []string{dirCache}, false, "The cache is already empty")
+		},
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/art"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/card"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// cardResult is the JSON output of card
+type cardResult struct {
+	Occasion string `json:"occasion"`
+	Name     string `json:"name"`
+	Card     string `json:"card"`
+	// Path is the image the card was written to, with --out
+	Path string `json:"path,omitempty"`
+}
+
+// newCardCmd creates the card command
+func newCardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "card",
+		Short: "Render a greeting card for a celebration",
+		Long: `Card renders a greeting card with a border, a gopher and a message for a
+birthday, work anniversary or another occasion, for celebration bots and
+team chats.
+
+The card is printed as text, colored on a terminal. With --out it is also
+written as an image, PNG or SVG by the extension of the file, ready to be
+posted to a chat.
+
+The name honors the name setting like greet does.`,
+		Example: `  hello-gopher card --name Alice --occasion birthday
+  hello-gopher card -n Bob --occasion farewell --from "The Platform Team"
+  hello-gopher card -n Alice --occasion anniversary --out card.png
+  hello-gopher card -n Carol --occasion welcome --variant wave --out card.svg`,
+		Args: exactArgs(0, "card doesn't accept positional arguments; pass the name with --name"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			c, err := newCard(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			result := cardResult{Occasion: c.Occasion.Name, Name: c.Name, Card: c.Render(card.Style{})}
+
+			if path, _ := cmd.Flags().GetString("out"); path != "" {
+				if err := writeCardImage(c, path); err != nil {
+					return err
+				}
+				result.Path = path
+			}
+
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			if accessible(cmd) {
+				fmt.Fprint(cmd.OutOrStdout(), c.Plain())
+			} else {
+				fmt.Fprint(cmd.OutOrStdout(), c.Render(cardStyle(styler)))
+			}
+			if result.Path != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %s\n", result.Path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("name", "n", "", "Name on the card (default: Gopher)")
+	cmd.Flags().String("occasion", "birthday", fmt.Sprintf("What the card celebrates (%s)", strings.Join(card.Occasions(), ", ")))
+	cmd.Flags().String("from", "", "Sign the card, such as \"The Platform Team\"")
+	cmd.Flags().String("variant", art.DefaultVariant, fmt.Sprintf("Art variant (%s)", strings.Join(art.Variants(), ", ")))
+	cmd.Flags().Int("width", card.DefaultWidth, "Width of the inside of the card")
+	cmd.Flags().String("out", "", "Also write the card as an image, a .png or .svg file")
+	return cmd
+}
+
+// newCard builds the card requested by the flags of cmd
+func newCard(cmd *cobra.Command, cfg *config.Config) (card.Card, error) {
+	occasionName, _ := cmd.Flags().GetString("occasion")
+	occasion, err := card.ParseOccasion(occasionName)
+	if err != nil {
+		return card.Card{}, NewUsageError(err.Error(), "Pass one of the occasions with --occasion")
+	}
+
+	name := resolveString(cmd, cfg, "name", config.KeyName)
+	if err := checkUTF8(cmd, cfg, "name", name); err != nil {
+		return card.Card{}, err
+	}
+	maxNameLen, err := resolveMaxNameLength(cmd, cfg)
+	if err != nil {
+		return card.Card{}, err
+	}
+	name, _ = greeting.TruncateName(greeting.SanitizeName(name), maxNameLen)
+
+	from, _ := cmd.Flags().GetString("from")
+	width, _ := cmd.Flags().GetInt("width")
+	if width < 1 {
+		return card.Card{}, NewUsageError(fmt.Sprintf("Invalid --width: %d", width), "Use a positive number of columns")
+	}
+	variant, _ := cmd.Flags().GetString("variant")
+	a, err := loadArt(variant)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	return card.Card{
+		Occasion: occasion,
+		Name:     name,
+		From:     greeting.SanitizeName(from),
+		Art:      a,
+		Width:    width,
+	}, nil
+}
+
+// cardStyle colors a card like the rest of the output of styler
+func cardStyle(styler color.Styler) card.Style {
+	return card.Style{
+		Border: func(s string) string { return styler.Paint(s, color.Cyan) },
+		Title:  func(s string) string { return styler.Paint(s, color.Bold, color.Magenta) },
+	}
+}
+
+// writeCardImage writes c as an image to path in the format of its
+// extension
+func writeCardImage(c card.Card, path string) error {
+	format, err := card.FormatOf(path)
+	if err != nil {
+		return NewUsageError(err.Error(), "Pass a file ending in .png or .svg to --out")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return NewSystemError(
+			fmt.Sprintf("Failed to create %s", path),
+			err,
+			"Check that the directory exists and is writable",
+		)
+	}
+	if err := c.WriteImage(f, format); err != nil {
+		f.Close()
+		return NewSystemError(fmt.Sprintf("Failed to write %s", path), err, "")
+	}
+	if err := f.Close(); err != nil {
+		return NewSystemError(fmt.Sprintf("Failed to write %s", path), err, "")
+	}
+	return nil
+}
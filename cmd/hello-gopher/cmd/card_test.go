@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestCardCommand(t *testing.T) {
+	appDirs(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "card", "--name", "Alice", "--occasion", "farewell", "--from", "The Team", "--color", "never")
+	if code != ExitSuccess {
+		t.Fatalf("card failed with exit code %d: %s", code, stderr)
+	}
+	for _, want := range []string{"FAREWELL", "Goodbye and good luck, Alice!", "- The Team", "+---"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("card output doesn't contain %q:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "\x1b[") {
+		t.Errorf("--color never output contains escape codes:\n%q", stdout)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "card", "--occasion", "wedding")
+	if code != ExitUsageError || !strings.Contains(stderr, "birthday") {
+		t.Errorf("unknown occasion: exit code %d, stderr %q; want a usage error listing the occasions", code, stderr)
+	}
+	_, _, code = testsupport.RunCommand(t, "card", "--width", "0")
+	if code != ExitUsageError {
+		t.Errorf("--width 0: exit code %d, want %d", code, ExitUsageError)
+	}
+}
+
+func TestCardCommandImage(t *testing.T) {
+	appDirs(t)
+	dir := t.TempDir()
+
+	for _, name := range []string{"card.png", "card.svg"} {
+		path := filepath.Join(dir, name)
+		stdout, stderr, code := testsupport.RunCommand(t, "card", "-n", "Bob", "--out", path, "--output", "json")
+		if code != ExitSuccess {
+			t.Fatalf("card --out %s failed with exit code %d: %s", name, code, stderr)
+		}
+		var result cardResult
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("output isn't JSON: %v\n%s", err, stdout)
+		}
+		if result.Occasion != "birthday" || result.Name != "Bob" || result.Path != path || !strings.Contains(result.Card, "Bob") {
+			t.Errorf("card --output json = %+v", result)
+		}
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("card --out didn't write %s: %v", name, err)
+		}
+	}
+
+	_, _, code := testsupport.RunCommand(t, "card", "--out", filepath.Join(dir, "card.gif"))
+	if code != ExitUsageError {
+		t.Errorf("--out card.gif: exit code %d, want %d", code, ExitUsageError)
+	}
+	_, _, code = testsupport.RunCommand(t, "card", "--out", filepath.Join(dir, "missing", "card.png"))
+	if code != ExitSystemError {
+		t.Errorf("--out into a missing directory: exit code %d, want %d", code, ExitSystemError)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	testsupport.SetRunner(runCLI)
+}
+
+// testDeps completes deps for a run in a test. Unless the test chose a
+// config file and directories with withEnv, the run reads a missing config
+// and writes to empty temporary directories, so the developer's own
+// settings and files can't leak in. Telemetry is sent in the test process
+// instead of a background one.
+func testDeps(t testing.TB, deps Deps) Deps {
+	env := testEnv
+	if env == nil {
+		env = os.LookupEnv
+	}
+	isolated := map[string]string{
+		config.EnvConfig:   filepath.Join(t.TempDir(), "missing.yaml"),
+		config.EnvCacheDir: t.TempDir(),
+		config.EnvDataDir:  t.TempDir(),
+		config.EnvStateDir: t.TempDir(),
+	}
+	if deps.LookupEnv == nil {
+		deps.LookupEnv = func(key string) (string, bool) {
+			if v, ok := env(key); ok {
+				return v, ok
+			}
+			v, ok := isolated[key]
+			return v, ok
+		}
+	}
+	if deps.StartTelemetryFlush == nil {
+		deps.StartTelemetryFlush = flushTelemetry
+	}
+	return deps
+}
+
+// useTestDeps gives cmd, executed without the root command, the Deps of
+// testDeps
+func useTestDeps(t testing.TB, cmd *cobra.Command) *cobra.Command {
+	cmd.SetContext(withDeps(context.Background(), testDeps(t, Deps{})))
+	return cmd
+}
+
+// runCLI runs a fresh command tree with args like Execute does and returns
+// the exit code
+func runCLI(t testing.TB, args []string, stdout, stderr io.Writer) int {
+	root := NewRootCmd(testDeps(t, Deps{In: strings.NewReader(""), Out: stdout, Err: stderr}))
+	args, err := resolveAliases(root, args)
+	if err == nil {
+		root.SetArgs(args)
+		err = root.Execute()
+	}
+
+	code := ExitSuccess
+	HandleErrorWith(err, func(c int) { code = c }, stderr)
+	return code
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/playlist"
+	"github.com/spf13/cobra"
+)
+
+// newConfigCmd creates the config command and its subcommands
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and change hello-gopher configuration",
+		Long: `Config command manages the hello-gopher configuration file.
+
+The file provides defaults for every command. Values are resolved with the
+following precedence: command-line flag > environment variable > config file >
+built-in default. The config file itself can be selected with --config or
+$HELLO_GOPHER_CONFIG, and HELLO_GOPHER_NO_COLOR=1 is shorthand for
+HELLO_GOPHER_COLOR=never.
+
+Supported keys:
+` + configKeyHelp(),
+		Example: `  hello-gopher config list              # Show every setting
+  hello-gopher config get language      # Show a single setting
+  hello-gopher config set name Alice    # Greet Alice by default
+  hello-gopher config edit              # Open the file in $EDITOR`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown config subcommand: %s", args[0]),
+					"Run 'hello-gopher config --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newConfigGetCmd(), newConfigSetCmd(), newConfigListCmd(), newConfigEditCmd(), newConfigPathCmd())
+	return cmd
+}
+
+// newConfigGetCmd creates the config get command
+func newConfigGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Args:  exactArgs(1, "config get requires exactly one key"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			value, err := getSetting(cfg, args[0])
+			if err != nil {
+				return NewUsageError(err.Error(), "Run 'hello-gopher config list' to see available keys")
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newConfigSetCmd creates the config set command
+func newConfigSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting in the config file",
+		Args:  exactArgs(2, "config set requires a key and a value"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := setSetting(cfg, args[0], args[1]); err != nil {
+				return NewUsageError(err.Error(), "Run 'hello-gopher config --help' to see supported keys and values")
+			}
+
+			if err := cfg.Save(path); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to write configuration to %s", path),
+					err,
+					"Check that the config directory is writable",
+				)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// getSetting returns the effective value of key in cfg. Besides the fixed
+// keys it reads alias.<name> and playlist.<name>.
+func getSetting(cfg *config.Config, key string) (string, error) {
+	if name, ok := strings.CutPrefix(key, config.AliasPrefix); ok {
+		if command, ok := cfg.Alias(name); ok {
+			return command, nil
+		}
+		return "", fmt.Errorf("alias %q is not set", name)
+	}
+	if name, ok := strings.CutPrefix(key, config.PlaylistPrefix); ok {
+		if items, ok := cfg.Playlist(name); ok {
+			return items, nil
+		}
+		return "", fmt.Errorf("playlist %q is not set", name)
+	}
+	return cfg.Get(key)
+}
+
+// setSetting validates and stores value for key in cfg. Besides the fixed
+// keys it sets alias.<name> and playlist.<name>.
+func setSetting(cfg *config.Config, key, value string) error {
+	if name, ok := strings.CutPrefix(key, config.AliasPrefix); ok {
+		return cfg.SetAlias(name, value)
+	}
+	if name, ok := strings.CutPrefix(key, config.PlaylistPrefix); ok {
+		if _, err := playlist.Parse(name, value); err != nil {
+			return err
+		}
+		cfg.SetPlaylist(name, value)
+		return nil
+	}
+	return cfg.Set(key, value)
+}
+
+// newConfigListCmd creates the config list command
+func newConfigListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print every setting and its effective value",
+		Args:  exactArgs(0, "config list doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			for _, spec := range config.Specs() {
+				line := fmt.Sprintf("%s: %s", spec.Key, cfg.Value(spec.Key))
+				switch cfg.Source(spec.Key) {
+				case config.SourceDefault:
+					line += "  # default"
+				case config.SourceEnv:
+					line += "  # from $" + cfg.EnvSource(spec.Key)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), line)
+			}
+			for _, key := range cfg.UnknownKeys() {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: ?  # unknown key, ignored\n", key)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newConfigEditCmd creates the config edit command
+func newConfigEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Open the config file in $VISUAL or $EDITOR",
+		Args:  exactArgs(0, "config edit doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+
+			// Make sure there is a file to edit
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				if err := config.New().Save(path); err != nil {
+					return NewSystemError(fmt.Sprintf("Failed to create %s", path), err, "Check that the config directory is writable")
+				}
+			}
+
+			editor := strings.Fields(editorCommand())
+			// #nosec G204 -- the editor is chosen by the user via $VISUAL/$EDITOR
+			editCmd := exec.Command(editor[0], append(editor[1:], path)...)
+			editCmd.Stdin = os.Stdin
+			editCmd.Stdout = cmd.OutOrStdout()
+			editCmd.Stderr = cmd.ErrOrStderr()
+			if err := editCmd.Run(); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Editor %q failed", editor[0]),
+					err,
+					"Set $EDITOR to your preferred editor",
+				)
+			}
+
+			// Validate the result so mistakes are reported right away
+			_, err = loadConfig(cmd)
+			return err
+		},
+	}
+	return cmd
+}
+
+// newConfigPathCmd creates the config path command
+func newConfigPathCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "path",
+		Short: "Print the location of the config file",
+		Args:  exactArgs(0, "config path doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), path)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// editorCommand returns the user's preferred editor
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// configKeyHelp renders the supported keys for the config help text
+func configKeyHelp() string {
+	var b strings.Builder
+	for _, spec := range config.Specs() {
+		fmt.Fprintf(&b, "  %-10s %s (default: %s, env: %s)\n", spec.Key, spec.Description, spec.Default, config.EnvName(spec.Key))
+	}
+	return b.String()
+}
+
+// exactArgs is like cobra.ExactArgs but reports a usage error with guidance
+func exactArgs(n int, message string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != n {
+			return NewUsageError(
+				fmt.Sprintf("%s (got %d)", message, len(args)),
+				fmt.Sprintf("Run '%s --help' for usage information", cmd.CommandPath()),
+			)
+		}
+		return nil
+	}
+}
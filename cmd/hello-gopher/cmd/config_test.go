@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/spf13/cobra"
+)
+
+// runConfig runs the CLI with args and fails the test unless it succeeds
+func runConfig(t *testing.T, args ...string) string {
+	t.Helper()
+	stdout, stderr, code := testsupport.RunCommand(t, args...)
+	if code != ExitSuccess {
+		t.Fatalf("hello-gopher %s exited with %d: %s", strings.Join(args, " "), code, stderr)
+	}
+	return stdout
+}
+
+func TestConfigSetGetList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	runConfig(t, "config", "set", "name", "Alice", "--config", path)
+
+	out := runConfig(t, "config", "get", "name", "--config", path)
+	if strings.TrimSpace(out) != "Alice" {
+		t.Errorf("config get name = %q, want %q", strings.TrimSpace(out), "Alice")
+	}
+
+	out = runConfig(t, "config", "list", "--config", path)
+	for _, want := range []string{"name: Alice\n", "language: en  # default", "output: text  # default"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("config list output missing %q, got:\n%s", want, out)
+		}
+	}
+
+	out = runConfig(t, "config", "path", "--config", path)
+	if strings.TrimSpace(out) != path {
+		t.Errorf("config path = %q, want %q", strings.TrimSpace(out), path)
+	}
+}
+
+func TestConfigSetGetNamedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	runConfig(t, "config", "set", "playlist.x", "[4, errors]", "--config", path)
+	runConfig(t, "config", "set", "alias.hi", "greet -n Al", "--config", path)
+
+	if out := runConfig(t, "config", "get", "playlist.x", "--config", path); strings.TrimSpace(out) != "[4, errors]" {
+		t.Errorf("config get playlist.x = %q, want %q", strings.TrimSpace(out), "[4, errors]")
+	}
+	if out := runConfig(t, "config", "get", "alias.hi", "--config", path); strings.TrimSpace(out) != "greet -n Al" {
+		t.Errorf("config get alias.hi = %q, want %q", strings.TrimSpace(out), "greet -n Al")
+	}
+
+	// The playlist set is the one proverb --playlist plays
+	var proverb struct{ ID int }
+	out := runConfig(t, "proverb", "--playlist", "x", "--output", "json", "--config", path)
+	if err := json.Unmarshal([]byte(out), &proverb); err != nil || proverb.ID != 4 {
+		t.Errorf("proverb --playlist x = %s, want proverb #4 first", out)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{"unknown key on get", []string{"config", "get", "nope"}, ExitUsageError},
+		{"invalid value on set", []string{"config", "set", "output", "xml"}, ExitUsageError},
+		{"missing value on set", []string{"config", "set", "name"}, ExitUsageError},
+		{"unset playlist on get", []string{"config", "get", "playlist.nope"}, ExitUsageError},
+		{"empty playlist on set", []string{"config", "set", "playlist.x", "[]"}, ExitUsageError},
+		{"invalid playlist name on set", []string{"config", "set", "playlist.-x", "[4]"}, ExitUsageError},
+		{"unset alias on get", []string{"config", "get", "alias.nope"}, ExitUsageError},
+		{"invalid alias name on set", []string{"config", "set", "alias.a b", "greet"}, ExitUsageError},
+		{"unknown subcommand", []string{"config", "bogus"}, ExitUsageError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, append(tt.args, "--config", path)...)
+			if code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d (stderr %q)", tt.wantCode, code, stderr)
+			}
+		})
+	}
+}
+
+func TestConfigMalformedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("language: klingon\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, code := testsupport.RunCommand(t, "config", "list", "--config", path)
+	if code != ExitDataError {
+		t.Errorf("Expected data error for malformed config, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestGreetRespectsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	for _, kv := range [][2]string{{"language", "es"}, {"style", "formal"}, {"name", "Config"}} {
+		runConfig(t, "config", "set", kv[0], kv[1], "--config", path)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want greetResult
+	}{
+		{
+			name: "config values",
+			args: []string{"greet"},
+			want: greetResult{Greeting: "Buenos días, Config.", Name: "Config"},
+		},
+		{
+			name: "flags override config",
+			args: []string{"greet", "--name", "Flag", "--lang", "en", "--style", "casual"},
+			want: greetResult{Greeting: "Hey, Flag!", Name: "Flag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runConfig(t, append(tt.args, "--config", path, "--output", "json")...)
+
+			var got greetResult
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("greet --output json produced invalid JSON %q: %v", out, err)
+			}
+			if got != tt.want {
+				t.Errorf("greet = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testEnv is the fake environment set with withEnv, which testDeps gives
+// the commands of a test instead of the process environment
+var testEnv config.LookupEnvFunc
+
+// withEnv makes vars the whole environment of the commands run for the
+// duration of the test
+func withEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+	original := testEnv
+	testEnv = func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+	t.Cleanup(func() { testEnv = original })
+}
+
+func TestGreetRespectsEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("name: File\nlanguage: de\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG": path,
+		"HELLO_GOPHER_NAME":   "Env",
+		"HELLO_GOPHER_OUTPUT": "json",
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want greetResult
+	}{
+		{"env overrides file", []string{"greet"}, greetResult{Greeting: "Hallo, Env!", Name: "Env"}},
+		{"flag overrides env", []string{"greet", "-n", "Flag"}, greetResult{Greeting: "Hallo, Flag!", Name: "Flag"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runConfig(t, tt.args...)
+			var got greetResult
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("expected JSON output from HELLO_GOPHER_OUTPUT, got %q: %v", out, err)
+			}
+			if got != tt.want {
+				t.Errorf("greet = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidEnvironmentIsUsageError(t *testing.T) {
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml"),
+		"HELLO_GOPHER_STYLE":  "grumpy",
+	})
+
+	_, stderr, code := testsupport.RunCommand(t, "greet")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error for invalid env, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestColorResolution(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		args      []string
+		wantColor bool
+		wantErr   bool
+	}{
+		{"auto mode is plain when not a terminal", nil, nil, false, false},
+		{"flag forces color", nil, []string{"--color", "always"}, true, false},
+		{"env forces color", map[string]string{"HELLO_GOPHER_COLOR": "always"}, nil, true, false},
+		{"flag beats env", map[string]string{"HELLO_GOPHER_COLOR": "always"}, []string{"--color", "never"}, false, false},
+		{"NO_COLOR does not override explicit always", map[string]string{"NO_COLOR": "1"}, []string{"--color", "always"}, true, false},
+		{"invalid flag", nil, []string{"--color", "rainbow"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withEnv(t, tt.env)
+
+			testCmd := useTestDeps(t, &cobra.Command{Use: "test"})
+			testCmd.Flags().String("color", "", "")
+			testCmd.SetOut(&bytes.Buffer{})
+			if err := testCmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := config.New()
+			if err := cfg.ApplyEnv(testEnv); err != nil {
+				t.Fatal(err)
+			}
+
+			styler, err := newStyler(testCmd, cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newStyler() unexpected error: %v", err)
+			}
+			if styler.Enabled() != tt.wantColor {
+				t.Errorf("color enabled = %v, want %v", styler.Enabled(), tt.wantColor)
+			}
+		})
+	}
+}
@@ -1,13 +1,12 @@
 package cmd
 
 import (
-	"bytes"
 	"errors"
 	"runtime"
 	"strings"
 	"testing"
 
-	"github.com/spf13/cobra"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
 )
 
 // TestRootCommandEdgeCases tests edge cases for root command to improve coverage
@@ -39,32 +38,17 @@ func TestRootCommandEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := &cobra.Command{
-				Use:   "hello-gopher",
-				RunE:  rootCmd.RunE,
-			}
-			cmd.Flags().BoolP("version", "v", false, "version info")
-			
-			var output bytes.Buffer
-			cmd.SetOut(&output)
-			cmd.SetErr(&output)
-			cmd.SetArgs(tt.args)
+			_, stderr, code := testsupport.RunCommand(t, tt.args...)
 
-			err := cmd.Execute()
-			
-			if tt.expectError && err == nil {
+			if tt.expectError && code == ExitSuccess {
 				t.Errorf("Expected error but got none")
 			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Unexpected error: %v", err)
+			if !tt.expectError && code != ExitSuccess {
+				t.Errorf("Unexpected exit code %d: %s", code, stderr)
 			}
-			
-			if tt.errorType == "usage" && err != nil {
-				if cliErr, ok := err.(*CLIError); ok {
-					if cliErr.Code != ExitUsageError {
-						t.Errorf("Expected usage error code %d, got %d", ExitUsageError, cliErr.Code)
-					}
-				}
+
+			if tt.errorType == "usage" && tt.expectError && code != ExitUsageError {
+				t.Errorf("Expected usage error code %d, got %d", ExitUsageError, code)
 			}
 		})
 	}
@@ -94,32 +78,17 @@ func TestGreetCommandEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := &cobra.Command{
-				Use:  "greet",
-				RunE: greetCmd.RunE,
-			}
-			cmd.Flags().StringP("name", "n", "", "Name to greet")
-			
-			var output bytes.Buffer
-			cmd.SetOut(&output)
-			cmd.SetErr(&output)
-			cmd.SetArgs(tt.args)
+			_, stderr, code := testsupport.RunCommand(t, append([]string{"greet"}, tt.args...)...)
 
-			err := cmd.Execute()
-			
-			if tt.expectError && err == nil {
+			if tt.expectError && code == ExitSuccess {
 				t.Errorf("Expected error but got none")
 			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Unexpected error: %v", err)
+			if !tt.expectError && code != ExitSuccess {
+				t.Errorf("Unexpected exit code %d: %s", code, stderr)
 			}
-			
-			if tt.errorType == "usage" && err != nil {
-				if cliErr, ok := err.(*CLIError); ok {
-					if cliErr.Code != ExitUsageError {
-						t.Errorf("Expected usage error code %d, got %d", ExitUsageError, cliErr.Code)
-					}
-				}
+
+			if tt.errorType == "usage" && tt.expectError && code != ExitUsageError {
+				t.Errorf("Expected usage error code %d, got %d", ExitUsageError, code)
 			}
 		})
 	}
@@ -149,31 +118,17 @@ func TestProverbCommandEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := &cobra.Command{
-				Use:  "proverb",
-				RunE: proverbCmd.RunE,
-			}
-			
-			var output bytes.Buffer
-			cmd.SetOut(&output)
-			cmd.SetErr(&output)
-			cmd.SetArgs(tt.args)
+			_, stderr, code := testsupport.RunCommand(t, append([]string{"proverb"}, tt.args...)...)
 
-			err := cmd.Execute()
-			
-			if tt.expectError && err == nil {
+			if tt.expectError && code == ExitSuccess {
 				t.Errorf("Expected error but got none")
 			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Unexpected error: %v", err)
+			if !tt.expectError && code != ExitSuccess {
+				t.Errorf("Unexpected exit code %d: %s", code, stderr)
 			}
-			
-			if tt.errorType == "usage" && err != nil {
-				if cliErr, ok := err.(*CLIError); ok {
-					if cliErr.Code != ExitUsageError {
-						t.Errorf("Expected usage error code %d, got %d", ExitUsageError, cliErr.Code)
-					}
-				}
+
+			if tt.errorType == "usage" && tt.expectError && code != ExitUsageError {
+				t.Errorf("Expected usage error code %d, got %d", ExitUsageError, code)
 			}
 		})
 	}
@@ -265,93 +220,26 @@ func TestErrorMessages(t *testing.T) {
 
 // TestVersionCommandOutput tests version command output formatting
 func TestVersionCommandOutput(t *testing.T) {
-	// Create a custom version command that uses cmd.Printf instead of fmt.Printf
-	var output bytes.Buffer
-	cmd := &cobra.Command{
-		Use: "version",
-		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("hello-gopher version %s\n", version)
-			cmd.Printf("Build date: %s\n", buildDate)
-			cmd.Printf("Git commit: %s\n", gitCommit)
-			cmd.Printf("Go version: %s\n", runtime.Version())
-			cmd.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
-		},
-	}
-	cmd.SetOut(&output)
-	cmd.SetArgs([]string{})
-
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
-	}
-
-	outputStr := output.String()
-	expectedStrings := []string{
-		"hello-gopher version",
-		"Build date:",
-		"Git commit:",
-		"Go version:",
-		"OS/Arch:",
-	}
-
-	for _, expected := range expectedStrings {
-		if !strings.Contains(outputStr, expected) {
-			t.Errorf("Expected output to contain %q, got: %s", expected, outputStr)
-		}
-	}
-}
-
-// TestRootCommandVersionFlag tests version flag on root command
-func TestRootCommandVersionFlag(t *testing.T) {
-	var output bytes.Buffer
-	cmd := &cobra.Command{
-		Use:  "hello-gopher",
-		RunE: rootCmd.RunE,
-	}
-	cmd.Flags().BoolP("version", "v", false, "version info")
-	cmd.SetOut(&output)
-	cmd.SetArgs([]string{"--version"})
-
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
-	}
-
-	outputStr := output.String()
-	expectedStrings := []string{
-		"hello-gopher version",
-		"Build date:",
-		"Git commit:",
-		"Go version:",
-		"OS/Arch:",
-	}
-
-	for _, expected := range expectedStrings {
-		if !strings.Contains(outputStr, expected) {
-			t.Errorf("Expected output to contain %q, got: %s", expected, outputStr)
-		}
-	}
-}
-
-// TestRootCommandShortVersionFlag tests short version flag
-func TestRootCommandShortVersionFlag(t *testing.T) {
-	var output bytes.Buffer
-	cmd := &cobra.Command{
-		Use:  "hello-gopher",
-		RunE: rootCmd.RunE,
-	}
-	cmd.Flags().BoolP("version", "v", false, "version info")
-	cmd.SetOut(&output)
-	cmd.SetArgs([]string{"-v"})
-
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
-	}
+	for _, args := range [][]string{{"version"}, {"--version"}, {"-v"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, args...)
+			if code != ExitSuccess {
+				t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+			}
 
-	outputStr := output.String()
-	if !strings.Contains(outputStr, "hello-gopher version") {
-		t.Errorf("Expected version output, got: %s", outputStr)
+			expectedStrings := []string{
+				"hello-gopher version " + version,
+				"Build date: " + buildDate,
+				"Git commit: " + gitCommit,
+				"Go version: " + runtime.Version(),
+				"OS/Arch: " + runtime.GOOS + "/" + runtime.GOARCH,
+			}
+			for _, expected := range expectedStrings {
+				if !strings.Contains(stdout, expected) {
+					t.Errorf("Expected output to contain %q, got: %s", expected, stdout)
+				}
+			}
+		})
 	}
 }
 
@@ -391,45 +279,23 @@ func TestHandleErrorFunction(t *testing.T) {
 
 // TestFlagErrorHandling tests custom flag error handling
 func TestFlagErrorHandling(t *testing.T) {
-	// Test that invalid flags are handled properly
-	cmd := &cobra.Command{
-		Use:  "hello-gopher",
-		RunE: rootCmd.RunE,
-	}
-	cmd.Flags().BoolP("version", "v", false, "version info")
-	
-	// Set the same flag error function as the root command
-	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
-		return NewUsageError(
-			err.Error(),
-			"Run 'hello-gopher --help' for usage information",
-		)
-	})
-	
-	var output bytes.Buffer
-	cmd.SetOut(&output)
-	cmd.SetErr(&output)
-	cmd.SetArgs([]string{"--invalid-flag"})
-
-	err := cmd.Execute()
-	if err == nil {
-		t.Error("Expected error for invalid flag")
-	}
-	
-	if cliErr, ok := err.(*CLIError); ok {
-		if cliErr.Code != ExitUsageError {
-			t.Errorf("Expected usage error code %d, got %d", ExitUsageError, cliErr.Code)
-		}
-		if !strings.Contains(cliErr.Error(), "Suggestion:") {
-			t.Error("Expected suggestion in flag error")
-		}
-	} else {
-		t.Errorf("Expected CLIError, got %T", err)
+	for _, args := range [][]string{{"--invalid-flag"}, {"greet", "--invalid-flag"}, {"proverb", "--seed"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, args...)
+			if code != ExitUsageError {
+				t.Errorf("Expected usage error code %d, got %d", ExitUsageError, code)
+			}
+			if !strings.Contains(stderr, "Suggestion:") {
+				t.Errorf("Expected suggestion in flag error, got %q", stderr)
+			}
+		})
 	}
 }
 
 // TestCommandInitialization tests that commands are properly initialized
 func TestCommandInitialization(t *testing.T) {
+	greetCmd := newGreetCmd(Deps{})
+	rootCmd := NewRootCmd(Deps{})
 	// Test that greet command has proper flags
 	if greetCmd.Flags().Lookup("name") == nil {
 		t.Error("Expected greet command to have 'name' flag")
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// issueURL is where users report bugs
+const issueURL = "https://github.com/louiellywton/go-portfolio/issues/new"
+
+// handlePanic reports a recovered panic: it writes a crash report with the
+// build information and stack trace to a file in dir, tells the user where to
+// find it and how to report the bug, and exits with ExitCrash. If the report
+// can't be written, the stack trace is printed to stderr instead.
+func handlePanic(recovered any, stack []byte, exit func(int), stderr io.Writer, dir string) {
+	report := crashReport(recovered, stack, time.Now())
+
+	fmt.Fprintln(stderr, "hello-gopher crashed unexpectedly. This is a bug, not something you did wrong.")
+	if path, err := writeCrashReport(dir, report); err == nil {
+		fmt.Fprintf(stderr, "A crash report was written to %s\n", path)
+		fmt.Fprintf(stderr, "Please open an issue at %s and attach the report.\n", issueURL)
+	} else {
+		fmt.Fprintf(stderr, "Please open an issue at %s with the report below.\n\n", issueURL)
+		fmt.Fprint(stderr, report)
+	}
+	exit(ExitCrash)
+}
+
+// crashReport formats the report for a panic with value recovered. Command
+// line arguments are left out on purpose, as they may contain webhook URLs
+// or API tokens.
+func crashReport(recovered any, stack []byte, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "hello-gopher crash report")
+	fmt.Fprintf(&b, "Time: %s\n", now.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s\n", version)
+	fmt.Fprintf(&b, "Build date: %s\n", buildDate)
+	fmt.Fprintf(&b, "Git commit: %s\n", gitCommit)
+	fmt.Fprintf(&b, "Go version: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "\nPanic: %v\n\n", recovered)
+	b.Write(stack)
+	if len(stack) > 0 && stack[len(stack)-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// writeCrashReport stores report in a new file in dir and returns its path
+func writeCrashReport(dir, report string) (string, error) {
+	f, err := os.CreateTemp(dir, "hello-gopher-crash-*.txt")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(report); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestHandlePanic(t *testing.T) {
+	dir := t.TempDir()
+	var stderr bytes.Buffer
+	code := -1
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				handlePanic(r, debug.Stack(), func(c int) { code = c }, &stderr, dir)
+			}
+		}()
+		panic("something broke")
+	}()
+
+	if code != ExitCrash {
+		t.Errorf("exit code = %d, want %d", code, ExitCrash)
+	}
+
+	out := stderr.String()
+	if !strings.Contains(out, issueURL) {
+		t.Errorf("stderr should point to the issue tracker, got %q", out)
+	}
+	if strings.Contains(out, "goroutine") {
+		t.Errorf("stderr should not contain the raw stack trace, got %q", out)
+	}
+
+	path := regexp.MustCompile(`written to (\S+)`).FindStringSubmatch(out)
+	if path == nil || filepath.Dir(path[1]) != dir {
+		t.Fatalf("stderr should name the report file in %s, got %q", dir, out)
+	}
+	report, err := os.ReadFile(path[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Version: " + version, "OS/Arch: ", "Panic: something broke", "goroutine", "TestHandlePanic"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("crash report should contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestHandlePanicUnwritableDir(t *testing.T) {
+	var stderr bytes.Buffer
+	code := -1
+	handlePanic("boom", []byte("goroutine 1 [running]:\n"), func(c int) { code = c }, &stderr, filepath.Join(t.TempDir(), "missing"))
+
+	if code != ExitCrash {
+		t.Errorf("exit code = %d, want %d", code, ExitCrash)
+	}
+	if out := stderr.String(); !strings.Contains(out, "Panic: boom") || !strings.Contains(out, "goroutine 1") {
+		t.Errorf("the report should be printed when it can't be written, got %q", out)
+	}
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/history"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/norepeat"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/playlist"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/quiz"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/telemetry"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/update"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of directories hello-gopher writes to
+const (
+	dirConfig = "config"
+	dirCache  = "cache"
+	dirData   = "data"
+	dirState  = "state"
+)
+
+// dirKinds lists the directory kinds in the order data path prints them
+var dirKinds = []string{dirConfig, dirCache, dirData, dirState}
+
+// appFile is a file hello-gopher writes to its cache, data or state
+// directory. The config directory belongs to the user and is never cleaned.
+type appFile struct {
+	kind string
+	name string
+}
+
+// appFiles lists every file cache clear and data prune may remove
+var appFiles = []appFile{
+	{dirCache, update.CacheFileName},
+	{dirData, userproverbs.FileName},
+	{dirState, history.FileName},
+	{dirState, norepeat.FileName},
+	{dirState, playlist.StateFileName},
+	{dirState, lastProverbFile},
+	{dirState, quiz.FileName},
+	{dirState, telemetry.QueueFileName},
+	{dirState, webhook.DeadLetterFileName},
+}
+
+// newDataCmd creates the data command and its subcommands
+func newDataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "data",
+		Short: "Show and clean up the files hello-gopher keeps",
+		Long: `Data command manages the directories hello-gopher writes to:
+
+  config  settings and favorites
+  cache   results that are fetched again when missing, such as update checks
+  data    your own content, such as proverbs added with proverb import
+  state   history kept between runs
+
+The locations follow the platform conventions, such as the XDG base
+directories on Linux, and can be moved with $HELLO_GOPHER_CACHE_DIR,
+$HELLO_GOPHER_DATA_DIR and $HELLO_GOPHER_STATE_DIR.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown data subcommand: %s", args[0]),
+					"Run 'hello-gopher data --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newDataPathCmd(), newDataPruneCmd())
+	return cmd
+}
+
+// newDataPathCmd creates the data path command
+func newDataPathCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "path [config|cache|data|state]",
+		Short: "Print the directories hello-gopher writes to",
+		Long: `Path prints the config, cache, data and state directories. Given a kind,
+only that directory is printed, which is handy in scripts.`,
+		Example: `  hello-gopher data path                  # Every directory
+  hello-gopher data path cache            # Only the cache directory
+  hello-gopher data path --output json    # Every directory as JSON`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				dir, err := appDir(cmd, args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), dir)
+				return nil
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			dirs := make(map[string]string, len(dirKinds))
+			for _, kind := range dirKinds {
+				if dirs[kind], err = appDir(cmd, kind); err != nil {
+					return err
+				}
+			}
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), dirs)
+			}
+
+			out := bufio.NewWriter(cmd.OutOrStdout())
+			for _, kind := range dirKinds {
+				fmt.Fprintf(out, "%-7s %s\n", kind, dirs[kind])
+			}
+			if err := out.Flush(); err != nil {
+				return NewSystemError("Failed to write directories", err, "")
+			}
+			return nil
+		},
+	}
+}
+
+// newDataPruneCmd creates the data prune command
+func newDataPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached files and state",
+		Long: `Prune removes the files hello-gopher keeps in its cache and state
+directories, and the directories themselves once empty. Your data, such as
+imported proverbs, is only removed with --all; settings and favorites are
+never touched.`,
+		Example: `  hello-gopher data prune --dry-run   # List what would be removed
+  hello-gopher data prune             # Remove the cache and state
+  hello-gopher data prune --all       # Also remove imported proverbs`,
+		Args: exactArgs(0, "data prune doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, _ := cmd.Flags().GetBool("all")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			kinds := []string{dirCache, dirState}
+			if all {
+				kinds = append(kinds, dirData)
+			}
+			return removeAppFiles(cmd, kinds, dryRun, "Nothing to prune")
+		},
+	}
+
+	cmd.Flags().Bool("all", false, "Also remove your data, such as imported proverbs")
+	cmd.Flags().Bool("dry-run", false, "List what would be removed without removing it")
+	return cmd
+}
+
+// appDir returns the directory of kind used by cmd
+func appDir(cmd *cobra.Command, kind string) (string, error) {
+	var (
+		dir string
+		err error
+	)
+	switch kind {
+	case dirConfig:
+		dir, err = configPath(cmd)
+		dir = filepath.Dir(dir)
+	case dirCache:
+		dir, err = cacheDir(cmd)
+	case dirData:
+		dir, err = dataDir(cmd)
+	case dirState:
+		dir, err = stateDir(cmd)
+	default:
+		return "", NewUsageError(
+			fmt.Sprintf("Unknown directory: %s", kind),
+			fmt.Sprintf("Use one of: %s", strings.Join(dirKinds, ", ")),
+		)
+	}
+	if err != nil {
+		return "", NewSystemError(
+			fmt.Sprintf("Failed to locate the %s directory", kind),
+			err,
+			"Set HOME, or the HELLO_GOPHER_*_DIR variable of the directory",
+		)
+	}
+	return dir, nil
+}
+
+// removeAppFiles removes the files of appFiles in the directories of kinds,
+// then the directories once empty, and reports every file removed. With
+// dryRun it only reports them. none is printed when there is nothing to do.
+func removeAppFiles(cmd *cobra.Command, kinds []string, dryRun bool, none string) error {
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+
+	removed := 0
+	for _, kind := range kinds {
+		dir, err := appDir(cmd, kind)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range appFiles {
+			if f.kind != kind {
+				continue
+			}
+			path := filepath.Join(dir, f.name)
+			if _, err := os.Lstat(path); errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if !dryRun {
+				if err := os.Remove(path); err != nil {
+					return NewSystemError(fmt.Sprintf("Failed to remove %s", path), err, "")
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", verb, path)
+			removed++
+		}
+
+		if !dryRun {
+			// Only succeeds for an empty directory, which is left behind
+			// by hello-gopher alone
+			_ = os.Remove(dir)
+		}
+	}
+
+	if removed == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), none)
+	}
+	return nil
+}
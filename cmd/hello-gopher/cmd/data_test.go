@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/update"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+)
+
+// appDirs points the cache, data and state directories at new temporary
+// directories and returns them
+func appDirs(t *testing.T) (cache, data, state string) {
+	t.Helper()
+	cache, data, state = t.TempDir(), t.TempDir(), t.TempDir()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_CACHE_DIR": cache,
+		"HELLO_GOPHER_DATA_DIR":  data,
+		"HELLO_GOPHER_STATE_DIR": state,
+	})
+	return cache, data, state
+}
+
+// touch creates an empty file at path
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDataPath(t *testing.T) {
+	cache, data, state := appDirs(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "data", "path")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	for _, want := range []string{"cache   " + cache, "data    " + data, "state   " + state} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("data path output misses %q:\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "data", "path", "cache")
+	if stdout != cache+"\n" {
+		t.Errorf("data path cache = %q, want %q", stdout, cache)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "data", "path", "--output", "json")
+	var dirs map[string]string
+	if err := json.Unmarshal([]byte(stdout), &dirs); err != nil || dirs["state"] != state || dirs["config"] == "" {
+		t.Errorf("data path --output json = %q (err %v)", stdout, err)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "data", "path", "logs")
+	if code != ExitUsageError || !strings.Contains(stderr, "Unknown directory") {
+		t.Errorf("unknown kind: exit code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	cache, data, _ := appDirs(t)
+	touch(t, filepath.Join(cache, update.CacheFileName))
+	touch(t, filepath.Join(data, userproverbs.FileName))
+
+	stdout, stderr, code := testsupport.RunCommand(t, "cache", "clear")
+	if code != ExitSuccess || !strings.HasPrefix(stdout, "Removed ") {
+		t.Fatalf("cache clear = %q, %q, exit code %d", stdout, stderr, code)
+	}
+	if _, err := os.Stat(cache); !os.IsNotExist(err) {
+		t.Errorf("cache clear left the empty cache directory behind (stat error %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(data, userproverbs.FileName)); err != nil {
+		t.Errorf("cache clear removed data: %v", err)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "cache", "clear")
+	if stdout != "The cache is already empty\n" {
+		t.Errorf("second cache clear = %q", stdout)
+	}
+}
+
+func TestDataPrune(t *testing.T) {
+	cache, data, _ := appDirs(t)
+	cached := filepath.Join(cache, update.CacheFileName)
+	imported := filepath.Join(data, userproverbs.FileName)
+	touch(t, cached)
+	touch(t, imported)
+	touch(t, filepath.Join(cache, "not-ours.txt"))
+
+	stdout, _, code := testsupport.RunCommand(t, "data", "prune", "--all", "--dry-run")
+	if code != ExitSuccess || strings.Count(stdout, "Would remove ") != 2 {
+		t.Errorf("dry run = %q", stdout)
+	}
+	if _, err := os.Stat(cached); err != nil {
+		t.Fatalf("dry run removed a file: %v", err)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "data", "prune")
+	if stdout != "Removed "+cached+"\n" {
+		t.Errorf("data prune = %q, want only the cache file removed", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(cache, "not-ours.txt")); err != nil {
+		t.Errorf("data prune removed a file it doesn't own: %v", err)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "data", "prune", "--all")
+	if stdout != "Removed "+imported+"\n" {
+		t.Errorf("data prune --all = %q, want the imported proverbs removed", stdout)
+	}
+	stdout, _, _ = testsupport.RunCommand(t, "data", "prune", "--all")
+	if stdout != "Nothing to prune\n" {
+		t.Errorf("data prune on empty directories = %q", stdout)
+	}
+}
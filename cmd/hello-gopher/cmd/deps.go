@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/whoami"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// Deps are the external dependencies of the command tree. Zero fields fall
+// back to the process's standard streams and the real greeting service, so
+// Deps{} runs the real CLI.
+type Deps struct {
+	// In is read by interactive commands such as tui
+	In io.Reader
+	// Out receives the command output
+	Out io.Writer
+	// Err receives diagnostics and error messages
+	Err io.Writer
+
+	// NewGreeter creates the greeter used by greet and post --greet. It
+	// receives the language and style options resolved for the run and
+	// takes precedence over the provider setting.
+	NewGreeter func(opts ...greeting.Option) greeting.Greeter
+	// NewProverbProvider creates the proverb source used by the proverb
+	// command. It receives options such as the --seed. Providers that only
+	// implement greeting.ProverbProvider print proverbs without IDs or tags
+	// and can't serve --daily. It takes precedence over the provider
+	// setting.
+	NewProverbProvider func(opts ...greeting.Option) greeting.ProverbProvider
+
+	// Clock tells the time that picks the proverb of the day. It defaults
+	// to the system clock.
+	Clock greeting.Clock
+	// Rand drives random proverb selection without --seed and the jitter
+	// of proverb --watch. It defaults to a time-seeded source.
+	Rand greeting.Rand
+	// Whoami guesses the name greeted with --auto-name. It defaults to
+	// whoami.DefaultDetector().
+	Whoami *whoami.Detector
+	// IsTerminal reports whether the command input is an interactive
+	// terminal, which enables the greet name prompt. It defaults to
+	// color.IsTerminal.
+	IsTerminal func(r io.Reader) bool
+	// LookupEnv reads the environment variables selecting the config file,
+	// the directories and other settings. It defaults to os.LookupEnv.
+	LookupEnv config.LookupEnvFunc
+	// StartTelemetryFlush sends the queued telemetry events without making
+	// cmd wait for the endpoint. It defaults to running 'hello-gopher
+	// telemetry flush' in a process of its own.
+	StartTelemetryFlush func(cmd *cobra.Command) error
+}
+
+// depsKey is the context key of the Deps a command tree was created with
+type depsKey struct{}
+
+// withDeps returns ctx carrying deps for commandDeps
+func withDeps(ctx context.Context, deps Deps) context.Context {
+	return context.WithValue(ctx, depsKey{}, deps)
+}
+
+// commandDeps returns the Deps the command tree of cmd was created with.
+// Commands executed without the root command, as in tests, get Deps{}.
+func commandDeps(cmd *cobra.Command) Deps {
+	if ctx := cmd.Context(); ctx != nil {
+		if deps, ok := ctx.Value(depsKey{}).(Deps); ok {
+			return deps
+		}
+	}
+	return Deps{}
+}
+
+// options returns the greeting options for the clock and random source in
+// d. Options resolved for a run, such as --seed, are applied after them.
+func (d Deps) options() []greeting.Option {
+	var opts []greeting.Option
+	if d.Clock != nil {
+		opts = append(opts, greeting.WithClock(d.Clock))
+	}
+	if d.Rand != nil {
+		opts = append(opts, greeting.WithRand(d.Rand))
+	}
+	return opts
+}
+
+// now returns the current time from the clock in d
+func (d Deps) now() time.Time {
+	if d.Clock != nil {
+		return d.Clock.Now()
+	}
+	return time.Now()
+}
+
+// random returns the random source in d, or a new time-seeded one
+func (d Deps) random() greeting.Rand {
+	if d.Rand != nil {
+		return d.Rand
+	}
+	return greeting.NewRand(d.now().UnixNano())
+}
+
+// detectName returns the current user's name found by the detector in d
+func (d Deps) detectName() (name, source string, ok bool) {
+	if d.Whoami != nil {
+		return d.Whoami.Detect()
+	}
+	return whoami.DefaultDetector().Detect()
+}
+
+// lookupEnv reads the environment variable key with the lookup in d
+func (d Deps) lookupEnv(key string) (string, bool) {
+	if d.LookupEnv != nil {
+		return d.LookupEnv(key)
+	}
+	return os.LookupEnv(key)
+}
+
+// lookupEnv reads the environment variable key for cmd
+func lookupEnv(cmd *cobra.Command, key string) (string, bool) {
+	return commandDeps(cmd).lookupEnv(key)
+}
+
+// startTelemetryFlush starts sending the telemetry queue of cmd with the
+// function in d
+func (d Deps) startTelemetryFlush(cmd *cobra.Command) error {
+	if d.StartTelemetryFlush != nil {
+		return d.StartTelemetryFlush(cmd)
+	}
+	return startTelemetryProcess(cmd)
+}
+
+// interactive reports whether the input of cmd is an interactive terminal
+func (d Deps) interactive(cmd *cobra.Command) bool {
+	if d.IsTerminal != nil {
+		return d.IsTerminal(cmd.InOrStdin())
+	}
+	// Only files can be terminals, and they are writers too
+	f, ok := cmd.InOrStdin().(*os.File)
+	return ok && color.IsTerminal(f)
+}
+
+// greeter returns the greeter for cmd configured with opts, created by the
+// provider selected in cfg unless NewGreeter is set
+func (d Deps) greeter(cmd *cobra.Command, cfg *config.Config, opts ...greeting.Option) (greeting.Greeter, error) {
+	opts = append(d.options(), opts...)
+	if d.NewGreeter != nil {
+		return d.NewGreeter(opts...), nil
+	}
+	name, provider, err := newProvider(cmd, cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	greeter, ok := provider.(greeting.Greeter)
+	if !ok {
+		return nil, NewUsageError(
+			fmt.Sprintf("The %s provider doesn't provide greetings", name),
+			fmt.Sprintf("Run 'hello-gopher config set provider %s'", greeting.BuiltinProvider),
+		)
+	}
+	return greeter, nil
+}
+
+// proverbProvider returns the proverb source for cmd configured with opts,
+// created by the provider selected in cfg unless NewProverbProvider is set
+func (d Deps) proverbProvider(cmd *cobra.Command, cfg *config.Config, opts ...greeting.Option) (greeting.ProverbProvider, error) {
+	opts = append(d.options(), opts...)
+	if d.NewProverbProvider != nil {
+		return d.NewProverbProvider(opts...), nil
+	}
+	name, provider, err := newProvider(cmd, cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	proverbs, ok := provider.(greeting.ProverbProvider)
+	if !ok {
+		return nil, NewUsageError(
+			fmt.Sprintf("The %s provider doesn't provide proverbs", name),
+			fmt.Sprintf("Run 'hello-gopher config set provider %s'", greeting.BuiltinProvider),
+		)
+	}
+	return proverbs, nil
+}
+
+// newProvider creates the provider selected by the provider setting in cfg,
+// configured with opts, and returns it with its name
+func newProvider(cmd *cobra.Command, cfg *config.Config, opts ...greeting.Option) (string, interface{}, error) {
+	name := cfg.Value(config.KeyProvider)
+	if name == greeting.BuiltinProvider {
+		return name, newGreetingService(cmd, opts...), nil
+	}
+
+	// The config package only accepts registered providers, so this fails
+	// only if a provider is unregistered in a custom build
+	factory, ok := greeting.LookupProvider(name)
+	if !ok {
+		return "", nil, NewUsageError(
+			fmt.Sprintf("Unknown provider: %s", name),
+			fmt.Sprintf("Use one of: %s", strings.Join(greeting.Providers(), ", ")),
+		)
+	}
+	commandLogger(cmd).Debug("using provider", "name", name)
+	return name, factory(append([]greeting.Option{greeting.WithLogger(commandLogger(cmd))}, opts...)...), nil
+}
+
+// entryProvider is implemented by providers that return whole proverbs,
+// such as greeting.Service
+type entryProvider interface {
+	RandomEntry() (greeting.Proverb, error)
+}
+
+// dailyProvider is implemented by providers that can pick the proverb of
+// the day, such as greeting.Service
+type dailyProvider interface {
+	DailyProverb(t time.Time) (greeting.Proverb, error)
+}
+
+// randomEntry returns a random proverb from p. Plain providers only supply
+// the text, so the proverb has no ID or tags.
+func randomEntry(p greeting.ProverbProvider) (greeting.Proverb, error) {
+	if ep, ok := p.(entryProvider); ok {
+		return ep.RandomEntry()
+	}
+	return greeting.Proverb{Text: p.RandomProverb()}, nil
+}
+
+// dailyProverb returns the proverb of the day t from p
+func dailyProverb(p greeting.ProverbProvider, t time.Time) (greeting.Proverb, error) {
+	if dp, ok := p.(dailyProvider); ok {
+		return dp.DailyProverb(t)
+	}
+	return greeting.Proverb{}, fmt.Errorf("%T can't pick a proverb of the day", p)
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/whoami"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
+)
+
+// fakeProverbProvider implements only greeting.ProverbProvider
+type fakeProverbProvider struct {
+	proverb string
+	loadErr error
+}
+
+func (f *fakeProverbProvider) RandomProverb() string { return f.proverb }
+func (f *fakeProverbProvider) LoadProverbs() error   { return f.loadErr }
+
+// runWithDeps executes the full command tree built from deps
+func runWithDeps(t *testing.T, deps Deps, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	deps.Out, deps.Err = &out, &out
+	root := NewRootCmd(testDeps(t, deps))
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestInjectedGreeter(t *testing.T) {
+	greeter := greetingtest.NewFakeGreeter()
+	greeter.GreetFunc = func(name string) string { return "Fake hello, " + name }
+	var optCount int
+	deps := Deps{
+		NewGreeter: func(opts ...greeting.Option) greeting.Greeter {
+			optCount = len(opts)
+			return greeter
+		},
+	}
+
+	out, err := runWithDeps(t, deps, "greet", "--name", "Alice", "--lang", "de", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"greeting": "Fake hello, Alice"`) {
+		t.Errorf("greet should use the injected greeter, got %q", out)
+	}
+	if calls := greeter.Calls(); len(calls) != 1 || calls[0] != `Greet("Alice")` {
+		t.Errorf("greeter calls = %v, want [Greet(\"Alice\")]", calls)
+	}
+	if optCount == 0 {
+		t.Error("the greeter factory should receive the resolved language and style options")
+	}
+}
+
+func TestInjectedProverbProvider(t *testing.T) {
+	provider := &fakeProverbProvider{proverb: "Mocks are values."}
+	deps := Deps{
+		NewProverbProvider: func(opts ...greeting.Option) greeting.ProverbProvider { return provider },
+	}
+
+	out, err := runWithDeps(t, deps, "proverb", "--color", "never")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(out) != "Mocks are values." {
+		t.Errorf("proverb output = %q, want the injected proverb", out)
+	}
+
+	_, err = runWithDeps(t, deps, "proverb", "--daily")
+	if !errors.Is(err, ErrData) {
+		t.Errorf("--daily with a plain provider should be a data error, got %v", err)
+	}
+
+	provider.loadErr = errors.New("no proverbs")
+	_, err = runWithDeps(t, deps, "proverb")
+	if !errors.Is(err, ErrData) {
+		t.Errorf("a failing provider should be a data error, got %v", err)
+	}
+}
+
+func TestInjectedClockAndRand(t *testing.T) {
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	deps := Deps{
+		Clock: greetingtest.NewFakeClock(day),
+		Rand:  greetingtest.NewFakeRand(2),
+	}
+	want, err := greeting.NewService().DailyProverb(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runWithDeps(t, deps, "proverb", "--daily", "--color", "never")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(out) != want.Text {
+		t.Errorf("proverb --daily = %q, want the proverb of %s %q", out, day.Format(time.DateOnly), want.Text)
+	}
+
+	out, err = runWithDeps(t, deps, "post", "--dry-run", "--platform", "discord")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, fmt.Sprintf("Go Proverb #%d", want.ID)) {
+		t.Errorf("post should use the injected clock, got %q", out)
+	}
+
+	out, err = runWithDeps(t, deps, "proverb", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"id": 3`) {
+		t.Errorf("proverb should pick with the injected random source, got %q", out)
+	}
+}
+
+func TestInjectedLookupEnv(t *testing.T) {
+	env := map[string]string{
+		"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml"),
+		"HELLO_GOPHER_LANG":   "de",
+	}
+	deps := Deps{LookupEnv: func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}}
+
+	out, err := runWithDeps(t, deps, "greet", "-n", "Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out); got != "Hallo, Alice!" {
+		t.Errorf("greet with HELLO_GOPHER_LANG=de = %q, want %q", got, "Hallo, Alice!")
+	}
+}
+
+func TestGreetCountUsesOneGreeter(t *testing.T) {
+	greeter := greetingtest.NewFakeGreeter()
+	var created int
+	deps := Deps{
+		NewGreeter: func(opts ...greeting.Option) greeting.Greeter {
+			created++
+			return greeter
+		},
+	}
+
+	if _, err := runWithDeps(t, deps, "greet", "--count", "4"); err != nil {
+		t.Fatal(err)
+	}
+	if created != 1 || len(greeter.Calls()) != 4 {
+		t.Errorf("created %d greeters with %d calls, want 1 greeter with 4 calls", created, len(greeter.Calls()))
+	}
+}
+
+func TestGreetAutoName(t *testing.T) {
+	found := &whoami.Detector{GitUserName: func() (string, error) { return "Grace Hopper", nil }}
+	notFound := &whoami.Detector{}
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		detector *whoami.Detector
+		config   string
+		args     []string
+		want     string
+	}{
+		{"flag", found, "", []string{"--auto-name"}, "Hello, Grace Hopper!"},
+		{"disabled", found, "", nil, "Hello, Gopher!"},
+		{"explicit name wins", found, "", []string{"--auto-name", "--name", "Bob"}, "Hello, Bob!"},
+		{"nothing found", notFound, "", []string{"--auto-name"}, "Hello, Gopher!"},
+		{"config", found, "auto_name: true\n", nil, "Hello, Grace Hopper!"},
+		{"flag overrides config", found, "auto_name: true\n", []string{"--auto-name=false"}, "Hello, Gopher!"},
+		{"configured name wins", found, "auto_name: true\nname: Carol\n", nil, "Hello, Carol!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"greet"}, tt.args...)
+			if tt.config != "" {
+				args = append(args, "--config", writeConfig(t, tt.config))
+			}
+			out, err := runWithDeps(t, Deps{Whoami: tt.detector}, args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(out); got != tt.want {
+				t.Errorf("greet %v = %q, want %q", args[1:], got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetPromptsForName(t *testing.T) {
+	terminal := func(io.Reader) bool { return true }
+	notTerminal := func(io.Reader) bool { return false }
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(config, []byte("prompt_name: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		isTerminal func(io.Reader) bool
+		input      string
+		args       []string
+		want       string
+		wantPrompt bool
+	}{
+		{"answer", terminal, "Alice\n", nil, "Hello, Alice!", true},
+		{"default", terminal, "\n", nil, "Hello, Gopher!", true},
+		{"explicit name", terminal, "Alice\n", []string{"--name", "Bob"}, "Hello, Bob!", false},
+		{"no terminal", notTerminal, "Alice\n", nil, "Hello, Gopher!", false},
+		{"bypassed", terminal, "Alice\n", []string{"--no-prompt"}, "Hello, Gopher!", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := Deps{In: strings.NewReader(tt.input), IsTerminal: tt.isTerminal}
+			args := append([]string{"greet", "--config", config}, tt.args...)
+			out, err := runWithDeps(t, deps, args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prompt, greeting, _ := strings.Cut(out, "] ")
+			if !tt.wantPrompt {
+				prompt, greeting = "", out
+			}
+			if tt.wantPrompt && prompt != "Who should I greet? [Gopher" {
+				t.Errorf("prompt = %q, want %q", prompt, "Who should I greet? [Gopher")
+			}
+			if got := strings.TrimSpace(greeting); got != tt.want {
+				t.Errorf("greet %v = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetPromptSuggestsDetectedName(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(config, []byte("prompt_name: true\nauto_name: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deps := Deps{
+		In:         strings.NewReader("\n"),
+		IsTerminal: func(io.Reader) bool { return true },
+		Whoami:     &whoami.Detector{GitUserName: func() (string, error) { return "Grace", nil }},
+	}
+
+	out, err := runWithDeps(t, deps, "greet", "--config", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Who should I greet? [Grace] Hello, Grace!\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+// shoutingGreeter is a provider that only greets
+type shoutingGreeter struct{}
+
+func (shoutingGreeter) Greet(name string) string { return "HEY " + strings.ToUpper(name) + "!" }
+
+func init() {
+	greeting.RegisterProvider("test-wiki", func(opts ...greeting.Option) interface{} {
+		wiki := []greeting.Proverb{{Text: "Ship it on Fridays.", Tags: []string{"wiki"}}}
+		return greeting.NewService(append(opts, greeting.WithExtraProverbs(wiki))...)
+	})
+	greeting.RegisterProvider("test-shouting", func(...greeting.Option) interface{} { return shoutingGreeter{} })
+}
+
+func TestConfiguredProvider(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(provider string) {
+		t.Helper()
+		if err := os.WriteFile(config, []byte("provider: "+provider+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("test-shouting")
+	out, err := runWithDeps(t, Deps{}, "greet", "--config", config, "--name", "Alice")
+	if err != nil || out != "HEY ALICE!\n" {
+		t.Errorf("greet with the test-shouting provider = %q, %v", out, err)
+	}
+	_, err = runWithDeps(t, Deps{}, "proverb", "--config", config)
+	if err == nil || !strings.Contains(err.Error(), "doesn't provide proverbs") {
+		t.Errorf("proverb with a greeter-only provider: error = %v", err)
+	}
+
+	// The wiki proverb follows the embedded ones
+	write("test-wiki")
+	all, _ := greeting.Default().Proverbs()
+	seed := int64(0)
+	for ; seed < 1000; seed++ {
+		if greeting.NewRand(seed).Intn(len(all)+1) == len(all) {
+			break
+		}
+	}
+	out, err = runWithDeps(t, Deps{}, "proverb", "--config", config, "--seed", fmt.Sprint(seed))
+	if err != nil || !strings.Contains(out, "Ship it on Fridays.") {
+		t.Errorf("proverb with the test-wiki provider = %q, %v", out, err)
+	}
+}
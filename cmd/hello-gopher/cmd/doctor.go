@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// doctorCheck is a check run by the doctor command. run returns what it
+// found, or why the check failed.
+type doctorCheck struct {
+	name string
+	run  func(cmd *cobra.Command) (string, error)
+}
+
+// doctorChecks lists the checks in the order doctor runs them
+var doctorChecks = []doctorCheck{
+	{"config file", checkConfigFile},
+	{"embedded proverbs", checkEmbeddedProverbs},
+	{"imported proverbs", checkImportedProverbs},
+}
+
+// newDoctorCmd creates the doctor command
+func newDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the installation for problems",
+		Long: `Doctor checks that hello-gopher can run as expected:
+
+  config file        the config file can be read and has no unknown keys
+  embedded proverbs  the proverbs compiled in match the checksum recorded at
+                     build time, so a corrupted or tampered binary is noticed
+  imported proverbs  your own collection from 'proverb import' can be read
+
+Every check is run, and doctor fails if any of them does.`,
+		Example: `  hello-gopher doctor`,
+		Args:    exactArgs(0, "doctor doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			failed := 0
+			for _, check := range doctorChecks {
+				detail, err := check.run(cmd)
+				status := "ok"
+				if err != nil {
+					status, detail = "FAIL", err.Error()
+					failed++
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%-4s  %s: %s\n", status, check.name, detail)
+			}
+
+			if failed > 0 {
+				return NewDataError(
+					fmt.Sprintf("%d of %d checks failed", failed, len(doctorChecks)),
+					nil,
+					"Fix the problems listed above; reinstall hello-gopher if its proverbs are corrupted",
+				)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// checkConfigFile reads the config file without the environment, which
+// loadConfig applies
+func checkConfigFile(cmd *cobra.Command) (string, error) {
+	path, err := configPath(cmd)
+	if err != nil {
+		return "", err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return "", err
+	}
+	if unknown := cfg.UnknownKeys(); len(unknown) > 0 {
+		return "", fmt.Errorf("%s: unknown keys %s", path, strings.Join(unknown, ", "))
+	}
+	return path, nil
+}
+
+// checkEmbeddedProverbs verifies the checksum of the embedded proverbs
+func checkEmbeddedProverbs(*cobra.Command) (string, error) {
+	if err := greeting.VerifyEmbeddedProverbs(); err != nil {
+		return "", err
+	}
+	proverbs, err := greeting.Default().Proverbs()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d proverbs, %s", len(proverbs), greeting.EmbeddedChecksum()), nil
+}
+
+// checkImportedProverbs reads the user's own proverb collection
+func checkImportedProverbs(cmd *cobra.Command) (string, error) {
+	store, err := loadUserProverbs(cmd)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d proverbs in %s", len(store.Proverbs()), store.Path()), nil
+}
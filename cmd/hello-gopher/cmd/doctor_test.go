@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestDoctor(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "doctor")
+	if code != ExitSuccess {
+		t.Fatalf("doctor exited with %d: %s\n%s", code, stderr, stdout)
+	}
+	for _, want := range []string{"ok    config file: ", "ok    embedded proverbs: ", greeting.EmbeddedChecksum(), "ok    imported proverbs: 0 proverbs"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("doctor output doesn't contain %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestDoctorFailures(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("colour: never\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "proverbs.txt"), []byte("| no text\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": path, "HELLO_GOPHER_DATA_DIR": dir})
+
+	stdout, stderr, code := testsupport.RunCommand(t, "doctor")
+	if code != ExitDataError || !strings.Contains(stderr, "2 of 3 checks failed") {
+		t.Errorf("doctor exited with %d: %s", code, stderr)
+	}
+	for _, want := range []string{"FAIL  config file: ", "unknown keys colour", "ok    embedded proverbs", "FAIL  imported proverbs: "} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("doctor output doesn't contain %q:\n%s", want, stdout)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -11,8 +13,27 @@ const (
 	ExitUsageError = 1
 	ExitDataError  = 2
 	ExitSystemError = 3
+	ExitCrash       = 4
+	// ExitInterrupted follows the shell convention of 128 plus the signal
+	// number for a process stopped by SIGINT (Ctrl-C)
+	ExitInterrupted = 130
 )
 
+// Sentinel errors for the categories of CLIError. A *CLIError matches the
+// sentinel of its category with errors.Is, even when it is wrapped:
+//
+//	if errors.Is(err, cmd.ErrUsage) { ... }
+var (
+	ErrUsage  = errors.New("usage error")
+	ErrData   = errors.New("data error")
+	ErrSystem = errors.New("system error")
+)
+
+// ErrInterrupted is returned by interactive and watch modes the user stopped
+// with Ctrl-C. HandleErrorWith exits with ExitInterrupted without printing
+// it, since the user knows what happened.
+var ErrInterrupted = errors.New("interrupted")
+
 // CLIError represents a CLI-specific error with user guidance
 type CLIError struct {
 	Code       int
@@ -34,6 +55,20 @@ func (e *CLIError) Unwrap() error {
 	return e.Cause
 }
 
+// Is reports whether target is the sentinel error of the error's category
+func (e *CLIError) Is(target error) bool {
+	switch target {
+	case ErrUsage:
+		return e.Code == ExitUsageError
+	case ErrData:
+		return e.Code == ExitDataError
+	case ErrSystem:
+		return e.Code == ExitSystemError
+	default:
+		return false
+	}
+}
+
 // NewUsageError creates a new usage error with helpful suggestions
 func NewUsageError(message string, suggestion string) *CLIError {
 	return &CLIError{
@@ -65,16 +100,28 @@ func NewSystemError(message string, cause error, suggestion string) *CLIError {
 
 // HandleError processes CLI errors and exits with appropriate codes
 func HandleError(err error) {
+	HandleErrorWith(err, os.Exit, os.Stderr)
+}
+
+// HandleErrorWith prints err to stderr and calls exit with the exit code
+// matching the error. Errors that are not a *CLIError are reported as system
+// errors. A nil err does nothing, so exit is not called.
+func HandleErrorWith(err error, exit func(int), stderr io.Writer) {
 	if err == nil {
 		return
 	}
+	if errors.Is(err, ErrInterrupted) {
+		exit(ExitInterrupted)
+		return
+	}
 
-	if cliErr, ok := err.(*CLIError); ok {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", cliErr.Error())
-		os.Exit(cliErr.Code)
+	var cliErr *CLIError
+	if errors.As(err, &cliErr) {
+		fmt.Fprintf(stderr, "Error: %s\n", cliErr.Error())
+		exit(cliErr.Code)
 	} else {
 		// Handle non-CLI errors as generic system errors
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitSystemError)
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		exit(ExitSystemError)
 	}
-}
\ No newline at end of file
+}
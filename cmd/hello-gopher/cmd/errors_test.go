@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -123,22 +125,130 @@ func TestNewSystemError(t *testing.T) {
 	}
 }
 
-// Note: HandleError function cannot be easily tested as it calls os.Exit
-// In a real application, this would be tested through integration tests
-// or by refactoring to accept an exit function as a parameter
-
 func TestHandleError_NilError(t *testing.T) {
-	// Test that HandleError with nil doesn't panic
-	// We can't test the actual exit behavior without more complex setup
+	// Test that HandleError with nil doesn't panic or exit
 	defer func() {
 		if r := recover(); r != nil {
 			t.Errorf("HandleError(nil) should not panic, got: %v", r)
 		}
 	}()
-	
-	// This test verifies the function exists and can handle nil
-	// The actual exit behavior would be tested in integration tests
-	t.Log("HandleError function exists and can be called")
+
+	HandleError(nil)
+}
+
+func TestHandleErrorWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantStderr string
+	}{
+		{
+			name:       "usage error",
+			err:        NewUsageError("Unknown flag", "Run --help"),
+			wantCode:   ExitUsageError,
+			wantStderr: "Error: Unknown flag\nSuggestion: Run --help\n",
+		},
+		{
+			name:       "data error",
+			err:        NewDataError("Bad data", errors.New("cause"), ""),
+			wantCode:   ExitDataError,
+			wantStderr: "Error: Bad data\n",
+		},
+		{
+			name:       "system error",
+			err:        NewSystemError("Disk full", nil, ""),
+			wantCode:   ExitSystemError,
+			wantStderr: "Error: Disk full\n",
+		},
+		{
+			name:       "plain error",
+			err:        errors.New("boom"),
+			wantCode:   ExitSystemError,
+			wantStderr: "Error: boom\n",
+		},
+		{
+			name:     "interrupted",
+			err:      ErrInterrupted,
+			wantCode: ExitInterrupted,
+		},
+		{
+			name:     "wrapped interruption",
+			err:      fmt.Errorf("watching proverbs: %w", ErrInterrupted),
+			wantCode: ExitInterrupted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			code := -1
+			HandleErrorWith(tt.err, func(c int) { code = c }, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		var stderr bytes.Buffer
+		HandleErrorWith(nil, func(c int) { t.Errorf("exit(%d) called for a nil error", c) }, &stderr)
+		if stderr.Len() > 0 {
+			t.Errorf("stderr = %q, want nothing", stderr.String())
+		}
+	})
+}
+
+func TestCLIErrorSentinels(t *testing.T) {
+	cause := errors.New("file not found")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"usage", NewUsageError("bad flag", ""), ErrUsage},
+		{"data", NewDataError("bad data", cause, ""), ErrData},
+		{"system", NewSystemError("no disk", nil, ""), ErrSystem},
+		{"wrapped", fmt.Errorf("running greet: %w", NewDataError("bad data", cause, "")), ErrData},
+	}
+
+	sentinels := []error{ErrUsage, ErrData, ErrSystem}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				if got := errors.Is(tt.err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(err, %v) = %v", sentinel, got)
+				}
+			}
+
+			var cliErr *CLIError
+			if !errors.As(tt.err, &cliErr) {
+				t.Error("errors.As should find the *CLIError")
+			}
+		})
+	}
+
+	// The cause stays reachable through the chain
+	if !errors.Is(NewDataError("bad data", cause, ""), cause) {
+		t.Error("errors.Is should match the cause of a CLIError")
+	}
+}
+
+func TestHandleErrorWithWrappedError(t *testing.T) {
+	var stderr bytes.Buffer
+	code := -1
+	HandleErrorWith(fmt.Errorf("context: %w", NewUsageError("bad flag", "")), func(c int) { code = c }, &stderr)
+
+	if code != ExitUsageError {
+		t.Errorf("exit code = %d, want %d for a wrapped usage error", code, ExitUsageError)
+	}
+	if stderr.String() != "Error: bad flag\n" {
+		t.Errorf("stderr = %q", stderr.String())
+	}
 }
 
 // TestExitCodes verifies the exit code constants
@@ -152,6 +262,8 @@ func TestExitCodes(t *testing.T) {
 		{"ExitUsageError", ExitUsageError, 1},
 		{"ExitDataError", ExitDataError, 2},
 		{"ExitSystemError", ExitSystemError, 3},
+		{"ExitCrash", ExitCrash, 4},
+		{"ExitInterrupted", ExitInterrupted, 130},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/site"
+	"github.com/spf13/cobra"
+)
+
+// newGenCmd creates the gen command and its subcommands
+func newGenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate artifacts from the proverb collection",
+		Long: `Gen command groups generators that turn the proverb collection into
+other formats.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown gen subcommand: %s", args[0]),
+					"Run 'hello-gopher gen --help' to see available generators",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newGenSiteCmd())
+	return cmd
+}
+
+// newGenSiteCmd creates the gen site command
+func newGenSiteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "site",
+		Short: "Render the proverbs as a static website",
+		Long: `Site command renders the proverb collection as a static website: an
+index page with search, one page per proverb and per tag, and a search.json
+index. All links are relative, so the output can be published as-is, for
+example on GitHub Pages.`,
+		Example: `  hello-gopher gen site                 # Write the site to ./public
+  hello-gopher gen site --out docs      # Write the site to ./docs`,
+		Args: exactArgs(0, "gen site doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, _ := cmd.Flags().GetString("out")
+			if out == "" {
+				return NewUsageError("Output directory must not be empty", "Pass a directory with --out")
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			service, err := proverbService(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			proverbs, err := service.Proverbs()
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+
+			files, err := site.Generate(out, proverbs)
+			if err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to generate site in %s", out),
+					err,
+					"Check that the output directory is writable",
+				)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d files for %d proverbs to %s\n", files, len(proverbs), out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("out", "o", "public", "Directory to write the site to")
+	return cmd
+}
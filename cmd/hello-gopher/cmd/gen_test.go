@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestGenSite(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "public")
+
+	stdout, stderr, code := testsupport.RunCommand(t, "gen", "site", "--out", out)
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Wrote ") {
+		t.Errorf("Unexpected output: %q", stdout)
+	}
+	for _, name := range []string{"index.html", "proverbs/1.html", "tags/concurrency.html", "search.json", "style.css"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("expected %s to be generated: %v", name, err)
+		}
+	}
+}
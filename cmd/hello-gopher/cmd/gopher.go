@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/art"
+	"github.com/spf13/cobra"
+)
+
+// artGap is the number of columns between the art and the text beside it
+const artGap = 3
+
+// newGopherCmd creates the gopher command, greeting with the greeter in deps
+func newGopherCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gopher",
+		Short: "Show an ASCII-art gopher saying hello",
+		Long: `Gopher command renders an embedded ASCII-art gopher next to a greeting.
+
+Several art variants are available; list them with --list. The greeting honors
+the same name, language and style settings as the greet command.`,
+		Example: `  hello-gopher gopher                   # Classic gopher greeting the default gopher
+  hello-gopher gopher --variant wave    # A waving gopher
+  hello-gopher gopher --bubble          # The gopher says hello in a speech bubble
+  hello-gopher gopher -n Alice          # Greet Alice
+  hello-gopher gopher --list            # List available variants`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unexpected argument(s): %v", args),
+					"The gopher command doesn't accept positional arguments. Use --name flag instead",
+				)
+			}
+
+			if list, _ := cmd.Flags().GetBool("list"); list {
+				fmt.Fprintln(cmd.OutOrStdout(), strings.Join(art.Variants(), "\n"))
+				return nil
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			result, err := buildGreeting(cmd, cfg, deps)
+			if err != nil {
+				return err
+			}
+
+			message := highlightName(styler, result.Greeting, result.Name)
+			variant, _ := cmd.Flags().GetString("variant")
+
+			var rendered string
+			if accessible(cmd) {
+				rendered = message + "\n"
+			} else if bubble, _ := cmd.Flags().GetBool("bubble"); bubble {
+				rendered, err = withBubble(cmd, variant, message)
+			} else {
+				rendered, err = withArt(variant, message)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), rendered)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("name", "n", "", "Name to greet (default: Gopher)")
+	cmd.Flags().String("variant", art.DefaultVariant, fmt.Sprintf("Art variant (%s)", strings.Join(art.Variants(), ", ")))
+	cmd.Flags().Bool("list", false, "List the available art variants")
+	addBubbleFlags(cmd)
+	return cmd
+}
+
+// withArt renders text beside the named art variant
+func withArt(variant, text string) (string, error) {
+	a, err := loadArt(variant)
+	if err != nil {
+		return "", err
+	}
+	return art.Beside(a, text, artGap), nil
+}
+
+// withBubble renders text in a speech bubble attached to the named art
+// variant, wrapped to the width selected with --width
+func withBubble(cmd *cobra.Command, variant, text string) (string, error) {
+	a, err := loadArt(variant)
+	if err != nil {
+		return "", err
+	}
+
+	width := art.DefaultBubbleWidth
+	if cmd.Flags().Changed("width") {
+		width, _ = cmd.Flags().GetInt("width")
+	}
+	if width < 1 {
+		return "", NewUsageError(
+			fmt.Sprintf("Invalid bubble width: %d", width),
+			"Use a positive --width such as 40",
+		)
+	}
+	return art.Say(a, text, width), nil
+}
+
+// loadArt loads an art variant, reporting unknown variants as usage errors
+func loadArt(variant string) (art.Art, error) {
+	a, err := art.Load(variant)
+	if err != nil {
+		return art.Art{}, NewUsageError(err.Error(), "Run 'hello-gopher gopher --list' to see available variants")
+	}
+	return a, nil
+}
+
+// addBubbleFlags registers the flags controlling speech bubble rendering
+func addBubbleFlags(c *cobra.Command) {
+	c.Flags().Bool("bubble", false, "Show the text in a speech bubble above an ASCII-art gopher")
+	c.Flags().Int("width", art.DefaultBubbleWidth, "Maximum text width inside the speech bubble")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestGopherCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		contains []string
+		wantErr  bool
+	}{
+		{"default variant", []string{}, []string{"(o)", "Hello, Gopher!"}, false},
+		{"custom name and variant", []string{"-n", "Alice", "--variant", "sleepy"}, []string{"(-)", "Hello, Alice!"}, false},
+		{"list variants", []string{"--list"}, []string{"classic", "wave"}, false},
+		{"unknown variant", []string{"--variant", "nope"}, nil, true},
+		{"positional argument", []string{"extra"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, append([]string{"gopher"}, tt.args...)...)
+			if tt.wantErr {
+				if code != ExitUsageError {
+					t.Errorf("Expected usage error, got code %d (stderr %q)", code, stderr)
+				}
+				return
+			}
+			if code != ExitSuccess {
+				t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestWithArtAlignsGreeting(t *testing.T) {
+	rendered, err := withArt("classic", "Hello, Gopher!")
+	if err != nil {
+		t.Fatalf("withArt() unexpected error: %v", err)
+	}
+
+	var column = -1
+	for _, line := range strings.Split(rendered, "\n") {
+		if i := strings.Index(line, "Hello, Gopher!"); i >= 0 {
+			column = i
+		}
+	}
+	if column < 0 {
+		t.Fatalf("greeting missing from output:\n%s", rendered)
+	}
+	if column <= artGap {
+		t.Errorf("greeting should be placed to the right of the art, got column %d", column)
+	}
+}
+
+func TestProverbBubble(t *testing.T) {
+	out, stderr, code := testsupport.RunCommand(t, "proverb", "--bubble", "--width", "20", "--seed", "1")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+
+	if !strings.HasPrefix(out, " _") || !strings.Contains(out, "(o)") {
+		t.Errorf("Expected bubble above gopher art, got:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "/") || strings.HasPrefix(line, "|") || strings.HasPrefix(line, "<") {
+			if len([]rune(line)) > 20+4 {
+				t.Errorf("Bubble line exceeds requested width: %q", line)
+			}
+		}
+	}
+}
+
+func TestBubbleInvalidWidth(t *testing.T) {
+	testCmd := newGopherCmd(Deps{})
+	if _, err := withBubble(testCmd, "classic", "hi"); err != nil {
+		t.Fatalf("withBubble() with default width unexpected error: %v", err)
+	}
+
+	if err := testCmd.ParseFlags([]string{"--width", "0"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := withBubble(testCmd, "classic", "hi")
+	if !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected usage error for zero width, got %v", err)
+	}
+}
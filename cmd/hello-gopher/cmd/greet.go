@@ -1,54 +1,418 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
 
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/art"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/profile"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/prompt"
 	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
 	"github.com/spf13/cobra"
 )
 
-var greetCmd = &cobra.Command{
-	Use:   "greet",
-	Short: "Greet a gopher by name",
-	Long: `Greet command provides friendly greeting functionality.
+// newGreetCmd creates the greet command, greeting with the greeter in deps
+func newGreetCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "greet",
+		Short: "Greet a gopher by name",
+		Long: `Greet command provides friendly greeting functionality.
 By default, it greets "Gopher", but you can specify a custom name using the --name flag.
 
 This command demonstrates basic CLI functionality with flag support and integration
 with the greeting package interfaces.`,
-	Example: `  hello-gopher greet                    # Greet the default gopher
+		Example: `  hello-gopher greet                    # Greet the default gopher
   hello-gopher greet --name Alice       # Greet Alice
-  hello-gopher greet -n Bob             # Greet Bob using short flag`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		name, err := cmd.Flags().GetString("name")
+  hello-gopher greet -n Bob             # Greet Bob using short flag
+  hello-gopher greet --auto-name        # Greet the current user
+  hello-gopher greet -n Curie --title Dr.  # Hello, Dr. Curie!
+  hello-gopher greet --no-prompt        # Never ask for a name
+  hello-gopher greet --lang de --style formal  # Formal German greeting
+  hello-gopher greet --profile-file people.yaml  # Greet everyone in the file
+  hello-gopher greet --art              # Greet with an ASCII-art gopher
+  hello-gopher greet --art --variant wave  # Pick another art variant
+  hello-gopher greet --bubble -n José   # The gopher greets José in a speech bubble
+  hello-gopher greet --count 3          # Greet three times
+  hello-gopher greet --shout --reverse  # Transform the greeting text
+  hello-gopher greet --random-phrase    # Hi, Howdy, Hey there...
+  hello-gopher greet --random-phrase --seed 42  # The same phrasing every time
+  hello-gopher greet --art --rainbow    # Taste the rainbow
+  hello-gopher --server https://proverbs.example.com greet  # Greeted by a server
+  hello-gopher greet --emoji            # 👋 Hello, Gopher 🐹!
+  hello-gopher greet --festive --region us  # Happy Independence Day on July 4`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate that no unexpected arguments were provided
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unexpected argument(s): %v", args),
+					"The greet command doesn't accept positional arguments. Use --name flag instead",
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			count, _ := cmd.Flags().GetInt("count")
+			if count < 1 {
+				return NewUsageError(
+					fmt.Sprintf("Invalid count: %d", count),
+					"Use --count with a number of 1 or more",
+				)
+			}
+
+			remote, server, err := remoteClient(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			profileFile, _ := cmd.Flags().GetString("profile-file")
+			var greets []func() greetResult
+			switch {
+			case remote != nil:
+				greet, err := remoteGreetFunc(cmd, cfg, deps, remote, server)
+				if err != nil {
+					return err
+				}
+				greets = append(greets, greet)
+			case profileFile != "":
+				if greets, err = profileGreetFuncs(cmd, cfg, deps, profileFile); err != nil {
+					return err
+				}
+			default:
+				greet, err := newGreetFunc(cmd, cfg, deps)
+				if err != nil {
+					return err
+				}
+				greets = append(greets, greet)
+			}
+			recordRun(cmd, cfg, deps, "greet", 0)
+
+			if output == outputJSON {
+				if !cmd.Flags().Changed("count") && profileFile == "" {
+					return writeJSON(cmd.OutOrStdout(), greets[0]())
+				}
+				results := make([]greetResult, 0, count*len(greets))
+				for _, greet := range greets {
+					for i := 0; i < count; i++ {
+						results = append(results, greet())
+					}
+				}
+				return writeJSON(cmd.OutOrStdout(), results)
+			}
+
+			// Repeated greetings share one greeter and one buffered write
+			out := bufio.NewWriter(cmd.OutOrStdout())
+			lines := 0
+			for _, greet := range greets {
+				for i := 0; i < count; i++ {
+					message, err := renderGreeting(cmd, styler, greet())
+					if err != nil {
+						return err
+					}
+					out.WriteString(withRainbow(cmd, styler, message, lines))
+					lines += strings.Count(message, "\n")
+				}
+			}
+			if err := out.Flush(); err != nil {
+				return NewSystemError("Failed to write greeting", err, "")
+			}
+			return nil
+		},
+	}
+
+	// Add name flag with both long and short versions
+	cmd.Flags().StringP("name", "n", "", "Name to greet (default: Gopher)")
+	cmd.Flags().String("profile-file", "", "Greet everyone in this YAML file of names, languages, honorifics and styles")
+	cmd.Flags().String("title", "", "Honorific put before the name, such as Dr. or Prof.")
+	cmd.Flags().Int("max-name-length", 0, fmt.Sprintf("Truncate longer names with an ellipsis, 0 disables (default: %d)", greeting.DefaultMaxNameLength))
+	cmd.Flags().Bool("normalize", false, "Normalize Unicode in the name and isolate right-to-left names")
+	cmd.Flags().Bool("neutral", false, "Avoid gendered phrasing such as Mr. or Mrs.")
+	cmd.Flags().Bool("auto-name", false, "Greet the current user when no name is set")
+	cmd.Flags().Bool("no-prompt", false, "Don't ask for a name, even if prompt_name is enabled")
+	cmd.Flags().StringP("lang", "l", "", fmt.Sprintf("Greeting language (%s)", strings.Join(greeting.Languages(), ", ")))
+	cmd.Flags().String("style", "", "Greeting style (friendly, formal, casual)")
+	cmd.Flags().Bool("art", false, "Show an ASCII-art gopher next to the greeting")
+	cmd.Flags().String("variant", art.DefaultVariant, fmt.Sprintf("Art variant used with --art or --bubble (%s)", strings.Join(art.Variants(), ", ")))
+	cmd.Flags().Int("count", 1, "Print the greeting this many times")
+	cmd.Flags().Bool("shout", false, "Upper-case the greeting and end it with '!'")
+	cmd.Flags().Bool("whisper", false, "Lower-case the greeting")
+	cmd.Flags().Bool("reverse", false, "Reverse the greeting")
+	cmd.Flags().Bool("random-phrase", false, "Pick a random phrasing instead of the style's greeting")
+	cmd.Flags().Int64("seed", 0, "Seed the random phrasing for reproducible output")
+	cmd.Flags().Bool("emoji", false, "Decorate the greeting with emoji (shortcodes if the terminal isn't UTF-8)")
+	cmd.Flags().Bool("festive", false, "Use a holiday greeting on holidays")
+	cmd.Flags().String("region", "", "Holiday region used with --festive (default: global)")
+	addBubbleFlags(cmd)
+	addRainbowFlag(cmd)
+	return cmd
+}
+
+// renderGreeting formats result as text for cmd, with the art or speech
+// bubble requested by its flags
+func renderGreeting(cmd *cobra.Command, styler color.Styler, result greetResult) (string, error) {
+	message := highlightName(styler, result.Greeting, result.Name)
+	if accessible(cmd) {
+		return message + "\n", nil
+	}
+	variant, _ := cmd.Flags().GetString("variant")
+	if bubble, _ := cmd.Flags().GetBool("bubble"); bubble {
+		return withBubble(cmd, variant, message)
+	}
+	if showArt, _ := cmd.Flags().GetBool("art"); showArt {
+		return withArt(variant, message)
+	}
+	return message + "\n", nil
+}
+
+// buildGreeting resolves the name, language and style settings for cmd and
+// generates the greeting
+func buildGreeting(cmd *cobra.Command, cfg *config.Config, deps Deps) (greetResult, error) {
+	greet, err := newGreetFunc(cmd, cfg, deps)
+	if err != nil {
+		return greetResult{}, err
+	}
+	return greet(), nil
+}
+
+// newGreetFunc resolves the name, language and style settings for cmd and
+// returns a function generating the greeting, so repeated greetings share a
+// single greeter
+func newGreetFunc(cmd *cobra.Command, cfg *config.Config, deps Deps) (func() greetResult, error) {
+	name, err := resolveName(cmd, cfg, deps)
+	if err != nil {
+		return nil, err
+	}
+	return greetFuncFor(cmd, cfg, deps, name)
+}
+
+// greetFuncFor returns a function greeting name with the settings of cmd,
+// overridden by extra options such as those of a profile
+func greetFuncFor(cmd *cobra.Command, cfg *config.Config, deps Deps, name string, extra ...greeting.Option) (func() greetResult, error) {
+	if err := checkUTF8(cmd, cfg, "name", name); err != nil {
+		return nil, err
+	}
+
+	opts, err := greetingOptions(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if randomPhrase, _ := cmd.Flags().GetBool("random-phrase"); randomPhrase {
+		opts = append(opts, greeting.WithRandomPhrase())
+	}
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetInt64("seed")
+		opts = append(opts, greeting.WithSeed(seed))
+	}
+	normalize, _ := cmd.Flags().GetBool("normalize")
+	if normalize {
+		opts = append(opts, greeting.WithNormalizedNames())
+	}
+	if emoji, _ := cmd.Flags().GetBool("emoji"); emoji && !accessible(cmd) {
+		opts = append(opts, greeting.WithEmoji(emojiMode(cmd)))
+	}
+	titleOpts, err := titleOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, titleOpts...)
+	opts = append(opts, extra...)
+	if festive, _ := cmd.Flags().GetBool("festive"); festive {
+		region, err := resolveRegion(cmd, cfg)
 		if err != nil {
-			return NewSystemError(
-				"Failed to parse command flags",
-				err,
-				"Try running 'hello-gopher greet --help' for usage information",
-			)
+			return nil, err
 		}
+		opts = append(opts, greeting.WithFestive(region))
+	}
+
+	transforms, err := greetTransforms(cmd)
+	if err != nil {
+		return nil, err
+	}
 
-		// Validate that no unexpected arguments were provided
-		if len(args) > 0 {
-			return NewUsageError(
-				fmt.Sprintf("Unexpected argument(s): %v", args),
-				"The greet command doesn't accept positional arguments. Use --name flag instead",
+	base, err := deps.greeter(cmd, cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	greeter := greeting.Chain(base, transforms...)
+	maxNameLen, err := resolveMaxNameLength(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Match the name as it appears in the greeting
+	sanitized := greeting.SanitizeName(name)
+	if normalize {
+		sanitized = greeting.NormalizeName(sanitized)
+	}
+	sanitized, _ = greeting.TruncateName(sanitized, maxNameLen)
+	return func() greetResult {
+		return greetResult{Greeting: greeter.Greet(name), Name: sanitized}
+	}, nil
+}
+
+// profileGreetFuncs returns a function greeting each person of the profile
+// file at path, in their language, style and honorific where the profile
+// sets them and with the settings of cmd otherwise
+func profileGreetFuncs(cmd *cobra.Command, cfg *config.Config, deps Deps, path string) ([]func() greetResult, error) {
+	if cmd.Flags().Changed("name") {
+		return nil, NewUsageError(
+			"--profile-file and --name cannot be combined",
+			"The profile file names who to greet; drop one of the flags",
+		)
+	}
+	profiles, err := profile.Load(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, NewUsageError(fmt.Sprintf("Profile file not found: %s", path), "Check the path passed to --profile-file")
+		}
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read profiles: %v", err),
+			err,
+			"List people as '- name: Alice' with optional language, honorific and style keys",
+		)
+	}
+
+	neutral, _ := cmd.Flags().GetBool("neutral")
+	greets := make([]func() greetResult, len(profiles))
+	for i, p := range profiles {
+		if neutral && greeting.IsGenderedTitle(p.Honorific) {
+			return nil, NewUsageError(
+				fmt.Sprintf("%s line %d: honorific %q is gendered and can't be used with --neutral", path, p.Line, p.Honorific),
+				"Use a neutral honorific such as Dr., Prof. or Mx., or drop --neutral",
 			)
 		}
+		if greets[i], err = greetFuncFor(cmd, cfg, deps, p.Name, p.Options()...); err != nil {
+			return nil, err
+		}
+	}
+	commandLogger(cmd).Debug("loaded profiles", "path", path, "count", len(profiles))
+	return greets, nil
+}
+
+// resolveName returns the name to greet. Without an explicit --name or
+// configured name, --auto-name (or the auto_name setting) greets the current
+// user, falling back to the default name if it can't be found. With the
+// prompt_name setting, a name is then asked for on an interactive terminal,
+// suggesting the name found so far.
+func resolveName(cmd *cobra.Command, cfg *config.Config, deps Deps) (string, error) {
+	name := resolveString(cmd, cfg, "name", config.KeyName)
+	if cmd.Flags().Changed("name") || cfg.Source(config.KeyName) != config.SourceDefault {
+		return name, nil
+	}
 
-		// Create greeting service and generate greeting
-		service := greeting.NewService()
-		greeting := service.Greet(name)
-		
-		fmt.Println(greeting)
-		return nil
-	},
+	if resolveBool(cmd, cfg, "auto-name", config.KeyAutoName) {
+		logger := commandLogger(cmd)
+		if detected, source, ok := deps.detectName(); ok {
+			logger.Debug("detected user name", "name", detected, "source", source)
+			name = detected
+		} else {
+			logger.Debug("no user name found", "fallback", name)
+		}
+	}
+
+	// The prompt is enabled by config only; --no-prompt turns it off
+	promptName, _ := strconv.ParseBool(cfg.Value(config.KeyPromptName))
+	noPrompt, _ := cmd.Flags().GetBool("no-prompt")
+	if !promptName || noPrompt || !deps.interactive(cmd) {
+		return name, nil
+	}
+	answer, err := prompt.Ask(cmd.InOrStdin(), cmd.ErrOrStderr(), "Who should I greet?", name)
+	if err != nil {
+		return "", NewSystemError("Failed to read the name", err, "Pass a name with --name or use --no-prompt")
+	}
+	return answer, nil
 }
 
-func init() {
-	// Add greet command to root command
-	rootCmd.AddCommand(greetCmd)
-	
-	// Add name flag with both long and short versions
-	greetCmd.Flags().StringP("name", "n", "", "Name to greet (default: Gopher)")
-}
\ No newline at end of file
+// titleOptions returns the greeting options for the --title and --neutral
+// flags of cmd
+func titleOptions(cmd *cobra.Command) ([]greeting.Option, error) {
+	raw, _ := cmd.Flags().GetString("title")
+	neutral, _ := cmd.Flags().GetBool("neutral")
+
+	title, err := greeting.ParseTitle(raw)
+	if err != nil {
+		return nil, NewUsageError(err.Error(), "Use a title such as Dr. or Prof.")
+	}
+	if neutral && greeting.IsGenderedTitle(title) {
+		return nil, NewUsageError(
+			fmt.Sprintf("Title %q is gendered and can't be used with --neutral", title),
+			"Use a neutral title such as Dr., Prof. or Mx., or drop --neutral",
+		)
+	}
+
+	var opts []greeting.Option
+	if title != "" {
+		opts = append(opts, greeting.WithTitle(title))
+	}
+	if neutral {
+		opts = append(opts, greeting.WithNeutral())
+	}
+	return opts, nil
+}
+
+// emojiMode returns the emoji mode the terminal of cmd can display
+func emojiMode(cmd *cobra.Command) greeting.EmojiMode {
+	detector := color.DefaultDetector()
+	detector.LookupEnv = commandDeps(cmd).lookupEnv
+	if detector.UTF8() {
+		return greeting.EmojiUnicode
+	}
+	return greeting.EmojiASCII
+}
+
+// greetTransforms returns the greeting middlewares selected by the
+// --shout, --whisper and --reverse flags of cmd. Commands without these
+// flags get none.
+func greetTransforms(cmd *cobra.Command) ([]greeting.Middleware, error) {
+	shout, _ := cmd.Flags().GetBool("shout")
+	whisper, _ := cmd.Flags().GetBool("whisper")
+	reverse, _ := cmd.Flags().GetBool("reverse")
+
+	var transforms []greeting.Middleware
+	switch {
+	case shout && whisper:
+		return nil, NewUsageError(
+			"--shout and --whisper can't be used together",
+			"Pick either --shout or --whisper",
+		)
+	case shout:
+		transforms = append(transforms, greeting.Shout())
+	case whisper:
+		transforms = append(transforms, greeting.Whisper())
+	}
+	if reverse {
+		transforms = append(transforms, greeting.Reverse())
+	}
+	return transforms, nil
+}
+
+// highlightName styles the first occurrence of the greeted name in message
+func highlightName(styler color.Styler, message, name string) string {
+	if name == "" {
+		name = "Gopher"
+	}
+	return strings.Replace(message, name, styler.Highlight(name), 1)
+}
+
+// greetResult is the JSON representation of a greeting
+type greetResult struct {
+	Greeting string `json:"greeting"`
+	Name     string `json:"name"`
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
-	"bytes"
-	"fmt"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
 	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
-	"github.com/spf13/cobra"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
 )
 
 func TestGreetCommand(t *testing.T) {
@@ -45,41 +50,13 @@ func TestGreetCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a new command instance for testing
-			testGreetCmd := &cobra.Command{
-				Use:   "greet",
-				Short: "Greet a gopher by name",
-				RunE: func(cmd *cobra.Command, args []string) error {
-					name, err := cmd.Flags().GetString("name")
-					if err != nil {
-						return err
-					}
-
-					// Create greeting service and generate greeting
-					service := greeting.NewService()
-					greeting := service.Greet(name)
-					
-					// Write to command output instead of stdout
-					cmd.Print(greeting)
-					return nil
-				},
-			}
-			testGreetCmd.Flags().StringP("name", "n", "", "Name to greet (default: Gopher)")
-			
-			// Capture output
-			var output bytes.Buffer
-			testGreetCmd.SetOut(&output)
-			testGreetCmd.SetErr(&output)
-			testGreetCmd.SetArgs(tt.args[1:]) // Remove "greet" from args
-
-			// Execute command
-			err := testGreetCmd.Execute()
-			if err != nil {
-				t.Fatalf("Command execution failed: %v", err)
+			stdout, stderr, code := testsupport.RunCommand(t, tt.args...)
+			if code != ExitSuccess {
+				t.Fatalf("Command exited with %d: %s", code, stderr)
 			}
 
 			// Check output
-			result := strings.TrimSpace(output.String())
+			result := strings.TrimSpace(stdout)
 			if result != tt.expected {
 				t.Errorf("Expected %q, got %q", tt.expected, result)
 			}
@@ -88,44 +65,15 @@ func TestGreetCommand(t *testing.T) {
 }
 
 func TestGreetCommandHelp(t *testing.T) {
-	// Create a new root command
-	cmd := &cobra.Command{
-		Use: "hello-gopher",
-	}
-	cmd.AddCommand(greetCmd)
-	
-	// Capture output
-	var output bytes.Buffer
-	cmd.SetOut(&output)
-	cmd.SetErr(&output)
-	cmd.SetArgs([]string{"greet", "--help"})
-
-	// Execute command
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Help command execution failed: %v", err)
-	}
-
-	// Check that help output contains expected elements
-	result := output.String()
-	expectedElements := []string{
-		"Greet command provides friendly greeting functionality",
-		"Usage:",
-		"hello-gopher greet [flags]",
-		"Examples:",
-		"hello-gopher greet --name Alice",
-		"Flags:",
-		"-n, --name string",
-	}
-
-	for _, element := range expectedElements {
-		if !strings.Contains(result, element) {
-			t.Errorf("Help output missing expected element: %q", element)
-		}
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--help")
+	if code != ExitSuccess {
+		t.Fatalf("Help command exited with %d: %s", code, stderr)
 	}
+	testsupport.AssertGolden(t, "greet-help", stdout)
 }
 
 func TestGreetCommandIntegration(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Test that the greet command is properly registered with the root command
 	found := false
 	for _, cmd := range rootCmd.Commands() {
@@ -173,62 +121,389 @@ func TestGreetCommandErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a fresh root command for each test to avoid state issues
-			testRootCmd := &cobra.Command{
-				Use:   "hello-gopher",
-				Short: "A friendly CLI tool for Go enthusiasts",
-				SilenceUsage:  true,
-				SilenceErrors: true,
-				RunE: rootCmd.RunE,
-			}
-			testRootCmd.Flags().BoolP("version", "v", false, "version for hello-gopher")
-			testRootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
-				return NewUsageError(
-					err.Error(),
-					fmt.Sprintf("Run '%s --help' for usage information", cmd.CommandPath()),
-				)
-			})
-			
-			// Add a fresh greet command
-			testGreetCmd := &cobra.Command{
-				Use:   "greet",
-				Short: "Greet a gopher by name",
-				RunE:  greetCmd.RunE,
-			}
-			testGreetCmd.Flags().StringP("name", "n", "", "Name to greet (default: Gopher)")
-			testRootCmd.AddCommand(testGreetCmd)
-			
-			// Capture output
-			var output bytes.Buffer
-			testRootCmd.SetOut(&output)
-			testRootCmd.SetErr(&output)
-			testRootCmd.SetArgs(tt.args)
-
-			// Execute command
-			err := testRootCmd.Execute()
-			
+			_, stderr, code := testsupport.RunCommand(t, tt.args...)
+
 			if tt.expectError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-					return
-				}
-				
-				// Check if it's the right type of error
-				if cliErr, ok := err.(*CLIError); ok {
-					switch tt.errorType {
-					case "usage":
-						if cliErr.Code != ExitUsageError {
-							t.Errorf("Expected usage error (code %d), got code %d", ExitUsageError, cliErr.Code)
-						}
+				switch tt.errorType {
+				case "usage":
+					if code != ExitUsageError {
+						t.Errorf("Expected usage error (code %d), got code %d", ExitUsageError, code)
 					}
-				} else {
-					t.Errorf("Expected CLIError, got %T", err)
 				}
-			} else {
-				if err != nil {
-					t.Errorf("Expected no error but got: %v", err)
+				if !strings.HasPrefix(stderr, "Error: ") {
+					t.Errorf("Expected an error message on stderr, got %q", stderr)
 				}
+			} else if code != ExitSuccess {
+				t.Errorf("Expected no error but got code %d: %s", code, stderr)
+			}
+		})
+	}
+}
+
+func TestHighlightName(t *testing.T) {
+	styler := color.NewStyler(true)
+
+	tests := []struct {
+		name    string
+		message string
+		who     string
+		want    string
+	}{
+		{"highlights name", "Hello, Alice!", "Alice", "Hello, \x1b[1;36mAlice\x1b[0m!"},
+		{"default name", "Hello, Gopher!", "", "Hello, \x1b[1;36mGopher\x1b[0m!"},
+		{"only first occurrence", "Hey, Hey!", "Hey", "\x1b[1;36mHey\x1b[0m, Hey!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightName(styler, tt.message, tt.who); got != tt.want {
+				t.Errorf("highlightName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := highlightName(color.Styler{}, "Hello, Bob!", "Bob"); got != "Hello, Bob!" {
+		t.Errorf("disabled styler changed message: %q", got)
+	}
+}
+
+func TestCommandsWriteToCommandOutput(t *testing.T) {
+	tests := [][]string{
+		{"greet", "--name", "Alice"},
+		{"greet", "--art"},
+		{"greet", "--bubble"},
+		{"proverb", "--seed", "1"},
+		{"gopher"},
+		{"version"},
+		{"--version"},
+	}
+
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, args...)
+			if code != ExitSuccess {
+				t.Fatalf("unexpected exit code %d: %s", code, stderr)
+			}
+			if strings.TrimSpace(stdout) == "" {
+				t.Error("expected output on the command's output writer")
+			}
+			if stderr != "" {
+				t.Errorf("expected nothing on stderr, got %q", stderr)
+			}
+		})
+	}
+}
+
+func TestGreetStripsEscapeSequences(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--name", "\x1b[31mEvil\x1b]0;pwned\a", "--color", "never")
+	if code != ExitSuccess {
+		t.Fatalf("Command exited with %d: %s", code, stderr)
+	}
+	if stdout != "Hello, Evil!\n" {
+		t.Errorf("output = %q, want the escape sequences removed", stdout)
+	}
+}
+
+func TestGreetCount(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--count", "3", "-n", "Alice")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if want := strings.Repeat("Hello, Alice!\n", 3); stdout != want {
+		t.Errorf("greet --count 3 = %q, want %q", stdout, want)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "--count", "2", "--output", "json")
+	var results []greetResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil || len(results) != 2 {
+		t.Errorf("greet --count 2 --output json = %q (err %v), want an array of 2", stdout, err)
+	}
+
+	for _, count := range []string{"0", "-1"} {
+		if _, stderr, code := testsupport.RunCommand(t, "greet", "--count", count); code != ExitUsageError {
+			t.Errorf("--count %s: expected usage error, got code %d (stderr %q)", count, code, stderr)
+		}
+	}
+}
+
+func TestGreetTransforms(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"shout", []string{"--shout"}, "HELLO, ALICE!"},
+		{"whisper", []string{"--whisper"}, "hello, alice!"},
+		{"reverse", []string{"--reverse"}, "!ecilA ,olleH"},
+		{"shout and reverse", []string{"--reverse", "--shout"}, "!ECILA ,OLLEH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, append([]string{"greet", "-n", "Alice"}, tt.args...)...)
+			if code != ExitSuccess {
+				t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+			}
+			if got := strings.TrimSpace(stdout); got != tt.want {
+				t.Errorf("greet %v = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+
+	if _, stderr, code := testsupport.RunCommand(t, "greet", "--shout", "--whisper"); code != ExitUsageError {
+		t.Errorf("--shout --whisper: expected usage error, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestGreetRandomPhrase(t *testing.T) {
+	out, err := runWithDeps(t, Deps{Rand: greetingtest.NewFakeRand(3)}, "greet", "-n", "Alice", "--random-phrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out); got != "Howdy, Alice!" {
+		t.Errorf("greet --random-phrase = %q, want %q", got, "Howdy, Alice!")
+	}
+}
+
+func TestGreetRandomPhraseSeed(t *testing.T) {
+	args := []string{"greet", "-n", "Alice", "--random-phrase", "--seed", "42", "--count", "6"}
+	stdout, stderr, code := testsupport.RunCommand(t, args...)
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	testsupport.AssertGolden(t, "greet-seed", stdout)
+
+	// The seed wins over the random source of the dependencies
+	out, err := runWithDeps(t, Deps{Rand: greetingtest.NewFakeRand(3)}, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != stdout {
+		t.Errorf("greet --seed 42 with a fake random source = %q, want %q", out, stdout)
+	}
+}
+
+func TestGreetFestive(t *testing.T) {
+	newYear := greetingtest.NewFakeClock(time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC))
+	july4 := greetingtest.NewFakeClock(time.Date(2025, time.July, 4, 9, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name  string
+		clock greeting.Clock
+		args  []string
+		want  string
+	}{
+		{"new year", newYear, []string{"--festive"}, "Happy New Year, Alice!"},
+		{"not festive", newYear, nil, "Hello, Alice!"},
+		{"regional holiday", july4, []string{"--festive", "--region", "us"}, "Happy Fourth of July, Alice!"},
+		{"other region", july4, []string{"--festive", "--region", "gb"}, "Hello, Alice!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runWithDeps(t, Deps{Clock: tt.clock}, append([]string{"greet", "-n", "Alice"}, tt.args...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(out); got != tt.want {
+				t.Errorf("greet %v = %q, want %q", tt.args, got, tt.want)
 			}
 		})
 	}
-}
\ No newline at end of file
+
+	_, stderr, code := testsupport.RunCommand(t, "greet", "--festive", "--region", "atlantis")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error for an unknown region, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestGreetTitle(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--title", "Dr."}, "Hello, Dr. Curie!"},
+		{[]string{"--title", "Prof.", "--lang", "fr"}, "Bonjour, Pr Curie!"},
+		{[]string{"--title", "Mrs.", "--lang", "de", "--style", "formal"}, "Guten Tag, Frau Curie."},
+		{[]string{"--title", "Dr.", "--neutral"}, "Hello, Dr. Curie!"},
+		{[]string{"--neutral"}, "Hello, Curie!"},
+	}
+	for _, tt := range tests {
+		stdout, stderr, code := testsupport.RunCommand(t, append([]string{"greet", "-n", "Curie"}, tt.args...)...)
+		if code != ExitSuccess {
+			t.Errorf("greet %v: unexpected exit code %d: %s", tt.args, code, stderr)
+			continue
+		}
+		if got := strings.TrimSpace(stdout); got != tt.want {
+			t.Errorf("greet %v = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"--title", "Dr. #1"},
+		{"--title", "Mr.", "--neutral"},
+	} {
+		_, stderr, code := testsupport.RunCommand(t, append([]string{"greet"}, args...)...)
+		if code != ExitUsageError || !strings.Contains(stderr, "Suggestion:") {
+			t.Errorf("greet %v: expected a usage error with a suggestion, got code %d (stderr %q)", args, code, stderr)
+		}
+	}
+}
+
+func TestGreetNormalize(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--normalize", "--output", "json", "-n", "Jose\u0301\u200b")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	var result greetResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Greeting != "Hello, Jos\u00e9!" || result.Name != "Jos\u00e9" {
+		t.Errorf("greet --normalize = %+v, want greeting %q and name %q", result, "Hello, Jos\u00e9!", "Jos\u00e9")
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "--normalize", "-n", "\u05e9\u05e8\u05d4")
+	if want := "Hello, \u2068\u05e9\u05e8\u05d4\u2069!\n"; stdout != want {
+		t.Errorf("greet --normalize = %q, want %q", stdout, want)
+	}
+}
+
+func TestGreetMaxNameLength(t *testing.T) {
+	long := strings.Repeat("a", 300)
+
+	stdout, _, _ := testsupport.RunCommand(t, "greet", "-n", long)
+	if want := "Hello, " + strings.Repeat("a", 255) + "…!\n"; stdout != want {
+		t.Errorf("greet with a %d character name printed %d bytes, want the name cut to 256 characters", len(long), len(stdout))
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "-n", "Alexandria", "--max-name-length", "5", "--output", "json")
+	var result greetResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Greeting != "Hello, Alex…!" || result.Name != "Alex…" {
+		t.Errorf("greet --max-name-length 5 = %+v", result)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "-n", long, "--max-name-length", "0")
+	if want := "Hello, " + long + "!\n"; stdout != want {
+		t.Errorf("greet --max-name-length 0 should not truncate, got %d bytes", len(stdout))
+	}
+
+	_, stderr, code := testsupport.RunCommand(t, "greet", "--max-name-length", "-1")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error for a negative length, got code %d (stderr %q)", code, stderr)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "greet", "--max-name-length=abc")
+	if code != ExitUsageError || !strings.Contains(stderr, "max-name-length") {
+		t.Errorf("Expected usage error for a length that isn't a number, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestGreetLogsTruncationWhenVerbose(t *testing.T) {
+	_, stderr, _ := testsupport.RunCommand(t, "greet", "-n", "Alexandria", "--max-name-length", "5")
+	if strings.Contains(stderr, "truncated name") {
+		t.Errorf("truncation should only be logged with --verbose, got %q", stderr)
+	}
+	_, stderr, _ = testsupport.RunCommand(t, "greet", "-n", "Alexandria", "--max-name-length", "5", "--verbose")
+	if !strings.Contains(stderr, "truncated name") {
+		t.Errorf("expected a truncation message with --verbose, got %q", stderr)
+	}
+}
+
+func TestGreetRainbow(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "-n", "Ana", "--rainbow", "--color", "always", "--count", "2")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "\x1b[38;5;") {
+		t.Fatalf("expected two rainbow lines, got %q", stdout)
+	}
+	// The gradient rolls on: the second greeting doesn't repeat the first
+	if lines[0] == lines[1] {
+		t.Errorf("both greetings have the same colors: %q", lines[0])
+	}
+
+	// Without color, --rainbow prints plain text
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "-n", "Ana", "--rainbow", "--color", "never")
+	if stdout != "Hello, Ana!\n" {
+		t.Errorf("greet --rainbow --color never = %q, want plain text", stdout)
+	}
+}
+
+func TestGreetEmoji(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"en_US.UTF-8", "\U0001F44B Hello, Gopher \U0001F439!\n"},
+		{"C", ":wave: Hello, Gopher :gopher:!\n"},
+	}
+	for _, tt := range tests {
+		if runtime.GOOS == "windows" && tt.lang == "C" {
+			continue // Windows consoles always use UTF-8
+		}
+		withEnv(t, map[string]string{"LANG": tt.lang})
+		stdout, stderr, code := testsupport.RunCommand(t, "greet", "--emoji")
+		if code != ExitSuccess {
+			t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+		}
+		if stdout != tt.want {
+			t.Errorf("LANG=%s greet --emoji = %q, want %q", tt.lang, stdout, tt.want)
+		}
+	}
+}
+
+func TestGreetProfileFile(t *testing.T) {
+	appDirs(t)
+	path := filepath.Join(t.TempDir(), "people.yaml")
+	profiles := "- name: Alice\n  language: de\n  honorific: Dr.\n  style: formal\n- name: Bob\n  style: casual\n- name: Carol\n"
+	if err := os.WriteFile(path, []byte(profiles), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Profiles override the flags, which apply to the rest
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--profile-file", path, "--lang", "es")
+	if code != ExitSuccess {
+		t.Fatalf("greet --profile-file failed with exit code %d: %s", code, stderr)
+	}
+	want := "Guten Tag, Dr. Alice.\n¡Qué tal, Bob!\n¡Hola, Carol!\n"
+	if stdout != want {
+		t.Errorf("greet --profile-file = %q, want %q", stdout, want)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "greet", "--profile-file", path, "--output", "json")
+	var results []greetResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("output isn't a JSON array: %v\n%s", err, stdout)
+	}
+	if len(results) != 3 || results[0].Name != "Alice" || results[2].Greeting != "Hello, Carol!" {
+		t.Errorf("greet --profile-file --output json = %+v", results)
+	}
+
+	_, _, code = testsupport.RunCommand(t, "greet", "--profile-file", path, "--name", "Dave")
+	if code != ExitUsageError {
+		t.Errorf("--profile-file with --name exited with %d, want %d", code, ExitUsageError)
+	}
+	_, stderr, code = testsupport.RunCommand(t, "greet", "--profile-file", filepath.Join(t.TempDir(), "missing.yaml"))
+	if code != ExitUsageError || !strings.Contains(stderr, "not found") {
+		t.Errorf("missing profile file exited with %d: %q", code, stderr)
+	}
+
+	if err := os.WriteFile(path, []byte("- name: Alice\n  honorific: Mrs.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, code = testsupport.RunCommand(t, "greet", "--profile-file", path, "--neutral")
+	if code != ExitUsageError || !strings.Contains(stderr, "line 1") {
+		t.Errorf("gendered honorific with --neutral exited with %d: %q", code, stderr)
+	}
+	if err := os.WriteFile(path, []byte("- name: Alice\n  language: xx\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, code = testsupport.RunCommand(t, "greet", "--profile-file", path)
+	if code != ExitDataError || !strings.Contains(stderr, "line 2") {
+		t.Errorf("invalid profile file exited with %d: %q", code, stderr)
+	}
+}
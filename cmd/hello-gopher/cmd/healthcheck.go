@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/httpclient"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+// defaultHealthcheckURL is the health endpoint of serve with its default
+// address
+const defaultHealthcheckURL = "http://localhost" + defaultAddr + "/livez"
+
+// defaultHealthcheckTimeout bounds a probe unless --timeout is set
+const defaultHealthcheckTimeout = 2 * time.Second
+
+// exitUnhealthy is the exit code of a failed probe. Docker reads 1 as
+// unhealthy and reserves every other non-zero code, so healthcheck never
+// exits with the data or system error codes.
+const exitUnhealthy = 1
+
+// newHealthcheckCmd creates the healthcheck command
+func newHealthcheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "healthcheck",
+		Short: "Probe a running server, for container health checks",
+		Long: `Healthcheck requests a health endpoint of a running hello-gopher server and
+exits with 0 if it answers with a 2xx status, or 1 otherwise, the exit codes
+Docker expects from a HEALTHCHECK command. Images don't need curl or wget:
+
+  HEALTHCHECK --interval=30s --timeout=5s CMD ["/hello-gopher", "healthcheck"]
+
+A probe gives up after 2s unless --timeout is set, and isn't retried unless
+--retries is set, since the container runtime retries failed checks itself.
+Use --url /readyz to take a draining server out of rotation.`,
+		Example: `  hello-gopher healthcheck                                      # Probe http://localhost:8080/livez
+  hello-gopher healthcheck --url http://localhost:9090/livez
+  hello-gopher healthcheck --url http://localhost:8080/readyz --timeout 1s`,
+		Args: exactArgs(0, "healthcheck doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, _ := cmd.Flags().GetString("url")
+			if u, err := url.Parse(target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return NewUsageError(
+					fmt.Sprintf("Invalid health check URL: %s", target),
+					"Use an http:// or https:// URL such as --url http://localhost:8080/livez",
+				)
+			}
+
+			retries := 0
+			if cmd.Flags().Changed("retries") {
+				retries = networkRetries(cmd)
+			}
+			timeout := networkTimeout(cmd, defaultHealthcheckTimeout)
+			client := httpclient.New(logging.Transport(commandLogger(cmd), networkTransport(cmd)), timeout, retries)
+
+			resp, err := client.Get(target)
+			if err != nil {
+				return unhealthyError(fmt.Sprintf("Unhealthy: %v", err), err)
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				return unhealthyError(fmt.Sprintf("Unhealthy: %s answered %s", target, resp.Status), nil)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Healthy: %s answered %s\n", target, resp.Status)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("url", defaultHealthcheckURL, "Health endpoint to probe")
+	return cmd
+}
+
+// unhealthyError reports a failed probe with exitUnhealthy
+func unhealthyError(message string, cause error) *CLIError {
+	return &CLIError{
+		Code:    exitUnhealthy,
+		Message: message,
+		Cause:   cause,
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestHealthcheck(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.Write([]byte(`{"status": "ok"}`))
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"--url", ts.URL + "/healthz"}, ExitSuccess, "Healthy: " + ts.URL + "/healthz answered 200 OK"},
+		{[]string{"--url", ts.URL + "/readyz"}, exitUnhealthy, "answered 503 Service Unavailable"},
+		{[]string{"--url", closed.URL + "/healthz"}, exitUnhealthy, "Unhealthy:"},
+		{[]string{"--url", ts.URL + "/slow", "--timeout", "50ms"}, exitUnhealthy, "Client.Timeout exceeded"},
+		{[]string{"--url", "localhost:8080/healthz"}, ExitUsageError, "Invalid health check URL"},
+	}
+	for _, tt := range tests {
+		stdout, stderr, code := testsupport.RunCommand(t, append([]string{"healthcheck"}, tt.args...)...)
+		if code != tt.code || !strings.Contains(stdout+stderr, tt.want) {
+			t.Errorf("healthcheck %v = %q, %q (exit %d); want exit %d with %q", tt.args, stdout, stderr, code, tt.code, tt.want)
+		}
+	}
+}
+
+func TestHealthcheckDoesNotRetry(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	if _, _, code := testsupport.RunCommand(t, "healthcheck", "--url", ts.URL); code != exitUnhealthy || calls != 1 {
+		t.Errorf("healthcheck exited with %d after %d requests, want %d after 1", code, calls, exitUnhealthy)
+	}
+	calls = 0
+	if _, _, code := testsupport.RunCommand(t, "healthcheck", "--url", ts.URL, "--retries", "1"); code != exitUnhealthy || calls != 2 {
+		t.Errorf("healthcheck --retries 1 exited with %d after %d requests, want %d after 2", code, calls, exitUnhealthy)
+	}
+}
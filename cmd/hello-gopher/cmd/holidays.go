@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// newHolidaysCmd creates the holidays command and its subcommands
+func newHolidaysCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "holidays",
+		Short: "Show the holidays known to greet --festive",
+		Long: `Holidays command shows the embedded holiday table used by greet --festive.
+
+Global holidays apply everywhere; a region adds its own national holidays.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown holidays subcommand: %s", args[0]),
+					"Run 'hello-gopher holidays --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newHolidaysListCmd(deps))
+	return cmd
+}
+
+// newHolidaysListCmd creates the holidays list command
+func newHolidaysListCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the holidays of a region",
+		Long: fmt.Sprintf(`List prints the holidays greet --festive knows for a region, in calendar
+order. Today's holiday, if any, is marked with '*'.
+
+Supported regions: %s.`, strings.Join(greeting.Regions(), ", ")),
+		Example: `  hello-gopher holidays list              # Global holidays
+  hello-gopher holidays list --region us  # Global and US holidays
+  hello-gopher holidays list --output table --border`,
+		Args: exactArgs(0, "holidays list doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			region, err := resolveRegion(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			holidays := greeting.Holidays(region)
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), holidays)
+			}
+
+			now, err := today(cmd, cfg, deps)
+			if err != nil {
+				return err
+			}
+			if output == outputTable {
+				t := newTable(cmd, "DATE", "HOLIDAY", "TODAY")
+				for _, h := range holidays {
+					today := ""
+					if h.On(now) {
+						today = "yes"
+					}
+					t.Append(fmt.Sprintf("%s %02d", h.Month.String()[:3], h.Day), h.Name, today)
+				}
+				_, err := io.WriteString(cmd.OutOrStdout(), renderTable(t))
+				return err
+			}
+
+			out := bufio.NewWriter(cmd.OutOrStdout())
+			for _, h := range holidays {
+				marker := " "
+				if h.On(now) {
+					marker = "*"
+				}
+				fmt.Fprintf(out, "%s %s %02d  %s\n", marker, h.Month.String()[:3], h.Day, h.Name)
+			}
+			if err := out.Flush(); err != nil {
+				return NewSystemError("Failed to write holidays", err, "")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("region", "", "Holiday region (default: global)")
+	addTableFlags(cmd)
+	return cmd
+}
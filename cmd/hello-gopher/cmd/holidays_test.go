@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
+)
+
+func TestHolidaysList(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "holidays", "list")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Jan 01  New Year's Day") || strings.Contains(stdout, "Independence Day") {
+		t.Errorf("Unexpected global holidays:\n%s", stdout)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "holidays", "list", "--region", "us")
+	if !strings.Contains(stdout, "Jul 04  Independence Day") {
+		t.Errorf("Expected US holidays in:\n%s", stdout)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "holidays", "list", "--region", "atlantis")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error for an unknown region, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestHolidaysListMarksToday(t *testing.T) {
+	clock := greetingtest.NewFakeClock(time.Date(2025, time.November, 10, 12, 0, 0, 0, time.UTC))
+	out, err := runWithDeps(t, Deps{Clock: clock}, "holidays", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "* Nov 10  Gopher Day") {
+		t.Errorf("Expected Gopher Day to be marked:\n%s", out)
+	}
+}
+
+func TestHolidaysListJSON(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "holidays", "list", "--output", "json")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	var holidays []greeting.Holiday
+	if err := json.Unmarshal([]byte(stdout), &holidays); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(holidays) != len(greeting.Holidays(greeting.GlobalRegion)) {
+		t.Errorf("got %d holidays, want %d", len(holidays), len(greeting.Holidays(greeting.GlobalRegion)))
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	// 20:00 UTC on Nov 9 is already Gopher Day in Tokyo
+	clock := greetingtest.NewFakeClock(time.Date(2025, time.November, 9, 20, 0, 0, 0, time.UTC))
+
+	out, err := runWithDeps(t, Deps{Clock: clock}, "holidays", "list", "--tz", "Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "* Nov 10  Gopher Day") {
+		t.Errorf("holidays list --tz Asia/Tokyo doesn't mark Gopher Day:\n%s", out)
+	}
+	if out, _ = runWithDeps(t, Deps{Clock: clock}, "holidays", "list", "--tz", "UTC"); strings.Contains(out, "*") {
+		t.Errorf("holidays list --tz UTC marks a holiday on Nov 9:\n%s", out)
+	}
+
+	// The proverb of the day follows the date in the zone
+	daily := func(tz string) string {
+		out, err := runWithDeps(t, Deps{Clock: clock}, "proverb", "--daily", "--tz", tz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+	want, _ := greeting.NewService().DailyProverb(time.Date(2025, time.November, 10, 0, 0, 0, 0, time.UTC))
+	if got := daily("Asia/Tokyo"); !strings.Contains(got, want.Text) {
+		t.Errorf("proverb --daily --tz Asia/Tokyo = %q, want the proverb of Nov 10 %q", got, want.Text)
+	}
+	if daily("Asia/Tokyo") == daily("America/Los_Angeles") {
+		t.Error("proverb --daily gave the same proverb on different dates")
+	}
+}
+
+func TestTimeZoneInvalid(t *testing.T) {
+	appDirs(t)
+
+	_, stderr, code := testsupport.RunCommand(t, "holidays", "list", "--tz", "Mars/Olympus_Mons")
+	if code != ExitUsageError || !strings.Contains(stderr, `Unknown time zone "Mars/Olympus_Mons"`) {
+		t.Errorf("holidays list --tz Mars/Olympus_Mons exited with %d: %q, want a usage error", code, stderr)
+	}
+
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_TZ":     "Lisbon",
+	})
+	if _, stderr, code = testsupport.RunCommand(t, "proverb", "--daily"); code == ExitSuccess {
+		t.Errorf("proverb --daily with HELLO_GOPHER_TZ=Lisbon succeeded: %q", stderr)
+	}
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/motd"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/prompt"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// Shells init installs completion for, besides completionNone
+const (
+	shellBash      = "bash"
+	shellZsh       = "zsh"
+	shellFish      = "fish"
+	completionNone = "none"
+)
+
+// initSettings are the settings init asks for, with the flag setting each
+var initSettings = []struct {
+	key, flag, question string
+}{
+	{config.KeyName, "name", "Who should hello-gopher greet?"},
+	{config.KeyLanguage, "lang", fmt.Sprintf("Greeting language (%s)?", strings.Join(greeting.Languages(), ", "))},
+	{config.KeyStyle, "style", "Greeting style (friendly, formal, casual)?"},
+}
+
+// newInitCmd creates the init command, which asks questions only when the
+// input in deps is a terminal
+func newInitCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up the config file, shell completion and login message",
+		Long: `Init command sets hello-gopher up in one step:
+
+  1. writes the config file with your name, language and style
+  2. installs shell completion for bash, zsh or fish
+  3. optionally shows the proverb of the day when you log in (see 'motd')
+
+On a terminal each step is asked for, with flags answering questions in
+advance. Otherwise the flags and defaults are used as they are: completion is
+installed for the shell in $SHELL and the login message only with --motd.
+
+An existing config file is kept unless --force is given. With --minimal only
+the settings passed as flags are written, and nothing else is installed.`,
+		Example: `  hello-gopher init                     # Answer a few questions
+  hello-gopher init --name Alice --lang de --completion zsh --motd
+  hello-gopher init --minimal --style casual  # Just the config file
+  hello-gopher init --force             # Start over with a new config file`,
+		Args: exactArgs(0, "init doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+			force, _ := cmd.Flags().GetBool("force")
+			if _, err := os.Stat(path); err == nil && !force {
+				return NewUsageError(
+					fmt.Sprintf("Config file %s already exists", path),
+					"Use --force to replace it, or 'hello-gopher config set' to change single settings",
+				)
+			}
+
+			completion, err := completionShell(cmd)
+			if err != nil {
+				return err
+			}
+
+			minimal, _ := cmd.Flags().GetBool("minimal")
+			var in *bufio.Reader
+			if !minimal && deps.interactive(cmd) {
+				// One reader for every question, so none loses buffered answers
+				in = bufio.NewReader(cmd.InOrStdin())
+			}
+
+			cfg, err := initConfig(cmd, in)
+			if err != nil {
+				return err
+			}
+			if err := cfg.Save(path); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to write configuration to %s", path),
+					err,
+					"Check that the config directory is writable",
+				)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Config written: %s\n", path)
+			if minimal {
+				return nil
+			}
+
+			if completion != completionNone && in != nil {
+				install, err := confirm(cmd, in, fmt.Sprintf("Install %s completion?", completion), true)
+				if err != nil {
+					return err
+				}
+				if !install {
+					completion = completionNone
+				}
+			}
+			if completion != completionNone {
+				if err := installCompletion(cmd, completion); err != nil {
+					return err
+				}
+			}
+
+			showMotd, _ := cmd.Flags().GetBool("motd")
+			if !cmd.Flags().Changed("motd") && in != nil {
+				if showMotd, err = confirm(cmd, in, "Show the proverb of the day when you log in?", false); err != nil {
+					return err
+				}
+			}
+			if showMotd {
+				return installMotdSnippet(cmd)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("name", "n", "", "Default name to greet")
+	cmd.Flags().StringP("lang", "l", "", "Greeting language")
+	cmd.Flags().String("style", "", "Greeting style (friendly, formal, casual)")
+	cmd.Flags().String("completion", "", "Install completion for bash, zsh or fish, or none (default: the shell in $SHELL)")
+	cmd.Flags().Bool("motd", false, "Show the proverb of the day at login")
+	cmd.Flags().String("profile", "", "Shell profile edited for --motd (default: ~/.profile or ~/.zprofile)")
+	cmd.Flags().Bool("force", false, "Replace an existing config file")
+	cmd.Flags().Bool("minimal", false, "Only write the settings given as flags; ask nothing and install nothing else")
+	return cmd
+}
+
+// initConfig returns the configuration written by init: the settings given
+// as flags, and the answers to questions read from in for the others. A nil
+// in asks nothing.
+func initConfig(cmd *cobra.Command, in *bufio.Reader) (*config.Config, error) {
+	cfg := config.New()
+	for _, s := range initSettings {
+		if cmd.Flags().Changed(s.flag) {
+			value, _ := cmd.Flags().GetString(s.flag)
+			if err := cfg.Set(s.key, value); err != nil {
+				return nil, NewUsageError(err.Error(), "Run 'hello-gopher config --help' to see supported values")
+			}
+			continue
+		}
+		if in == nil {
+			continue
+		}
+
+		// Ask until the answer is valid; end of input picks the default
+		for {
+			answer, err := prompt.Ask(in, cmd.ErrOrStderr(), s.question, cfg.Value(s.key))
+			if err != nil {
+				return nil, NewSystemError("Failed to read the answer", err, "Pass the settings as flags or use --minimal")
+			}
+			if err := cfg.Set(s.key, answer); err != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), err)
+				continue
+			}
+			break
+		}
+	}
+	return cfg, nil
+}
+
+// confirm asks a yes/no question, answered with def on an empty answer
+func confirm(cmd *cobra.Command, in io.Reader, question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		answer, err := prompt.Ask(in, cmd.ErrOrStderr(), fmt.Sprintf("%s [%s]", question, hint), "")
+		if err != nil {
+			return false, NewSystemError("Failed to read the answer", err, "Pass the choices as flags or use --minimal")
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		if value, err := strconv.ParseBool(answer); err == nil {
+			return value, nil
+		}
+	}
+}
+
+// completionShell returns the shell given with --completion, or the shell
+// in $SHELL if completion can be installed for it, or completionNone
+func completionShell(cmd *cobra.Command) (string, error) {
+	if cmd.Flags().Changed("completion") {
+		shell, _ := cmd.Flags().GetString("completion")
+		switch shell {
+		case shellBash, shellZsh, shellFish, completionNone:
+			return shell, nil
+		}
+		return "", NewUsageError(
+			fmt.Sprintf("Unsupported shell for completion: %s", shell),
+			"Use --completion bash, zsh, fish or none; other shells can use 'hello-gopher completion'",
+		)
+	}
+
+	shell, _ := lookupEnv(cmd, "SHELL")
+	switch shell = filepath.Base(shell); shell {
+	case shellBash, shellZsh, shellFish:
+		return shell, nil
+	}
+	return completionNone, nil
+}
+
+// completionPath returns the file the completion script for shell is
+// installed to, where the shell finds it by itself. Zsh only looks there if
+// the directory is in $fpath.
+func completionPath(cmd *cobra.Command, shell string) (string, error) {
+	switch shell {
+	case shellBash:
+		dir, err := xdgHome(cmd, "XDG_DATA_HOME", ".local", "share")
+		return filepath.Join(dir, "bash-completion", "completions", "hello-gopher"), err
+	case shellZsh:
+		dir, err := xdgHome(cmd, "XDG_DATA_HOME", ".local", "share")
+		return filepath.Join(dir, "zsh", "site-functions", "_hello-gopher"), err
+	default:
+		dir, err := xdgHome(cmd, "XDG_CONFIG_HOME", ".config")
+		return filepath.Join(dir, "fish", "completions", "hello-gopher.fish"), err
+	}
+}
+
+// xdgHome returns the directory in the XDG variable env of cmd, or the
+// default below the home directory made of elem
+func xdgHome(cmd *cobra.Command, env string, elem ...string) (string, error) {
+	if dir, ok := lookupEnv(cmd, env); ok && filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{home}, elem...)...), nil
+}
+
+// installCompletion writes the completion script of the command tree for
+// shell
+func installCompletion(cmd *cobra.Command, shell string) error {
+	path, err := completionPath(cmd, shell)
+	if err != nil {
+		return NewSystemError("Failed to locate your home directory", err, "Use 'hello-gopher completion' to install completion by hand")
+	}
+
+	var script strings.Builder
+	switch shell {
+	case shellBash:
+		err = cmd.Root().GenBashCompletionV2(&script, true)
+	case shellZsh:
+		err = cmd.Root().GenZshCompletion(&script)
+	default:
+		err = cmd.Root().GenFishCompletion(&script, true)
+	}
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o750)
+	}
+	if err == nil {
+		// #nosec G306 -- completion scripts are sourced by the user's shell
+		err = os.WriteFile(path, []byte(script.String()), 0o644)
+	}
+	if err != nil {
+		return NewSystemError(fmt.Sprintf("Failed to install %s completion", shell), err, "Use 'hello-gopher completion' to install completion by hand")
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Completion installed: %s\n", path)
+	if shell == shellZsh {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Add 'fpath+=(%s)' before compinit in ~/.zshrc if it isn't there yet\n", filepath.Dir(path))
+	}
+	return nil
+}
+
+// installMotdSnippet adds the login message to the shell profile of the
+// user, like 'motd install --target profile'
+func installMotdSnippet(cmd *cobra.Command) error {
+	binary, err := executablePath()
+	if err != nil {
+		return err
+	}
+	path, err := profilePath(cmd)
+	if err != nil {
+		return err
+	}
+	result, err := motd.InstallSnippet(path, binary)
+	if err != nil {
+		return motdWriteError(path, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "MOTD %s: %s\n", result, path)
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+)
+
+// initEnv points the config file and the XDG directories of init into a
+// temporary directory and returns it
+func initEnv(t *testing.T, shell string) string {
+	t.Helper()
+	dir := t.TempDir()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG": filepath.Join(dir, "config.yaml"),
+		"XDG_DATA_HOME":       filepath.Join(dir, "data"),
+		"XDG_CONFIG_HOME":     filepath.Join(dir, "config"),
+		"SHELL":               shell,
+	})
+	return dir
+}
+
+// runInit runs init with input answering its questions on a terminal, or
+// without a terminal if input is empty
+func runInit(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+	deps := Deps{
+		In:         strings.NewReader(input),
+		IsTerminal: func(io.Reader) bool { return input != "" },
+	}
+	var out bytes.Buffer
+	deps.Out, deps.Err = &out, &out
+	root := NewRootCmd(testDeps(t, deps))
+	root.SetArgs(append([]string{"init"}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func loadInitConfig(t *testing.T, dir string) *config.Config {
+	t.Helper()
+	cfg, err := config.Load(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestInitInteractive(t *testing.T) {
+	dir := initEnv(t, "/bin/bash")
+	profile := filepath.Join(dir, ".profile")
+
+	// An invalid language is asked for again
+	out, err := runInit(t, "Alice\ntlh\nde\n\n\ny\n", "--profile", profile)
+	if err != nil {
+		t.Fatalf("init failed: %v\n%s", err, out)
+	}
+
+	cfg := loadInitConfig(t, dir)
+	for key, want := range map[string]string{config.KeyName: "Alice", config.KeyLanguage: "de", config.KeyStyle: "friendly"} {
+		if got := cfg.Value(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	for _, want := range []string{"Who should hello-gopher greet? [Gopher]", "unsupported language", "Install bash completion? [Y/n]", "Show the proverb of the day when you log in? [y/N]", "MOTD installed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output doesn't contain %q:\n%s", want, out)
+		}
+	}
+
+	script, err := os.ReadFile(filepath.Join(dir, "data", "bash-completion", "completions", "hello-gopher"))
+	if err != nil || !strings.Contains(string(script), "hello-gopher") {
+		t.Errorf("bash completion not installed: %v", err)
+	}
+	if data, _ := os.ReadFile(profile); !strings.Contains(string(data), "proverb --daily") {
+		t.Errorf("profile doesn't show the proverb of the day:\n%s", data)
+	}
+}
+
+func TestInitWithFlags(t *testing.T) {
+	dir := initEnv(t, "/usr/bin/zsh")
+
+	out, err := runInit(t, "", "--name", "Bob", "--style", "casual", "--completion", "fish")
+	if err != nil {
+		t.Fatalf("init failed: %v\n%s", err, out)
+	}
+	if cfg := loadInitConfig(t, dir); cfg.Value(config.KeyName) != "Bob" || cfg.Value(config.KeyStyle) != "casual" || cfg.IsSet(config.KeyLanguage) {
+		t.Errorf("unexpected config after init:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config", "fish", "completions", "hello-gopher.fish")); err != nil {
+		t.Errorf("fish completion not installed: %v", err)
+	}
+	if strings.Contains(out, "MOTD") || strings.Contains(out, "?") {
+		t.Errorf("init without a terminal asked questions or installed the MOTD:\n%s", out)
+	}
+}
+
+func TestInitMinimal(t *testing.T) {
+	dir := initEnv(t, "/bin/bash")
+
+	if out, err := runInit(t, "Alice\n", "--minimal", "--lang", "fr"); err != nil {
+		t.Fatalf("init failed: %v\n%s", err, out)
+	}
+	if cfg := loadInitConfig(t, dir); cfg.Value(config.KeyLanguage) != "fr" || cfg.IsSet(config.KeyName) {
+		t.Error("--minimal didn't write only the flags")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "data")); !os.IsNotExist(err) {
+		t.Errorf("--minimal installed completion: %v", err)
+	}
+}
+
+func TestInitKeepsExistingConfig(t *testing.T) {
+	dir := initEnv(t, "")
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("name: Kept\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := runInit(t, "", "--name", "New")
+	if !errors.Is(err, ErrUsage) {
+		t.Errorf("init over an existing config: %v, want a usage error", err)
+	}
+	if cfg := loadInitConfig(t, dir); cfg.Value(config.KeyName) != "Kept" {
+		t.Error("init changed an existing config without --force")
+	}
+
+	if out, err := runInit(t, "", "--name", "New", "--force"); err != nil {
+		t.Fatalf("init --force failed: %v\n%s", err, out)
+	}
+	if cfg := loadInitConfig(t, dir); cfg.Value(config.KeyName) != "New" {
+		t.Error("init --force didn't replace the config")
+	}
+}
+
+func TestInitInvalidFlags(t *testing.T) {
+	initEnv(t, "")
+	for _, args := range [][]string{{"--lang", "tlh"}, {"--completion", "tcsh"}} {
+		if _, err := runInit(t, "", args...); !errors.Is(err, ErrUsage) {
+			t.Errorf("init %v: %v, want a usage error", args, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// setupLogging builds the diagnostic logger selected with --verbose, --debug
+// and --log-format and attaches it to the context of cmd
+func setupLogging(cmd *cobra.Command) error {
+	level := slog.LevelWarn
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		level = slog.LevelInfo
+	}
+	if debug, _ := cmd.Flags().GetBool("debug"); debug {
+		level = slog.LevelDebug
+	}
+
+	format, _ := cmd.Flags().GetString("log-format")
+	logger, err := logging.New(cmd.ErrOrStderr(), format, level)
+	if err != nil {
+		return NewUsageError(
+			fmt.Sprintf("Unsupported log format: %s", format),
+			fmt.Sprintf("Use --log-format %s", strings.Join(logging.Formats(), " or --log-format ")),
+		)
+	}
+
+	cmd.SetContext(logging.NewContext(cmd.Context(), logger))
+	return nil
+}
+
+// commandLogger returns the diagnostic logger of cmd. Commands executed
+// without the root command, as in tests, get a logger that discards everything.
+func commandLogger(cmd *cobra.Command) *slog.Logger {
+	return logging.FromContext(cmd.Context())
+}
+
+// newGreetingService creates a greeting service logging to the logger of cmd
+func newGreetingService(cmd *cobra.Command, opts ...greeting.Option) *greeting.Service {
+	return greeting.NewService(append([]greeting.Option{greeting.WithLogger(commandLogger(cmd))}, opts...)...)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestLogging(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		contains []string
+		absent   []string
+	}{
+		{
+			name:   "quiet by default",
+			args:   []string{"proverb"},
+			absent: []string{"loaded"},
+		},
+		{
+			name:     "verbose",
+			args:     []string{"proverb", "--verbose"},
+			contains: []string{"level=INFO", `msg="loaded configuration"`, `msg="loaded proverbs"`, "duration="},
+			absent:   []string{"resolved setting"},
+		},
+		{
+			name:     "debug",
+			args:     []string{"proverb", "--debug"},
+			contains: []string{`msg="resolved setting" key=name value=Gopher source=default`},
+		},
+		{
+			name:     "json",
+			args:     []string{"proverb", "--verbose", "--log-format", "json"},
+			contains: []string{`"msg":"loaded proverbs"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, tt.args...)
+			if code != ExitSuccess {
+				t.Fatalf("unexpected exit code %d: %s", code, stderr)
+			}
+
+			if lines := strings.Split(strings.TrimSpace(stdout), "\n"); len(lines) != 1 {
+				t.Errorf("diagnostics leaked into stdout: %q", stdout)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(stderr, want) {
+					t.Errorf("stderr should contain %q, got %q", want, stderr)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(stderr, unwanted) {
+					t.Errorf("stderr should not contain %q, got %q", unwanted, stderr)
+				}
+			}
+		})
+	}
+}
+
+func TestLoggingInvalidFormat(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "proverb", "--log-format", "xml")
+	if code != ExitUsageError {
+		t.Errorf("expected usage error, got code %d (stderr %q)", code, stderr)
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/motd"
+	"github.com/spf13/cobra"
+)
+
+// MOTD install targets accepted by --target
+const (
+	motdTargetAuto       = "auto"
+	motdTargetUpdateMotd = "update-motd"
+	motdTargetProfile    = "profile"
+)
+
+// motdScriptDir is the update-motd.d directory; tests point it elsewhere
+var motdScriptDir = motd.ScriptDir
+
+// newMotdCmd creates the motd command and its subcommands, reading the
+// date of the proverb of the day from the clock in deps
+func newMotdCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "motd",
+		Short: "Show the proverb of the day when you log in",
+		Long: `MOTD command installs a login message that prints the proverb of the day.
+
+On Linux systems with /etc/update-motd.d, running 'motd install' as root adds a
+script shown to every user at login. Otherwise a snippet is added to your shell
+profile (~/.profile, or ~/.zprofile for zsh) that runs in interactive login
+shells. Installing again updates the existing entry instead of adding another.`,
+		Example: `  hello-gopher motd preview             # Show what the login message looks like
+  hello-gopher motd install             # Install for the current user or system-wide as root
+  sudo hello-gopher motd install --target update-motd
+  hello-gopher motd uninstall`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown motd subcommand: %s", args[0]),
+					"Run 'hello-gopher motd --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newMotdInstallCmd(), newMotdUninstallCmd(), newMotdPreviewCmd(deps))
+	return cmd
+}
+
+// newMotdInstallCmd creates the motd install command
+func newMotdInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Print the proverb of the day at login",
+		Args:  exactArgs(0, "motd install doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := motdTarget(cmd)
+			if err != nil {
+				return err
+			}
+
+			binary, err := executablePath()
+			if err != nil {
+				return err
+			}
+
+			var path string
+			var result motd.Result
+			if target == motdTargetUpdateMotd {
+				path = filepath.Join(motdScriptDir, motd.ScriptName)
+				result, err = motd.InstallScript(path, binary)
+			} else {
+				if path, err = profilePath(cmd); err != nil {
+					return err
+				}
+				result, err = motd.InstallSnippet(path, binary)
+			}
+			if err != nil {
+				return motdWriteError(path, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "MOTD %s: %s\n", result, path)
+			return nil
+		},
+	}
+
+	addMotdTargetFlags(cmd)
+	return cmd
+}
+
+// newMotdUninstallCmd creates the motd uninstall command
+func newMotdUninstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the login message",
+		Args:  exactArgs(0, "motd uninstall doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, _ := cmd.Flags().GetString("target")
+			if err := validateMotdTarget(target); err != nil {
+				return err
+			}
+
+			// In auto mode remove whatever was installed
+			if target == motdTargetAuto || target == motdTargetUpdateMotd {
+				path := filepath.Join(motdScriptDir, motd.ScriptName)
+				result, err := motd.UninstallScript(path)
+				if err != nil {
+					return motdWriteError(path, err)
+				}
+				if result == motd.Removed || target == motdTargetUpdateMotd {
+					fmt.Fprintf(cmd.OutOrStdout(), "MOTD %s: %s\n", result, path)
+				}
+			}
+
+			if target == motdTargetAuto || target == motdTargetProfile {
+				path, err := profilePath(cmd)
+				if err != nil {
+					return err
+				}
+				result, err := motd.UninstallSnippet(path)
+				if err != nil {
+					return motdWriteError(path, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "MOTD %s: %s\n", result, path)
+			}
+			return nil
+		},
+	}
+
+	addMotdTargetFlags(cmd)
+	return cmd
+}
+
+// newMotdPreviewCmd creates the motd preview command, reading the date
+// from the clock in deps
+func newMotdPreviewCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Print the login message",
+		Args:  exactArgs(0, "motd preview doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			opts, err := exclusionOptions(cfg)
+			if err != nil {
+				return err
+			}
+			now, err := today(cmd, cfg, deps)
+			if err != nil {
+				return err
+			}
+			proverb, err := newGreetingService(cmd, opts...).DailyProverb(now)
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+			fmt.Fprintln(cmd.OutOrStdout())
+			fmt.Fprintln(cmd.OutOrStdout(), proverb.Text)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// executablePath returns the resolved path of the running binary, which
+// login scripts call
+func executablePath() (string, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return "", NewSystemError("Failed to locate the hello-gopher binary", err, "")
+	}
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
+	return binary, nil
+}
+
+// validateMotdTarget checks the value of --target
+func validateMotdTarget(target string) error {
+	switch target {
+	case motdTargetAuto, motdTargetUpdateMotd, motdTargetProfile:
+		return nil
+	default:
+		return NewUsageError(
+			fmt.Sprintf("Unsupported MOTD target: %s", target),
+			"Use --target auto, --target update-motd or --target profile",
+		)
+	}
+}
+
+// motdTarget resolves --target, picking update-motd.d in auto mode only
+// when it is available and writable by the current user (root on Linux)
+func motdTarget(cmd *cobra.Command) (string, error) {
+	target, _ := cmd.Flags().GetString("target")
+	if err := validateMotdTarget(target); err != nil {
+		return "", err
+	}
+	if target != motdTargetAuto {
+		return target, nil
+	}
+
+	if runtime.GOOS == "linux" && os.Geteuid() == 0 {
+		if info, err := os.Stat(motdScriptDir); err == nil && info.IsDir() {
+			return motdTargetUpdateMotd, nil
+		}
+	}
+	return motdTargetProfile, nil
+}
+
+// profilePath returns the shell profile given with --profile, or the login
+// profile of the user's shell
+func profilePath(cmd *cobra.Command) (string, error) {
+	if path, _ := cmd.Flags().GetString("profile"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", NewSystemError("Failed to locate your home directory", err, "Pass the profile to edit with --profile")
+	}
+	if shell, _ := lookupEnv(cmd, "SHELL"); strings.HasSuffix(shell, "zsh") {
+		return filepath.Join(home, ".zprofile"), nil
+	}
+	return filepath.Join(home, ".profile"), nil
+}
+
+// motdWriteError reports a failure to change path, with a hint for
+// permission problems
+func motdWriteError(path string, err error) error {
+	suggestion := ""
+	if errors.Is(err, os.ErrPermission) {
+		suggestion = "Re-run with sudo, or use --target profile to change only your own login"
+	}
+	return NewSystemError(fmt.Sprintf("Failed to update %s", path), err, suggestion)
+}
+
+// addMotdTargetFlags registers the flags selecting where the login message is
+// installed
+func addMotdTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("target", motdTargetAuto, "Where to install: auto, update-motd or profile")
+	cmd.Flags().String("profile", "", "Shell profile to edit (default: ~/.profile or ~/.zprofile)")
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+// runMotd runs a motd subcommand and returns everything it printed together
+// with its exit code
+func runMotd(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	stdout, stderr, code := testsupport.RunCommand(t, append([]string{"motd"}, args...)...)
+	return stdout + stderr, code
+}
+
+func TestMotdProfileInstallUninstall(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), ".profile")
+
+	out, code := runMotd(t, "install", "--target", "profile", "--profile", profile)
+	if code != ExitSuccess {
+		t.Fatalf("install exited with %d: %s", code, out)
+	}
+	if !strings.Contains(out, "MOTD installed") {
+		t.Errorf("install output = %q", out)
+	}
+
+	out, _ = runMotd(t, "install", "--target", "profile", "--profile", profile)
+	if !strings.Contains(out, "MOTD unchanged") {
+		t.Errorf("second install output = %q", out)
+	}
+
+	data, _ := os.ReadFile(profile)
+	if !strings.Contains(string(data), "proverb --daily") {
+		t.Errorf("profile snippet missing:\n%s", data)
+	}
+
+	out, code = runMotd(t, "uninstall", "--target", "profile", "--profile", profile)
+	if code != ExitSuccess || !strings.Contains(out, "MOTD removed") {
+		t.Errorf("uninstall output = %q, exit code %d", out, code)
+	}
+}
+
+func TestMotdScriptInstall(t *testing.T) {
+	original := motdScriptDir
+	motdScriptDir = t.TempDir()
+	t.Cleanup(func() { motdScriptDir = original })
+
+	if out, code := runMotd(t, "install", "--target", "update-motd"); code != ExitSuccess {
+		t.Fatalf("install exited with %d: %s", code, out)
+	}
+	if _, err := os.Stat(filepath.Join(motdScriptDir, "60-hello-gopher")); err != nil {
+		t.Errorf("script not installed: %v", err)
+	}
+
+	// Auto uninstall removes the script and tolerates a missing profile snippet
+	profile := filepath.Join(t.TempDir(), ".profile")
+	out, code := runMotd(t, "uninstall", "--profile", profile)
+	if code != ExitSuccess || !strings.Contains(out, "MOTD removed") || !strings.Contains(out, "MOTD not installed") {
+		t.Errorf("uninstall output = %q, exit code %d", out, code)
+	}
+}
+
+func TestMotdErrors(t *testing.T) {
+	if out, code := runMotd(t, "install", "--target", "crontab"); code != ExitUsageError {
+		t.Errorf("Expected usage error for an unknown target, got code %d: %s", code, out)
+	}
+
+	original := motdScriptDir
+	motdScriptDir = filepath.Join(t.TempDir(), "missing")
+	t.Cleanup(func() { motdScriptDir = original })
+
+	if out, code := runMotd(t, "install", "--target", "update-motd"); code != ExitSystemError {
+		t.Errorf("Expected system error for a missing update-motd.d, got code %d: %s", code, out)
+	}
+}
+
+func TestMotdPreview(t *testing.T) {
+	out, code := runMotd(t, "preview")
+	if code != ExitSuccess {
+		t.Fatalf("preview exited with %d: %s", code, out)
+	}
+	if strings.TrimSpace(out) == "" {
+		t.Error("preview should print the proverb of the day")
+	}
+}
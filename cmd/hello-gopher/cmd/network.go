@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/httpclient"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+// addNetworkFlags adds the flags governing network operations to cmd, the
+// root command, so they apply to every command
+func addNetworkFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Duration("timeout", httpclient.DefaultTimeout, "How long a network operation may take, including retries; update checks give up after 2s unless set")
+	cmd.PersistentFlags().Int("retries", httpclient.DefaultRetries, "How often a failed network request is retried, with exponential backoff")
+	cmd.PersistentFlags().String("proxy", "", "Proxy URL for network requests, such as http://proxy.example.com:3128 (default: $HTTPS_PROXY or $HTTP_PROXY)")
+}
+
+// transportKey is the context key of the HTTP transport set up by
+// setupNetwork
+type transportKey struct{}
+
+// setupNetwork validates the network flags and stores the transport of the
+// network operations of cmd, which connects through --proxy or the proxy
+// chosen by the environment, in its context
+func setupNetwork(cmd *cobra.Command) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	retries, _ := cmd.Flags().GetInt("retries")
+	if timeout <= 0 || retries < 0 {
+		return NewUsageError(
+			fmt.Sprintf("--timeout must be positive and --retries can't be negative, got %v and %d", timeout, retries),
+			"Use values such as --timeout 10s --retries 2",
+		)
+	}
+
+	flag, _ := cmd.Flags().GetString("proxy")
+	proxy, err := httpclient.Proxy(flag, commandDeps(cmd).lookupEnv)
+	if err != nil {
+		return NewUsageError(err.Error(), "Use a URL such as --proxy http://proxy.example.com:3128")
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), transportKey{}, httpclient.NewTransport(proxy)))
+	return nil
+}
+
+// networkTransport returns the transport set up for cmd. Commands executed
+// without the root command, as in tests, use http.DefaultTransport.
+func networkTransport(cmd *cobra.Command) http.RoundTripper {
+	if ctx := cmd.Context(); ctx != nil {
+		if t, ok := ctx.Value(transportKey{}).(http.RoundTripper); ok {
+			return t
+		}
+	}
+	return http.DefaultTransport
+}
+
+// networkTimeout returns --timeout if it was set, or else fallback, the
+// default of the operation
+func networkTimeout(cmd *cobra.Command, fallback time.Duration) time.Duration {
+	if !cmd.Flags().Changed("timeout") {
+		return fallback
+	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	return timeout
+}
+
+// networkRetries returns how often a failed request of cmd is retried
+func networkRetries(cmd *cobra.Command) int {
+	retries, err := cmd.Flags().GetInt("retries")
+	if err != nil {
+		// Commands executed without the root command, as in tests
+		return httpclient.DefaultRetries
+	}
+	return retries
+}
+
+// httpClient returns an HTTP client whose requests are logged to the logger
+// of cmd and retried as --retries allows. A call may take --timeout, or
+// fallback if the flag wasn't set; zero leaves the timeout to the caller.
+func httpClient(cmd *cobra.Command, fallback time.Duration) *http.Client {
+	return httpclient.New(logging.Transport(commandLogger(cmd), networkTransport(cmd)), networkTimeout(cmd, fallback), networkRetries(cmd))
+}
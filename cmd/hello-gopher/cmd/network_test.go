@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+// flakyServer starts a server failing with 503 until it was called fails
+// times, and returns its URL and the number of calls
+func flakyServer(t *testing.T, fails int32) (string, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= fails {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"greeting": "Hello, Alice!", "name": "Alice"}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts.URL, &calls
+}
+
+func TestNetworkRetries(t *testing.T) {
+	appDirs(t)
+
+	url, calls := flakyServer(t, 2)
+	if _, stderr, code := testsupport.RunCommand(t, "post", "--webhook", url, "--retries", "2"); code != ExitSuccess {
+		t.Errorf("post with --retries 2 failed with exit code %d: %s", code, stderr)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("webhook called %d times, want 3", n)
+	}
+
+	url, calls = flakyServer(t, 1)
+	if _, _, code := testsupport.RunCommand(t, "post", "--webhook", url, "--retries", "0"); code == ExitSuccess || calls.Load() != 1 {
+		t.Errorf("post with --retries 0 exited with %d after %d calls, want a failure after 1", code, calls.Load())
+	}
+
+	// --server requests are retried as often as --retries allows, not more
+	url, calls = flakyServer(t, 1)
+	stdout, stderr, code := testsupport.RunCommand(t, "--server", url, "--retries", "1", "greet", "-n", "Alice")
+	if code != ExitSuccess || stdout != "Hello, Alice!\n" {
+		t.Errorf("greet with --server and --retries 1 = %q, exit code %d: %s", stdout, code, stderr)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("server called %d times, want 2", n)
+	}
+}
+
+func TestNetworkFlagsInvalid(t *testing.T) {
+	appDirs(t)
+
+	for _, args := range [][]string{{"--timeout", "0s"}, {"--retries", "-1"}} {
+		_, stderr, code := testsupport.RunCommand(t, append(args, "greet")...)
+		if code != ExitUsageError || !strings.Contains(stderr, "--timeout must be positive") {
+			t.Errorf("%v exited with %d: %q, want a usage error", args, code, stderr)
+		}
+	}
+}
+
+func TestNetworkProxy(t *testing.T) {
+	var requested []string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Method+" "+r.URL.String())
+	}))
+	t.Cleanup(stub.Close)
+
+	appDirs(t)
+	if _, stderr, code := testsupport.RunCommand(t, "--proxy", stub.URL, "post", "--webhook", "http://hooks.example.com/services/T1"); code != ExitSuccess {
+		t.Fatalf("post through --proxy failed with exit code %d: %s", code, stderr)
+	}
+
+	// HTTP_PROXY applies without the flag, unless NO_PROXY lists the host
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "config.yaml"),
+		"HTTP_PROXY":          stub.URL,
+		"NO_PROXY":            "direct.invalid",
+	})
+	if _, stderr, code := testsupport.RunCommand(t, "post", "--webhook", "http://hooks.example.com/services/T2"); code != ExitSuccess {
+		t.Fatalf("post through HTTP_PROXY failed with exit code %d: %s", code, stderr)
+	}
+	if _, _, code := testsupport.RunCommand(t, "post", "--retries", "0", "--webhook", "http://direct.invalid/"); code == ExitSuccess {
+		t.Error("post to a NO_PROXY host succeeded, want it to bypass the proxy and fail")
+	}
+
+	want := []string{"POST http://hooks.example.com/services/T1", "POST http://hooks.example.com/services/T2"}
+	if strings.Join(requested, "\n") != strings.Join(want, "\n") {
+		t.Errorf("proxy got %q, want %q", requested, want)
+	}
+
+	_, stderr, code := testsupport.RunCommand(t, "--proxy", "ftp://proxy.example.com", "greet")
+	if code != ExitUsageError || !strings.Contains(stderr, "unsupported scheme") {
+		t.Errorf("--proxy ftp://... exited with %d: %q, want a usage error", code, stderr)
+	}
+}
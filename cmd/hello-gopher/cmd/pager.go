@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+)
+
+// defaultPager is used when $PAGER is unset
+const defaultPager = "less -FRX"
+
+// writePaged writes content to out, piping it through $PAGER when out is a terminal.
+// It falls back to writing directly if paging is disabled or the pager cannot be started.
+func writePaged(out io.Writer, content string, usePager bool) error {
+	if usePager && color.IsTerminal(out) {
+		pager := os.Getenv("PAGER")
+		if pager == "" {
+			pager = defaultPager
+		}
+		if started, err := runPager(out, content, pager); started {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(out, content)
+	return err
+}
+
+// runPager pipes content through the pager command line to out and reports
+// whether the pager started. A started pager has shown the content, or the
+// user quit it early, so it must not be written again even if the pager
+// then fails.
+func runPager(out io.Writer, content, pager string) (started bool, err error) {
+	fields := strings.Fields(pager)
+	if len(fields) == 0 {
+		return false, nil
+	}
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		return false, err
+	}
+
+	// #nosec G204 -- the pager command is chosen by the user via $PAGER
+	pagerCmd := exec.Command(path, fields[1:]...)
+	pagerCmd.Stdin = strings.NewReader(content)
+	pagerCmd.Stdout = out
+	pagerCmd.Stderr = os.Stderr
+	if err := pagerCmd.Start(); err != nil {
+		return false, err
+	}
+	return true, pagerCmd.Wait()
+}
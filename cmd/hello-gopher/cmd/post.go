@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/httpclient"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+// envWebhook supplies the webhook URL, which usually embeds a secret
+const envWebhook = config.EnvPrefix + "WEBHOOK"
+
+// newPostCmd creates the post command, greeting with the greeter in deps
+func newPostCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "post",
+		Short: "Post the daily proverb or a greeting to Slack or Discord",
+		Long: `Post command sends the proverb of the day, or a greeting with --greet, to a
+Slack or Discord incoming webhook, formatted for the platform's payload schema.
+
+The webhook URL is read from --webhook or $HELLO_GOPHER_WEBHOOK. Use --dry-run
+to print the JSON payload instead of sending it.
+
+Deliveries failing because of the network, rate limiting or a server error
+are retried ` + fmt.Sprint(webhook.DefaultRetries) + ` times with exponential backoff, unless --retries is set. Every
+delivery carries an Idempotency-Key header that stays the same across
+retries, so receivers can drop duplicates. Payloads that still can't be
+delivered are kept in ` + webhook.DeadLetterFileName + ` in the state directory, as are
+those of proverb --watch; --redeliver sends them again with their original
+keys and webhook URLs.`,
+		Example: `  hello-gopher post --webhook https://hooks.slack.com/services/...
+  hello-gopher post --platform discord --webhook https://discord.com/api/webhooks/...
+  hello-gopher post --greet --name Team --dry-run
+  hello-gopher post --redeliver                # Retry the failed deliveries`,
+		Args: exactArgs(0, "post doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if redeliver, _ := cmd.Flags().GetBool("redeliver"); redeliver {
+				return redeliverWebhooks(cmd)
+			}
+
+			platformName, _ := cmd.Flags().GetString("platform")
+			platform, err := webhook.ParsePlatform(platformName)
+			if err != nil {
+				return NewUsageError(err.Error(), "Use --platform slack or --platform discord")
+			}
+
+			url, _ := cmd.Flags().GetString("webhook")
+			if url == "" {
+				url, _ = lookupEnv(cmd, envWebhook)
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if url == "" && !dryRun {
+				return NewUsageError(
+					"No webhook URL given",
+					"Pass --webhook <url>, set $HELLO_GOPHER_WEBHOOK, or preview the payload with --dry-run",
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg, err := buildPostMessage(cmd, cfg, deps)
+			if err != nil {
+				return err
+			}
+
+			payload, err := webhook.Payload(platform, msg)
+			if err != nil {
+				return NewSystemError("Failed to build webhook payload", err, "")
+			}
+
+			if dryRun {
+				fmt.Fprintln(cmd.OutOrStdout(), string(payload))
+				return nil
+			}
+
+			if deadLetters, err := deliverWebhook(cmd.Context(), cmd, webhook.NewDelivery(url, platform, payload)); err != nil {
+				return webhookError(platform, deadLetters, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Posted to %s\n", platform)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("webhook", "", "Incoming webhook URL (default: $HELLO_GOPHER_WEBHOOK)")
+	cmd.Flags().String("platform", string(webhook.Slack), "Payload format (slack, discord)")
+	cmd.Flags().Bool("greet", false, "Post a greeting instead of the proverb of the day")
+	cmd.Flags().StringP("name", "n", "", "Name to greet with --greet (default: Gopher)")
+	cmd.Flags().Bool("dry-run", false, "Print the JSON payload instead of posting it")
+	cmd.Flags().Bool("redeliver", false, "Send the payloads of failed deliveries again")
+	return cmd
+}
+
+// redeliverWebhooks sends the dead letters again, or with --dry-run prints
+// their payloads
+func redeliverWebhooks(cmd *cobra.Command) error {
+	path, err := deadLetterPath(cmd)
+	if err != nil {
+		return err
+	}
+	letters, err := webhook.LoadDeadLetters(path)
+	if err != nil {
+		return NewDataError(fmt.Sprintf("Failed to read failed deliveries: %v", err), err, fmt.Sprintf("Remove %s to start over", path))
+	}
+	out := cmd.OutOrStdout()
+	if len(letters) == 0 {
+		fmt.Fprintln(out, "No failed deliveries to redeliver.")
+		return nil
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		for _, l := range letters {
+			fmt.Fprintf(out, "%s delivery %s, failed %s after %s: %s\n%s\n",
+				l.Platform, l.Key, l.FailedAt.Local().Format("2006-01-02 15:04"), plural(l.Attempts, "attempt"), l.Error, l.Payload)
+		}
+		return nil
+	}
+
+	delivered, remaining, err := webhook.Redeliver(cmd.Context(), webhookClient(cmd), path)
+	fmt.Fprintf(out, "Redelivered %d of %s\n", delivered, plural(len(letters), "payload"))
+	if err != nil {
+		return NewSystemError(
+			fmt.Sprintf("%s still failed", plural(len(remaining), "delivery")),
+			err,
+			fmt.Sprintf("They stay in %s; run 'hello-gopher post --redeliver' again later", path),
+		)
+	}
+	return nil
+}
+
+// webhookClient returns the HTTP client of webhook deliveries, which are
+// retried webhook.DefaultRetries times unless --retries is set
+func webhookClient(cmd *cobra.Command) *http.Client {
+	retries := webhook.DefaultRetries
+	if cmd.Flags().Changed("retries") {
+		retries = networkRetries(cmd)
+	}
+	return httpclient.New(logging.Transport(commandLogger(cmd), networkTransport(cmd)), networkTimeout(cmd, webhook.DefaultTimeout), retries)
+}
+
+// deliverWebhook delivers d. If the delivery fails for other reasons than
+// ctx being canceled, d is added to the dead letters and their path is
+// returned with the error.
+func deliverWebhook(ctx context.Context, cmd *cobra.Command, d webhook.Delivery) (string, error) {
+	err := webhook.Deliver(ctx, webhookClient(cmd), d)
+	if err == nil || ctx.Err() != nil {
+		return "", err
+	}
+
+	path, pathErr := deadLetterPath(cmd)
+	if pathErr == nil {
+		_, pathErr = webhook.AddDeadLetter(path, d, err)
+	}
+	if pathErr != nil {
+		commandLogger(cmd).Warn("failed to keep the failed delivery", "error", pathErr)
+		return "", err
+	}
+	return path, err
+}
+
+// webhookError reports a failed delivery to platform, which was kept in
+// deadLetters unless that is empty
+func webhookError(platform webhook.Platform, deadLetters string, err error) *CLIError {
+	suggestion := "Check the webhook URL and your network connection"
+	if deadLetters != "" {
+		suggestion += "; the payload was kept in " + deadLetters + ", send it again with 'hello-gopher post --redeliver'"
+	}
+	return NewSystemError(fmt.Sprintf("Failed to post to %s", platform), err, suggestion)
+}
+
+// deadLetterPath returns the dead-letter file in the state directory
+func deadLetterPath(cmd *cobra.Command) (string, error) {
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, webhook.DeadLetterFileName), nil
+}
+
+// buildPostMessage returns the greeting requested with --greet, or the
+// proverb of the day. It is shared by post and send.
+func buildPostMessage(cmd *cobra.Command, cfg *config.Config, deps Deps) (webhook.Message, error) {
+	if greet, _ := cmd.Flags().GetBool("greet"); greet {
+		result, err := buildGreeting(cmd, cfg, deps)
+		if err != nil {
+			return webhook.Message{}, err
+		}
+		return webhook.Message{Text: result.Greeting}, nil
+	}
+
+	opts, err := exclusionOptions(cfg)
+	if err != nil {
+		return webhook.Message{}, err
+	}
+	now, err := today(cmd, cfg, deps)
+	if err != nil {
+		return webhook.Message{}, err
+	}
+	f, err := resolveLocale(cmd, cfg)
+	if err != nil {
+		return webhook.Message{}, err
+	}
+	proverb, err := newGreetingService(cmd, opts...).DailyProverb(now)
+	if err != nil {
+		return webhook.Message{}, NewDataError("Failed to load proverbs", err, "")
+	}
+	return webhook.Message{
+		Text:   proverb.Text,
+		Footer: fmt.Sprintf("Go Proverb #%d · proverb of the day, %s", proverb.ID, f.Date(now)),
+		Quote:  true,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/webhook"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
+)
+
+// runPost runs the post command and fails the test unless it succeeds
+func runPost(t *testing.T, args ...string) string {
+	t.Helper()
+	stdout, stderr, code := testsupport.RunCommand(t, append([]string{"post"}, args...)...)
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	return stdout
+}
+
+func TestPostDryRun(t *testing.T) {
+	out := runPost(t, "--dry-run", "--greet", "-n", "Team", "--platform", "discord")
+	if !strings.Contains(out, `"content": "Hello, Team!"`) {
+		t.Errorf("Unexpected Discord payload:\n%s", out)
+	}
+
+	out = runPost(t, "--dry-run")
+	if !strings.Contains(out, `"blocks"`) || !strings.Contains(out, "proverb of the day") {
+		t.Errorf("Unexpected Slack payload:\n%s", out)
+	}
+}
+
+func TestPostDailyDate(t *testing.T) {
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml"),
+		"HELLO_GOPHER_LANG":   "fr",
+		"HELLO_GOPHER_TZ":     "UTC",
+	})
+
+	var out bytes.Buffer
+	root := NewRootCmd(testDeps(t, Deps{Out: &out, Err: &out, Clock: greetingtest.NewFakeClock(time.Date(2026, 11, 10, 9, 0, 0, 0, time.UTC))}))
+	root.SetArgs([]string{"post", "--dry-run"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("post failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "proverb of the day, 10 novembre 2026") {
+		t.Errorf("Slack payload misses the French date of the day:\n%s", out.String())
+	}
+}
+
+func TestPostSendsToWebhook(t *testing.T) {
+	var received string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+	}))
+	defer ts.Close()
+
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":  filepath.Join(t.TempDir(), "missing.yaml"),
+		"HELLO_GOPHER_WEBHOOK": ts.URL,
+	})
+
+	out := runPost(t, "--greet")
+	if !strings.Contains(out, "Posted to slack") || !strings.Contains(received, "Hello, Gopher!") {
+		t.Errorf("output %q, webhook received %q", out, received)
+	}
+}
+
+func TestPostErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service", http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing webhook", []string{}, ExitUsageError},
+		{"unknown platform", []string{"--platform", "teams", "--dry-run"}, ExitUsageError},
+		{"webhook rejects payload", []string{"--webhook", ts.URL}, ExitSystemError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, append([]string{"post"}, tt.args...)...)
+			if code != tt.want {
+				t.Errorf("Expected exit code %d, got %d (stderr %q)", tt.want, code, stderr)
+			}
+		})
+	}
+}
+
+func TestPostRedeliver(t *testing.T) {
+	_, _, state := appDirs(t)
+	up := false
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(webhook.IdempotencyHeader))
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	_, stderr, code := testsupport.RunCommand(t, "post", "--greet", "--webhook", ts.URL, "--retries", "1")
+	if code != ExitSystemError || !strings.Contains(stderr, "post --redeliver") {
+		t.Fatalf("post to a failing webhook = exit %d, %q; want a system error pointing to --redeliver", code, stderr)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("webhook received idempotency keys %q, want the same key on every attempt", keys)
+	}
+	path := filepath.Join(state, webhook.DeadLetterFileName)
+	if letters, err := webhook.LoadDeadLetters(path); err != nil || len(letters) != 1 || letters[0].Key != keys[0] {
+		t.Fatalf("dead letters = %+v, %v; want the failed delivery", letters, err)
+	}
+
+	out := runPost(t, "--redeliver", "--dry-run")
+	if !strings.Contains(out, "Hello, Gopher!") || !strings.Contains(out, "after 1 attempt") {
+		t.Errorf("post --redeliver --dry-run = %q, want the kept payload", out)
+	}
+
+	up, keys = true, nil
+	if out := runPost(t, "--redeliver"); !strings.Contains(out, "Redelivered 1 of 1 payload") {
+		t.Errorf("post --redeliver = %q", out)
+	}
+	if len(keys) != 1 {
+		t.Errorf("webhook received %d redeliveries, want 1", len(keys))
+	}
+	if out := runPost(t, "--redeliver"); !strings.Contains(out, "No failed deliveries") {
+		t.Errorf("post --redeliver after success = %q", out)
+	}
+}
@@ -2,49 +2,336 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/art"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
 	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
 	"github.com/spf13/cobra"
 )
 
-var proverbCmd = &cobra.Command{
-	Use:   "proverb",
-	Short: "Display a random Go proverb",
-	Long: `Proverb command displays random Go proverbs to inspire and educate.
+// defaultTerminalWidth is the width proverbs are wrapped to and tables are
+// fitted to on a terminal when $COLUMNS doesn't tell
+const defaultTerminalWidth = 80
+
+// newProverbCmd creates the proverb command and its subcommands, drawing
+// proverbs from the provider in deps
+func newProverbCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proverb",
+		Short: "Display a random Go proverb",
+		Long: `Proverb command displays random Go proverbs to inspire and educate.
 Each execution shows a different proverb from a curated collection of Go programming
 wisdom and best practices.
 
+With --with-source the author and the URL of the talk or post the proverb
+comes from are printed beneath it, where known; JSON output always includes
+them.
+
+With --playlist proverbs come from a playlist an admin curated, such as an
+onboarding sequence for new hires. Playlists list proverb IDs and tags in
+the config file or in playlists.yaml in the data directory:
+
+  playlist.onboarding: [15, 4, 1, errors]
+  playlist.concurrency-week: [concurrency=3, design]
+
+An ordered playlist shows its proverbs one after the other across runs and
+then starts over; --reset starts it over early. A weight such as
+concurrency=3 on any item makes the playlist weighted: every run picks an
+item at random in proportion to the weights, 1 unless given.
+
+With --generate a parody proverb is made up for fun by a Markov chain over
+the words of the collection, and labeled as such. Use --seed to get the
+same one again.
+
+With --lang, or the language setting greetings use, proverbs are shown in
+that language where a translation exists, and in English otherwise.
+
+With --push-metrics a one-shot run, such as a daily cron job, pushes its
+outcome to the Prometheus Pushgateway given with --pushgateway or the
+pushgateway setting: the time, duration and success of the run and the time
+of the last success, so operators can alert when the job stops running.
+
+Long proverbs are wrapped to the width of the terminal, with the lines after
+the first indented; use --width to pick the width. Output that isn't a
+terminal gets every proverb on a single line.
+
 This command demonstrates integration with the ProverbProvider interface and
 proper error handling for data loading failures.`,
-	Example: `  hello-gopher proverb                  # Display a random Go proverb`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Validate that no unexpected arguments were provided
-		if len(args) > 0 {
-			return NewUsageError(
-				fmt.Sprintf("Unexpected argument(s): %v", args),
-				"The proverb command doesn't accept any arguments",
-			)
+		Example: `  hello-gopher proverb                  # Display a random Go proverb
+  hello-gopher proverb --seed 42        # Reproducible proverb for docs and CI
+  hello-gopher proverb --daily          # The proverb of the day
+  hello-gopher proverb --lang fr        # In French where translated
+  hello-gopher proverb --with-source    # Who said it, and where
+  hello-gopher proverb --no-repeat      # No repeats until every proverb was shown
+  hello-gopher proverb --playlist onboarding  # The next proverb of a playlist
+  hello-gopher proverb --generate --seed 7    # A made-up parody proverb
+  hello-gopher proverb --watch 1h       # Print a new proverb every hour
+  hello-gopher proverb --daily --push-metrics --pushgateway http://pushgateway:9091
+  hello-gopher --server https://proverbs.example.com proverb  # From a shared server
+  hello-gopher proverb --bubble         # A gopher recites the proverb
+  hello-gopher proverb list --numbered  # List every proverb with its ID`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate that no unexpected arguments were provided
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unexpected argument(s): %v", args),
+					"The proverb command doesn't accept any arguments",
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			if daily, _ := cmd.Flags().GetBool("daily"); daily && cmd.Flags().Changed("seed") {
+				return NewUsageError(
+					"--daily and --seed cannot be combined",
+					"The proverb of the day doesn't depend on a seed; drop one of the flags",
+				)
+			}
+			if daily, _ := cmd.Flags().GetBool("daily"); daily && cmd.Flags().Changed("no-repeat") {
+				return NewUsageError(
+					"--daily and --no-repeat cannot be combined",
+					"The proverb of the day is the same all day; drop one of the flags",
+				)
+			}
+
+			remote, server, err := remoteClient(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			if remote != nil {
+				return runRemoteProverb(cmd, cfg, deps, remote, server, styler, output)
+			}
+
+			lang, err := resolveLanguage(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			// Create the proverb provider and get a random proverb
+			opts, err := userProverbOptions(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("seed") {
+				seed, _ := cmd.Flags().GetInt64("seed")
+				opts = append(opts, greeting.WithSeed(seed))
+			}
+			provider, err := deps.proverbProvider(cmd, cfg, opts...)
+			if err != nil {
+				return err
+			}
+
+			// Load proverbs first to handle any loading errors
+			if err := provider.LoadProverbs(); err != nil {
+				return NewDataError(
+					"Failed to load Go proverbs",
+					err,
+					"This appears to be a data issue. Please check if the application was built correctly",
+				)
+			}
+
+			// Seeded runs pick the unseen proverbs reproducibly, too
+			rnd := deps.random()
+			if cmd.Flags().Changed("seed") {
+				seed, _ := cmd.Flags().GetInt64("seed")
+				rnd = greeting.NewRand(seed)
+			}
+			provider, err = withNoRepeat(cmd, provider, rnd)
+			if err != nil {
+				return err
+			}
+			provider, err = withPlaylist(cmd, cfg, provider, rnd)
+			if err != nil {
+				return err
+			}
+			provider, err = withGenerate(cmd, provider)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("watch") {
+				if daily, _ := cmd.Flags().GetBool("daily"); daily {
+					return NewUsageError(
+						"--daily and --watch cannot be combined",
+						"The proverb of the day changes only once a day; use --watch 24h without --daily",
+					)
+				}
+				return watchProverbs(cmd, deps.random(), provider, func(proverb greeting.Proverb) error {
+					recordRun(cmd, cfg, deps, "proverb", proverb.ID)
+					rememberProverb(cmd, proverb.ID)
+					proverb, _ = greeting.Translate(proverb, lang)
+					return printProverb(cmd, styler, output, proverb)
+				})
+			}
+
+			now, err := today(cmd, cfg, deps)
+			if err != nil {
+				return err
+			}
+			var proverb greeting.Proverb
+			if daily, _ := cmd.Flags().GetBool("daily"); daily {
+				proverb, err = dailyProverb(provider, now)
+			} else {
+				proverb, err = randomEntry(provider)
+			}
+			if err != nil {
+				return NewDataError("Failed to select a Go proverb", err, "")
+			}
+			recordRun(cmd, cfg, deps, "proverb", proverb.ID)
+			rememberProverb(cmd, proverb.ID)
+
+			proverb, _ = greeting.Translate(proverb, lang)
+			return printProverb(cmd, styler, output, proverb)
+		},
+	}
+
+	cmd.Flags().Int64("seed", 0, "Seed the random selection for reproducible output")
+	cmd.Flags().Bool("daily", false, "Show the proverb of the day instead of a random one")
+	cmd.Flags().Bool("generate", false, "Make up a parody proverb with a Markov chain over the collection")
+	cmd.Flags().String("playlist", "", "Show the next proverb of this playlist from the config or playlist file")
+	cmd.Flags().Bool("with-source", false, "Print the author and source URL beneath the proverb")
+	cmd.Flags().String("variant", art.DefaultVariant, "Art variant used with --bubble")
+	cmd.Flags().StringP("lang", "l", "", fmt.Sprintf("Proverb language (%s); untranslated proverbs are shown in English", strings.Join(greeting.Languages(), ", ")))
+	addBubbleFlags(cmd)
+	addWatchFlags(cmd)
+	addRainbowFlag(cmd)
+	addNoRepeatFlags(cmd)
+	addPushMetricsFlags(cmd)
+	cmd.RunE = withPushMetrics(cmd.RunE)
+
+	cmd.AddCommand(newProverbListCmd())
+	cmd.AddCommand(newProverbExportCmd())
+	cmd.AddCommand(newProverbImportCmd())
+	cmd.AddCommand(newProverbExplainCmd())
+	cmd.AddCommand(newProverbLintCmd())
+	cmd.AddCommand(newProverbConvertCmd())
+	cmd.AddCommand(newProverbSuggestCmd())
+	return cmd
+}
+
+// printProverb writes proverb to the command output in the requested format
+func printProverb(cmd *cobra.Command, styler color.Styler, output string, proverb greeting.Proverb) error {
+	if output == outputJSON {
+		return writeJSON(cmd.OutOrStdout(), proverb)
+	}
+
+	if bubble, _ := cmd.Flags().GetBool("bubble"); bubble && !accessible(cmd) {
+		variant, _ := cmd.Flags().GetString("variant")
+		rendered, err := withBubble(cmd, variant, styler.Quote(proverb.Text))
+		if err != nil {
+			return err
 		}
+		fmt.Fprint(cmd.OutOrStdout(), withRainbow(cmd, styler, rendered, 0))
+		printLabel(cmd, styler, proverb)
+		printSource(cmd, styler, proverb)
+		return nil
+	}
+
+	width, err := proverbWidth(cmd)
+	if err != nil {
+		return err
+	}
+	lines := wrapProverb(proverb.Text, width)
+	for i, line := range lines {
+		lines[i] = styler.Quote(line)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), withRainbow(cmd, styler, strings.Join(lines, "\n"), 0))
+	printLabel(cmd, styler, proverb)
+	printSource(cmd, styler, proverb)
+	return nil
+}
 
-		// Create greeting service and get a random proverb
-		service := greeting.NewService()
-		
-		// Load proverbs first to handle any loading errors
-		if err := service.LoadProverbs(); err != nil {
-			return NewDataError(
-				"Failed to load Go proverbs",
-				err,
-				"This appears to be a data issue. Please check if the application was built correctly",
+// printLabel marks a generated proverb as such beneath it
+func printLabel(cmd *cobra.Command, styler color.Styler, proverb greeting.Proverb) {
+	if !proverb.Generated {
+		return
+	}
+	if accessible(cmd) {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s.\n", generatedLabel)
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", styler.Muted("("+generatedLabel+")"))
+}
+
+// printSource writes the author and source URL of proverb beneath it with
+// --with-source. Proverbs without them get no lines.
+func printSource(cmd *cobra.Command, styler color.Styler, proverb greeting.Proverb) {
+	if withSource, _ := cmd.Flags().GetBool("with-source"); !withSource {
+		return
+	}
+	if proverb.Author != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", styler.Muted(attribution(cmd, proverb.Author)))
+	}
+	if proverb.Source != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", styler.Muted(proverb.Source))
+	}
+}
+
+// proverbWidth returns the width proverbs are wrapped to: --width, which
+// also sets the width of --bubble, or the width of the terminal in $COLUMNS
+// if the output is one. Output that isn't
+// a terminal isn't wrapped, so scripts get a proverb per line.
+func proverbWidth(cmd *cobra.Command) (int, error) {
+	if cmd.Flags().Changed("width") {
+		width, _ := cmd.Flags().GetInt("width")
+		if width < 0 {
+			return 0, NewUsageError(
+				fmt.Sprintf("Invalid --width: %d", width),
+				"Use a positive number of columns, or 0 to disable wrapping",
 			)
 		}
-		
-		proverb := service.RandomProverb()
-		cmd.Println(proverb)
-		return nil
-	},
+		return width, nil
+	}
+	return terminalWidth(cmd), nil
 }
 
-func init() {
-	// Add proverb command to root command
-	rootCmd.AddCommand(proverbCmd)
-}
\ No newline at end of file
+// terminalWidth returns the width of the terminal in $COLUMNS if the output
+// of cmd is one, or 0 if it isn't
+func terminalWidth(cmd *cobra.Command) int {
+	if !color.IsTerminal(cmd.OutOrStdout()) {
+		return 0
+	}
+	if columns, ok := lookupEnv(cmd, "COLUMNS"); ok {
+		if width, err := strconv.Atoi(columns); err == nil && width > 0 {
+			return width
+		}
+	}
+	return defaultTerminalWidth
+}
+
+// wrapProverb breaks text into lines no wider than width, indenting the
+// lines after the first by two spaces like continuation lines in
+// proverb.txt. A width of 0 keeps the text on one line.
+func wrapProverb(text string, width int) []string {
+	if width <= 0 {
+		return []string{text}
+	}
+	first := textwidth.Wrap(text, width)
+	if len(first) == 1 {
+		return first
+	}
+
+	// Wrap the rest again to make room for the indentation
+	rest := textwidth.Wrap(strings.Join(strings.Fields(strings.Join(first[1:], " ")), " "), max(width-2, 1))
+	lines := first[:1]
+	for _, line := range rest {
+		lines = append(lines, "  "+line)
+	}
+	return lines
+}
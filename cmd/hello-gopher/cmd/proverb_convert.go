@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// newProverbConvertCmd creates the proverb convert command
+func newProverbConvertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert [file]",
+		Short: "Convert a proverb file to the v2 format",
+		Long: `Convert rewrites a proverb file in the line format of 'proverb import' in
+the v2 format, where every proverb is preceded by YAML front matter with its
+metadata:
+
+  ---
+  id: 1
+  tags: [concurrency, design]
+  author: Rob Pike
+  source: https://go-proverbs.github.io/
+  added: 2015-11-18
+  translations:
+    de: Kommuniziere nicht durch geteilten Speicher, ...
+  ---
+  Don't communicate by sharing memory, share memory by communicating.
+
+Every key is optional, and the id is the position of the proverb in the
+file. Both formats can be imported and linted, and are told apart by the
+first line that isn't blank or a '#' comment: "---" starts the v2 format.
+
+Without a file it converts your own collection; '-' reads the file from
+stdin. The result is written to stdout, or to the file given with --out.`,
+		Example: `  hello-gopher proverb convert team.txt --out team.v2.txt
+  hello-gopher proverb convert                          # Your own collection
+  hello-gopher proverb convert - < team.txt`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("out")
+
+			name, r, err := openLintFile(cmd, args)
+			if err != nil {
+				return err
+			}
+			if r == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "No proverbs imported yet (%s)\n", name)
+				return nil
+			}
+			proverbs, err := userproverbs.Parse(name, r)
+			r.Close()
+			if err != nil {
+				return NewDataError(
+					fmt.Sprintf("Invalid proverb file: %v", err),
+					err,
+					"Run 'hello-gopher proverb lint' to see every problem",
+				)
+			}
+
+			if path == "" || path == "-" {
+				return convertProverbs(cmd, proverbs)
+			}
+
+			f, err := os.Create(path)
+			if err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to create %s", path),
+					err,
+					"Check that the directory exists and is writable",
+				)
+			}
+			if err := greeting.WriteProverbsV2(f, proverbs); err != nil {
+				f.Close()
+				return NewDataError(fmt.Sprintf("Failed to convert %s: %v", name, err), err, "")
+			}
+			if err := f.Close(); err != nil {
+				return NewSystemError(fmt.Sprintf("Failed to write %s", path), err, "")
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Converted %d proverbs to %s\n", len(proverbs), path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("out", "o", "", "File to write the converted proverbs to (default: stdout)")
+	return cmd
+}
+
+// convertProverbs writes proverbs in the v2 format to the command output
+func convertProverbs(cmd *cobra.Command, proverbs []greeting.Proverb) error {
+	if err := greeting.WriteProverbsV2(cmd.OutOrStdout(), proverbs); err != nil {
+		return NewDataError(fmt.Sprintf("Failed to convert proverbs: %v", err), err, "")
+	}
+	return nil
+}
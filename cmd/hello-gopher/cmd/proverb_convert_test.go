@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestProverbConvert(t *testing.T) {
+	file := writeProverbFile(t, "# team wisdom\nShip small. | release, process | The Team\nErrors are values.\n")
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "convert", file)
+	if code != ExitSuccess {
+		t.Fatalf("convert exited with %d: %s", code, stderr)
+	}
+	want := "---\nid: 1\ntags: [release, process]\nauthor: The Team\n---\nShip small.\n\n---\nid: 2\n---\nErrors are values.\n"
+	if stdout != want {
+		t.Errorf("convert output = %q, want %q", stdout, want)
+	}
+
+	out := filepath.Join(t.TempDir(), "team.v2.txt")
+	stdout, _, code = testsupport.RunCommand(t, "proverb", "convert", file, "--out", out)
+	if code != ExitSuccess || !strings.Contains(stdout, "Converted 2 proverbs to") {
+		t.Fatalf("convert --out exited with %d: %s", code, stdout)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proverbs, err := greeting.ParseProverbsV2(string(data)); err != nil || len(proverbs) != 2 {
+		t.Errorf("converted file = %+v, %v", proverbs, err)
+	}
+}
+
+func TestProverbConvertInvalid(t *testing.T) {
+	file := writeProverbFile(t, "Fine.\n| no text\n")
+
+	_, stderr, code := testsupport.RunCommand(t, "proverb", "convert", file)
+	if code != ExitDataError || !strings.Contains(stderr, "team.txt:2: proverb text is empty") {
+		t.Errorf("convert of an invalid file exited with %d: %s", code, stderr)
+	}
+}
+
+func TestProverbImportV2(t *testing.T) {
+	dataDir := t.TempDir()
+	withEnv(t, map[string]string{"HELLO_GOPHER_DATA_DIR": dataDir})
+	file := writeProverbFile(t, "---\ntags: [release]\nadded: 2024-05-01\n---\nShip small, ship often.\n")
+
+	if _, stderr, code := testsupport.RunCommand(t, "proverb", "import", file); code != ExitSuccess {
+		t.Fatalf("import of a v2 file exited with %d: %s", code, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(dataDir, "proverbs.txt"))
+	if err != nil || !strings.Contains(string(data), "added: 2024-05-01\n") {
+		t.Errorf("saved collection = %q, %v; want the added date kept", data, err)
+	}
+}
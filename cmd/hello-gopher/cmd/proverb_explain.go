@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// lastProverbFile is the state file remembering the ID of the last proverb
+// shown by the proverb command, for proverb explain --last
+const lastProverbFile = "last-proverb"
+
+// explainWidth is the width explanations are wrapped to
+const explainWidth = 72
+
+// explainResult is the JSON form of the proverb explain output
+type explainResult struct {
+	greeting.Proverb
+	Explanation *greeting.Explanation `json:"explanation,omitempty"`
+}
+
+// newProverbExplainCmd creates the proverb explain command
+func newProverbExplainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <id>",
+		Short: "Explain what a proverb means",
+		Long: `Explain prints a proverb together with a short commentary and links to
+talks or articles about it.
+
+Proverbs are selected by the ID shown by 'proverb list --numbered', or with
+--last the proverb most recently shown by the proverb command.`,
+		Example: `  hello-gopher proverb explain 15       # Explain proverb 15
+  hello-gopher proverb explain --last   # Explain the proverb you just saw`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			last, _ := cmd.Flags().GetBool("last")
+			if last == (len(args) == 1) || len(args) > 1 {
+				return NewUsageError(
+					"proverb explain needs either one proverb ID or --last",
+					"Run 'hello-gopher proverb list --numbered' to see the IDs",
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			var id int
+			if last {
+				if id, err = lastProverb(cmd); err != nil {
+					return err
+				}
+			} else if id, err = strconv.Atoi(args[0]); err != nil {
+				return NewUsageError(fmt.Sprintf("Invalid proverb ID: %s", args[0]), "Pass a number such as 15")
+			}
+
+			proverb, err := proverbByID(cmd, cfg, id)
+			if err != nil {
+				return err
+			}
+
+			result := explainResult{Proverb: proverb}
+			if e, ok := greeting.Explain(proverb); ok {
+				result.Explanation = &e
+			}
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			return writeExplanation(cmd, styler, result)
+		},
+	}
+
+	cmd.Flags().Bool("last", false, "Explain the proverb most recently shown by the proverb command")
+	return cmd
+}
+
+// writeExplanation prints result as text
+func writeExplanation(cmd *cobra.Command, styler color.Styler, result explainResult) error {
+	out := bufio.NewWriter(cmd.OutOrStdout())
+	fmt.Fprintf(out, "%s %s\n", styler.Muted(fmt.Sprintf("#%d", result.ID)), styler.Quote(result.Text))
+	if result.Author != "" {
+		fmt.Fprintf(out, "  %s\n", styler.Muted(attribution(cmd, result.Author)))
+	}
+
+	fmt.Fprintln(out)
+	if result.Explanation == nil {
+		fmt.Fprintln(out, "There is no explanation for this proverb yet.")
+	} else {
+		for _, line := range textwidth.Wrap(result.Explanation.Text, explainWidth) {
+			fmt.Fprintln(out, line)
+		}
+		if len(result.Explanation.Links) > 0 {
+			fmt.Fprintln(out)
+			for _, link := range result.Explanation.Links {
+				fmt.Fprintf(out, "  %s\n", link)
+			}
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return NewSystemError("Failed to write explanation", err, "")
+	}
+	return nil
+}
+
+// proverbByID returns the proverb with id from the embedded and imported
+// proverbs that cfg doesn't exclude
+func proverbByID(cmd *cobra.Command, cfg *config.Config, id int) (greeting.Proverb, error) {
+	service, err := proverbService(cmd, cfg)
+	if err != nil {
+		return greeting.Proverb{}, err
+	}
+	proverbs, err := service.Proverbs()
+	if err != nil {
+		return greeting.Proverb{}, NewDataError("Failed to load proverbs", err, "")
+	}
+	for _, p := range proverbs {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return greeting.Proverb{}, NewUsageError(
+		fmt.Sprintf("No proverb with ID %d", id),
+		fmt.Sprintf("Proverb IDs run from 1 to %d", len(proverbs)),
+	)
+}
+
+// lastProverbPath returns the state file remembering the last proverb shown
+func lastProverbPath(cmd *cobra.Command) (string, error) {
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, lastProverbFile), nil
+}
+
+// lastProverb returns the ID of the proverb most recently shown
+func lastProverb(cmd *cobra.Command) (int, error) {
+	path, err := lastProverbPath(cmd)
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's own state file
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, NewUsageError("No proverb has been shown yet", "Run 'hello-gopher proverb' first, or pass a proverb ID")
+	}
+	if err != nil {
+		return 0, NewSystemError("Failed to read the last proverb", err, "")
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, NewDataError("Failed to read the last proverb", err, fmt.Sprintf("Remove %s", path))
+	}
+	return id, nil
+}
+
+// rememberProverb stores id as the last proverb shown. A failure is logged
+// and doesn't fail the command.
+func rememberProverb(cmd *cobra.Command, id int) {
+	if id == 0 {
+		return
+	}
+	path, err := lastProverbPath(cmd)
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(path), 0o750); err == nil {
+			err = os.WriteFile(path, []byte(strconv.Itoa(id)+"\n"), 0o600)
+		}
+	}
+	if err != nil {
+		commandLogger(cmd).Warn("failed to remember the last proverb", "error", err)
+	}
+}
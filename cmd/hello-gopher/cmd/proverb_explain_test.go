@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestProverbExplain(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "explain", "15")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	for _, want := range []string{"#15 Errors are values.", "— Rob Pike", "not exceptions", "https://go.dev/blog/errors-are-values"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("explanation misses %q:\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "explain", "15", "--output", "json")
+	var result explainResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || result.Explanation == nil || result.ID != 15 {
+		t.Errorf("JSON explanation = %q (err %v)", stdout, err)
+	}
+}
+
+func TestProverbExplainLast(t *testing.T) {
+	appDirs(t)
+
+	_, stderr, code := testsupport.RunCommand(t, "proverb", "explain", "--last")
+	if code != ExitUsageError || !strings.Contains(stderr, "No proverb has been shown yet") {
+		t.Errorf("--last before any proverb: exit code %d, stderr %q", code, stderr)
+	}
+
+	shown, _, _ := testsupport.RunCommand(t, "proverb", "--seed", "3")
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "explain", "--last")
+	if code != ExitSuccess || !strings.Contains(stdout, strings.TrimSpace(shown)) {
+		t.Errorf("explain --last = %q, %q; want the proverb %q", stdout, stderr, shown)
+	}
+}
+
+func TestProverbExplainErrors(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{}, "either one proverb ID or --last"},
+		{[]string{"1", "--last"}, "either one proverb ID or --last"},
+		{[]string{"one"}, "Invalid proverb ID"},
+		{[]string{"9999"}, "No proverb with ID 9999"},
+	} {
+		_, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "explain"}, tt.args...)...)
+		if code != ExitUsageError || !strings.Contains(stderr, tt.want) {
+			t.Errorf("%v: exit code %d, stderr %q; want %q", tt.args, code, stderr, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// Export formats supported by proverb export
+const (
+	exportCSV  = "csv"
+	exportTSV  = "tsv"
+	exportJSON = "json"
+	exportYAML = "yaml"
+)
+
+// exportFormats lists the proverb export formats in the order shown in help
+var exportFormats = []string{exportCSV, exportTSV, exportJSON, exportYAML}
+
+// exportColumns is the header row of CSV and TSV exports
+var exportColumns = []string{"id", "text", "tags", "author"}
+
+// newProverbExportCmd creates the proverb export command
+func newProverbExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the proverb collection as CSV, TSV, JSON or YAML",
+		Long: `Export writes the whole proverb collection with the ID, text, tags and
+author of every proverb, for use in spreadsheets, notebooks or bots.
+
+CSV and TSV exports start with a header row and join tags with commas. The
+author is empty for proverbs without a known attribution.`,
+		Example: `  hello-gopher proverb export                           # CSV on stdout
+  hello-gopher proverb export --format tsv               # Tab-separated values
+  hello-gopher proverb export --format yaml --out p.yaml # Write YAML to a file`,
+		Args: exactArgs(0, "proverb export doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			path, _ := cmd.Flags().GetString("out")
+
+			format = strings.ToLower(strings.TrimSpace(format))
+			if !isExportFormat(format) {
+				return NewUsageError(
+					fmt.Sprintf("Invalid export format: %s", format),
+					fmt.Sprintf("Use one of: %s", strings.Join(exportFormats, ", ")),
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			service, err := proverbService(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			proverbs, err := service.Proverbs()
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+
+			if path == "" || path == "-" {
+				return exportProverbs(cmd.OutOrStdout(), format, proverbs)
+			}
+
+			f, err := os.Create(path)
+			if err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to create %s", path),
+					err,
+					"Check that the directory exists and is writable",
+				)
+			}
+			if err := exportProverbs(f, format, proverbs); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return NewSystemError(fmt.Sprintf("Failed to write %s", path), err, "")
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Exported %d proverbs to %s\n", len(proverbs), path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("format", "f", exportCSV, "Export format: "+strings.Join(exportFormats, ", "))
+	cmd.Flags().StringP("out", "o", "", "File to write the export to (default: stdout)")
+	return cmd
+}
+
+// isExportFormat reports whether format is a supported export format
+func isExportFormat(format string) bool {
+	for _, f := range exportFormats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
+// exportProverbs writes proverbs to w in format
+func exportProverbs(w io.Writer, format string, proverbs []greeting.Proverb) error {
+	if format == exportJSON {
+		return writeJSON(w, proverbs)
+	}
+
+	out := bufio.NewWriter(w)
+	switch format {
+	case exportCSV, exportTSV:
+		writeProverbTable(out, format, proverbs)
+	case exportYAML:
+		writeProverbYAML(out, proverbs)
+	}
+	if err := out.Flush(); err != nil {
+		return NewSystemError("Failed to write proverb export", err, "")
+	}
+	return nil
+}
+
+// writeProverbTable writes proverbs as comma- or tab-separated values with a
+// header row
+func writeProverbTable(w *bufio.Writer, format string, proverbs []greeting.Proverb) {
+	cw := csv.NewWriter(w)
+	if format == exportTSV {
+		cw.Comma = '\t'
+	}
+
+	_ = cw.Write(exportColumns)
+	for _, p := range proverbs {
+		_ = cw.Write([]string{strconv.Itoa(p.ID), p.Text, strings.Join(p.Tags, ","), p.Author})
+	}
+	// Errors surface when the underlying writer is flushed
+	cw.Flush()
+}
+
+// writeProverbYAML writes proverbs as a YAML sequence. Strings are always
+// double-quoted, so no proverb text can be mistaken for another YAML type.
+func writeProverbYAML(w *bufio.Writer, proverbs []greeting.Proverb) {
+	for _, p := range proverbs {
+		fmt.Fprintf(w, "- id: %d\n", p.ID)
+		fmt.Fprintf(w, "  text: %s\n", strconv.Quote(p.Text))
+
+		tags := make([]string, len(p.Tags))
+		for i, tag := range p.Tags {
+			tags[i] = strconv.Quote(tag)
+		}
+		fmt.Fprintf(w, "  tags: [%s]\n", strings.Join(tags, ", "))
+		fmt.Fprintf(w, "  author: %s\n", strconv.Quote(p.Author))
+	}
+}
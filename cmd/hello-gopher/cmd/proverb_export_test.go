@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestProverbExportTable(t *testing.T) {
+	for _, tt := range []struct {
+		format string
+		comma  rune
+	}{
+		{"csv", ','},
+		{"tsv", '\t'},
+	} {
+		t.Run(tt.format, func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, "proverb", "export", "--format", tt.format)
+			if code != ExitSuccess {
+				t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+			}
+
+			r := csv.NewReader(strings.NewReader(stdout))
+			r.Comma = tt.comma
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("export isn't valid %s: %v", tt.format, err)
+			}
+			if got := strings.Join(records[0], " "); got != "id text tags author" {
+				t.Errorf("header = %q", got)
+			}
+
+			first := records[1]
+			want := []string{"1", "Don't communicate by sharing memory, share memory by communicating.", "concurrency", "Rob Pike"}
+			if strings.Join(first, "|") != strings.Join(want, "|") {
+				t.Errorf("first record = %q, want %q", first, want)
+			}
+		})
+	}
+}
+
+func TestProverbExportJSON(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "export", "-f", "JSON")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+
+	var proverbs []greeting.Proverb
+	if err := json.Unmarshal([]byte(stdout), &proverbs); err != nil {
+		t.Fatalf("export isn't valid JSON: %v", err)
+	}
+	all, _ := greeting.Default().Proverbs()
+	if len(proverbs) != len(all) {
+		t.Errorf("exported %d proverbs, want %d", len(proverbs), len(all))
+	}
+}
+
+func TestProverbExportYAMLToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proverbs.yaml")
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "export", "--format", "yaml", "--out", path)
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "Exported ") {
+		t.Errorf("stdout = %q, want a summary", stdout)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `- id: 1
+  text: "Don't communicate by sharing memory, share memory by communicating."
+  tags: ["concurrency"]
+  author: "Rob Pike"
+`
+	if !strings.HasPrefix(string(data), want) {
+		t.Errorf("YAML export starts with:\n%.200s\nwant:\n%s", data, want)
+	}
+}
+
+func TestProverbExportErrors(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "proverb", "export", "--format", "xml")
+	if code != ExitUsageError || !strings.Contains(stderr, "Invalid export format") {
+		t.Errorf("exit code %d, stderr %q; want a usage error", code, stderr)
+	}
+
+	path := filepath.Join(t.TempDir(), "missing", "proverbs.csv")
+	_, stderr, code = testsupport.RunCommand(t, "proverb", "export", "--out", path)
+	if code != ExitSystemError || !strings.Contains(stderr, "Failed to create") {
+		t.Errorf("exit code %d, stderr %q; want a system error", code, stderr)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// generatedLabel is printed beneath generated proverbs so nobody quotes
+// them as real ones
+const generatedLabel = "Made up by a Markov chain, not a real proverb"
+
+// generator is implemented by providers that make up parody proverbs, such
+// as greeting.Service
+type generator interface {
+	GenerateProverb() (greeting.Proverb, error)
+}
+
+// generateProvider returns generated proverbs instead of real ones
+type generateProvider struct {
+	greeting.ProverbProvider
+	generator generator
+}
+
+// RandomEntry returns a generated proverb
+func (p *generateProvider) RandomEntry() (greeting.Proverb, error) {
+	return p.generator.GenerateProverb()
+}
+
+// RandomProverb returns the text of RandomEntry
+func (p *generateProvider) RandomProverb() string {
+	proverb, err := p.RandomEntry()
+	if err != nil {
+		return "Error generating a proverb: " + err.Error()
+	}
+	return proverb.Text
+}
+
+// withGenerate wraps provider to make up parody proverbs when cmd runs with
+// --generate. Without it provider is returned unchanged.
+func withGenerate(cmd *cobra.Command, provider greeting.ProverbProvider) (greeting.ProverbProvider, error) {
+	if generate, _ := cmd.Flags().GetBool("generate"); !generate {
+		return provider, nil
+	}
+	for _, flag := range []string{"daily", "no-repeat", "playlist"} {
+		if cmd.Flags().Changed(flag) {
+			return nil, NewUsageError(
+				fmt.Sprintf("--generate and --%s cannot be combined", flag),
+				"Generated proverbs are made up on every run; drop one of the flags",
+			)
+		}
+	}
+
+	g, ok := provider.(generator)
+	if !ok {
+		return nil, NewUsageError(
+			fmt.Sprintf("%T can't generate proverbs", provider),
+			fmt.Sprintf("Run 'hello-gopher config set provider %s'", greeting.BuiltinProvider),
+		)
+	}
+	return &generateProvider{ProverbProvider: provider, generator: g}, nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// newProverbImportCmd creates the proverb import command
+func newProverbImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import proverbs into your own collection",
+		Long: `Import adds the proverbs of a file to your own collection, which every
+proverb command shows after the built-in proverbs.
+
+The file uses the format of the built-in collection, one proverb per line:
+
+  <proverb text> | <comma-separated tags> | <author> | <source URL>
+
+Tags, author and source are optional; blank lines and lines starting with '#' are
+ignored. Long proverbs can continue on lines starting with a space or tab:
+
+  There are two ways of constructing a software design: One way is to
+    make it so simple that there are obviously no deficiencies, ...
+    | design | Tony Hoare
+
+Files in the v2 format of 'proverb convert', which adds an added date and
+translations to every proverb, can be imported as well.
+
+A malformed line fails the import with its line number; run 'proverb lint'
+first to see every problem at once. Proverbs already in the built-in or your
+own collection, or repeated in the file, are skipped. With --replace your
+collection is replaced by the file instead.
+
+The collection is stored in proverbs.txt in the user data directory
+($XDG_DATA_HOME/hello-gopher on Linux), or in $HELLO_GOPHER_DATA_DIR.`,
+		Example: `  hello-gopher proverb import team.txt            # Add the proverbs of team.txt
+  hello-gopher proverb import team.txt --dry-run  # Only report what would change
+  hello-gopher proverb import team.txt --replace  # Replace your collection`,
+		Args: exactArgs(1, "proverb import needs exactly one file"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			replace, _ := cmd.Flags().GetBool("replace")
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return NewUsageError(fmt.Sprintf("Failed to open %s: %v", args[0], err), "Check the file name")
+			}
+			incoming, err := userproverbs.Parse(args[0], f)
+			f.Close()
+			if err != nil {
+				return NewDataError(
+					fmt.Sprintf("Invalid proverb file: %v", err),
+					err,
+					"Fix the line; each proverb is '<text> | <tags> | <author> | <source>'",
+				)
+			}
+
+			store, err := loadUserProverbs(cmd)
+			if err != nil {
+				return err
+			}
+			embedded, err := greeting.Default().Proverbs()
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+
+			if replace {
+				store.Clear()
+			}
+			added, skipped := store.Merge(incoming, embedded)
+
+			if !dryRun {
+				if err := store.Save(); err != nil {
+					return NewSystemError(
+						fmt.Sprintf("Failed to save %s", store.Path()),
+						err,
+						"Check that the data directory is writable",
+					)
+				}
+			}
+
+			verb := "Imported"
+			if dryRun {
+				verb = "Would import"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s, skipped %s (%d in %s)\n",
+				verb, plural(added, "proverb"), plural(skipped, "duplicate"), len(store.Proverbs()), store.Path())
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Report what would be imported without saving")
+	cmd.Flags().Bool("replace", false, "Replace your collection instead of adding to it")
+	return cmd
+}
+
+// loadUserProverbs reads the user's imported proverbs
+func loadUserProverbs(cmd *cobra.Command) (*userproverbs.Store, error) {
+	dir, err := dataDir(cmd)
+	if err != nil {
+		return nil, NewSystemError(
+			"Failed to locate the data directory",
+			err,
+			"Set HELLO_GOPHER_DATA_DIR to a writable directory",
+		)
+	}
+
+	path := filepath.Join(dir, userproverbs.FileName)
+	store, err := userproverbs.Load(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read your proverbs: %v", err),
+			err,
+			fmt.Sprintf("Fix or remove %s", path),
+		)
+	}
+	return store, nil
+}
+
+// userProverbOptions returns the greeting options adding the user's imported
+// proverbs to the embedded collection and hiding those excluded by cfg
+func userProverbOptions(cmd *cobra.Command, cfg *config.Config) ([]greeting.Option, error) {
+	opts, err := exclusionOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	store, err := loadUserProverbs(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(store.Proverbs()) == 0 {
+		return opts, nil
+	}
+	return append(opts, greeting.WithExtraProverbs(store.Proverbs())), nil
+}
+
+// userProverbs returns the user's imported proverbs, read again on every
+// call
+func userProverbs(cmd *cobra.Command) ([]greeting.Proverb, error) {
+	store, err := loadUserProverbs(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return store.Proverbs(), nil
+}
+
+// proverbService returns the service holding the embedded proverbs together
+// with the user's imported ones, without those excluded by cfg
+func proverbService(cmd *cobra.Command, cfg *config.Config) (*greeting.Service, error) {
+	opts, err := userProverbOptions(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts) == 0 {
+		return greeting.Default(), nil
+	}
+	return greeting.NewService(opts...), nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+)
+
+// writeProverbFile writes an import file with content and returns its path
+func writeProverbFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "team.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProverbImport(t *testing.T) {
+	dataDir := t.TempDir()
+	withEnv(t, map[string]string{"HELLO_GOPHER_DATA_DIR": dataDir})
+	file := writeProverbFile(t, "# team wisdom\nShip small, ship often. | release | The Team\nErrors are values.\nship small,  ship OFTEN.\n")
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "import", file, "--dry-run")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "Would import 1 proverb, skipped 2 duplicates") {
+		t.Errorf("dry run output = %q", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, userproverbs.FileName)); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote the proverb file (stat error %v)", err)
+	}
+
+	stdout, stderr, code = testsupport.RunCommand(t, "proverb", "import", file)
+	if code != ExitSuccess || !strings.HasPrefix(stdout, "Imported 1 proverb, skipped 2 duplicates") {
+		t.Fatalf("import = %q, %q, exit code %d", stdout, stderr, code)
+	}
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "import", file)
+	if !strings.HasPrefix(stdout, "Imported 0 proverbs, skipped 3 duplicates") {
+		t.Errorf("second import output = %q", stdout)
+	}
+
+	again := writeProverbFile(t, "Errors are values.\nClear is better than clever, they say.\n")
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "import", again, "--dry-run")
+	if !strings.HasPrefix(stdout, "Would import 1 proverb, skipped 1 duplicate (") {
+		t.Errorf("import of one duplicate output = %q", stdout)
+	}
+
+	stdout, _, code = testsupport.RunCommand(t, "proverb", "list", "--tag", "release", "--numbered", "--no-pager")
+	if code != ExitSuccess || !strings.Contains(stdout, "Ship small, ship often.") {
+		t.Errorf("proverb list doesn't show the imported proverb: %q", stdout)
+	}
+
+	other := writeProverbFile(t, "Read the source. | learning\n")
+	stdout, _, code = testsupport.RunCommand(t, "proverb", "import", other, "--replace")
+	if code != ExitSuccess || !strings.Contains(stdout, "Imported 1 proverb, skipped 0 duplicates (1 in ") {
+		t.Errorf("replace output = %q", stdout)
+	}
+}
+
+func TestProverbImportErrors(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "proverb", "import")
+	if code != ExitUsageError {
+		t.Errorf("missing file: exit code %d, want %d (%s)", code, ExitUsageError, stderr)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "proverb", "import", filepath.Join(t.TempDir(), "missing.txt"))
+	if code != ExitUsageError || !strings.Contains(stderr, "Failed to open") {
+		t.Errorf("unreadable file: exit code %d, stderr %q", code, stderr)
+	}
+
+	file := writeProverbFile(t, "Fine.\nAlso fine. | tag,\n")
+	_, stderr, code = testsupport.RunCommand(t, "proverb", "import", file)
+	if code != ExitDataError || !strings.Contains(stderr, "team.txt:2: empty tag") {
+		t.Errorf("malformed file: exit code %d, stderr %q", code, stderr)
+	}
+}
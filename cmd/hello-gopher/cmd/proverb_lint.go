@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+	"github.com/spf13/cobra"
+)
+
+// lintReport is the JSON output of proverb lint
+type lintReport struct {
+	File     string                 `json:"file"`
+	Errors   int                    `json:"errors"`
+	Warnings int                    `json:"warnings"`
+	Findings []userproverbs.Finding `json:"findings"`
+}
+
+// newProverbLintCmd creates the proverb lint command
+func newProverbLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [file]",
+		Short: "Check a proverb file for mistakes",
+		Long: `Lint checks a proverb file in the format of 'proverb import' and reports
+every problem with its line number, instead of stopping at the first one.
+
+Errors are lines that 'proverb import' rejects, such as invalid UTF-8 or an
+empty tag, and duplicate proverbs and malformed tags. Warnings are lines that
+are accepted but probably not as intended: overly long lines, tags that
+aren't lower case or are repeated, and proverbs without tags or author.
+
+A file in the v2 format of 'proverb convert' stops at its first syntax error;
+its other findings are reported by proverb instead of by line.
+
+Lint fails on errors, and with --strict on warnings as well. Without a file
+it checks your own collection; '-' reads the file from stdin.`,
+		Example: `  hello-gopher proverb lint team.txt           # Check a file before importing it
+  hello-gopher proverb lint                    # Check your own collection
+  hello-gopher proverb lint team.txt --strict  # Fail on warnings too
+  hello-gopher proverb lint - --output json < team.txt`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			maxLength, _ := cmd.Flags().GetInt("max-length")
+			if maxLength < 0 {
+				return NewUsageError(
+					fmt.Sprintf("Invalid --max-length: %d", maxLength),
+					"Use a positive length, or 0 to allow any length",
+				)
+			}
+
+			name, r, err := openLintFile(cmd, args)
+			if err != nil {
+				return err
+			}
+			if r == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "No proverbs imported yet (%s)\n", name)
+				return nil
+			}
+			findings, err := userproverbs.Lint(r, maxLength)
+			r.Close()
+			if err != nil {
+				return NewDataError(fmt.Sprintf("Failed to read %s: %v", name, err), err, "")
+			}
+
+			report := lintReport{File: name, Errors: userproverbs.Errors(findings), Findings: findings}
+			report.Warnings = len(findings) - report.Errors
+			if output == outputJSON {
+				if report.Findings == nil {
+					report.Findings = []userproverbs.Finding{}
+				}
+				if err := writeJSON(cmd.OutOrStdout(), report); err != nil {
+					return err
+				}
+			} else {
+				printLintReport(cmd.OutOrStdout(), report)
+			}
+
+			if report.Errors > 0 || (report.Warnings > 0 && strictMode(cmd, cfg)) {
+				return NewDataError(
+					fmt.Sprintf("%s has %d errors and %d warnings", name, report.Errors, report.Warnings),
+					nil,
+					"Fix the lines listed above",
+				)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("max-length", userproverbs.DefaultMaxLineLength, "Warn about lines longer than this many characters (0 disables)")
+	return cmd
+}
+
+// openLintFile opens the file named in args, stdin for "-", or the user's
+// collection without args. A missing collection yields a nil reader.
+func openLintFile(cmd *cobra.Command, args []string) (string, io.ReadCloser, error) {
+	if len(args) == 1 && args[0] == "-" {
+		return "stdin", io.NopCloser(cmd.InOrStdin()), nil
+	}
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return "", nil, NewUsageError(fmt.Sprintf("Failed to open %s: %v", args[0], err), "Check the file name")
+		}
+		return args[0], f, nil
+	}
+
+	dir, err := dataDir(cmd)
+	if err != nil {
+		return "", nil, NewSystemError(
+			"Failed to locate the data directory",
+			err,
+			"Set HELLO_GOPHER_DATA_DIR to a writable directory",
+		)
+	}
+	path := filepath.Join(dir, userproverbs.FileName)
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own proverb file
+	if os.IsNotExist(err) {
+		return path, nil, nil
+	}
+	if err != nil {
+		return "", nil, NewSystemError(fmt.Sprintf("Failed to open %s: %v", path, err), err, "")
+	}
+	return path, f, nil
+}
+
+// printLintReport writes the findings of report as "file:line: severity:
+// message" lines, followed by a summary
+func printLintReport(w io.Writer, report lintReport) {
+	for _, f := range report.Findings {
+		location := report.File
+		if f.Line > 0 {
+			location = fmt.Sprintf("%s:%d", report.File, f.Line)
+		}
+		fmt.Fprintf(w, "%s: %s: %s\n", location, f.Severity, f.Message)
+	}
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(w, "%s: no problems found\n", report.File)
+		return
+	}
+	fmt.Fprintf(w, "%d errors, %d warnings\n", report.Errors, report.Warnings)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+)
+
+func TestProverbLint(t *testing.T) {
+	file := writeProverbFile(t, "Ship small. | release | The Team\nShip small. | release\nEmpty tag. | tag, | Me\n")
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "lint", file)
+	if code != ExitDataError || !strings.Contains(stderr, "2 errors and 1 warnings") {
+		t.Errorf("lint exited with %d: %s", code, stderr)
+	}
+	for _, want := range []string{"team.txt:2: error: duplicate of line 1\n", "team.txt:2: warning: no author\n", "team.txt:3: error: empty tag\n"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("lint output doesn't contain %q:\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "lint", file, "--output", "json")
+	var report lintReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil || report.Errors != 2 || len(report.Findings) != 3 {
+		t.Errorf("lint --output json = %+v, %v", report, err)
+	}
+}
+
+func TestProverbLintWarnings(t *testing.T) {
+	file := writeProverbFile(t, "Errors are values.\n")
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "lint", file)
+	if code != ExitSuccess || !strings.Contains(stdout, "0 errors, 2 warnings") {
+		t.Errorf("lint with warnings exited with %d: %q, %q", code, stdout, stderr)
+	}
+	if _, _, code := testsupport.RunCommand(t, "proverb", "lint", file, "--strict"); code != ExitDataError {
+		t.Errorf("lint --strict with warnings exited with %d, want %d", code, ExitDataError)
+	}
+}
+
+func TestProverbLintCollection(t *testing.T) {
+	dataDir := t.TempDir()
+	withEnv(t, map[string]string{"HELLO_GOPHER_DATA_DIR": dataDir})
+
+	stdout, _, code := testsupport.RunCommand(t, "proverb", "lint")
+	if code != ExitSuccess || !strings.HasPrefix(stdout, "No proverbs imported yet") {
+		t.Errorf("lint without a collection = %q, exit code %d", stdout, code)
+	}
+
+	path := filepath.Join(dataDir, userproverbs.FileName)
+	if err := os.WriteFile(path, []byte("Read the source. | learning | Me\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, code = testsupport.RunCommand(t, "proverb", "lint")
+	if code != ExitSuccess || stdout != path+": no problems found\n" {
+		t.Errorf("lint of the collection = %q, exit code %d", stdout, code)
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/tablewriter"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// newProverbListCmd creates the proverb list command
+func newProverbListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every Go proverb",
+		Long: `List prints the whole proverb collection, one proverb per line.
+
+Proverbs can be narrowed down by tag or by a case-insensitive text search, and
+numbered with their stable IDs. When the output is an interactive terminal the
+list is shown through $PAGER (default "less"); use --no-pager to disable this.`,
+		Example: `  hello-gopher proverb list                     # List every proverb
+  hello-gopher proverb list --numbered          # Prefix each proverb with its ID
+  hello-gopher proverb list --tag concurrency   # Only concurrency proverbs
+  hello-gopher proverb list --search error      # Proverbs mentioning "error"
+  hello-gopher proverb list --output table      # IDs, proverbs and tags in a table`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unexpected argument(s): %v", args),
+					"Use --tag or --search to filter the list",
+				)
+			}
+
+			numbered, _ := cmd.Flags().GetBool("numbered")
+			noPager, _ := cmd.Flags().GetBool("no-pager")
+			tag, _ := cmd.Flags().GetString("tag")
+			search, _ := cmd.Flags().GetString("search")
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			service, err := proverbService(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			proverbs, err := service.FilterProverbs(greeting.Filter{Tag: tag, Search: search})
+			if err != nil {
+				return NewDataError(
+					"Failed to load Go proverbs",
+					err,
+					"This appears to be a data issue. Please check if the application was built correctly",
+				)
+			}
+
+			if len(proverbs) == 0 {
+				return NewUsageError(
+					"No proverbs match the given filters",
+					"Try a different --tag or --search value",
+				)
+			}
+
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), proverbs)
+			}
+			text := formatProverbList(styler, proverbs, numbered)
+			if output == outputTable {
+				text = renderTable(proverbTable(cmd, proverbs))
+			}
+
+			return writePaged(cmd.OutOrStdout(), text, !noPager)
+		},
+	}
+
+	cmd.Flags().BoolP("numbered", "N", false, "Prefix each proverb with its ID")
+	cmd.Flags().Bool("no-pager", false, "Never pipe the list through $PAGER")
+	cmd.Flags().StringP("tag", "t", "", "Only list proverbs with this tag")
+	cmd.Flags().StringP("search", "s", "", "Only list proverbs containing this text (case-insensitive)")
+	addTableFlags(cmd)
+	return cmd
+}
+
+// formatProverbList renders proverbs one per line, optionally prefixed with their IDs
+func formatProverbList(styler color.Styler, proverbs []greeting.Proverb, numbered bool) string {
+	var b strings.Builder
+	width := len(fmt.Sprint(proverbs[len(proverbs)-1].ID))
+	for _, p := range proverbs {
+		if numbered {
+			b.WriteString(styler.Muted(fmt.Sprintf("%*d.", width, p.ID)))
+			b.WriteByte(' ')
+		}
+		b.WriteString(p.Text)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// proverbTable lays proverbs out in a table of their IDs, texts and tags
+func proverbTable(cmd *cobra.Command, proverbs []greeting.Proverb) *tablewriter.Table {
+	t := newTable(cmd, "ID", "PROVERB", "TAGS")
+	t.SetAlign(0, tablewriter.AlignRight)
+	for _, p := range proverbs {
+		t.Append(strconv.Itoa(p.ID), p.Text, strings.Join(p.Tags, ", "))
+	}
+	return t
+}
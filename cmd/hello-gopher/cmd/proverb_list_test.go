@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestProverbListCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		validate func(t *testing.T, lines []string)
+	}{
+		{
+			name: "lists every proverb",
+			args: []string{},
+			validate: func(t *testing.T, lines []string) {
+				if len(lines) < 50 {
+					t.Errorf("Expected at least 50 proverbs, got %d", len(lines))
+				}
+			},
+		},
+		{
+			name: "numbered output",
+			args: []string{"--numbered", "--tag", "cgo"},
+			validate: func(t *testing.T, lines []string) {
+				for _, line := range lines {
+					if !strings.Contains(line, ". Cgo") {
+						t.Errorf("Expected numbered cgo proverb, got %q", line)
+					}
+				}
+			},
+		},
+		{
+			name: "search filter",
+			args: []string{"-s", "goroutine"},
+			validate: func(t *testing.T, lines []string) {
+				for _, line := range lines {
+					if !strings.Contains(strings.ToLower(line), "goroutine") {
+						t.Errorf("Search returned non-matching proverb %q", line)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "list"}, tt.args...)...)
+			if code != ExitSuccess {
+				t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+			}
+
+			lines := strings.Split(strings.TrimSpace(stdout), "\n")
+			tt.validate(t, lines)
+		})
+	}
+}
+
+func TestProverbListCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unexpected argument", []string{"extra"}},
+		{"no matches", []string{"--tag", "no-such-tag"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "list"}, tt.args...)...)
+			if code != ExitUsageError {
+				t.Errorf("Expected usage error code %d, got %d (stderr %q)", ExitUsageError, code, stderr)
+			}
+		})
+	}
+}
+
+func TestWritePagedWithoutTerminal(t *testing.T) {
+	// A bytes.Buffer is never a terminal, so the pager must be bypassed
+	t.Setenv("PAGER", "false")
+
+	var buf bytes.Buffer
+	if err := writePaged(&buf, "content\n", true); err != nil {
+		t.Fatalf("writePaged() unexpected error: %v", err)
+	}
+	if buf.String() != "content\n" {
+		t.Errorf("writePaged() wrote %q, want %q", buf.String(), "content\n")
+	}
+}
+
+func TestRunPager(t *testing.T) {
+	// A pager that shows everything and then fails, like quitting with an
+	// error status, must not get the content written a second time
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake pager is a shell script")
+	}
+	pager := filepath.Join(t.TempDir(), "pager")
+	if err := os.WriteFile(pager, []byte("#!/bin/sh\ncat\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	started, err := runPager(&buf, "content\n", pager)
+	if !started || err == nil {
+		t.Errorf("runPager() = %v, %v; want a started pager and its error", started, err)
+	}
+	if buf.String() != "content\n" {
+		t.Errorf("runPager() wrote %q, want the content once", buf.String())
+	}
+
+	if started, _ := runPager(&buf, "content\n", "no-such-pager-hello-gopher"); started {
+		t.Error("runPager() started a missing pager")
+	}
+}
+
+func TestProverbListExclude(t *testing.T) {
+	withEnv(t, map[string]string{"HELLO_GOPHER_EXCLUDE": "[cgo, syscall, 1]", "HELLO_GOPHER_DATA_DIR": t.TempDir()})
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "list", "--numbered")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if strings.Contains(stdout, "Cgo") || strings.Contains(stdout, "Syscalls") || strings.Contains(stdout, " 1. ") {
+		t.Errorf("Excluded proverbs are listed:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, " 2. Concurrency is not parallelism.") {
+		t.Errorf("Remaining proverbs lost their IDs:\n%s", stdout)
+	}
+
+	if _, _, code := testsupport.RunCommand(t, "proverb", "explain", "1"); code == ExitSuccess {
+		t.Error("proverb explain found an excluded proverb")
+	}
+
+	withEnv(t, map[string]string{"HELLO_GOPHER_EXCLUDE": "[Not A Tag]", "HELLO_GOPHER_DATA_DIR": t.TempDir()})
+	if _, _, code := testsupport.RunCommand(t, "proverb", "list"); code == ExitSuccess {
+		t.Error("proverb list succeeded with an invalid exclude setting")
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/norepeat"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// listProvider is implemented by providers that return their whole
+// collection, such as greeting.Service
+type listProvider interface {
+	Proverbs() ([]greeting.Proverb, error)
+}
+
+// noRepeatProvider picks random proverbs not shown in the current cycle,
+// remembering them across runs
+type noRepeatProvider struct {
+	greeting.ProverbProvider
+	proverbs []greeting.Proverb
+	store    *norepeat.Store
+	rand     greeting.Rand
+}
+
+// RandomEntry returns a proverb not shown in the current cycle and saves it
+// as shown
+func (p *noRepeatProvider) RandomEntry() (greeting.Proverb, error) {
+	proverb := p.store.Next(p.proverbs, p.rand)
+	if err := p.store.Save(); err != nil {
+		return greeting.Proverb{}, fmt.Errorf("saving shown proverbs: %w", err)
+	}
+	return proverb, nil
+}
+
+// RandomProverb returns the text of RandomEntry
+func (p *noRepeatProvider) RandomProverb() string {
+	proverb, err := p.RandomEntry()
+	if err != nil {
+		return "Error loading proverbs: " + err.Error()
+	}
+	return proverb.Text
+}
+
+// addNoRepeatFlags adds the --no-repeat and --reset flags to cmd
+func addNoRepeatFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-repeat", false, "Don't repeat a proverb until every proverb has been shown, across runs")
+	cmd.Flags().Bool("reset", false, "Forget the proverbs shown with --no-repeat or --playlist and start over")
+}
+
+// withNoRepeat wraps provider to skip the proverbs already shown when cmd
+// runs with --no-repeat, choosing among the rest with rnd. Other providers
+// are returned unchanged.
+func withNoRepeat(cmd *cobra.Command, provider greeting.ProverbProvider, rnd greeting.Rand) (greeting.ProverbProvider, error) {
+	noRepeat, _ := cmd.Flags().GetBool("no-repeat")
+	reset, _ := cmd.Flags().GetBool("reset")
+	if reset && !noRepeat && !cmd.Flags().Changed("playlist") {
+		return nil, NewUsageError("--reset can only be used with --no-repeat or --playlist", "Run 'hello-gopher proverb --no-repeat --reset'")
+	}
+	if !noRepeat {
+		return provider, nil
+	}
+
+	lp, ok := provider.(listProvider)
+	if !ok {
+		return nil, NewDataError(fmt.Sprintf("%T can't list its proverbs for --no-repeat", provider), nil, "")
+	}
+	proverbs, err := lp.Proverbs()
+	if err != nil {
+		return nil, NewDataError("Failed to load Go proverbs", err, "")
+	}
+
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, norepeat.FileName)
+	store, err := norepeat.Load(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read the shown proverbs: %v", err),
+			err,
+			"Run 'hello-gopher proverb --no-repeat --reset' to start over",
+		)
+	}
+	if reset {
+		store.Reset()
+	}
+
+	commandLogger(cmd).Debug("no-repeat cycle", "remaining", len(store.Remaining(proverbs)), "total", len(proverbs))
+	return &noRepeatProvider{ProverbProvider: provider, proverbs: proverbs, store: store, rand: rnd}, nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/norepeat"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// noRepeatProverb runs proverb --no-repeat with extra args and returns the
+// ID of the proverb shown
+func noRepeatProverb(t *testing.T, args ...string) int {
+	t.Helper()
+	stdout, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "--no-repeat", "--output", "json"}, args...)...)
+	if code != ExitSuccess {
+		t.Fatalf("proverb --no-repeat failed with exit code %d: %s", code, stderr)
+	}
+	var p greeting.Proverb
+	if err := json.Unmarshal([]byte(stdout), &p); err != nil {
+		t.Fatalf("output isn't JSON: %v\n%s", err, stdout)
+	}
+	return p.ID
+}
+
+func TestProverbNoRepeat(t *testing.T) {
+	_, _, state := appDirs(t)
+	all, err := greeting.Default().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int]bool)
+	for range all {
+		id := noRepeatProverb(t)
+		if seen[id] {
+			t.Fatalf("proverb %d repeated before the collection cycled (after %d proverbs)", id, len(seen))
+		}
+		seen[id] = true
+	}
+
+	// The next run starts a new cycle
+	noRepeatProverb(t)
+	data, err := os.ReadFile(filepath.Join(state, norepeat.FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shown []int
+	if err := json.Unmarshal(data, &shown); err != nil || len(shown) != 1 {
+		t.Errorf("shown proverbs after a new cycle = %s (err %v), want one", data, err)
+	}
+
+	noRepeatProverb(t)
+	noRepeatProverb(t, "--reset")
+	data, _ = os.ReadFile(filepath.Join(state, norepeat.FileName))
+	if err := json.Unmarshal(data, &shown); err != nil || len(shown) != 1 {
+		t.Errorf("shown proverbs after --reset = %s (err %v), want one", data, err)
+	}
+}
+
+func TestProverbNoRepeatFlagErrors(t *testing.T) {
+	appDirs(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"proverb", "--reset"}, "--reset can only be used with --no-repeat"},
+		{[]string{"proverb", "--no-repeat", "--daily"}, "--daily and --no-repeat cannot be combined"},
+	} {
+		_, stderr, code := testsupport.RunCommand(t, tt.args...)
+		if code != ExitUsageError || !strings.Contains(stderr, tt.want) {
+			t.Errorf("%v: exit code %d, stderr %q; want %q", tt.args, code, stderr, tt.want)
+		}
+	}
+
+}
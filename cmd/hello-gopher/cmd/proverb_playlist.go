@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/playlist"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// playlistProvider picks proverbs from a playlist, remembering the position
+// in ordered playlists across runs
+type playlistProvider struct {
+	greeting.ProverbProvider
+	playlist *playlist.Playlist
+	proverbs []greeting.Proverb
+	state    *playlist.State
+	rand     greeting.Rand
+}
+
+// RandomEntry returns the next proverb of the playlist and saves the
+// position
+func (p *playlistProvider) RandomEntry() (greeting.Proverb, error) {
+	proverb, err := p.state.Next(p.playlist, p.proverbs, p.rand)
+	if err != nil {
+		return greeting.Proverb{}, err
+	}
+	if !p.playlist.Weighted {
+		if err := p.state.Save(); err != nil {
+			return greeting.Proverb{}, fmt.Errorf("saving the playlist position: %w", err)
+		}
+	}
+	return proverb, nil
+}
+
+// RandomProverb returns the text of RandomEntry
+func (p *playlistProvider) RandomProverb() string {
+	proverb, err := p.RandomEntry()
+	if err != nil {
+		return "Error loading proverbs: " + err.Error()
+	}
+	return proverb.Text
+}
+
+// withPlaylist wraps provider to pick from the playlist given with
+// --playlist, choosing with rnd in weighted playlists. Without --playlist
+// provider is returned unchanged.
+func withPlaylist(cmd *cobra.Command, cfg *config.Config, provider greeting.ProverbProvider, rnd greeting.Rand) (greeting.ProverbProvider, error) {
+	name, _ := cmd.Flags().GetString("playlist")
+	if name == "" {
+		return provider, nil
+	}
+	for _, flag := range []string{"daily", "no-repeat"} {
+		if cmd.Flags().Changed(flag) {
+			return nil, NewUsageError(
+				fmt.Sprintf("--playlist and --%s cannot be combined", flag),
+				"The playlist decides which proverb comes next; drop one of the flags",
+			)
+		}
+	}
+
+	list, err := loadPlaylist(cmd, cfg, name)
+	if err != nil {
+		return nil, err
+	}
+
+	lp, ok := provider.(listProvider)
+	if !ok {
+		return nil, NewDataError(fmt.Sprintf("%T can't list its proverbs for --playlist", provider), nil, "")
+	}
+	proverbs, err := lp.Proverbs()
+	if err != nil {
+		return nil, NewDataError("Failed to load Go proverbs", err, "")
+	}
+
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, playlist.StateFileName)
+	state, err := playlist.LoadState(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read the playlist positions: %v", err),
+			err,
+			fmt.Sprintf("Run 'hello-gopher proverb --playlist %s --reset' to start over", name),
+		)
+	}
+	if reset, _ := cmd.Flags().GetBool("reset"); reset {
+		state.Reset(name)
+	}
+
+	commandLogger(cmd).Debug("playlist", "name", name, "items", len(list.Items), "weighted", list.Weighted)
+	return &playlistProvider{ProverbProvider: provider, playlist: list, proverbs: proverbs, state: state, rand: rnd}, nil
+}
+
+// loadPlaylist returns the playlist name from the config file, or else from
+// the playlist file in the data directory
+func loadPlaylist(cmd *cobra.Command, cfg *config.Config, name string) (*playlist.Playlist, error) {
+	if items, ok := cfg.Playlist(name); ok {
+		list, err := playlist.Parse(name, items)
+		if err != nil {
+			return nil, NewUsageError(
+				fmt.Sprintf("Invalid %s%s setting: %v", config.PlaylistPrefix, name, err),
+				"List proverb IDs and tags, such as [15, 4, errors], with '=<weight>' for a weighted playlist",
+			)
+		}
+		return list, nil
+	}
+
+	dir, err := appDir(cmd, dirData)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, playlist.FileName)
+	lists, err := playlist.LoadFile(path)
+	if err != nil {
+		return nil, NewDataError(fmt.Sprintf("Failed to read playlists: %v", err), err, fmt.Sprintf("Fix %s", path))
+	}
+	for _, list := range lists {
+		if list.Name == name {
+			return list, nil
+		}
+	}
+	return nil, NewUsageError(
+		fmt.Sprintf("Unknown playlist: %s", name),
+		fmt.Sprintf("Add '%s%s: [15, 4, errors]' to the config file, or '%s: [15, 4, errors]' to %s", config.PlaylistPrefix, name, name, path),
+	)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/playlist"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// playlistProverb runs proverb --playlist name with extra args and returns
+// the ID of the proverb shown
+func playlistProverb(t *testing.T, name string, args ...string) int {
+	t.Helper()
+	stdout, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "--playlist", name, "--output", "json"}, args...)...)
+	if code != ExitSuccess {
+		t.Fatalf("proverb --playlist %s failed with exit code %d: %s", name, code, stderr)
+	}
+	var p greeting.Proverb
+	if err := json.Unmarshal([]byte(stdout), &p); err != nil {
+		t.Fatalf("output isn't JSON: %v\n%s", err, stdout)
+	}
+	return p.ID
+}
+
+func TestProverbPlaylist(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	data, state := t.TempDir(), t.TempDir()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    config,
+		"HELLO_GOPHER_DATA_DIR":  data,
+		"HELLO_GOPHER_STATE_DIR": state,
+	})
+	if err := os.WriteFile(config, []byte("playlist.onboarding: [15, 4, 1]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(data, playlist.FileName), []byte("cgo-only: [cgo=2]\nonboarding: [2]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The config file wins over the playlist file
+	var ids []int
+	for i := 0; i < 4; i++ {
+		ids = append(ids, playlistProverb(t, "onboarding"))
+	}
+	if want := []int{15, 4, 1, 15}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("--playlist onboarding showed %v, want %v", ids, want)
+	}
+	if _, err := os.Stat(filepath.Join(state, playlist.StateFileName)); err != nil {
+		t.Errorf("the playlist position wasn't saved: %v", err)
+	}
+	if id := playlistProverb(t, "onboarding", "--reset"); id != 15 {
+		t.Errorf("--reset showed #%d, want #15", id)
+	}
+
+	if id := playlistProverb(t, "cgo-only"); id != 10 && id != 11 {
+		t.Errorf("--playlist cgo-only showed #%d, want a cgo proverb", id)
+	}
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"proverb", "--playlist", "missing"}, "Unknown playlist: missing"},
+		{[]string{"proverb", "--playlist", "onboarding", "--daily"}, "--playlist and --daily cannot be combined"},
+		{[]string{"proverb", "--playlist", "onboarding", "--no-repeat"}, "--playlist and --no-repeat cannot be combined"},
+	} {
+		_, stderr, code := testsupport.RunCommand(t, tt.args...)
+		if code != ExitUsageError || !strings.Contains(stderr, tt.want) {
+			t.Errorf("%v: exit code %d, stderr %q; want %q", tt.args, code, stderr, tt.want)
+		}
+	}
+}
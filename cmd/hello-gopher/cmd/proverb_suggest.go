@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// suggestResult is the JSON output of proverb suggest
+type suggestResult struct {
+	Proverb greeting.Proverb `json:"proverb"`
+	// Added is false when the proverb was in the user's collection already
+	Added bool   `json:"added"`
+	Path  string `json:"path"`
+	// IssueURL proposes the proverb upstream, with --upstream
+	IssueURL string `json:"issue_url,omitempty"`
+}
+
+// newProverbSuggestCmd creates the proverb suggest command
+func newProverbSuggestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggest <text>",
+		Short: "Add a proverb to your collection and propose it upstream",
+		Long: `Suggest adds a single proverb to your own collection, like 'proverb import'
+does for a file, so every proverb command shows it right away.
+
+A proverb that is in the built-in collection already is rejected; one that
+is in your collection already is left as it is. Proverbs are compared by
+their text, ignoring case and runs of white space.
+
+With --upstream a link to a prefilled GitHub issue proposing the proverb for
+the built-in collection is printed. Nothing is posted: open the link to
+review and submit the issue.`,
+		Example: `  hello-gopher proverb suggest "Ship small, ship often." --tags release
+  hello-gopher proverb suggest "Clear is kind." --author "Jane Doe" --source https://example.com/talk
+  hello-gopher proverb suggest "Ship small, ship often." --upstream`,
+		Args: exactArgs(1, "proverb suggest needs the proverb text as one quoted argument"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			proverb, err := suggestedProverb(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			embedded, err := greeting.Default().Proverbs()
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+			if known, ok := userproverbs.Find(embedded, proverb.Text); ok {
+				return NewDataError(
+					fmt.Sprintf("Proverb #%d is already built in: %s", known.ID, known.Text),
+					nil,
+					fmt.Sprintf("Run 'hello-gopher proverb explain %d' to read about it", known.ID),
+				)
+			}
+
+			store, err := loadUserProverbs(cmd)
+			if err != nil {
+				return err
+			}
+			result := suggestResult{Proverb: proverb, Path: store.Path()}
+			if added, _ := store.Merge([]greeting.Proverb{proverb}, nil); added > 0 {
+				if err := store.Save(); err != nil {
+					return NewSystemError(
+						fmt.Sprintf("Failed to save %s", store.Path()),
+						err,
+						"Check that the data directory is writable",
+					)
+				}
+				result.Added = true
+			}
+			if upstream, _ := cmd.Flags().GetBool("upstream"); upstream {
+				result.IssueURL = suggestIssueURL(proverb)
+			}
+
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			out := cmd.OutOrStdout()
+			if result.Added {
+				fmt.Fprintf(out, "Added to your collection (%d in %s)\n", len(store.Proverbs()), store.Path())
+			} else {
+				fmt.Fprintf(out, "Already in your collection (%s)\n", store.Path())
+			}
+			if result.IssueURL != "" {
+				fmt.Fprintf(out, "Propose it upstream by opening this link:\n%s\n", result.IssueURL)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice("tags", nil, "Tags of the proverb, repeat or comma-separate for several")
+	cmd.Flags().String("author", "", "Who the proverb is attributed to")
+	cmd.Flags().String("source", "", "URL of the talk or post the proverb comes from")
+	cmd.Flags().Bool("upstream", false, "Print a link to a prefilled GitHub issue proposing the proverb")
+	return cmd
+}
+
+// suggestedProverb builds the proverb from text and the flags of cmd and
+// checks it like a line of 'proverb import'
+func suggestedProverb(cmd *cobra.Command, text string) (greeting.Proverb, error) {
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	author, _ := cmd.Flags().GetString("author")
+	source, _ := cmd.Flags().GetString("source")
+
+	for _, field := range append([]string{text, author, source}, tags...) {
+		if strings.ContainsAny(field, "|\n") {
+			return greeting.Proverb{}, NewUsageError(
+				fmt.Sprintf("Invalid proverb: %q contains '|' or a line break", field),
+				"Leave out '|' and line breaks; pass tags, author and source with their flags",
+			)
+		}
+	}
+
+	line := userproverbs.FormatLine(greeting.Proverb{Text: text, Tags: tags, Author: author, Source: source})
+	p, ok, err := greeting.ParseProverbLine(line)
+	if err == nil && !ok {
+		return greeting.Proverb{}, NewUsageError(
+			"Invalid proverb: the text is empty or starts with '#'",
+			"Pass the text as one quoted argument",
+		)
+	}
+	if err != nil {
+		return greeting.Proverb{}, NewUsageError(
+			fmt.Sprintf("Invalid proverb: %v", err),
+			"Pass the text as one quoted argument; --source must be an http or https URL",
+		)
+	}
+	return p, nil
+}
+
+// suggestIssueURL returns the link to a new issue at issueURL proposing p
+// for the built-in collection in the line format of proverb.txt
+func suggestIssueURL(p greeting.Proverb) string {
+	body := fmt.Sprintf("I'd like to suggest this proverb for the built-in collection:\n\n```\n%s\n```\n",
+		userproverbs.FormatLine(p))
+	q := url.Values{}
+	q.Set("title", "Proverb suggestion: "+p.Text)
+	q.Set("body", body)
+	q.Set("labels", "proverb")
+	return issueURL + "?" + q.Encode()
+}
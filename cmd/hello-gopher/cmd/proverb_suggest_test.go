@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/userproverbs"
+)
+
+func TestProverbSuggest(t *testing.T) {
+	dataDir := t.TempDir()
+	withEnv(t, map[string]string{"HELLO_GOPHER_DATA_DIR": dataDir})
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "suggest", "Ship small, ship often.", "--tags", "release,process", "--author", "The Team")
+	if code != ExitSuccess || !strings.HasPrefix(stdout, "Added to your collection (1 in ") {
+		t.Fatalf("suggest = %q, %q, exit code %d", stdout, stderr, code)
+	}
+	data, err := os.ReadFile(filepath.Join(dataDir, userproverbs.FileName))
+	if err != nil || !strings.Contains(string(data), "Ship small, ship often. | release, process | The Team\n") {
+		t.Errorf("saved collection = %q, %v", data, err)
+	}
+
+	stdout, _, code = testsupport.RunCommand(t, "proverb", "suggest", "ship small,  ship OFTEN.", "--upstream", "--output", "json")
+	var result suggestResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || code != ExitSuccess || result.Added {
+		t.Fatalf("suggest of a proverb in the collection = %+v, %v, exit code %d", result, err, code)
+	}
+	issue, err := url.Parse(result.IssueURL)
+	if err != nil || !strings.HasPrefix(result.IssueURL, issueURL+"?") {
+		t.Fatalf("issue URL = %q, %v", result.IssueURL, err)
+	}
+	if title := issue.Query().Get("title"); title != "Proverb suggestion: ship small,  ship OFTEN." {
+		t.Errorf("issue title = %q", title)
+	}
+	if body := issue.Query().Get("body"); !strings.Contains(body, "\nship small,  ship OFTEN.\n") {
+		t.Errorf("issue body = %q, want the proverb line", body)
+	}
+}
+
+func TestProverbSuggestErrors(t *testing.T) {
+	withEnv(t, map[string]string{"HELLO_GOPHER_DATA_DIR": t.TempDir()})
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"built in", []string{"errors  are VALUES."}, ExitDataError, "is already built in: Errors are values."},
+		{"separator", []string{"Ship | small"}, ExitUsageError, "contains '|'"},
+		{"comment", []string{"# Ship small."}, ExitUsageError, "starts with '#'"},
+		{"empty tag", []string{"Ship small.", "--tags", "release,"}, ExitUsageError, "empty tag"},
+		{"source", []string{"Ship small.", "--source", "example.com"}, ExitUsageError, "not an http or https URL"},
+		{"no text", nil, ExitUsageError, "needs the proverb text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "suggest"}, tt.args...)...)
+			if code != tt.code || !strings.Contains(stderr, tt.want) {
+				t.Errorf("suggest exited with %d: %s; want %d and %q", code, stderr, tt.code, tt.want)
+			}
+		})
+	}
+}
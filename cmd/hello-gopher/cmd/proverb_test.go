@@ -1,11 +1,13 @@
 package cmd
 
 import (
-	"bytes"
+	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 
-	"github.com/spf13/cobra"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
 )
 
 func TestProverbCommand(t *testing.T) {
@@ -29,84 +31,46 @@ func TestProverbCommand(t *testing.T) {
 				}
 			},
 		},
-		{
-			name: "proverb command with help flag",
-			args: []string{"proverb", "--help"},
-			validate: func(t *testing.T, output string) {
-				if !strings.Contains(output, "Display a random Go proverb") {
-					t.Error("Expected help text to contain command description")
-				}
-				if !strings.Contains(output, "Usage:") {
-					t.Error("Expected help text to contain usage information")
-				}
-				if !strings.Contains(output, "Examples:") {
-					t.Error("Expected help text to contain examples")
-				}
-			},
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Use a fresh copy of the proverb command for each test
-			testCmd := &cobra.Command{
-				Use:   "proverb",
-				Short: "Display a random Go proverb",
-				Long: `Proverb command displays random Go proverbs to inspire and educate.
-Each execution shows a different proverb from a curated collection of Go programming
-wisdom and best practices.
-
-This command demonstrates integration with the ProverbProvider interface and
-proper error handling for data loading failures.`,
-				Example: `  hello-gopher proverb                  # Display a random Go proverb`,
-				RunE:    proverbCmd.RunE, // Use the same RunE function
-			}
-			
-			// Capture output
-			var buf bytes.Buffer
-			testCmd.SetOut(&buf)
-			testCmd.SetErr(&buf)
-			testCmd.SetArgs(tt.args[1:]) // Remove "proverb" from args since we're calling the command directly
-
-			err := testCmd.Execute()
-			
-			if tt.wantErr && err == nil {
+			stdout, stderr, code := testsupport.RunCommand(t, tt.args...)
+
+			if tt.wantErr && code == ExitSuccess {
 				t.Error("Expected error but got none")
 			}
-			if !tt.wantErr && err != nil {
-				t.Errorf("Unexpected error: %v", err)
+			if !tt.wantErr && code != ExitSuccess {
+				t.Errorf("Unexpected exit code %d: %s", code, stderr)
 			}
 
-			output := buf.String()
 			if tt.validate != nil {
-				tt.validate(t, output)
+				tt.validate(t, stdout)
 			}
 		})
 	}
 }
 
+func TestProverbCommandHelp(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--help")
+	if code != ExitSuccess {
+		t.Fatalf("Help command exited with %d: %s", code, stderr)
+	}
+	testsupport.AssertGolden(t, "proverb-help", stdout)
+}
+
 func TestProverbCommandRandomness(t *testing.T) {
 	// Test that multiple executions can produce different results
 	// Note: This test might occasionally fail due to randomness, but it's unlikely
 	results := make(map[string]bool)
 	
 	for i := 0; i < 10; i++ {
-		testCmd := &cobra.Command{
-			Use:  "proverb",
-			RunE: proverbCmd.RunE, // Use the same RunE function
-		}
-		
-		var buf bytes.Buffer
-		testCmd.SetOut(&buf)
-		testCmd.SetErr(&buf)
-		testCmd.SetArgs([]string{}) // No args needed for direct command execution
-
-		err := testCmd.Execute()
-		if err != nil {
-			t.Fatalf("Unexpected error: %v", err)
+		stdout, stderr, code := testsupport.RunCommand(t, "proverb")
+		if code != ExitSuccess {
+			t.Fatalf("Unexpected exit code %d: %s", code, stderr)
 		}
 
-		output := strings.TrimSpace(buf.String())
+		output := strings.TrimSpace(stdout)
 		if output == "" {
 			t.Error("Expected non-empty proverb output")
 		}
@@ -123,23 +87,15 @@ func TestProverbCommandRandomness(t *testing.T) {
 }
 
 func TestProverbCommandIntegration(t *testing.T) {
-	// Test the full integration with the greeting service
-	testCmd := &cobra.Command{
-		Use:  "proverb",
-		RunE: proverbCmd.RunE, // Use the same RunE function
-	}
-	
-	var buf bytes.Buffer
-	testCmd.SetOut(&buf)
-	testCmd.SetErr(&buf)
-	testCmd.SetArgs([]string{}) // No args needed for direct command execution
-
-	err := testCmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	// Test the full integration with the greeting service. The selection is
+	// seeded so the check for error messages below can't trip over a proverb
+	// that merely talks about errors.
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--seed", "1")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
 	}
 
-	output := strings.TrimSpace(buf.String())
+	output := strings.TrimSpace(stdout)
 	
 	// Verify the output is a valid proverb (non-empty and reasonable length)
 	if len(output) == 0 {
@@ -156,5 +112,199 @@ func TestProverbCommandIntegration(t *testing.T) {
 	}
 }
 
-// Note: Proverb command error handling tests are skipped due to command registration issues
-// The error handling code is implemented correctly in the proverb.go file
\ No newline at end of file
+func TestProverbCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unexpected argument", []string{"proverb", "extra"}, ExitUsageError},
+		{"daily and watch", []string{"proverb", "--daily", "--watch", "1h"}, ExitUsageError},
+		{"invalid seed", []string{"proverb", "--seed", "abc"}, ExitUsageError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, tt.args...)
+			if code != tt.want {
+				t.Errorf("exit code = %d, want %d (stderr %q)", code, tt.want, stderr)
+			}
+			if !strings.Contains(stderr, "Suggestion:") {
+				t.Errorf("expected a suggestion on stderr, got %q", stderr)
+			}
+		})
+	}
+}
+
+func TestProverbCommandSeed(t *testing.T) {
+	run := func(args ...string) string {
+		stdout, stderr, code := testsupport.RunCommand(t, append([]string{"proverb"}, args...)...)
+		if code != ExitSuccess {
+			t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+		}
+		return strings.TrimSpace(stdout)
+	}
+
+	for _, seed := range []string{"1", "42", "-7"} {
+		first := run("--seed", seed)
+		second := run("--seed", seed)
+		if first != second {
+			t.Errorf("--seed %s produced different proverbs: %q vs %q", seed, first, second)
+		}
+	}
+}
+
+func TestProverbCommandDaily(t *testing.T) {
+	run := func(args ...string) (string, int) {
+		stdout, _, code := testsupport.RunCommand(t, append([]string{"proverb"}, args...)...)
+		return strings.TrimSpace(stdout), code
+	}
+
+	first, code := run("--daily")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d", code)
+	}
+	if second, _ := run("--daily"); first != second {
+		t.Errorf("--daily produced different proverbs: %q vs %q", first, second)
+	}
+
+	if _, code = run("--daily", "--seed", "1"); code != ExitUsageError {
+		t.Errorf("Expected usage error for --daily with --seed, got code %d", code)
+	}
+}
+
+func TestProverbCommandLang(t *testing.T) {
+	run := func(args ...string) greeting.Proverb {
+		stdout, stderr, code := testsupport.RunCommand(t, append([]string{"proverb", "--output", "json"}, args...)...)
+		if code != ExitSuccess {
+			t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+		}
+		var p greeting.Proverb
+		if err := json.Unmarshal([]byte(stdout), &p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	// Find a seed picking a translated proverb and one picking an
+	// untranslated proverb
+	var translated, untranslated string
+	for seed := 0; seed < 200 && (translated == "" || untranslated == ""); seed++ {
+		p := run("--seed", strconv.Itoa(seed))
+		if _, ok := greeting.Translate(p, "fr"); ok {
+			translated = strconv.Itoa(seed)
+		} else {
+			untranslated = strconv.Itoa(seed)
+		}
+	}
+	if translated == "" || untranslated == "" {
+		t.Fatal("no seed picks both a translated and an untranslated proverb")
+	}
+
+	english := run("--seed", translated)
+	french := run("--seed", translated, "--lang", "fr")
+	if want, _ := greeting.Translate(english, "fr"); french.Text != want.Text || french.ID != english.ID {
+		t.Errorf("--lang fr = %+v, want %+v", french, want)
+	}
+	if got := run("--seed", untranslated, "--lang", "fr"); got.Text != run("--seed", untranslated).Text {
+		t.Errorf("--lang fr of an untranslated proverb = %q, want the English text", got.Text)
+	}
+
+	// The language setting of greetings applies, too
+	withEnv(t, map[string]string{"HELLO_GOPHER_LANG": "fr", "HELLO_GOPHER_DATA_DIR": t.TempDir()})
+	if got := run("--seed", translated); got.Text != french.Text {
+		t.Errorf("HELLO_GOPHER_LANG=fr = %q, want %q", got.Text, french.Text)
+	}
+
+	if _, _, code := testsupport.RunCommand(t, "proverb", "--lang", "xx"); code != ExitUsageError {
+		t.Errorf("Expected usage error for an unsupported --lang, got code %d", code)
+	}
+}
+
+func TestProverbCommandWithSource(t *testing.T) {
+	sourced := 0
+	for seed := 0; seed < 20; seed++ {
+		stdout, _, _ := testsupport.RunCommand(t, "proverb", "--seed", strconv.Itoa(seed), "--output", "json")
+		var p greeting.Proverb
+		if err := json.Unmarshal([]byte(stdout), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--seed", strconv.Itoa(seed), "--with-source")
+		if code != ExitSuccess {
+			t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+		}
+		want := p.Text + "\n"
+		if p.Author != "" {
+			want += "  — " + p.Author + "\n"
+		}
+		if p.Source != "" {
+			want += "  " + p.Source + "\n"
+			sourced++
+		}
+		if stdout != want {
+			t.Errorf("--seed %d --with-source = %q, want %q", seed, stdout, want)
+		}
+	}
+	if sourced == 0 {
+		t.Error("no seed picked a proverb with a source")
+	}
+}
+
+func TestProverbCommandWidth(t *testing.T) {
+	plain, _, _ := testsupport.RunCommand(t, "proverb", "--seed", "42")
+	wrapped, stderr, code := testsupport.RunCommand(t, "proverb", "--seed", "42", "--width", "20")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if strings.Count(plain, "\n") != 1 || strings.Join(strings.Fields(wrapped), " ") != strings.TrimSpace(plain) {
+		t.Errorf("--width changed more than the line breaks: %q vs %q", wrapped, plain)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(wrapped, "\n"), "\n")[1:] {
+		if !strings.HasPrefix(line, "  ") || len(line) > 20 {
+			t.Errorf("continuation line %q isn't indented or is too wide", line)
+		}
+	}
+
+	if _, _, code := testsupport.RunCommand(t, "proverb", "--width", "-1"); code != ExitUsageError {
+		t.Errorf("Expected usage error for a negative --width, got code %d", code)
+	}
+}
+
+func TestWrapProverb(t *testing.T) {
+	text := "There are two ways of constructing a software design."
+	want := []string{"There are two ways of", "  constructing a", "  software design."}
+	if got := wrapProverb(text, 21); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapProverb() = %q, want %q", got, want)
+	}
+	if got := wrapProverb(text, 0); len(got) != 1 || got[0] != text {
+		t.Errorf("wrapProverb() without a width = %q", got)
+	}
+	if got := wrapProverb("Short.", 21); len(got) != 1 {
+		t.Errorf("wrapProverb() of a short proverb = %q", got)
+	}
+}
+
+func TestProverbCommandGenerate(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--generate", "--seed", "7")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "(Made up by a Markov chain, not a real proverb)") {
+		t.Errorf("Generated proverb isn't labeled:\n%s", stdout)
+	}
+	again, _, _ := testsupport.RunCommand(t, "proverb", "--generate", "--seed", "7")
+	if again != stdout {
+		t.Errorf("--seed 7 generated %q, then %q", stdout, again)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "--generate", "--output", "json")
+	var p greeting.Proverb
+	if err := json.Unmarshal([]byte(stdout), &p); err != nil || !p.Generated || p.Text == "" {
+		t.Errorf("--generate --output json = %s (err %v), want a generated proverb", stdout, err)
+	}
+
+	if _, stderr, code := testsupport.RunCommand(t, "proverb", "--generate", "--daily"); code != ExitUsageError || !strings.Contains(stderr, "--generate and --daily cannot be combined") {
+		t.Errorf("--generate --daily: exit code %d, stderr %q", code, stderr)
+	}
+}
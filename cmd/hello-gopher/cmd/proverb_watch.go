@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/webhook"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// watchOptions are the settings of proverb --watch
+type watchOptions struct {
+	interval time.Duration
+	jitter   time.Duration
+	maxCount int
+	webhook  string
+	platform webhook.Platform
+}
+
+// notifySignal relays the signals stopping watch mode to a channel; tests
+// replace it to simulate Ctrl-C
+var notifySignal = signal.Notify
+
+// interruptContext returns a copy of ctx that is cancelled when the process
+// receives SIGINT or SIGTERM. interrupted reports whether it was SIGINT, the
+// Ctrl-C of a user rather than a service manager stopping the process.
+func interruptContext(ctx context.Context) (_ context.Context, interrupted func() bool, stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	signals := make(chan os.Signal, 1)
+	notifySignal(signals, os.Interrupt, syscall.SIGTERM)
+
+	var sigint atomic.Bool
+	go func() {
+		select {
+		case sig := <-signals:
+			sigint.Store(sig == os.Interrupt)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, sigint.Load, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// readWatchOptions validates the watch flags of cmd
+func readWatchOptions(cmd *cobra.Command) (watchOptions, error) {
+	var opts watchOptions
+	opts.interval, _ = cmd.Flags().GetDuration("watch")
+	opts.jitter, _ = cmd.Flags().GetDuration("jitter")
+	opts.maxCount, _ = cmd.Flags().GetInt("max-count")
+	opts.webhook, _ = cmd.Flags().GetString("webhook")
+
+	if opts.interval <= 0 {
+		return opts, NewUsageError(
+			fmt.Sprintf("Invalid watch interval: %s", opts.interval),
+			"Use a positive duration such as --watch 30m or --watch 1h",
+		)
+	}
+	if opts.jitter < 0 {
+		return opts, NewUsageError("Jitter must not be negative", "Use a duration such as --jitter 5m")
+	}
+	if opts.maxCount < 0 {
+		return opts, NewUsageError("--max-count must not be negative", "Use 0 to print proverbs until interrupted")
+	}
+
+	if opts.webhook != "" {
+		name, _ := cmd.Flags().GetString("platform")
+		platform, err := webhook.ParsePlatform(name)
+		if err != nil {
+			return opts, NewUsageError(err.Error(), "Use --platform slack or --platform discord")
+		}
+		opts.platform = platform
+	}
+	return opts, nil
+}
+
+// watchProverbs emits a random proverb right away and then after every
+// interval plus a random jitter, until --max-count proverbs were emitted or
+// the process receives SIGINT or SIGTERM. Proverbs are printed with print,
+// or posted to the webhook if one is configured. The jitter is drawn from rng.
+// Ctrl-C stops the watch with ErrInterrupted; SIGTERM stops it cleanly.
+func watchProverbs(cmd *cobra.Command, rng greeting.Rand, provider greeting.ProverbProvider, print func(greeting.Proverb) error) error {
+	opts, err := readWatchOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, interrupted, stop := interruptContext(cmd.Context())
+	defer stop()
+
+	stopDebug, err := startDebugServer(ctx, cmd, log.New(cmd.ErrOrStderr(), "", log.LstdFlags))
+	if err != nil {
+		return err
+	}
+	defer stopDebug()
+
+	for count := 1; ; count++ {
+		proverb, err := randomEntry(provider)
+		if err != nil {
+			return NewDataError("Failed to select a Go proverb", err, "")
+		}
+
+		if opts.webhook != "" {
+			err = postProverb(ctx, cmd, opts, proverb)
+		} else {
+			err = print(proverb)
+		}
+		if err != nil && interrupted() {
+			// Ctrl-C cancelled the webhook request
+			return ErrInterrupted
+		} else if err != nil {
+			return err
+		}
+
+		if opts.maxCount > 0 && count >= opts.maxCount {
+			return nil
+		}
+
+		wait := opts.interval
+		if opts.jitter > 0 {
+			wait += time.Duration(rng.Int63n(int64(opts.jitter)))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if interrupted() {
+				return ErrInterrupted
+			}
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// postProverb sends proverb to the configured webhook. A failed delivery
+// that was kept for post --redeliver is only reported, so the watch goes on.
+func postProverb(ctx context.Context, cmd *cobra.Command, opts watchOptions, proverb greeting.Proverb) error {
+	footer := fmt.Sprintf("Go Proverb #%d", proverb.ID)
+	if proverb.Generated {
+		footer = generatedLabel
+	}
+	payload, err := webhook.Payload(opts.platform, webhook.Message{
+		Text:   proverb.Text,
+		Footer: footer,
+		Quote:  true,
+	})
+	if err != nil {
+		return NewSystemError("Failed to build webhook payload", err, "")
+	}
+	deadLetters, err := deliverWebhook(ctx, cmd, webhook.NewDelivery(opts.webhook, opts.platform, payload))
+	switch {
+	case err == nil || ctx.Err() != nil:
+		return nil
+	case deadLetters != "":
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to post to %s: %v (kept for 'hello-gopher post --redeliver')\n", opts.platform, err)
+		return nil
+	default:
+		return webhookError(opts.platform, "", err)
+	}
+}
+
+// addWatchFlags registers the flags controlling proverb --watch
+func addWatchFlags(c *cobra.Command) {
+	c.Flags().Duration("watch", 0, "Keep running and emit a new proverb every interval, e.g. 30m or 1h")
+	c.Flags().Duration("jitter", 0, "Add a random delay of up to this duration to every interval")
+	c.Flags().Int("max-count", 0, "Stop after this many proverbs (default: run until interrupted)")
+	c.Flags().String("webhook", "", "Post proverbs to this webhook instead of printing them")
+	c.Flags().String("platform", string(webhook.Slack), "Webhook payload format (slack, discord)")
+	addDebugAddrFlag(c)
+}
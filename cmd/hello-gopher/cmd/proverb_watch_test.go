@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/webhook"
+)
+
+func TestProverbWatchMaxCount(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--watch", "1ms", "--jitter", "1ms", "--max-count", "3", "--seed", "7")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout), "\n"); len(lines) != 3 {
+		t.Errorf("Expected 3 proverbs, got %d:\n%s", len(lines), stdout)
+	}
+}
+
+func TestProverbWatchStopsOnCancel(t *testing.T) {
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	root := NewRootCmd(testDeps(t, Deps{Out: io.Discard, Err: io.Discard}))
+	root.SetArgs([]string{"proverb", "--watch", "1h"})
+
+	done := make(chan error, 1)
+	go func() { done <- root.ExecuteContext(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop after cancellation")
+	}
+}
+
+func TestProverbWatchPostsToWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--watch", "1ms", "--max-count", "2", "--webhook", ts.URL, "--platform", "discord")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if stdout != "" || stderr != "" {
+		t.Errorf("Proverbs should be posted, not printed: %q", stdout+stderr)
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[0], `"embeds"`) {
+		t.Errorf("Webhook received %d payloads: %v", len(bodies), bodies)
+	}
+}
+
+func TestProverbWatchKeepsFailedDeliveries(t *testing.T) {
+	_, _, state := appDirs(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service", http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	_, stderr, code := testsupport.RunCommand(t, "proverb", "--watch", "1ms", "--max-count", "2", "--webhook", ts.URL)
+	if code != ExitSuccess || strings.Count(stderr, "post --redeliver") != 2 {
+		t.Fatalf("watch with a failing webhook = exit %d, %q; want it to go on and warn twice", code, stderr)
+	}
+	if letters, err := webhook.LoadDeadLetters(filepath.Join(state, webhook.DeadLetterFileName)); err != nil || len(letters) != 2 {
+		t.Errorf("watch kept %d failed deliveries (%v), want 2", len(letters), err)
+	}
+}
+
+func TestProverbWatchInvalidFlags(t *testing.T) {
+	tests := [][]string{
+		{"--watch", "0s"},
+		{"--watch", "1m", "--jitter", "-1s"},
+		{"--watch", "1m", "--max-count", "-1"},
+		{"--watch", "1m", "--webhook", "http://localhost", "--platform", "irc"},
+		{"--watch", "1m", "--daily"},
+	}
+
+	for _, args := range tests {
+		_, stderr, code := testsupport.RunCommand(t, append([]string{"proverb"}, args...)...)
+		if code != ExitUsageError {
+			t.Errorf("%v: expected usage error, got code %d (stderr %q)", args, code, stderr)
+		}
+	}
+}
+
+func TestProverbWatchInterrupted(t *testing.T) {
+	original := notifySignal
+	t.Cleanup(func() { notifySignal = original })
+
+	for sig, want := range map[os.Signal]int{os.Interrupt: ExitInterrupted, syscall.SIGTERM: ExitSuccess} {
+		notifySignal = func(c chan<- os.Signal, _ ...os.Signal) { c <- sig }
+
+		stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--watch", "1h")
+		if code != want {
+			t.Errorf("watch stopped by %v exited with %d, want %d: %s", sig, code, want, stderr)
+		}
+		if stdout == "" || stderr != "" {
+			t.Errorf("watch stopped by %v printed %q, %q; want the first proverb and no error", sig, stdout, stderr)
+		}
+	}
+}
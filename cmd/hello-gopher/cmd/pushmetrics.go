@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/pushgateway"
+	"github.com/spf13/cobra"
+)
+
+// pushTimeout bounds pushing the metrics of a run, unless --timeout is set
+const pushTimeout = 5 * time.Second
+
+// addPushMetricsFlags registers the flags pushing the metrics of a run
+func addPushMetricsFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("push-metrics", false, "Push the outcome of the run to a Prometheus Pushgateway, for cron jobs")
+	cmd.Flags().String("pushgateway", "", "Pushgateway URL for --push-metrics (default: the pushgateway setting)")
+}
+
+// withPushMetrics wraps the RunE of a command so that with --push-metrics
+// every run pushes its outcome, success or failure, to the Pushgateway. A
+// failed push fails an otherwise successful run, so cron reports it.
+func withPushMetrics(run func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if push, _ := cmd.Flags().GetBool("push-metrics"); !push {
+			return run(cmd, args)
+		}
+
+		gateway, err := pushgatewayURL(cmd)
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("watch") {
+			return NewUsageError(
+				"--push-metrics and --watch cannot be combined",
+				"Push metrics from one-shot runs, such as a cron job running 'hello-gopher proverb --daily --push-metrics'",
+			)
+		}
+
+		start := time.Now()
+		runErr := run(cmd, args)
+		pushErr := pushRunMetrics(cmd, gateway, start, runErr == nil)
+		if runErr != nil {
+			if pushErr != nil {
+				commandLogger(cmd).Warn("failed to push metrics", "pushgateway", gateway, "error", pushErr)
+			}
+			return runErr
+		}
+		if pushErr != nil {
+			return NewSystemError(
+				fmt.Sprintf("Failed to push metrics to %s", gateway),
+				pushErr,
+				"Check the Pushgateway URL and your network connection",
+			)
+		}
+		return nil
+	}
+}
+
+// pushgatewayURL returns the Pushgateway given with --pushgateway or the
+// pushgateway setting
+func pushgatewayURL(cmd *cobra.Command) (string, error) {
+	// Warnings about the configuration are left to the command
+	cfg, err := peekConfig(cmd)
+	if err != nil {
+		if cfg, err = loadConfig(cmd); err != nil {
+			return "", err
+		}
+	}
+	gateway := resolveString(cmd, cfg, "pushgateway", config.KeyPushgateway)
+	if gateway == "" {
+		return "", NewUsageError(
+			"No Pushgateway configured for --push-metrics",
+			"Pass --pushgateway <url>, set $HELLO_GOPHER_PUSHGATEWAY, or run 'hello-gopher config set pushgateway http://pushgateway:9091'",
+		)
+	}
+	if u, err := url.Parse(gateway); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", NewUsageError(
+			fmt.Sprintf("Invalid Pushgateway URL: %s", gateway),
+			"Use an http:// or https:// URL such as --pushgateway http://pushgateway:9091",
+		)
+	}
+	return gateway, nil
+}
+
+// pushRunMetrics pushes the outcome of a run that started at start to
+// gateway. The time of the last success is only pushed by successful runs,
+// so it keeps its value when runs fail: alert on
+//
+//	time() - hello_gopher_last_success_timestamp_seconds > 26 * 3600
+func pushRunMetrics(cmd *cobra.Command, gateway string, start time.Time, success bool) error {
+	end := time.Now()
+	result := 0.0
+	if success {
+		result = 1
+	}
+	metrics := []pushgateway.Metric{
+		{Name: "hello_gopher_last_run_timestamp_seconds", Help: "When the last run of hello-gopher finished, in Unix seconds", Value: unixSeconds(end)},
+		{Name: "hello_gopher_last_run_duration_seconds", Help: "How long the last run of hello-gopher took", Value: end.Sub(start).Seconds()},
+		{Name: "hello_gopher_last_run_success", Help: "Whether the last run of hello-gopher succeeded (1) or failed (0)", Value: result},
+	}
+	if success {
+		metrics = append(metrics, pushgateway.Metric{
+			Name: "hello_gopher_last_success_timestamp_seconds", Help: "When the last successful run of hello-gopher finished, in Unix seconds", Value: unixSeconds(end),
+		})
+	}
+
+	instance, _ := os.Hostname()
+	err := pushgateway.Push(cmd.Context(), httpClient(cmd, pushTimeout), gateway, pushgateway.DefaultJob, instance, metrics)
+	if err == nil {
+		commandLogger(cmd).Debug("pushed metrics", "pushgateway", gateway, "success", success)
+	}
+	return err
+}
+
+// unixSeconds returns t in Unix seconds with a millisecond fraction
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+// pushgatewayServer records the paths and bodies pushed to a fake Pushgateway
+func pushgatewayServer(t *testing.T, status int) (*httptest.Server, func() (path, body string)) {
+	t.Helper()
+	var mu sync.Mutex
+	var path, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		path, body = r.Method+" "+r.URL.Path, string(b)
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, func() (string, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return path, body
+	}
+}
+
+func TestProverbPushMetrics(t *testing.T) {
+	ts, pushed := pushgatewayServer(t, http.StatusOK)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "--daily", "--push-metrics", "--pushgateway", ts.URL)
+	if code != ExitSuccess || stdout == "" {
+		t.Fatalf("proverb --push-metrics exited with %d: %s", code, stderr)
+	}
+	path, body := pushed()
+	if !strings.HasPrefix(path, "POST /metrics/job/hello_gopher") {
+		t.Errorf("metrics pushed to %q, want POST /metrics/job/hello_gopher", path)
+	}
+	for _, want := range []string{
+		"hello_gopher_last_run_success 1\n",
+		"hello_gopher_last_success_timestamp_seconds ",
+		"hello_gopher_last_run_duration_seconds ",
+		"# TYPE hello_gopher_last_run_timestamp_seconds gauge\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("pushed metrics miss %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestProverbPushMetricsOnFailure(t *testing.T) {
+	ts, pushed := pushgatewayServer(t, http.StatusOK)
+
+	_, _, code := testsupport.RunCommand(t, "proverb", "--bubble", "--variant", "missing", "--push-metrics", "--pushgateway", ts.URL)
+	if code == ExitSuccess {
+		t.Fatal("proverb with an unknown variant succeeded")
+	}
+	_, body := pushed()
+	if !strings.Contains(body, "hello_gopher_last_run_success 0\n") || strings.Contains(body, "last_success_timestamp") {
+		t.Errorf("failed run pushed:\n%s", body)
+	}
+}
+
+func TestProverbPushMetricsErrors(t *testing.T) {
+	failing, _ := pushgatewayServer(t, http.StatusBadGateway)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"no gateway", []string{"--push-metrics"}, ExitUsageError, "No Pushgateway configured"},
+		{"invalid URL", []string{"--push-metrics", "--pushgateway", "pushgateway:9091"}, ExitUsageError, "Invalid Pushgateway URL"},
+		{"watch", []string{"--push-metrics", "--pushgateway", failing.URL, "--watch", "1h"}, ExitUsageError, "cannot be combined"},
+		{"failed push", []string{"--push-metrics", "--pushgateway", failing.URL}, ExitSystemError, "Failed to push metrics"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, append([]string{"proverb"}, tt.args...)...)
+			if code != tt.code || !strings.Contains(stderr, tt.want) {
+				t.Errorf("exit code %d with %q, want %d with %q", code, stderr, tt.code, tt.want)
+			}
+		})
+	}
+}
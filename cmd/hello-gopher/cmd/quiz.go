@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/quiz"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// Quiz modes; mixed alternates between the question kinds at random
+const (
+	quizFill   = quiz.Fill
+	quizChoice = quiz.Choice
+	quizMixed  = "mixed"
+)
+
+// quizModes lists the quiz modes in the order shown in help
+var quizModes = []string{quizMixed, quizFill, quizChoice}
+
+// newQuizCmd creates the quiz command
+func newQuizCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quiz",
+		Short: "Learn the Go proverbs with a quiz",
+		Long: `Quiz asks questions about the Go proverbs: type the word missing from a
+proverb, or pick it among four options by number. Your score is shown after
+every answer and at the end of the quiz; end it early with Ctrl+D.
+
+With --save the score is added to your progress, kept in quiz.json in the
+state directory, and the summary shows how many proverbs you have learned.`,
+		Example: `  hello-gopher quiz                       # Five questions of either kind
+  hello-gopher quiz --mode fill -n 10     # Ten fill-in-the-blank questions
+  hello-gopher quiz --mode choice --save  # Multiple choice, keeping progress`,
+		Args: exactArgs(0, "quiz doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, _ := cmd.Flags().GetInt("questions")
+			mode, _ := cmd.Flags().GetString("mode")
+			save, _ := cmd.Flags().GetBool("save")
+
+			if count < 1 {
+				return NewUsageError(fmt.Sprintf("Invalid number of questions: %d", count), "Ask at least one question, such as --questions 5")
+			}
+			mode = strings.ToLower(strings.TrimSpace(mode))
+			if mode != quizMixed && mode != quizFill && mode != quizChoice {
+				return NewUsageError(
+					fmt.Sprintf("Invalid quiz mode: %s", mode),
+					fmt.Sprintf("Use one of: %s", strings.Join(quizModes, ", ")),
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			service, err := proverbService(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			proverbs, err := service.Proverbs()
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+
+			rnd := deps.random()
+			if cmd.Flags().Changed("seed") {
+				seed, _ := cmd.Flags().GetInt64("seed")
+				rnd = greeting.NewRand(seed)
+			}
+			questions := quizQuestions(mode, count, proverbs, rnd)
+			if len(questions) == 0 {
+				return NewDataError("No proverb can be turned into a question", nil, "")
+			}
+
+			var progress *quiz.Progress
+			if save {
+				if progress, err = loadQuizProgress(cmd); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			in := bufio.NewReader(cmd.InOrStdin())
+			asked, correct := 0, 0
+			for i, q := range questions {
+				writeQuestion(out, i+1, len(questions), q, styler.Quote)
+
+				answer, ok, err := readAnswer(in)
+				if err != nil {
+					return NewSystemError("Failed to read the answer", err, "")
+				}
+				if !ok {
+					fmt.Fprintln(out)
+					break
+				}
+
+				asked++
+				right := q.Check(answer)
+				if right {
+					correct++
+					fmt.Fprintf(out, "%s %s\n\n", styler.Highlight("Correct!"), styler.Muted(fmt.Sprintf("(%d/%d)", correct, asked)))
+				} else {
+					fmt.Fprintf(out, "Not quite, the answer is %s. %s\n\n", styler.Highlight(q.Answer), styler.Muted(fmt.Sprintf("(%d/%d)", correct, asked)))
+				}
+				if progress != nil {
+					progress.Record(q.Proverb.ID, right)
+				}
+			}
+
+			fmt.Fprintf(out, "You answered %d of %s right.\n", correct, plural(asked, "question"))
+			if progress == nil || asked == 0 {
+				return nil
+			}
+			if err := progress.Save(); err != nil {
+				return NewSystemError(fmt.Sprintf("Failed to save the quiz progress to %s", progress.Path()), err, "")
+			}
+			fmt.Fprintf(out, "Overall: %d of %d right in %s; %d of %d proverbs learned.\n",
+				progress.Correct, progress.Asked, plural(progress.Sessions, "session"),
+				progress.Learned(), len(proverbs))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntP("questions", "n", 5, "Number of questions to ask")
+	cmd.Flags().String("mode", quizMixed, "Question kind: "+strings.Join(quizModes, ", "))
+	cmd.Flags().Int64("seed", 0, "Seed the choice of questions for a reproducible quiz")
+	cmd.Flags().Bool("save", false, "Add the score to your progress in the state directory")
+	return cmd
+}
+
+// quizQuestions returns up to count questions in mode about distinct
+// proverbs of collection chosen with rnd
+func quizQuestions(mode string, count int, collection []greeting.Proverb, rnd greeting.Rand) []quiz.Question {
+	order := make([]greeting.Proverb, len(collection))
+	copy(order, collection)
+	for i := len(order) - 1; i > 0; i-- {
+		j := rnd.Intn(i + 1)
+		order[i], order[j] = order[j], order[i]
+	}
+
+	var questions []quiz.Question
+	for _, p := range order {
+		if len(questions) == count {
+			break
+		}
+		kind := mode
+		if mode == quizMixed {
+			kind = []string{quizFill, quizChoice}[rnd.Intn(2)]
+		}
+		if q, ok := quiz.New(kind, p, collection, rnd); ok {
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
+// writeQuestion writes question n of total to w, styling the proverb with
+// quote
+func writeQuestion(w io.Writer, n, total int, q quiz.Question, quote func(string) string) {
+	fmt.Fprintf(w, "Question %d of %d: which word is missing?\n  %s\n", n, total, quote(q.Prompt))
+	for i, choice := range q.Choices {
+		fmt.Fprintf(w, "  %d) %s\n", i+1, choice)
+	}
+	fmt.Fprint(w, "> ")
+}
+
+// readAnswer reads a one-line answer from r. It reports false at the end of
+// the input, which ends the quiz.
+func readAnswer(r *bufio.Reader) (string, bool, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, err
+	}
+	if errors.Is(err, io.EOF) && line == "" {
+		return "", false, nil
+	}
+	return strings.TrimSpace(line), true, nil
+}
+
+// loadQuizProgress reads the quiz progress from the state directory of cmd
+func loadQuizProgress(cmd *cobra.Command) (*quiz.Progress, error) {
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := quiz.Load(filepath.Join(dir, quiz.FileName))
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read the quiz progress: %v", err),
+			err,
+			"Run 'hello-gopher data prune' to start over",
+		)
+	}
+	return progress, nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// runQuiz runs the quiz command with input as the answers
+func runQuiz(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	root := NewRootCmd(testDeps(t, Deps{In: strings.NewReader(input), Out: &out, Err: &out}))
+	root.SetArgs(append([]string{"quiz"}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+// quizAnswers returns the right answers to the quiz run with --mode mode
+// --seed seed, one per line
+func quizAnswers(t *testing.T, mode string, count int, seed int64) string {
+	t.Helper()
+	proverbs, err := greeting.Default().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var answers strings.Builder
+	for _, q := range quizQuestions(mode, count, proverbs, greeting.NewRand(seed)) {
+		answers.WriteString(strings.ToUpper(q.Answer) + "\n")
+	}
+	return answers.String()
+}
+
+func TestQuizScore(t *testing.T) {
+	appDirs(t)
+
+	for _, mode := range []string{quizFill, quizChoice} {
+		out, err := runQuiz(t, quizAnswers(t, mode, 3, 7), "--mode", mode, "-n", "3", "--seed", "7")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, "Question 3 of 3") || !strings.Contains(out, "You answered 3 of 3 questions right.") {
+			t.Errorf("%s quiz with right answers:\n%s", mode, out)
+		}
+		if strings.Contains(out, "Overall") {
+			t.Errorf("%s quiz without --save shows the overall progress:\n%s", mode, out)
+		}
+	}
+
+	out, err := runQuiz(t, "wrong\n", "-n", "3", "--seed", "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Not quite, the answer is") || !strings.Contains(out, "You answered 0 of 1 question right.") {
+		t.Errorf("quiz ended after a wrong answer:\n%s", out)
+	}
+}
+
+func TestQuizSave(t *testing.T) {
+	appDirs(t)
+
+	answers := quizAnswers(t, quizFill, 2, 3)
+	for _, want := range []string{"Overall: 2 of 2 right in 1 session;", "Overall: 4 of 4 right in 2 sessions; 2 of"} {
+		out, err := runQuiz(t, answers, "--mode", "fill", "-n", "2", "--seed", "3", "--save")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, want) {
+			t.Errorf("quiz --save output misses %q:\n%s", want, out)
+		}
+	}
+
+	stdout, _, _ := testsupport.RunCommand(t, "data", "prune", "--dry-run")
+	if !strings.Contains(stdout, "quiz.json") {
+		t.Errorf("data prune doesn't remove the quiz progress:\n%s", stdout)
+	}
+}
+
+func TestQuizErrors(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-n", "0"}, "Invalid number of questions"},
+		{[]string{"--mode", "essay"}, "Invalid quiz mode"},
+		{[]string{"now"}, "quiz doesn't accept positional arguments"},
+	} {
+		_, stderr, code := testsupport.RunCommand(t, append([]string{"quiz"}, tt.args...)...)
+		if code != ExitUsageError || !strings.Contains(stderr, tt.want) {
+			t.Errorf("%v: exit code %d, stderr %q; want %q", tt.args, code, stderr, tt.want)
+		}
+	}
+}
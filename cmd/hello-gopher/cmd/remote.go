@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/httpclient"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/client"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// localGreetFlags change how a greeting is generated, which a server does
+// with its own settings
+var localGreetFlags = []string{
+	"lang", "style", "title", "neutral", "normalize", "max-name-length",
+	"random-phrase", "seed", "emoji", "festive", "region", "tz", "profile-file",
+}
+
+// localProverbFlags select proverbs from the local collection
+var localProverbFlags = []string{"seed", "no-repeat", "reset", "watch", "playlist", "generate", "tz"}
+
+// addServerFlags adds the flags selecting a remote server to cmd, the root
+// command, so they apply to every command
+func addServerFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("server", "", "Get greetings and proverbs from the hello-gopher server at this URL")
+}
+
+// remoteClient returns a client of the server set with --server or the
+// server setting, and the server's URL. Without a server it returns nil, and
+// commands use local data.
+func remoteClient(cmd *cobra.Command, cfg *config.Config) (*client.Client, string, error) {
+	server := resolveString(cmd, cfg, "server", config.KeyServer)
+	if server == "" {
+		return nil, "", nil
+	}
+
+	// The HTTP client retries, so the client doesn't retry on top
+	c, err := client.New(server,
+		client.WithHTTPClient(httpclient.New(logging.Transport(commandLogger(cmd), networkTransport(cmd)), 0, networkRetries(cmd))),
+		client.WithTimeout(networkTimeout(cmd, client.DefaultTimeout)),
+		client.WithRetries(0),
+	)
+	if err != nil {
+		return nil, "", NewUsageError(err.Error(), "Use a URL such as --server https://proverbs.example.com")
+	}
+	commandLogger(cmd).Debug("using server", "url", server)
+	return c, server, nil
+}
+
+// rejectLocalFlags returns a usage error if one of flags, which only apply
+// to local data, was set together with a server
+func rejectLocalFlags(cmd *cobra.Command, flags []string) error {
+	for _, name := range flags {
+		if cmd.Flags().Changed(name) {
+			return NewUsageError(
+				fmt.Sprintf("--%s can't be used with --server", name),
+				fmt.Sprintf("The server decides this with its own settings; drop --%s or --server", name),
+			)
+		}
+	}
+	return nil
+}
+
+// remoteError reports a failed call to server
+func remoteError(server string, err error) error {
+	return NewSystemError(
+		fmt.Sprintf("Request to %s failed: %v", server, err),
+		err,
+		"Check that the server is running and reachable, or drop --server to use local data",
+	)
+}
+
+// remoteGreetFunc asks the server for the greeting of the name resolved for
+// cmd. The greeting only depends on the name, so it is requested once and
+// repeated for --count; the --shout, --whisper and --reverse transforms are
+// applied locally.
+func remoteGreetFunc(cmd *cobra.Command, cfg *config.Config, deps Deps, c *client.Client, server string) (func() greetResult, error) {
+	if err := rejectLocalFlags(cmd, localGreetFlags); err != nil {
+		return nil, err
+	}
+	transforms, err := greetTransforms(cmd)
+	if err != nil {
+		return nil, err
+	}
+	name, err := resolveName(cmd, cfg, deps)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUTF8(cmd, cfg, "name", name); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Greet(cmd.Context(), name)
+	if err != nil {
+		return nil, remoteError(server, err)
+	}
+	greeter := greeting.Chain(greeting.GreeterFunc(func(string) string { return resp.Greeting }), transforms...)
+	result := greetResult{Greeting: greeter.Greet(resp.Name), Name: greeting.SanitizeName(resp.Name)}
+	return func() greetResult { return result }, nil
+}
+
+// runRemoteProverb prints a random proverb, or with --daily the proverb of
+// the day, from the server
+func runRemoteProverb(cmd *cobra.Command, cfg *config.Config, deps Deps, c *client.Client, server string, styler color.Styler, output string) error {
+	if err := rejectLocalFlags(cmd, localProverbFlags); err != nil {
+		return err
+	}
+	lang, err := resolveLanguage(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	var proverb greeting.Proverb
+	if daily, _ := cmd.Flags().GetBool("daily"); daily {
+		proverb, err = c.DailyProverb(cmd.Context())
+	} else {
+		proverb, err = c.RandomProverb(cmd.Context())
+	}
+	if err != nil {
+		return remoteError(server, err)
+	}
+	// IDs belong to the server's collection, so they aren't recorded
+	recordRun(cmd, cfg, deps, "proverb", 0)
+	proverb, _ = greeting.Translate(proverb, lang)
+	return printProverb(cmd, styler, output, proverb)
+}
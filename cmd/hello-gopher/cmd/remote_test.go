@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/server"
+)
+
+// startRemote serves the API of a server greeting in German until the test
+// ends and returns its URL
+func startRemote(t *testing.T) string {
+	t.Helper()
+	appDirs(t)
+	svc := greeting.NewService(greeting.WithLanguage("de"))
+	ts := httptest.NewServer(server.New(svc, nil).Handler())
+	t.Cleanup(ts.Close)
+	return ts.URL
+}
+
+func TestRemoteGreet(t *testing.T) {
+	url := startRemote(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "--server", url, "greet", "-n", "Alice", "--shout", "--count", "2")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if want := "HALLO, ALICE!\nHALLO, ALICE!\n"; stdout != want {
+		t.Errorf("Unexpected output %q, want %q", stdout, want)
+	}
+}
+
+func TestRemoteProverb(t *testing.T) {
+	url := startRemote(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "--server", url, "proverb", "--daily", "--output", "json")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	var got greeting.Proverb
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", stdout, err)
+	}
+	want, _ := greeting.NewService().TodaysProverb()
+	if got.ID != want.ID {
+		t.Errorf("Got proverb %d, want the proverb of the day %d", got.ID, want.ID)
+	}
+}
+
+func TestRemoteFromEnvironment(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"greeting": "Howdy, Gopher!", "name": "Gopher"}`))
+	}))
+	defer ts.Close()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    filepath.Join(t.TempDir(), "missing.yaml"),
+		"HELLO_GOPHER_STATE_DIR": t.TempDir(),
+		"HELLO_GOPHER_SERVER":    ts.URL,
+	})
+
+	stdout, _, code := testsupport.RunCommand(t, "greet")
+	if code != ExitSuccess || stdout != "Howdy, Gopher!\n" || calls.Load() != 1 {
+		t.Errorf("Got %q (code %d) after %d requests, want the server's greeting", stdout, code, calls.Load())
+	}
+}
+
+func TestRemoteErrors(t *testing.T) {
+	url := startRemote(t)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "draining"}`))
+	}))
+	defer failing.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"local-only greet flag", []string{"--server", url, "greet", "--lang", "fr"}, ExitUsageError, "--lang can't be used with --server"},
+		{"local-only proverb flag", []string{"--server", url, "proverb", "--seed", "1"}, ExitUsageError, "--seed can't be used with --server"},
+		{"invalid URL", []string{"--server", "proverbs.example.com", "proverb"}, ExitUsageError, "http://"},
+		{"negative retries", []string{"--server", url, "--retries", "-1", "proverb"}, ExitUsageError, "--retries can't be negative"},
+		{"removed flag", []string{"--server", url, "--server-retries", "1", "proverb"}, ExitUsageError, "server-retries"},
+		{"unreachable", []string{"--server", "http://127.0.0.1:1", "--retries", "0", "proverb"}, ExitSystemError, "Request to http://127.0.0.1:1 failed"},
+		{"server error", []string{"--server", failing.URL, "--retries", "1", "greet"}, ExitSystemError, "503 Service Unavailable: draining"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := testsupport.RunCommand(t, tt.args...)
+			if code != tt.code || !strings.Contains(stderr, tt.want) {
+				t.Errorf("Got code %d with stderr %q, want code %d mentioning %q", code, stderr, tt.code, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"runtime"
+	"os"
+	"runtime/debug"
 
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
 	"github.com/spf13/cobra"
 )
 
@@ -14,10 +17,14 @@ var (
 	gitCommit = "unknown"
 )
 
-var rootCmd = &cobra.Command{
-	Use:   "hello-gopher",
-	Short: "A friendly CLI tool for Go enthusiasts",
-	Long: `Hello-Gopher is a friendly command-line tool that demonstrates Go development best practices.
+// NewRootCmd returns a fresh hello-gopher command tree using deps. Every call
+// builds new commands, so programs embedding the CLI and tests can run it
+// repeatedly without sharing flag state.
+func NewRootCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hello-gopher",
+		Short: "A friendly CLI tool for Go enthusiasts",
+		Long: `Hello-Gopher is a friendly command-line tool that demonstrates Go development best practices.
 It provides greeting functionality and displays random Go proverbs, serving as a portfolio piece
 that showcases idiomatic Go code, comprehensive testing, and professional distribution.
 
@@ -26,51 +33,139 @@ Examples:
   hello-gopher greet --name Alice       # Greet Alice
   hello-gopher greet -n Bob             # Greet Bob (short flag)
   hello-gopher proverb                  # Display a random Go proverb
-  hello-gopher --version                # Show version information`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		versionFlag, _ := cmd.Flags().GetBool("version")
-		if versionFlag {
-			cmd.Printf("hello-gopher version %s\n", version)
-			cmd.Printf("Build date: %s\n", buildDate)
-			cmd.Printf("Git commit: %s\n", gitCommit)
-			cmd.Printf("Go version: %s\n", runtime.Version())
-			cmd.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
-			return nil
-		}
+  hello-gopher --server https://proverbs.example.com proverb  # Ask a shared server
+  hello-gopher --version                # Show version information
+  hello-gopher --version --short        # Show only the version number`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		// Unknown commands reach RunE, which reports them as usage errors
+		Args:                       cobra.ArbitraryArgs,
+		SuggestionsMinimumDistance: 2,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// ExecuteContext replaces the context set below
+			cmd.SetContext(withDeps(cmd.Context(), deps))
+			if err := setupLogging(cmd); err != nil {
+				return err
+			}
+			if err := setupNetwork(cmd); err != nil {
+				return err
+			}
+			return checkDeprecatedFlags(cmd)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			recordTelemetry(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			versionFlag, _ := cmd.Flags().GetBool("version")
+			if versionFlag {
+				return printVersion(cmd)
+			}
 
-		// If unexpected arguments are provided, show error
-		if len(args) > 0 {
-			return NewUsageError(
-				fmt.Sprintf("Unknown command: %s", args[0]),
-				"Run 'hello-gopher --help' to see available commands",
-			)
-		}
+			if format, _ := versionFormat(cmd); format != versionText {
+				return NewUsageError(
+					"--json and --short can only be used with --version",
+					"Run 'hello-gopher --version --json' or 'hello-gopher version --json'",
+				)
+			}
 
-		// If no subcommand is provided, show help
-		cmd.Help()
-		return nil
-	},
-}
+			// If unexpected arguments are provided, show error
+			if len(args) > 0 {
+				suggestion := "Run 'hello-gopher --help' to see available commands"
+				if similar := cmd.SuggestionsFor(args[0]); len(similar) > 0 {
+					suggestion = fmt.Sprintf("Did you mean 'hello-gopher %s'?", similar[0])
+				}
+				return NewUsageError(fmt.Sprintf("Unknown command: %s", args[0]), suggestion)
+			}
 
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		HandleError(err)
+			// If no subcommand is provided, show help
+			cmd.Help()
+			return nil
+		},
 	}
-}
 
-func init() {
+	// Commands reach deps through their context, even before the tree is
+	// executed, as when aliases are resolved
+	cmd.SetContext(withDeps(context.Background(), deps))
+
 	// Add version flag to root command
-	rootCmd.Flags().BoolP("version", "v", false, "version for hello-gopher")
+	cmd.Flags().BoolP("version", "v", false, "version for hello-gopher")
+	addVersionFormatFlags(cmd)
+
+	// Global flags shared by every command
+	cmd.PersistentFlags().String("config", "", "Config file (default: <user config dir>/hello-gopher/config.yaml)")
+	cmd.PersistentFlags().String("output", "", "Output format: text, json or table (default: text)")
+	cmd.PersistentFlags().String("color", "", "Colorize output: auto, always or never (default: auto)")
+	cmd.PersistentFlags().String("tz", "", "Time zone of the proverb of the day and holidays, such as Europe/Lisbon (default: the system zone)")
+	cmd.PersistentFlags().Bool("accessible", false, "Screen-reader friendly output: plain sentences without art, borders or color")
+	cmd.PersistentFlags().Bool("verbose", false, "Log diagnostics to stderr")
+	cmd.PersistentFlags().Bool("debug", false, "Log detailed diagnostics to stderr (implies --verbose)")
+	cmd.PersistentFlags().String("log-format", logging.FormatText, "Diagnostic log format: text or json")
+	cmd.PersistentFlags().Bool("strict", false, "Fail on unknown config keys, deprecated flags and invalid UTF-8 input instead of warning")
+	addServerFlags(cmd)
+	addNetworkFlags(cmd)
 
 	// Set custom error handling for unknown flags
-	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
 		return NewUsageError(
 			err.Error(),
 			fmt.Sprintf("Run '%s --help' for usage information", cmd.CommandPath()),
 		)
 	})
-}
\ No newline at end of file
+
+	if deps.In != nil {
+		cmd.SetIn(deps.In)
+	}
+	if deps.Out != nil {
+		cmd.SetOut(deps.Out)
+	}
+	if deps.Err != nil {
+		cmd.SetErr(deps.Err)
+	}
+
+	cmd.AddCommand(
+		newGreetCmd(deps),
+		newProverbCmd(deps),
+		newGopherCmd(deps),
+		newCardCmd(),
+		newVersionCmd(),
+		newConfigCmd(),
+		newAliasCmd(),
+		newInitCmd(deps),
+		newDoctorCmd(),
+		newTuiCmd(),
+		newServeCmd(),
+		newGenCmd(),
+		newPostCmd(deps),
+		newSendCmd(deps),
+		newMotdCmd(deps),
+		newHolidaysCmd(deps),
+		newDataCmd(),
+		newCacheCmd(),
+		newStatsCmd(deps),
+		newQuizCmd(deps),
+		newTelemetryCmd(),
+		newHealthcheckCmd(),
+	)
+	return cmd
+}
+
+// Execute builds the command tree and runs it with the process arguments,
+// after expanding a command alias. This is called by main.main(). A panic is
+// turned into a crash report instead of a raw Go stack trace.
+func Execute() {
+	defer func() {
+		if r := recover(); r != nil {
+			handlePanic(r, debug.Stack(), os.Exit, os.Stderr, os.TempDir())
+		}
+	}()
+
+	root := NewRootCmd(Deps{})
+	args, err := resolveAliases(root, os.Args[1:])
+	if err == nil {
+		root.SetArgs(args)
+		err = root.Execute()
+	}
+	if err != nil {
+		HandleError(err)
+	}
+}
@@ -2,11 +2,10 @@ package cmd
 
 import (
 	"bytes"
-	"fmt"
 	"strings"
 	"testing"
 
-	"github.com/spf13/cobra"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
 )
 
 func TestRootCommandErrorHandling(t *testing.T) {
@@ -24,6 +23,13 @@ func TestRootCommandErrorHandling(t *testing.T) {
 			errorType:   "usage",
 			errorMsg:    "Unknown command",
 		},
+		{
+			name:        "misspelled command",
+			args:        []string{"gret"},
+			expectError: true,
+			errorType:   "usage",
+			errorMsg:    "Did you mean 'hello-gopher greet'",
+		},
 		{
 			name:        "unknown flag",
 			args:        []string{"--unknown-flag"},
@@ -61,72 +67,34 @@ func TestRootCommandErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a fresh root command for each test
-			testRootCmd := &cobra.Command{
-				Use:   "hello-gopher",
-				Short: "A friendly CLI tool for Go enthusiasts",
-				SilenceUsage:  true,
-				SilenceErrors: true,
-				RunE: rootCmd.RunE, // Use the same RunE function
-			}
-			
-			// Add the same flags as the real root command
-			testRootCmd.Flags().BoolP("version", "v", false, "version for hello-gopher")
-			
-			// Set the same error handlers
-			testRootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
-				return NewUsageError(
-					err.Error(),
-					fmt.Sprintf("Run '%s --help' for usage information", cmd.CommandPath()),
-				)
-			})
-			
-			// Capture output
-			var output bytes.Buffer
-			testRootCmd.SetOut(&output)
-			testRootCmd.SetErr(&output)
-			testRootCmd.SetArgs(tt.args)
+			_, stderr, code := testsupport.RunCommand(t, tt.args...)
 
-			// Execute command
-			err := testRootCmd.Execute()
-			
 			if tt.expectError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-					return
-				}
-				
-				// Check if it's the right type of error
-				if cliErr, ok := err.(*CLIError); ok {
-					switch tt.errorType {
-					case "usage":
-						if cliErr.Code != ExitUsageError {
-							t.Errorf("Expected usage error (code %d), got code %d", ExitUsageError, cliErr.Code)
-						}
+				switch tt.errorType {
+				case "usage":
+					if code != ExitUsageError {
+						t.Errorf("Expected usage error (code %d), got code %d", ExitUsageError, code)
 					}
-					
-					// Check error message contains expected text
-					if tt.errorMsg != "" && !strings.Contains(strings.ToLower(cliErr.Error()), strings.ToLower(tt.errorMsg)) {
-						t.Errorf("Expected error message to contain %q, got %q", tt.errorMsg, cliErr.Error())
-					}
-					
-					// Verify suggestion is provided
-					if cliErr.Suggestion == "" {
-						t.Error("Expected error to include a suggestion")
-					}
-				} else {
-					t.Errorf("Expected CLIError, got %T: %v", err, err)
 				}
-			} else {
-				if err != nil {
-					t.Errorf("Expected no error but got: %v", err)
+
+				// Check error message contains expected text
+				if tt.errorMsg != "" && !strings.Contains(strings.ToLower(stderr), strings.ToLower(tt.errorMsg)) {
+					t.Errorf("Expected error message to contain %q, got %q", tt.errorMsg, stderr)
+				}
+
+				// Verify suggestion is provided
+				if !strings.Contains(stderr, "Suggestion:") {
+					t.Errorf("Expected error to include a suggestion, got %q", stderr)
 				}
+			} else if code != ExitSuccess {
+				t.Errorf("Expected no error but got code %d: %s", code, stderr)
 			}
 		})
 	}
 }
 
 func TestRootCommandVersionOutput(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Use the actual root command for testing
 	var output bytes.Buffer
 	rootCmd.SetOut(&output)
@@ -159,6 +127,7 @@ func TestRootCommandVersionOutput(t *testing.T) {
 }
 
 func TestRootCommandHelpOutput(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Use the actual root command for testing
 	var output bytes.Buffer
 	rootCmd.SetOut(&output)
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
 	"github.com/spf13/cobra"
 )
 
@@ -12,31 +13,19 @@ func TestRootCommand(t *testing.T) {
 	tests := []struct {
 		name     string
 		args     []string
-		validate func(t *testing.T, output string, err error)
+		validate func(t *testing.T, output string)
 	}{
 		{
 			name: "root command help",
 			args: []string{"--help"},
-			validate: func(t *testing.T, output string, err error) {
-				if err != nil {
-					t.Errorf("Help command should not error: %v", err)
-				}
-				t.Logf("Help output: %q", output)
-				if !strings.Contains(output, "Hello-Gopher is a CLI tool") {
-					t.Error("Expected help text to contain description")
-				}
-				if !strings.Contains(output, "Usage:") {
-					t.Error("Expected help text to contain usage information")
-				}
+			validate: func(t *testing.T, output string) {
+				testsupport.AssertGolden(t, "root-help", output)
 			},
 		},
 		{
 			name: "root command version flag",
 			args: []string{"--version"},
-			validate: func(t *testing.T, output string, err error) {
-				if err != nil {
-					t.Errorf("Version flag should not error: %v", err)
-				}
+			validate: func(t *testing.T, output string) {
 				// Version output should contain some version info
 				if strings.TrimSpace(output) == "" {
 					t.Error("Expected non-empty version output")
@@ -46,10 +35,7 @@ func TestRootCommand(t *testing.T) {
 		{
 			name: "root command short version flag",
 			args: []string{"-v"},
-			validate: func(t *testing.T, output string, err error) {
-				if err != nil {
-					t.Errorf("Short version flag should not error: %v", err)
-				}
+			validate: func(t *testing.T, output string) {
 				// Version output should contain some version info
 				if strings.TrimSpace(output) == "" {
 					t.Error("Expected non-empty version output")
@@ -59,61 +45,29 @@ func TestRootCommand(t *testing.T) {
 		{
 			name: "root command no args",
 			args: []string{},
-			validate: func(t *testing.T, output string, err error) {
+			validate: func(t *testing.T, output string) {
 				// Root command with no args should show help
-				if err != nil {
-					t.Errorf("Root command with no args should not error: %v", err)
-				}
-				if !strings.Contains(output, "Hello-Gopher is a CLI tool") {
-					t.Error("Expected help text when no args provided")
-				}
+				testsupport.AssertGolden(t, "root-help", output)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a fresh root command for each test
-			testRootCmd := &cobra.Command{
-				Use:   "hello-gopher",
-				Short: "A friendly CLI tool for Go enthusiasts",
-				Long: `Hello-Gopher is a CLI tool that demonstrates Go development best practices.
-It provides greeting functionality and displays random Go proverbs to inspire
-and educate developers.
-
-This tool serves as a portfolio piece showcasing:
-- Clean Go code architecture with interfaces
-- Comprehensive testing with mocks and benchmarks  
-- Professional CLI design with Cobra framework
-- Cross-platform distribution with Goreleaser
-- CI/CD integration with GitHub Actions`,
-				Example: `  hello-gopher greet --name Alice        # Greet Alice
-  hello-gopher greet                     # Default greeting
-  hello-gopher proverb                   # Show a random Go proverb
-  hello-gopher version                   # Show version information`,
-				SilenceUsage:  true,
-				SilenceErrors: true,
-				RunE:          rootCmd.RunE,
+			stdout, stderr, code := testsupport.RunCommand(t, tt.args...)
+			if code != ExitSuccess {
+				t.Errorf("%v should not fail, got exit code %d: %s", tt.args, code, stderr)
 			}
-			testRootCmd.Flags().BoolP("version", "v", false, "version for hello-gopher")
-			
-			// Capture output
-			var buf bytes.Buffer
-			testRootCmd.SetOut(&buf)
-			testRootCmd.SetErr(&buf)
-			testRootCmd.SetArgs(tt.args)
 
-			err := testRootCmd.Execute()
-			output := buf.String()
-			
 			if tt.validate != nil {
-				tt.validate(t, output, err)
+				tt.validate(t, stdout)
 			}
 		})
 	}
 }
 
 func TestRootCommandSubcommands(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Test that all expected subcommands are registered
 	expectedCommands := []string{"greet", "proverb", "version"}
 	
@@ -133,6 +87,7 @@ func TestRootCommandSubcommands(t *testing.T) {
 }
 
 func TestRootCommandFlags(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Test that expected flags are available
 	versionFlag := rootCmd.Flags().Lookup("version")
 	if versionFlag == nil {
@@ -145,6 +100,7 @@ func TestRootCommandFlags(t *testing.T) {
 }
 
 func TestRootCommandConfiguration(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Test root command configuration instead of error handling (which is already tested)
 	if rootCmd.Use != "hello-gopher" {
 		t.Errorf("Expected rootCmd.Use to be 'hello-gopher', got %q", rootCmd.Use)
@@ -167,7 +123,7 @@ func TestRootCommandConfiguration(t *testing.T) {
 func BenchmarkRootCommand(b *testing.B) {
 	testRootCmd := &cobra.Command{
 		Use:  "hello-gopher",
-		RunE: rootCmd.RunE,
+		RunE: NewRootCmd(Deps{}).RunE,
 	}
 	testRootCmd.Flags().BoolP("version", "v", false, "version for hello-gopher")
 	
@@ -186,6 +142,7 @@ func BenchmarkRootCommand(b *testing.B) {
 
 // TestExecute tests the Execute function
 func TestExecute(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// This is a basic test to ensure Execute doesn't panic
 	// We can't easily test the actual execution since it may call os.Exit
 	
@@ -201,4 +158,28 @@ func TestExecute(t *testing.T) {
 	if rootCmd.Use != "hello-gopher" {
 		t.Errorf("Expected rootCmd.Use to be 'hello-gopher', got %q", rootCmd.Use)
 	}
-}
\ No newline at end of file
+}
+func TestNewRootCmdIsolation(t *testing.T) {
+	run := func(args ...string) string {
+		var out bytes.Buffer
+		root := NewRootCmd(Deps{Out: &out, Err: &out})
+		root.SetArgs(args)
+		if err := root.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if got := run("--version", "--short"); strings.TrimSpace(got) != version {
+		t.Errorf("--version --short output = %q", got)
+	}
+
+	// A new tree must not remember --short from the previous run
+	if got := run("--version"); !strings.Contains(got, "Build date:") {
+		t.Errorf("--version output = %q, flags leaked between command trees", got)
+	}
+
+	if a, b := NewRootCmd(Deps{}), NewRootCmd(Deps{}); a == b || a.Commands()[0] == b.Commands()[0] {
+		t.Error("NewRootCmd should build a new command tree on every call")
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/email"
+	"github.com/spf13/cobra"
+)
+
+// envSMTPPassword supplies the SMTP password, which is never read from flags
+// or the config file
+const envSMTPPassword = config.EnvPrefix + "SMTP_PASSWORD"
+
+// newSendCmd creates the send command, greeting with the greeter in deps
+func newSendCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Email the daily proverb or a greeting",
+		Long: `Send command emails the proverb of the day, or a greeting with --greet, as a
+message with an HTML and a plain-text body.
+
+The SMTP server, username and sender are read from the flags or the
+smtp_server, smtp_username and smtp_from settings. The password is only read
+from $HELLO_GOPHER_SMTP_PASSWORD, so it never shows up in your shell history
+or config file. Connections to port 465 use TLS; other ports are upgraded with
+STARTTLS when the server supports it.
+
+Use --dry-run to print the message instead of sending it.`,
+		Example: `  hello-gopher send --to team@example.com --smtp smtp.example.com:587
+  hello-gopher send --to alice@example.com --greet --name Alice
+  hello-gopher send --to team@example.com --from gopher@example.com --dry-run`,
+		Args: exactArgs(0, "send doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to, _ := cmd.Flags().GetStringSlice("to")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if len(to) == 0 {
+				return NewUsageError("No recipient given", "Pass --to <address>, repeated or comma-separated for several recipients")
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			server := email.Server{
+				Addr:     resolveString(cmd, cfg, "smtp", config.KeySMTPServer),
+				Username: resolveString(cmd, cfg, "username", config.KeySMTPUsername),
+			}
+			server.Password, _ = lookupEnv(cmd, envSMTPPassword)
+			if !dryRun {
+				if server.Addr == "" {
+					return NewUsageError(
+						"No SMTP server given",
+						"Pass --smtp <host:port>, run 'hello-gopher config set smtp_server <host:port>', or preview the message with --dry-run",
+					)
+				}
+				if _, _, err := net.SplitHostPort(server.Addr); err != nil {
+					return NewUsageError(fmt.Sprintf("Invalid SMTP server: %s", server.Addr), "Use host:port, such as smtp.example.com:587")
+				}
+			}
+
+			from := resolveString(cmd, cfg, "from", config.KeySMTPFrom)
+			if from == "" && strings.Contains(server.Username, "@") {
+				from = server.Username
+			}
+			if from == "" {
+				return NewUsageError(
+					"No sender given",
+					"Pass --from <address> or run 'hello-gopher config set smtp_from <address>'",
+				)
+			}
+
+			content, err := buildPostMessage(cmd, cfg, deps)
+			if err != nil {
+				return err
+			}
+			msg := email.Message{
+				From:    from,
+				To:      to,
+				Subject: sendSubject(cmd),
+				Date:    deps.now(),
+				Text:    content.Text,
+				Footer:  content.Footer,
+				Quote:   content.Quote,
+			}
+
+			if dryRun {
+				data, err := msg.Bytes()
+				if err != nil {
+					return NewUsageError(err.Error(), "Check the --from and --to addresses")
+				}
+				fmt.Fprint(cmd.OutOrStdout(), strings.ReplaceAll(string(data), "\r\n", "\n"))
+				return nil
+			}
+
+			commandLogger(cmd).Debug("sending email", "server", server.Addr, "username", server.Username, "recipients", len(to))
+			if err := email.Send(cmd.Context(), server, msg); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to send email through %s", server.Addr),
+					err,
+					"Check the addresses, the SMTP server and $HELLO_GOPHER_SMTP_PASSWORD",
+				)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Sent to %s\n", strings.Join(to, ", "))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice("to", nil, "Recipient address, repeat or comma-separate for several")
+	cmd.Flags().String("smtp", "", "SMTP server as host:port (default: the smtp_server setting)")
+	cmd.Flags().String("username", "", "SMTP username (default: the smtp_username setting)")
+	cmd.Flags().String("from", "", "Sender address (default: the smtp_from setting or the username)")
+	cmd.Flags().String("subject", "", "Subject line (default: depends on the message)")
+	cmd.Flags().Bool("greet", false, "Send a greeting instead of the proverb of the day")
+	cmd.Flags().StringP("name", "n", "", "Name to greet with --greet (default: Gopher)")
+	cmd.Flags().Bool("dry-run", false, "Print the message instead of sending it")
+	return cmd
+}
+
+// sendSubject returns the --subject of cmd, or a subject for the message
+// being sent
+func sendSubject(cmd *cobra.Command) string {
+	if subject, _ := cmd.Flags().GetString("subject"); subject != "" {
+		return subject
+	}
+	if greet, _ := cmd.Flags().GetBool("greet"); greet {
+		return "A greeting from hello-gopher"
+	}
+	return "Go proverb of the day"
+}
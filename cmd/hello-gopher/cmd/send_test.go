@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestSendDryRun(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "send", "--dry-run", "--to", "alice@example.com,bob@example.com",
+		"--from", "gopher@example.com", "--greet", "-n", "Team")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	for _, want := range []string{
+		"From: <gopher@example.com>\n",
+		"To: <alice@example.com>, <bob@example.com>\n",
+		"Subject: A greeting from hello-gopher\n",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Type: text/html; charset=utf-8",
+		"Hello, Team!",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("message misses %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestSendUsesConfig(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	settings := "smtp_server: smtp.example.com:587\nsmtp_username: gopher@example.com\n"
+	if err := os.WriteFile(config, []byte(settings), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := testsupport.RunCommand(t, "send", "--config", config, "--to", "alice@example.com", "--subject", "Daily Go", "--dry-run")
+	if code != ExitSuccess {
+		t.Fatalf("Unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "From: <gopher@example.com>") || !strings.Contains(stdout, "Subject: Daily Go") {
+		t.Errorf("message doesn't use the configured sender and --subject:\n%s", stdout)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	// A closed port makes the send fail without reaching the network
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := l.Addr().String()
+	l.Close()
+
+	for _, tt := range []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"--from", "gopher@example.com", "--dry-run"}, ExitUsageError, "No recipient given"},
+		{[]string{"--to", "alice@example.com", "--dry-run"}, ExitUsageError, "No sender given"},
+		{[]string{"--to", "alice", "--from", "gopher@example.com", "--dry-run"}, ExitUsageError, "invalid recipient"},
+		{[]string{"--to", "alice@example.com", "--from", "gopher@example.com"}, ExitUsageError, "No SMTP server given"},
+		{[]string{"--to", "alice@example.com", "--from", "gopher@example.com", "--smtp", "localhost"}, ExitUsageError, "Invalid SMTP server"},
+		{[]string{"--to", "alice@example.com", "--from", "gopher@example.com", "--smtp", closed}, ExitSystemError, "Failed to send email"},
+	} {
+		_, stderr, code := testsupport.RunCommand(t, append([]string{"send"}, tt.args...)...)
+		if code != tt.code || !strings.Contains(stderr, tt.want) {
+			t.Errorf("%v: exit code %d, stderr %q; want %d and %q", tt.args, code, stderr, tt.code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/tracing"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/server"
+	"github.com/spf13/cobra"
+)
+
+// defaultAddr is the address the serve command listens on by default
+const defaultAddr = ":8080"
+
+// traceFlushTimeout bounds how long exporting the last spans may delay exit
+const traceFlushTimeout = 5 * time.Second
+
+// envAuthToken supplies an API token without exposing it in the process list
+const envAuthToken = config.EnvPrefix + "AUTH_TOKEN"
+
+// newServeCmd creates the serve command
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve greetings and proverbs over HTTP and SSH",
+		Long: `Serve command starts an HTTP server exposing a JSON API:
+
+  GET /               The proverb of the day as a web page
+  GET /greet?name=X   Greet X (default: Gopher)
+  POST /greet         Greet {"name": "X", "lang": "de", "style": "formal"}
+  POST /greet:batch   Greet {"names": [...]}, at most --max-batch-size names,
+                      ?stream=true for newline-delimited JSON
+  GET /proverb        A random proverb, ?daily=true for the proverb of the day
+  GET /proverbs       Proverbs matching ?tag and ?q, by ?page and ?per_page
+  GET /stream         A random proverb every ?interval=10s (Server-Sent Events)
+  GET /badge          A random proverb as a shields.io endpoint badge
+  GET /widget.js      A script embedding a rotating proverb in any web page
+  GET /livez          Liveness check; GET /healthz is the same
+  GET /readyz         Readiness check, 503 while the server starts, can't load
+                      its proverbs or drains
+
+Greetings use the configured language and style. Every request is logged to
+stderr as a structured record in the --log-format, text or json. With
+--otel-endpoint requests are traced with OpenTelemetry and exported to an
+OTLP/HTTP collector, including spans for proverb selection and greeting
+rendering; callers' W3C traceparent headers are honored.
+
+GET / renders an HTML page with the proverb of the day that follows the
+browser's light or dark theme. To restyle it, point --template-dir at a
+directory with an index.html Go html/template; other *.html files there can
+be included with {{template "name.html" .}}. The page receives .Proverb
+(with .Text, .ID and .Tags) and .Date.
+
+For rolling deployments, /livez only fails when the process can't answer at
+all, while /readyz also fails for --readiness-delay after the start, while the
+proverbs can't be loaded and during shutdown. Point Kubernetes liveness probes
+at /livez and readiness probes at /readyz.
+
+SIGINT or SIGTERM shut the server down gracefully: /readyz starts answering
+503 at once, requests are still served for --drain-delay so load balancers
+can take the server out of rotation, and then in-flight requests get
+--drain-timeout to finish. A second signal stops the server immediately.
+
+With --rate-limit every client IP gets a token bucket of the given size that
+refills at the given rate; excess requests receive 429 Too Many Requests with
+a Retry-After header. Health checks are never limited.
+
+JSON responses are plain payloads with snake_case fields unless
+--api-style enveloped wraps them in {"data", "error", "meta"}, with errors
+under "error" and the HTTP status under "meta", or --field-naming camelCase
+spells fields like perPage. GET /badge keeps the shields.io format.
+
+Administrative endpoints such as POST /admin/reload are enabled only when API
+tokens are configured with --auth-token, --auth-tokens-file or
+$HELLO_GOPHER_AUTH_TOKEN, and require an "Authorization: Bearer <token>"
+header. Read endpoints stay public. POST /admin/reload picks up proverbs
+imported with 'hello-gopher proverb import' since the server started.
+
+With --ssh the proverb explorer of the tui command is also served over SSH,
+so "ssh -p 2222 host" opens it in the client's terminal. The host key is
+created in the data directory on first start unless --ssh-host-key names one;
+its fingerprint is logged so users can verify it. Anyone may connect unless
+--ssh-authorized-keys restricts access to the keys in an authorized_keys file.
+
+With --debug-addr a second listener serves profiles under /debug/pprof/,
+runtime statistics under /debug/vars and the effective configuration under
+/debug/config. Bind it to a loopback address such as 127.0.0.1:6060.`,
+		Example: `  hello-gopher serve                    # Listen on :8080
+  hello-gopher serve --addr 127.0.0.1:9000
+  hello-gopher serve --rate-limit 10/s  # At most 10 requests per second per IP
+  hello-gopher serve --auth-tokens-file /etc/hello-gopher/tokens
+  hello-gopher serve --template-dir ./web
+  hello-gopher serve --api-style enveloped --field-naming camelCase
+  hello-gopher serve --drain-delay 5s --drain-timeout 30s
+  hello-gopher serve --log-format json --otel-endpoint http://localhost:4318
+  hello-gopher serve --debug-addr 127.0.0.1:6060
+  hello-gopher serve --ssh :2222        # Also run the explorer for 'ssh -p 2222 host'
+  curl 'localhost:8080/greet?name=Alice'
+  curl -N 'localhost:8080/stream?interval=5s'`,
+		Args: exactArgs(0, "serve doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, _ := cmd.Flags().GetString("addr")
+			if addr == "" {
+				addr = defaultAddr
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts, err := greetingOptions(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			exclude, err := exclusionOptions(cfg)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, exclude...)
+			opts = append(opts, greeting.WithExtraProverbsFunc(func() ([]greeting.Proverb, error) {
+				return userProverbs(cmd)
+			}))
+
+			serverOpts, err := serverOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			svc := newGreetingService(cmd, opts...)
+			logger := log.New(cmd.ErrOrStderr(), "", log.LstdFlags)
+
+			if endpoint, _ := cmd.Flags().GetString("otel-endpoint"); endpoint != "" {
+				tp, err := tracing.NewProvider(cmd.Context(), endpoint, version)
+				if err != nil {
+					return NewUsageError(err.Error(), "Pass the OTLP/HTTP URL of your collector, such as --otel-endpoint http://localhost:4318")
+				}
+				defer func() {
+					// Flush the spans of the last requests
+					ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+					defer cancel()
+					if err := tp.Shutdown(ctx); err != nil {
+						logger.Printf("Failed to export traces: %v", err)
+					}
+				}()
+				serverOpts = append(serverOpts, server.WithTracerProvider(tp))
+				logger.Printf("Exporting traces to %s", endpoint)
+			}
+
+			sshSrv, sshLn, err := listenSSH(cmd, cfg, svc, logger)
+			if err != nil {
+				return err
+			}
+
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				if sshLn != nil {
+					sshLn.Close()
+				}
+				return NewSystemError(
+					fmt.Sprintf("Failed to listen on %s", addr),
+					err,
+					"Choose another address with --addr",
+				)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				// Restore the default signal behavior, so a second signal
+				// ends a drain that takes too long
+				<-ctx.Done()
+				stop()
+			}()
+
+			stopDebug, err := startDebugServer(ctx, cmd, logger)
+			if err != nil {
+				ln.Close()
+				if sshLn != nil {
+					sshLn.Close()
+				}
+				return err
+			}
+			defer stopDebug()
+
+			sshErr := make(chan error, 1)
+			if sshSrv != nil {
+				logger.Printf("Serving the proverb explorer on ssh://%s", sshLn.Addr())
+				go func() {
+					err := sshSrv.Serve(ctx, sshLn)
+					if err != nil {
+						stop()
+					}
+					sshErr <- err
+				}()
+			} else {
+				sshErr <- nil
+			}
+
+			logger.Printf("Listening on http://%s", ln.Addr())
+			err = server.New(svc, nil, serverOpts...).Serve(ctx, ln)
+			stop()
+			if err != nil {
+				return NewSystemError("HTTP server failed", err, "")
+			}
+			if err := <-sshErr; err != nil {
+				return NewSystemError("SSH server failed", err, "")
+			}
+			logger.Printf("Server stopped")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("addr", defaultAddr, "Address to listen on")
+	cmd.Flags().String("rate-limit", "", "Limit requests per client IP, e.g. 10/s or 100/m (default: unlimited)")
+	cmd.Flags().StringArray("auth-token", nil, "API token accepted by the admin endpoints (repeatable)")
+	cmd.Flags().String("auth-tokens-file", "", "File with one API token per line")
+	cmd.Flags().String("template-dir", "", "Directory with an index.html template replacing the built-in page at GET /")
+	cmd.Flags().String("otel-endpoint", "", "Export OpenTelemetry traces to this OTLP/HTTP collector URL, e.g. http://localhost:4318")
+	cmd.Flags().Duration("drain-delay", 0, "Keep serving for this long after a shutdown signal while /readyz fails")
+	cmd.Flags().Duration("drain-timeout", server.ShutdownTimeout, "How long in-flight requests may take to finish on shutdown")
+	cmd.Flags().Duration("readiness-delay", 0, "Fail /readyz for this long after the start, so new instances warm up before getting traffic")
+	cmd.Flags().Int("max-batch-size", server.DefaultMaxBatchSize, "Most names accepted by POST /greet:batch")
+	cmd.Flags().String("api-style", string(server.StylePlain), "Shape of JSON responses: plain or enveloped in {data, error, meta}")
+	cmd.Flags().String("field-naming", string(server.NamingSnakeCase), "Spelling of JSON fields: snake_case or camelCase")
+	addSSHFlags(cmd)
+	addDebugAddrFlag(cmd)
+	return cmd
+}
+
+// serverOptions translates the serve flags into server options
+func serverOptions(cmd *cobra.Command) ([]server.Option, error) {
+	format, _ := cmd.Flags().GetString("log-format")
+	accessLog, err := logging.New(cmd.ErrOrStderr(), format, slog.LevelInfo)
+	if err != nil {
+		return nil, NewUsageError(err.Error(), fmt.Sprintf("Use --log-format %s", strings.Join(logging.Formats(), " or --log-format ")))
+	}
+	opts := []server.Option{server.WithAccessLog(accessLog)}
+
+	if limit, _ := cmd.Flags().GetString("rate-limit"); limit != "" {
+		rate, err := server.ParseRate(limit)
+		if err != nil {
+			return nil, NewUsageError(err.Error(), "Use a rate such as --rate-limit 10/s or --rate-limit 100/m")
+		}
+		opts = append(opts, server.WithRateLimit(rate))
+	}
+
+	delay, _ := cmd.Flags().GetDuration("drain-delay")
+	timeout, _ := cmd.Flags().GetDuration("drain-timeout")
+	if delay < 0 || timeout <= 0 {
+		return nil, NewUsageError("Drain durations must be positive", "Use durations such as --drain-delay 5s --drain-timeout 30s")
+	}
+	opts = append(opts, server.WithDrainDelay(delay), server.WithDrainTimeout(timeout))
+
+	readiness, _ := cmd.Flags().GetDuration("readiness-delay")
+	if readiness < 0 {
+		return nil, NewUsageError("--readiness-delay can't be negative", "Use a duration such as --readiness-delay 5s")
+	}
+	opts = append(opts, server.WithReadinessDelay(readiness))
+
+	batch, _ := cmd.Flags().GetInt("max-batch-size")
+	if batch <= 0 {
+		return nil, NewUsageError("--max-batch-size must be positive", fmt.Sprintf("Use a size such as --max-batch-size %d", server.DefaultMaxBatchSize))
+	}
+	opts = append(opts, server.WithMaxBatchSize(batch))
+
+	styleName, _ := cmd.Flags().GetString("api-style")
+	style, err := server.ParseAPIStyle(styleName)
+	if err != nil {
+		return nil, NewUsageError(err.Error(), "Use --api-style plain or --api-style enveloped")
+	}
+	namingName, _ := cmd.Flags().GetString("field-naming")
+	naming, err := server.ParseFieldNaming(namingName)
+	if err != nil {
+		return nil, NewUsageError(err.Error(), "Use --field-naming snake_case or --field-naming camelCase")
+	}
+	opts = append(opts, server.WithAPIStyle(style, naming))
+
+	if dir, _ := cmd.Flags().GetString("template-dir"); dir != "" {
+		tmpl, err := server.ParsePageTemplate(os.DirFS(dir))
+		if err != nil {
+			return nil, NewDataError(
+				fmt.Sprintf("Failed to load page template: %v", err),
+				err,
+				"Check that --template-dir contains a valid index.html",
+			)
+		}
+		opts = append(opts, server.WithPageTemplate(tmpl))
+	}
+
+	tokens, _ := cmd.Flags().GetStringArray("auth-token")
+	if token, ok := lookupEnv(cmd, envAuthToken); ok && token != "" {
+		tokens = append(tokens, token)
+	}
+	if path, _ := cmd.Flags().GetString("auth-tokens-file"); path != "" {
+		fileTokens, err := server.LoadTokens(path)
+		if err != nil {
+			return nil, NewDataError(
+				fmt.Sprintf("Failed to read tokens file: %v", err),
+				err,
+				"Check the path passed to --auth-tokens-file",
+			)
+		}
+		tokens = append(tokens, fileTokens...)
+	}
+	if len(tokens) > 0 {
+		opts = append(opts, server.WithAuthTokens(tokens...))
+	}
+	return opts, nil
+}
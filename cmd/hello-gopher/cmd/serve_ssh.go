@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/sshserver"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/tui"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+)
+
+// addSSHFlags adds the flags of the SSH server to the serve command
+func addSSHFlags(cmd *cobra.Command) {
+	cmd.Flags().String("ssh", "", "Also serve the proverb explorer over SSH on this address, e.g. :2222")
+	cmd.Flags().String("ssh-host-key", "", "Private host key file, created if missing (default: ssh_host_ed25519_key in the data directory)")
+	cmd.Flags().String("ssh-authorized-keys", "", "authorized_keys file of the clients allowed to connect (default: anyone)")
+}
+
+// listenSSH prepares the SSH server requested with --ssh and listens on its
+// address. It returns a nil server if --ssh is not set.
+func listenSSH(cmd *cobra.Command, cfg *config.Config, svc *greeting.Service, logger *log.Logger) (*sshserver.Server, net.Listener, error) {
+	addr, _ := cmd.Flags().GetString("ssh")
+	if addr == "" {
+		return nil, nil, nil
+	}
+
+	hostKey, err := sshHostKey(cmd, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var opts []sshserver.Option
+	if path, _ := cmd.Flags().GetString("ssh-authorized-keys"); path != "" {
+		keys, err := sshserver.LoadAuthorizedKeys(path)
+		if err != nil {
+			return nil, nil, NewDataError(
+				fmt.Sprintf("Failed to read authorized keys: %v", err),
+				err,
+				"Check the path passed to --ssh-authorized-keys",
+			)
+		}
+		opts = append(opts, sshserver.WithAuthorizedKeys(keys...))
+	}
+
+	mode, err := color.ParseMode(resolveString(cmd, cfg, "color", config.KeyColor))
+	if err != nil {
+		return nil, nil, NewUsageError(err.Error(), "Use --color auto, --color always or --color never")
+	}
+	proverbs, err := svc.Proverbs()
+	if err != nil {
+		return nil, nil, NewDataError("Failed to load proverbs", err, "")
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, NewSystemError(
+			fmt.Sprintf("Failed to listen on %s", addr),
+			err,
+			"Choose another address with --ssh",
+		)
+	}
+	return sshserver.New(hostKey, explorerSession(proverbs, mode), logger, opts...), ln, nil
+}
+
+// sshHostKey loads the host key named by --ssh-host-key, or the one in the
+// data directory, creating it on first use
+func sshHostKey(cmd *cobra.Command, logger *log.Logger) (ssh.Signer, error) {
+	path, _ := cmd.Flags().GetString("ssh-host-key")
+	if path == "" {
+		dir, err := appDir(cmd, dirData)
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, sshserver.HostKeyFileName)
+	}
+
+	hostKey, created, err := sshserver.LoadOrCreateHostKey(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to load the SSH host key: %v", err),
+			err,
+			"Pass an OpenSSH or PEM private key with --ssh-host-key",
+		)
+	}
+	if created {
+		logger.Printf("Created SSH host key %s", path)
+	}
+	logger.Printf("SSH host key fingerprint %s", ssh.FingerprintSHA256(hostKey.PublicKey()))
+	return hostKey, nil
+}
+
+// explorerSession runs the proverb explorer for every SSH session. Favorites
+// are not offered because they belong to the server's user.
+func explorerSession(proverbs []greeting.Proverb, mode color.Mode) sshserver.Handler {
+	return func(ctx context.Context, s *sshserver.Session) error {
+		colored := mode == color.Always || (mode == color.Auto && s.Term != "" && s.Term != "dumb")
+		program := tea.NewProgram(
+			tui.New(proverbs, nil, color.NewStyler(colored)),
+			tea.WithAltScreen(),
+			tea.WithInput(s),
+			tea.WithOutput(s),
+			tea.WithContext(ctx),
+			tea.WithoutSignalHandler(),
+		)
+
+		go func() {
+			for {
+				select {
+				case size := <-s.Resize:
+					program.Send(tea.WindowSizeMsg{Width: size.Width, Height: size.Height})
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		// Ctrl-C ends the session like q does
+		if _, err := program.Run(); err != nil && !errors.Is(err, tea.ErrInterrupted) && !(errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil) {
+			return err
+		}
+		return nil
+	}
+}
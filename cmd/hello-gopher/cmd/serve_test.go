@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/sshserver"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestServeStopsWhenContextCanceled(t *testing.T) {
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	root := NewRootCmd(testDeps(t, Deps{Out: &buf, Err: &buf}))
+	root.SetArgs([]string{"serve", "--addr", "127.0.0.1:0"})
+
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "Listening on http://127.0.0.1:") || !strings.Contains(out, "Server stopped") {
+		t.Errorf("Unexpected log output:\n%s", out)
+	}
+}
+
+func TestServeAddressInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", ln.Addr().String())
+	if code != ExitSystemError {
+		t.Errorf("Expected system error for an address in use, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeInvalidRateLimit(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--rate-limit", "lots")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error for an invalid rate, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeMissingTokensFile(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--auth-tokens-file", filepath.Join(t.TempDir(), "nope"))
+	if code != ExitDataError {
+		t.Errorf("Expected data error for a missing tokens file, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeSSHCreatesHostKey(t *testing.T) {
+	_, data, _ := appDirs(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	root := NewRootCmd(testDeps(t, Deps{Out: &buf, Err: &buf}))
+	root.SetArgs([]string{"serve", "--addr", "127.0.0.1:0", "--ssh", "127.0.0.1:0"})
+
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"Created SSH host key", "SSH host key fingerprint SHA256:", "ssh://127.0.0.1:"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected log output to contain %q:\n%s", want, buf.String())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(data, sshserver.HostKeyFileName)); err != nil {
+		t.Errorf("Expected a host key in the data directory: %v", err)
+	}
+}
+
+func TestServeSSHMissingAuthorizedKeys(t *testing.T) {
+	appDirs(t)
+
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--ssh", "127.0.0.1:0",
+		"--ssh-authorized-keys", filepath.Join(t.TempDir(), "nope"))
+	if code != ExitDataError {
+		t.Errorf("Expected data error for a missing authorized keys file, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestExplorerSession(t *testing.T) {
+	hostKey, _, err := sshserver.LoadOrCreateHostKey(filepath.Join(t.TempDir(), sshserver.HostKeyFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proverbs, err := greeting.Default().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sshserver.New(hostKey, explorerSession(proverbs, color.Never), nil).Serve(ctx, ln)
+
+	client, err := ssh.Dial("tcp", ln.Addr().String(), &ssh.ClientConfig{
+		User:            "gopher",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out syncBuffer
+	session.Stdout = &out
+	stdin, _ := session.StdinPipe()
+	if err := session.RequestPty("xterm", 30, 100, ssh.TerminalModes{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Shell(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Quit once the explorer has drawn the list
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), proverbs[0].Text) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Explorer never showed the first proverb:\n%q", out.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stdin.Write([]byte("q"))
+
+	if err := session.Wait(); err != nil {
+		t.Errorf("Expected the session to end cleanly, got %v", err)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for a writer and a reader in different
+// goroutines
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestServeInvalidDrainTimeout(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--drain-timeout", "0s")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error for a zero drain timeout, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeInvalidReadinessDelay(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--readiness-delay", "-1s")
+	if code != ExitUsageError || !strings.Contains(stderr, "--readiness-delay") {
+		t.Errorf("Expected usage error for a negative readiness delay, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeInvalidMaxBatchSize(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--max-batch-size", "0")
+	if code != ExitUsageError || !strings.Contains(stderr, "--max-batch-size") {
+		t.Errorf("Expected usage error for a zero batch size, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeInvalidAPIStyle(t *testing.T) {
+	tests := [][]string{
+		{"--api-style", "jsonapi"},
+		{"--field-naming", "kebab-case"},
+	}
+	for _, args := range tests {
+		_, stderr, code := testsupport.RunCommand(t, append([]string{"serve", "--addr", "127.0.0.1:0"}, args...)...)
+		if code != ExitUsageError || !strings.Contains(stderr, args[0]) {
+			t.Errorf("Expected usage error for %v, got code %d (stderr %q)", args, code, stderr)
+		}
+	}
+}
+
+func TestServeInvalidOtelEndpoint(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--otel-endpoint", "localhost:4318")
+	if code != ExitUsageError || !strings.Contains(stderr, "http://localhost:4318") {
+		t.Errorf("Expected usage error for an endpoint without scheme, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestServeInvalidTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+	_, stderr, code := testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--template-dir", dir)
+	if code != ExitDataError || !strings.Contains(stderr, "index.html") {
+		t.Errorf("Expected data error for a directory without index.html, got code %d (stderr %q)", code, stderr)
+	}
+
+	os.WriteFile(filepath.Join(dir, "index.html"), []byte("{{.Proverb"), 0o644)
+	_, stderr, code = testsupport.RunCommand(t, "serve", "--addr", "127.0.0.1:0", "--template-dir", dir)
+	if code != ExitDataError {
+		t.Errorf("Expected data error for an invalid template, got code %d (stderr %q)", code, stderr)
+	}
+}
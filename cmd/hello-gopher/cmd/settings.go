@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/locale"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by --output
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputTable = "table"
+)
+
+// configPath returns the config file selected with --config or
+// $HELLO_GOPHER_CONFIG, or the default location
+func configPath(cmd *cobra.Command) (string, error) {
+	if flag := cmd.Flags().Lookup("config"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String(), nil
+	}
+	if path, ok := lookupEnv(cmd, config.EnvConfig); ok && path != "" {
+		return path, nil
+	}
+	return config.DefaultPath()
+}
+
+// dataDir returns the data directory selected with $HELLO_GOPHER_DATA_DIR,
+// or the default location
+func dataDir(cmd *cobra.Command) (string, error) {
+	return dirFromEnv(cmd, config.EnvDataDir, config.DataDir)
+}
+
+// stateDir returns the state directory selected with
+// $HELLO_GOPHER_STATE_DIR, or the default location
+func stateDir(cmd *cobra.Command) (string, error) {
+	return dirFromEnv(cmd, config.EnvStateDir, config.StateDir)
+}
+
+// cacheDir returns the cache directory selected with
+// $HELLO_GOPHER_CACHE_DIR, or the default location
+func cacheDir(cmd *cobra.Command) (string, error) {
+	return dirFromEnv(cmd, config.EnvCacheDir, config.CacheDir)
+}
+
+// dirFromEnv returns the directory named by the environment variable env of
+// cmd, or the one returned by fallback when it is unset
+func dirFromEnv(cmd *cobra.Command, env string, fallback func() (string, error)) (string, error) {
+	if dir, ok := lookupEnv(cmd, env); ok && dir != "" {
+		return dir, nil
+	}
+	return fallback()
+}
+
+// loadConfig reads the configuration file used by cmd
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	path, err := configPath(cmd)
+	if err != nil {
+		return nil, NewSystemError(
+			"Failed to locate the configuration file",
+			err,
+			"Pass an explicit file with --config",
+		)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read configuration: %v", err),
+			err,
+			"Fix the file with 'hello-gopher config edit' or remove it to restore defaults",
+		)
+	}
+
+	if err := cfg.ApplyEnv(commandDeps(cmd).lookupEnv); err != nil {
+		return nil, NewUsageError(
+			fmt.Sprintf("Invalid environment variable: %v", err),
+			"Fix or unset the variable; run 'hello-gopher config --help' for allowed values",
+		)
+	}
+
+	logConfig(cmd, path, cfg)
+	if err := checkUnknownKeys(cmd, cfg, path); err != nil {
+		return nil, err
+	}
+	if err := verifyEmbeddedProverbs(cmd, cfg); err != nil {
+		return nil, err
+	}
+	applyAccessible(cmd, cfg)
+	return cfg, nil
+}
+
+// peekConfig loads the configuration of cmd like loadConfig, but without
+// logging it or warning about it, for work that must neither fail nor
+// clutter the output of the command, such as recording telemetry
+func peekConfig(cmd *cobra.Command) (*config.Config, error) {
+	path, err := configPath(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyEnv(commandDeps(cmd).lookupEnv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// logConfig records the config file used by cmd and where every setting
+// comes from
+func logConfig(cmd *cobra.Command, path string, cfg *config.Config) {
+	logger := commandLogger(cmd)
+	logger.Info("loaded configuration", "path", path)
+	for _, spec := range config.Specs() {
+		logger.Debug("resolved setting", "key", spec.Key, "value", cfg.Value(spec.Key), "source", cfg.Source(spec.Key))
+	}
+}
+
+// resolveString returns the value of flag if it was set on the command line,
+// falling back to the environment, the config file and finally the key's default
+func resolveString(cmd *cobra.Command, cfg *config.Config, flag, key string) string {
+	if cmd.Flags().Changed(flag) {
+		value, _ := cmd.Flags().GetString(flag)
+		return value
+	}
+	return cfg.Value(key)
+}
+
+// resolveBool returns the boolean flag of cmd if it was set, or else the
+// configured value of key
+func resolveBool(cmd *cobra.Command, cfg *config.Config, flag, key string) bool {
+	if cmd.Flags().Changed(flag) {
+		value, _ := cmd.Flags().GetBool(flag)
+		return value
+	}
+	// The config package validates boolean keys
+	value, _ := strconv.ParseBool(cfg.Value(key))
+	return value
+}
+
+// resolveInt returns the integer flag of cmd if it was set, or else the
+// configured value of key
+func resolveInt(cmd *cobra.Command, cfg *config.Config, flag, key string) int {
+	if cmd.Flags().Changed(flag) {
+		value, _ := cmd.Flags().GetInt(flag)
+		return value
+	}
+	// The config package validates integer keys
+	value, _ := strconv.Atoi(cfg.Value(key))
+	return value
+}
+
+// resolveOutput returns the validated output format for cmd. Commands
+// without tables print text instead of a table asked for by the output
+// setting, and reject --output table.
+func resolveOutput(cmd *cobra.Command, cfg *config.Config) (string, error) {
+	output := resolveString(cmd, cfg, "output", config.KeyOutput)
+	switch output {
+	case outputText, outputJSON:
+		return output, nil
+	case outputTable:
+		if supportsTable(cmd) {
+			return output, nil
+		}
+		if !cmd.Flags().Changed("output") {
+			return outputText, nil
+		}
+		return "", NewUsageError(
+			fmt.Sprintf("%s can't print a table", cmd.CommandPath()),
+			"Use --output text or --output json; tables are printed by proverb list, stats and holidays list",
+		)
+	default:
+		return "", NewUsageError(
+			fmt.Sprintf("Unsupported output format: %s", output),
+			"Use --output text, --output json or --output table",
+		)
+	}
+}
+
+// resolveMaxNameLength returns the number of characters after which names
+// are truncated, or 0 if they never are
+func resolveMaxNameLength(cmd *cobra.Command, cfg *config.Config) (int, error) {
+	n := resolveInt(cmd, cfg, "max-name-length", config.KeyMaxNameLength)
+	if n < 0 {
+		return 0, NewUsageError(
+			fmt.Sprintf("Invalid maximum name length: %d", n),
+			"Use --max-name-length with a number of 0 or more (0 disables truncation)",
+		)
+	}
+	return n, nil
+}
+
+// resolveLanguage returns the validated language for cmd from --lang or the
+// language setting, which greetings and proverbs share
+func resolveLanguage(cmd *cobra.Command, cfg *config.Config) (string, error) {
+	lang, err := greeting.ParseLanguage(resolveString(cmd, cfg, "lang", config.KeyLanguage))
+	if err != nil {
+		return "", NewUsageError(err.Error(), "Run 'hello-gopher greet --help' to see supported languages")
+	}
+	return lang, nil
+}
+
+// resolveLocale returns the formatter of counts and dates for the language
+// resolved for cmd, the one greetings use
+func resolveLocale(cmd *cobra.Command, cfg *config.Config) (locale.Formatter, error) {
+	lang, err := resolveLanguage(cmd, cfg)
+	if err != nil {
+		return locale.Formatter{}, err
+	}
+	return locale.New(lang), nil
+}
+
+// greetingOptions resolves the language, style and name length settings for
+// cmd
+func greetingOptions(cmd *cobra.Command, cfg *config.Config) ([]greeting.Option, error) {
+	lang, err := resolveLanguage(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	style, err := greeting.ParseStyle(resolveString(cmd, cfg, "style", config.KeyStyle))
+	if err != nil {
+		return nil, NewUsageError(err.Error(), "Run 'hello-gopher greet --help' to see supported styles")
+	}
+
+	maxNameLen, err := resolveMaxNameLength(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := resolveLocation(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return []greeting.Option{greeting.WithLanguage(lang), greeting.WithStyle(style), greeting.WithMaxNameLength(maxNameLen), greeting.WithLocation(loc)}, nil
+}
+
+// exclusionOptions returns the greeting options hiding the proverbs listed
+// by the exclude setting, if any
+func exclusionOptions(cfg *config.Config) ([]greeting.Option, error) {
+	exclusion, err := greeting.ParseExclusion(config.SplitList(cfg.Value(config.KeyExclude)))
+	if err != nil {
+		return nil, NewUsageError(
+			fmt.Sprintf("Invalid exclude setting: %v", err),
+			"List proverb IDs and tags, such as 'hello-gopher config set exclude \"[cgo, syscall, 12]\"'",
+		)
+	}
+	if exclusion.IsZero() {
+		return nil, nil
+	}
+	return []greeting.Option{greeting.WithExclusion(exclusion)}, nil
+}
+
+// resolveRegion returns the validated holiday region for cmd
+func resolveRegion(cmd *cobra.Command, cfg *config.Config) (string, error) {
+	region, err := greeting.ParseRegion(resolveString(cmd, cfg, "region", config.KeyRegion))
+	if err != nil {
+		return "", NewUsageError(err.Error(), "Run 'hello-gopher holidays list --help' to see supported regions")
+	}
+	return region, nil
+}
+
+// resolveLocation returns the time zone set with --tz or the timezone
+// setting, or the system zone if neither is set
+func resolveLocation(cmd *cobra.Command, cfg *config.Config) (*time.Location, error) {
+	name := resolveString(cmd, cfg, "tz", config.KeyTimezone)
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, NewUsageError(
+			fmt.Sprintf("Unknown time zone %q", name),
+			"Use an IANA time zone name such as Europe/Lisbon, America/New_York or UTC",
+		)
+	}
+	return loc, nil
+}
+
+// today returns the current time of deps in the time zone resolved for cmd,
+// which decides the date of the proverb of the day and of holidays
+func today(cmd *cobra.Command, cfg *config.Config, deps Deps) (time.Time, error) {
+	loc, err := resolveLocation(cmd, cfg)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return deps.now().In(loc), nil
+}
+
+// newStyler resolves the color mode for cmd and returns a styler for its output
+func newStyler(cmd *cobra.Command, cfg *config.Config) (color.Styler, error) {
+	mode, err := color.ParseMode(resolveString(cmd, cfg, "color", config.KeyColor))
+	if err != nil {
+		return color.Styler{}, NewUsageError(err.Error(), "Use --color auto, --color always or --color never")
+	}
+
+	suggestAccessible(cmd, cfg)
+	if accessible(cmd) {
+		// Escape codes are read out by some screen readers
+		return color.NewStyler(false), nil
+	}
+	detector := color.DefaultDetector()
+	detector.LookupEnv = commandDeps(cmd).lookupEnv
+	return color.NewStyler(detector.Enabled(mode, cmd.OutOrStdout())), nil
+}
+
+// addRainbowFlag adds the --rainbow flag to cmd
+func addRainbowFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("rainbow", false, "Color the output with a rainbow gradient (needs color, see --color)")
+}
+
+// withRainbow paints text with a rainbow gradient if --rainbow is set on cmd
+// and styler is enabled. firstLine is the number of lines already written,
+// so the gradient continues across separate writes.
+func withRainbow(cmd *cobra.Command, styler color.Styler, text string, firstLine int) string {
+	if rainbow, _ := cmd.Flags().GetBool("rainbow"); rainbow {
+		return styler.Rainbow(text, firstLine)
+	}
+	return text
+}
+
+// writeJSON writes v as indented JSON followed by a newline
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return NewSystemError("Failed to encode JSON output", err, "")
+	}
+	return nil
+}
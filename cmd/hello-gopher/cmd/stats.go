@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/history"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/locale"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// statsTopProverbs is how many of the most-seen proverbs stats lists
+const statsTopProverbs = 5
+
+// statsResult is the JSON form of the stats command output
+type statsResult struct {
+	history.Stats
+	Enabled bool `json:"enabled"`
+}
+
+// newStatsCmd creates the stats command
+func newStatsCmd(deps Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show statistics of your greet and proverb runs",
+		Long: `Stats summarizes the recorded greet and proverb runs: how many there were,
+the proverbs you saw most and your streak of consecutive days.
+
+Nothing is recorded unless you opt in with the history setting:
+
+  hello-gopher config set history true
+
+The history is kept in history.jsonl in the state directory; remove it with
+'hello-gopher data prune'.`,
+		Example: `  hello-gopher stats                  # Totals, most-seen proverbs and streaks
+  hello-gopher stats --output json    # The same as JSON
+  hello-gopher stats --output table   # The same as tables`,
+		Args: exactArgs(0, "stats doesn't accept positional arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			f, err := resolveLocale(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			path, err := historyPath(cmd)
+			if err != nil {
+				return err
+			}
+			entries, err := history.Load(path)
+			if err != nil {
+				return NewDataError(fmt.Sprintf("Failed to read history: %v", err), err, fmt.Sprintf("Remove %s to start over", path))
+			}
+
+			result := statsResult{
+				Stats:   history.Summarize(entries, deps.now(), statsTopProverbs),
+				Enabled: historyEnabled(cfg),
+			}
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			return writeStats(cmd, cfg, f, result, output)
+		},
+	}
+	addTableFlags(cmd)
+	return cmd
+}
+
+// writeStats prints result as text, or as tables for the table output, with
+// counts and dates formatted by f
+func writeStats(cmd *cobra.Command, cfg *config.Config, f locale.Formatter, result statsResult, output string) error {
+	out := bufio.NewWriter(cmd.OutOrStdout())
+	if result.Total == 0 {
+		fmt.Fprintln(out, "No runs recorded yet.")
+	} else {
+		commands := make([]string, 0, len(result.Commands))
+		for name := range result.Commands {
+			commands = append(commands, name)
+		}
+		sort.Strings(commands)
+		for i, name := range commands {
+			commands[i] = fmt.Sprintf("%s %s", name, f.Number(result.Commands[name]))
+		}
+
+		runs := fmt.Sprintf("%s (%s)", f.Number(result.Total), strings.Join(commands, ", "))
+		if output == outputTable {
+			writeStatsTables(cmd, out, cfg, f, result, runs)
+		} else {
+			fmt.Fprintf(out, "Runs:            %s\n", runs)
+			fmt.Fprintf(out, "Since:           %s\n", f.Date(result.First))
+			fmt.Fprintf(out, "Current streak:  %s\n", pluralIn(f, result.CurrentStreak, "day"))
+			fmt.Fprintf(out, "Longest streak:  %s\n", pluralIn(f, result.LongestStreak, "day"))
+		}
+
+		if len(result.TopProverbs) > 0 && output != outputTable {
+			fmt.Fprintln(out, "\nMost-seen proverbs:")
+			texts := proverbTexts(cmd, cfg)
+			for _, p := range result.TopProverbs {
+				if accessible(cmd) {
+					fmt.Fprintf(out, "  #%d, seen %s: %s\n", p.ID, pluralIn(f, p.Count, "time"), texts[p.ID])
+				} else {
+					fmt.Fprintf(out, "  %3s×  #%d %s\n", f.Number(p.Count), p.ID, texts[p.ID])
+				}
+			}
+		}
+	}
+
+	if !result.Enabled {
+		fmt.Fprintln(out, "\nHistory is off. Turn it on with 'hello-gopher config set history true'.")
+	}
+	if err := out.Flush(); err != nil {
+		return NewSystemError("Failed to write stats", err, "")
+	}
+	return nil
+}
+
+// writeStatsTables writes the totals and the most-seen proverbs of result
+// as tables to out
+func writeStatsTables(cmd *cobra.Command, out io.Writer, cfg *config.Config, f locale.Formatter, result statsResult, runs string) {
+	totals := newTable(cmd, "STAT", "VALUE")
+	totals.Append("Runs", runs)
+	totals.Append("Since", f.Date(result.First))
+	totals.Append("Current streak", pluralIn(f, result.CurrentStreak, "day"))
+	totals.Append("Longest streak", pluralIn(f, result.LongestStreak, "day"))
+	io.WriteString(out, renderTable(totals))
+
+	if len(result.TopProverbs) == 0 {
+		return
+	}
+	top := newTable(cmd, "SEEN", "ID", "PROVERB")
+	top.SetAlign(0, tablewriter.AlignRight)
+	top.SetAlign(1, tablewriter.AlignRight)
+	texts := proverbTexts(cmd, cfg)
+	for _, p := range result.TopProverbs {
+		top.Append(f.Number(p.Count), strconv.Itoa(p.ID), texts[p.ID])
+	}
+	fmt.Fprintf(out, "\n%s", renderTable(top))
+}
+
+// plural returns n followed by unit, pluralized with an "s" unless n is 1
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
+
+// pluralIn is plural with n formatted by f
+func pluralIn(f locale.Formatter, n int, unit string) string {
+	if n == 1 {
+		return plural(n, unit)
+	}
+	return f.Number(n) + " " + unit + "s"
+}
+
+// proverbTexts maps proverb IDs to their text. Stats are printed without the
+// texts if the proverbs can't be loaded.
+func proverbTexts(cmd *cobra.Command, cfg *config.Config) map[int]string {
+	texts := make(map[int]string)
+	service, err := proverbService(cmd, cfg)
+	if err != nil {
+		return texts
+	}
+	proverbs, _ := service.Proverbs()
+	for _, p := range proverbs {
+		texts[p.ID] = p.Text
+	}
+	return texts
+}
+
+// historyEnabled reports whether runs are recorded with cfg
+func historyEnabled(cfg *config.Config) bool {
+	// The config package validates boolean keys
+	enabled, _ := strconv.ParseBool(cfg.Value(config.KeyHistory))
+	return enabled
+}
+
+// historyPath returns the history file in the state directory
+func historyPath(cmd *cobra.Command) (string, error) {
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, history.FileName), nil
+}
+
+// recordRun appends a run of command, which showed the proverb with the
+// given ID if any, to the history when it is enabled in cfg. A failure to
+// record is logged and doesn't fail the command.
+func recordRun(cmd *cobra.Command, cfg *config.Config, deps Deps, command string, proverbID int) {
+	if !historyEnabled(cfg) {
+		return
+	}
+
+	logger := commandLogger(cmd)
+	path, err := historyPath(cmd)
+	if err == nil {
+		err = history.Append(path, history.Entry{Time: deps.now(), Command: command, ProverbID: proverbID})
+	}
+	if err != nil {
+		logger.Warn("failed to record history", "error", err)
+		return
+	}
+	logger.Debug("recorded run", "command", command, "path", path)
+}
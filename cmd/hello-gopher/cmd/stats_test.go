@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/history"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
+)
+
+func TestHistoryIsOptIn(t *testing.T) {
+	_, _, state := appDirs(t)
+
+	if _, stderr, code := testsupport.RunCommand(t, "greet"); code != ExitSuccess {
+		t.Fatalf("greet failed: %s", stderr)
+	}
+	if _, err := os.Stat(filepath.Join(state, history.FileName)); !os.IsNotExist(err) {
+		t.Errorf("greet recorded history without opting in (stat error %v)", err)
+	}
+
+	stdout, _, code := testsupport.RunCommand(t, "stats")
+	if code != ExitSuccess || !strings.Contains(stdout, "No runs recorded yet.") || !strings.Contains(stdout, "History is off.") {
+		t.Errorf("stats without history = %q", stdout)
+	}
+}
+
+func TestStatsRecordsRuns(t *testing.T) {
+	state := t.TempDir()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_STATE_DIR": state,
+		"HELLO_GOPHER_HISTORY":   "true",
+	})
+
+	for _, args := range [][]string{{"greet"}, {"proverb", "--seed", "1"}, {"proverb", "--seed", "1"}} {
+		if _, stderr, code := testsupport.RunCommand(t, args...); code != ExitSuccess {
+			t.Fatalf("%v failed: %s", args, stderr)
+		}
+	}
+
+	stdout, stderr, code := testsupport.RunCommand(t, "stats", "--output", "json")
+	if code != ExitSuccess {
+		t.Fatalf("stats failed: %s", stderr)
+	}
+	var result statsResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("stats output isn't JSON: %v\n%s", err, stdout)
+	}
+	if result.Total != 3 || result.Commands["proverb"] != 2 || !result.Enabled {
+		t.Errorf("stats = %+v", result)
+	}
+	if len(result.TopProverbs) != 1 || result.TopProverbs[0].Count != 2 {
+		t.Errorf("TopProverbs = %+v, want the seeded proverb twice", result.TopProverbs)
+	}
+}
+
+func TestStatsText(t *testing.T) {
+	state := t.TempDir()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_STATE_DIR": state,
+		"HELLO_GOPHER_DATA_DIR":  t.TempDir(),
+		"HELLO_GOPHER_HISTORY":   "true",
+	})
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	path := filepath.Join(state, history.FileName)
+	for _, e := range []history.Entry{
+		{Time: now.AddDate(0, 0, -2), Command: "proverb", ProverbID: 18},
+		{Time: now.AddDate(0, 0, -1), Command: "proverb", ProverbID: 18},
+		{Time: now, Command: "greet"},
+	} {
+		if err := history.Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	root := NewRootCmd(testDeps(t, Deps{Out: &out, Err: &out, Clock: greetingtest.NewFakeClock(now)}))
+	root.SetArgs([]string{"stats"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Runs:            3 (greet 1, proverb 2)",
+		"Since:           October 15, 2026",
+		"Current streak:  3 days",
+		"    2×  #18 Documentation is for users.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("stats output misses %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "History is off") {
+		t.Errorf("stats claims history is off:\n%s", out.String())
+	}
+}
+
+func TestStatsLocale(t *testing.T) {
+	state := t.TempDir()
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_STATE_DIR": state,
+		"HELLO_GOPHER_DATA_DIR":  t.TempDir(),
+		"HELLO_GOPHER_LANG":      "de",
+	})
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	if err := history.Append(filepath.Join(state, history.FileName), history.Entry{Time: now.AddDate(0, 0, -2), Command: "greet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	root := NewRootCmd(testDeps(t, Deps{Out: &out, Err: &out, Clock: greetingtest.NewFakeClock(now)}))
+	root.SetArgs([]string{"stats", "--output", "table"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "15. Oktober 2026") {
+		t.Errorf("stats with the German locale misses the German date:\n%s", out.String())
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// strictMode reports whether --strict or the strict setting turns warnings
+// into errors for cmd
+func strictMode(cmd *cobra.Command, cfg *config.Config) bool {
+	return resolveBool(cmd, cfg, "strict", config.KeyStrict)
+}
+
+// checkUnknownKeys warns about keys of the config file at path that
+// hello-gopher doesn't know, or fails in strict mode
+func checkUnknownKeys(cmd *cobra.Command, cfg *config.Config, path string) error {
+	unknown := cfg.UnknownKeys()
+	if len(unknown) == 0 {
+		return nil
+	}
+	if strictMode(cmd, cfg) {
+		return NewDataError(
+			fmt.Sprintf("Unknown keys in %s: %s", path, strings.Join(unknown, ", ")),
+			nil,
+			"Fix or remove them with 'hello-gopher config edit'; 'hello-gopher config list' shows the known keys",
+		)
+	}
+	commandLogger(cmd).Warn("ignoring unknown config keys", "path", path, "keys", unknown)
+	return nil
+}
+
+// verifyEmbeddedProverbs fails in strict mode if the embedded proverbs don't
+// match the checksum recorded at build time
+func verifyEmbeddedProverbs(cmd *cobra.Command, cfg *config.Config) error {
+	if !strictMode(cmd, cfg) {
+		return nil
+	}
+	if err := greeting.VerifyEmbeddedProverbs(); err != nil {
+		return NewDataError(
+			fmt.Sprintf("The proverbs of this build are corrupted: %v", err),
+			err,
+			"Reinstall hello-gopher; 'hello-gopher doctor' checks the installation",
+		)
+	}
+	return nil
+}
+
+// checkDeprecatedFlags fails in strict mode if a deprecated flag of cmd is
+// used. Cobra already warns about them while parsing.
+func checkDeprecatedFlags(cmd *cobra.Command) error {
+	var deprecated []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Deprecated != "" || f.ShorthandDeprecated != "" {
+			deprecated = append(deprecated, "--"+f.Name)
+		}
+	})
+	if len(deprecated) == 0 {
+		return nil
+	}
+
+	// The config file is only read when it matters
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if !strictMode(cmd, cfg) {
+		return nil
+	}
+	return NewUsageError(
+		fmt.Sprintf("Deprecated flags used: %s", strings.Join(deprecated, ", ")),
+		fmt.Sprintf("Run 'hello-gopher %s --help' to see their replacements", cmd.Name()),
+	)
+}
+
+// checkUTF8 warns that value, described by what, isn't valid UTF-8 and will
+// be printed with replacement characters, or fails in strict mode
+func checkUTF8(cmd *cobra.Command, cfg *config.Config, what, value string) error {
+	if utf8.ValidString(value) {
+		return nil
+	}
+	if strictMode(cmd, cfg) {
+		return NewUsageError(
+			fmt.Sprintf("The %s is not valid UTF-8", what),
+			"Pass text in UTF-8, or run without --strict to replace invalid bytes",
+		)
+	}
+	commandLogger(cmd).Warn("input is not valid UTF-8; invalid bytes are replaced", "input", what)
+	return nil
+}
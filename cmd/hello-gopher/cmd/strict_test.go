@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestStrictUnknownConfigKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("name: Alice\nlangauge: de\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, code := testsupport.RunCommand(t, "greet", "--config", path)
+	if code != ExitSuccess || !strings.Contains(stderr, "langauge") {
+		t.Errorf("unknown key without --strict exited with %d, want a warning: %q", code, stderr)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "greet", "--strict", "--config", path)
+	if code != ExitDataError || !strings.Contains(stderr, "Unknown keys in") {
+		t.Errorf("unknown key with --strict exited with %d: %q", code, stderr)
+	}
+
+	// The setting turns strict mode on as well
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": path, "HELLO_GOPHER_STRICT": "true"})
+	if _, _, code := testsupport.RunCommand(t, "greet"); code != ExitDataError {
+		t.Errorf("unknown key with HELLO_GOPHER_STRICT exited with %d, want %d", code, ExitDataError)
+	}
+}
+
+func TestStrictInvalidUTF8(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "-n", "Bob\xff")
+	if code != ExitSuccess || !strings.Contains(stdout, "Bob") || !strings.Contains(stderr, "not valid UTF-8") {
+		t.Errorf("invalid UTF-8 without --strict exited with %d: %q, %q", code, stdout, stderr)
+	}
+
+	_, stderr, code = testsupport.RunCommand(t, "greet", "--strict", "-n", "Bob\xff")
+	if code != ExitUsageError {
+		t.Errorf("invalid UTF-8 with --strict exited with %d, want %d: %q", code, ExitUsageError, stderr)
+	}
+}
+
+func TestStrictDeprecatedFlags(t *testing.T) {
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml")})
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCmd(testDeps(t, Deps{Out: &out, Err: &out}))
+		greet, _, err := root.Find([]string{"greet"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := greet.Flags().MarkDeprecated("shout", "it will be removed"); err != nil {
+			t.Fatal(err)
+		}
+		root.SetArgs(args)
+		err = root.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("greet", "--shout")
+	if err != nil || !strings.Contains(out, "deprecated") {
+		t.Errorf("deprecated flag without --strict: %v, want a warning:\n%s", err, out)
+	}
+	if _, err := run("greet", "--shout", "--strict"); !errors.Is(err, ErrUsage) {
+		t.Errorf("deprecated flag with --strict: %v, want a usage error", err)
+	}
+}
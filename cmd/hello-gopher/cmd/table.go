@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// tableAnnotation marks the commands that print tables with --output table
+const tableAnnotation = "hello-gopher/table"
+
+// addTableFlags marks cmd as printing tables with --output table and adds
+// the flags controlling them
+func addTableFlags(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[tableAnnotation] = "true"
+	cmd.Flags().Bool("border", false, "Draw a border around tables printed with --output table")
+}
+
+// supportsTable reports whether cmd prints tables with --output table
+func supportsTable(cmd *cobra.Command) bool {
+	return cmd.Annotations[tableAnnotation] == "true"
+}
+
+// newTable returns a table for the output of cmd, fitted to the width of
+// the terminal. --border frames it with box-drawing characters, or ASCII on
+// terminals that aren't UTF-8 and in accessible mode.
+func newTable(cmd *cobra.Command, header ...string) *tablewriter.Table {
+	t := tablewriter.New(header...)
+	t.SetWidth(terminalWidth(cmd))
+	if border, _ := cmd.Flags().GetBool("border"); border {
+		detector := color.DefaultDetector()
+		detector.LookupEnv = commandDeps(cmd).lookupEnv
+		if detector.UTF8() && !accessible(cmd) {
+			t.SetBorder(tablewriter.BorderUnicode)
+		} else {
+			t.SetBorder(tablewriter.BorderASCII)
+		}
+	}
+	return t
+}
+
+// renderTable returns t as text
+func renderTable(t *tablewriter.Table) string {
+	var b strings.Builder
+	// Writing to a strings.Builder doesn't fail
+	_ = t.Render(&b)
+	return b.String()
+}
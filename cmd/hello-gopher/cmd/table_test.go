@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+)
+
+func TestProverbListTable(t *testing.T) {
+	appDirs(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "proverb", "list", "--output", "table", "--search", "errors are values")
+	if code != ExitSuccess {
+		t.Fatalf("proverb list --output table failed with exit code %d: %s", code, stderr)
+	}
+	want := "ID  PROVERB             TAGS\n15  Errors are values.  errors\n"
+	if stdout != want {
+		t.Errorf("proverb list --output table = %q, want %q", stdout, want)
+	}
+
+	stdout, _, _ = testsupport.RunCommand(t, "proverb", "list", "--output", "table", "--border", "--tag", "errors")
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) < 5 || lines[0] != lines[2] || lines[0] != lines[len(lines)-1] || !strings.Contains(lines[1], "PROVERB") {
+		t.Errorf("proverb list --output table --border = %q, want a framed table", stdout)
+	}
+}
+
+func TestStatsTable(t *testing.T) {
+	withEnv(t, map[string]string{
+		"HELLO_GOPHER_CONFIG":    filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_STATE_DIR": t.TempDir(),
+		"HELLO_GOPHER_HISTORY":   "true",
+	})
+	if _, stderr, code := testsupport.RunCommand(t, "proverb", "--seed", "1"); code != ExitSuccess {
+		t.Fatalf("proverb failed: %s", stderr)
+	}
+
+	stdout, stderr, code := testsupport.RunCommand(t, "stats", "--output", "table")
+	if code != ExitSuccess {
+		t.Fatalf("stats --output table failed with exit code %d: %s", code, stderr)
+	}
+	for _, want := range []string{"STAT  ", "Runs  ", "1 (proverb 1)", "SEEN  ID  PROVERB", "   1"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stats --output table doesn't contain %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestHolidaysListTable(t *testing.T) {
+	appDirs(t)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "holidays", "list", "--output", "table")
+	if code != ExitSuccess {
+		t.Fatalf("holidays list --output table failed with exit code %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "DATE    HOLIDAY") || !strings.Contains(stdout, "Jan 01  New Year's Day") {
+		t.Errorf("holidays list --output table = %q", stdout)
+	}
+}
+
+func TestTableOutputUnsupported(t *testing.T) {
+	appDirs(t)
+
+	_, stderr, code := testsupport.RunCommand(t, "greet", "--output", "table")
+	if code != ExitUsageError || !strings.Contains(stderr, "can't print a table") {
+		t.Errorf("greet --output table exited with %d: %q, want a usage error", code, stderr)
+	}
+
+	// A table in the config file falls back to text for the other commands
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("output: table\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--config", path)
+	if code != ExitSuccess || stdout != "Hello, Gopher!\n" {
+		t.Errorf("greet with output: table exited with %d: %q, %q; want the text greeting", code, stdout, stderr)
+	}
+	stdout, _, _ = testsupport.RunCommand(t, "holidays", "list", "--config", path)
+	if !strings.HasPrefix(stdout, "DATE") {
+		t.Errorf("holidays list with output: table = %q, want a table", stdout)
+	}
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// telemetryTimeout bounds sending a batch of events, unless --timeout is set
+const telemetryTimeout = 2 * time.Second
+
+// sourceDoNotTrack is the source of the telemetry setting when DO_NOT_TRACK
+// turns it off
+const sourceDoNotTrack = "DO_NOT_TRACK"
+
+// sourceNoEndpoint is the source of the telemetry setting when it is on but
+// no telemetry_endpoint is set to receive the events
+const sourceNoEndpoint = "no endpoint"
+
+// telemetryResult is the JSON form of the telemetry status output
+type telemetryResult struct {
+	Enabled  bool            `json:"enabled"`
+	Source   string          `json:"source"`
+	Endpoint string          `json:"endpoint"`
+	Queued   int             `json:"queued"`
+	Event    telemetry.Event `json:"event"`
+}
+
+// newTelemetryCmd creates the telemetry command and its subcommands
+func newTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Turn anonymous usage telemetry on or off",
+		Long: `Telemetry command manages anonymous usage telemetry, which is off unless you
+turn it on. When on, every run records an event with the command name, the
+hello-gopher version and the operating system and architecture, such as
+
+  {"command": "proverb list", "version": "v1.5.0", "os": "linux", "arch": "amd64"}
+
+and nothing else: no names, arguments, proverbs or identifiers. Events are
+queued in telemetry.jsonl in the state directory and sent in batches of ` + strconv.Itoa(telemetry.BatchSize) + ` to
+the telemetry_endpoint setting, in the background. There is no default
+endpoint: telemetry stays off until one is set. Turning telemetry off
+removes the queued events.
+
+Setting DO_NOT_TRACK=1 in the environment turns telemetry off whatever the
+configuration says.`,
+		Example: `  hello-gopher telemetry status          # Show whether telemetry is on
+  hello-gopher config set telemetry_endpoint https://telemetry.example.com/v1/events
+  hello-gopher telemetry on              # Opt in
+  hello-gopher telemetry off             # Opt out and drop queued events`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return NewUsageError(
+					fmt.Sprintf("Unknown telemetry subcommand: %s", args[0]),
+					"Run 'hello-gopher telemetry --help' to see available subcommands",
+				)
+			}
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newTelemetrySwitchCmd(true), newTelemetrySwitchCmd(false), newTelemetryStatusCmd(), newTelemetryFlushCmd())
+	return cmd
+}
+
+// newTelemetrySwitchCmd creates the telemetry on command, or the telemetry
+// off command if on is false
+func newTelemetrySwitchCmd(on bool) *cobra.Command {
+	use, short := "on", "Opt in to anonymous usage telemetry"
+	if !on {
+		use, short = "off", "Opt out of telemetry and remove queued events"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  exactArgs(0, "telemetry "+use+" doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath(cmd)
+			if err != nil {
+				return NewSystemError("Failed to locate the configuration file", err, "Pass an explicit file with --config")
+			}
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Set(config.KeyTelemetry, strconv.FormatBool(on)); err != nil {
+				return NewSystemError("Failed to change the telemetry setting", err, "")
+			}
+			if err := cfg.Save(path); err != nil {
+				return NewSystemError(
+					fmt.Sprintf("Failed to write configuration to %s", path),
+					err,
+					"Check that the config directory is writable",
+				)
+			}
+
+			if !on {
+				queue, err := telemetryQueuePath(cmd)
+				if err == nil {
+					err = telemetry.Clear(queue)
+				}
+				if err != nil {
+					return NewSystemError("Failed to remove the queued telemetry events", err, "Remove them with 'hello-gopher data prune'")
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "Telemetry is off.")
+				return nil
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Telemetry is on. Thank you! Turn it off any time with 'hello-gopher telemetry off'.")
+			if enabled, source := telemetryEnabled(cmd, cfg); source == sourceNoEndpoint {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Warning: nothing will be sent until the telemetry_endpoint setting is set")
+			} else if !enabled {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: nothing will be sent while %s turns telemetry off\n", describeTelemetrySource(cfg, source))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newTelemetryStatusCmd creates the telemetry status command
+func newTelemetryStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show whether telemetry is on and what would be sent",
+		Args:  exactArgs(0, "telemetry status doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			output, err := resolveOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			queue, err := telemetryQueuePath(cmd)
+			if err != nil {
+				return err
+			}
+			events, err := telemetry.Load(queue)
+			if err != nil {
+				return NewDataError(fmt.Sprintf("Failed to read queued telemetry events: %v", err), err, fmt.Sprintf("Remove %s to start over", queue))
+			}
+
+			result := telemetryResult{
+				Endpoint: telemetryEndpoint(cfg),
+				Queued:   len(events),
+				Event:    telemetry.NewEvent("telemetry status", version),
+			}
+			result.Enabled, result.Source = telemetryEnabled(cmd, cfg)
+			if output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+
+			state := "off"
+			if result.Enabled {
+				state = "on"
+			}
+			sample, _ := json.Marshal(result.Event)
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Telemetry:  %s (%s)\n", state, describeTelemetrySource(cfg, result.Source))
+			if result.Endpoint == "" {
+				fmt.Fprintln(out, "Endpoint:   none (set telemetry_endpoint)")
+			} else {
+				fmt.Fprintf(out, "Endpoint:   %s\n", result.Endpoint)
+			}
+			fmt.Fprintf(out, "Queued:     %s\n", plural(result.Queued, "event"))
+			fmt.Fprintf(out, "Sample:     %s\n", sample)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// newTelemetryFlushCmd creates the hidden telemetry flush command, which
+// recordTelemetry runs in the background to send the queued events
+func newTelemetryFlushCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "flush",
+		Short:  "Send the queued telemetry events",
+		Hidden: true,
+		Args:   exactArgs(0, "telemetry flush doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return flushTelemetry(cmd)
+		},
+	}
+	return cmd
+}
+
+// telemetryEnabled reports whether events are recorded, and the source of
+// the answer: a config.Source value, sourceDoNotTrack or sourceNoEndpoint
+func telemetryEnabled(cmd *cobra.Command, cfg *config.Config) (bool, string) {
+	if doNotTrack(cmd) {
+		return false, sourceDoNotTrack
+	}
+	// The config package validates boolean keys
+	enabled, _ := strconv.ParseBool(cfg.Value(config.KeyTelemetry))
+	if enabled && telemetryEndpoint(cfg) == "" {
+		return false, sourceNoEndpoint
+	}
+	return enabled, cfg.Source(config.KeyTelemetry)
+}
+
+// doNotTrack reports whether the DO_NOT_TRACK convention
+// (https://consoledonottrack.com) opts cmd out of telemetry
+func doNotTrack(cmd *cobra.Command) bool {
+	value, ok := lookupEnv(cmd, "DO_NOT_TRACK")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
+
+// describeTelemetrySource explains source, as returned by telemetryEnabled
+func describeTelemetrySource(cfg *config.Config, source string) string {
+	switch source {
+	case sourceDoNotTrack:
+		return "$DO_NOT_TRACK"
+	case sourceNoEndpoint:
+		return "no telemetry_endpoint set"
+	case config.SourceEnv:
+		return "$" + cfg.EnvSource(config.KeyTelemetry)
+	case config.SourceFile:
+		return "config file"
+	default:
+		return "default"
+	}
+}
+
+// telemetryEndpoint returns the configured endpoint, if any
+func telemetryEndpoint(cfg *config.Config) string {
+	return cfg.Value(config.KeyTelemetryURL)
+}
+
+// telemetryQueuePath returns the telemetry queue in the state directory
+func telemetryQueuePath(cmd *cobra.Command) (string, error) {
+	dir, err := appDir(cmd, dirState)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, telemetry.QueueFileName), nil
+}
+
+// recordTelemetry queues the event of a successful run of cmd when telemetry
+// is on, and starts sending the queue in the background once it holds a
+// batch, so the command never waits for the endpoint. Nothing here fails
+// the command; problems are logged.
+func recordTelemetry(cmd *cobra.Command) {
+	if !telemetryCommand(cmd) {
+		return
+	}
+
+	// The command already reported configuration problems, if it read the
+	// configuration at all
+	cfg, err := peekConfig(cmd)
+	if err != nil {
+		return
+	}
+	if enabled, _ := telemetryEnabled(cmd, cfg); !enabled {
+		return
+	}
+
+	logger := commandLogger(cmd)
+	queue, err := telemetryQueuePath(cmd)
+	if err != nil {
+		logger.Warn("failed to locate the telemetry queue", "error", err)
+		return
+	}
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	queued, err := telemetry.Enqueue(queue, telemetry.NewEvent(name, version))
+	if err != nil {
+		logger.Warn("failed to queue telemetry event", "error", err)
+		return
+	}
+	logger.Debug("queued telemetry event", "command", name, "queued", queued)
+	if queued < telemetry.BatchSize {
+		return
+	}
+
+	if err := commandDeps(cmd).startTelemetryFlush(cmd); err != nil {
+		logger.Debug("failed to start sending telemetry events", "error", err)
+	}
+}
+
+// telemetryFlushFlags are the flags of a run passed on to the telemetry
+// flush it starts
+var telemetryFlushFlags = []string{"config", "timeout", "retries", "proxy"}
+
+// startTelemetryProcess runs 'hello-gopher telemetry flush' in a process of
+// its own and doesn't wait for it
+func startTelemetryProcess(cmd *cobra.Command) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := []string{"telemetry", "flush"}
+	for _, name := range telemetryFlushFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			args = append(args, "--"+name+"="+f.Value.String())
+		}
+	}
+	flush := exec.Command(binary, args...) // #nosec G204 -- runs hello-gopher itself
+	if err := flush.Start(); err != nil {
+		return err
+	}
+	return flush.Process.Release()
+}
+
+// flushTelemetry sends the queued events to the configured endpoint when
+// telemetry is on
+func flushTelemetry(cmd *cobra.Command) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if enabled, _ := telemetryEnabled(cmd, cfg); !enabled {
+		return nil
+	}
+	queue, err := telemetryQueuePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	logger := commandLogger(cmd)
+	endpoint := telemetryEndpoint(cfg)
+	sent, err := telemetry.Flush(context.Background(), httpClient(cmd, telemetryTimeout), endpoint, queue)
+	if err != nil {
+		logger.Debug("failed to send telemetry events", "endpoint", endpoint, "error", err)
+		return NewSystemError("Failed to send the queued telemetry events", err, "They stay queued and are sent with a later batch")
+	}
+	logger.Debug("sent telemetry events", "endpoint", endpoint, "events", sent)
+	return nil
+}
+
+// telemetryCommand reports whether runs of cmd are recorded. Help, shell
+// completion, the root command, the telemetry commands themselves and
+// container health checks, which run every few seconds, are not.
+func telemetryCommand(cmd *cobra.Command) bool {
+	if cmd == cmd.Root() {
+		return false
+	}
+	for c := cmd; c != nil && c != cmd.Root(); c = c.Parent() {
+		if c.Hidden || c.Name() == "help" || c.Name() == "completion" || c.Name() == "telemetry" || c.Name() == "healthcheck" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/telemetry"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/spf13/cobra"
+)
+
+// telemetryEnv isolates the config file and state directory of a test and
+// points telemetry at a local endpoint, returning the queue file and the
+// events the endpoint received
+func telemetryEnv(t *testing.T, extra map[string]string) (string, *[]telemetry.Event) {
+	t.Helper()
+	var received []telemetry.Event
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []telemetry.Event
+		json.NewDecoder(r.Body).Decode(&batch)
+		received = append(received, batch...)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ts.Close)
+
+	state := t.TempDir()
+	vars := map[string]string{
+		"HELLO_GOPHER_CONFIG":             filepath.Join(t.TempDir(), "config.yaml"),
+		"HELLO_GOPHER_STATE_DIR":          state,
+		"HELLO_GOPHER_TELEMETRY_ENDPOINT": ts.URL,
+	}
+	for k, v := range extra {
+		vars[k] = v
+	}
+	withEnv(t, vars)
+	return filepath.Join(state, telemetry.QueueFileName), &received
+}
+
+func TestTelemetryIsOptIn(t *testing.T) {
+	queue, _ := telemetryEnv(t, nil)
+
+	if _, stderr, code := testsupport.RunCommand(t, "greet"); code != ExitSuccess {
+		t.Fatalf("greet failed: %s", stderr)
+	}
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("greet queued a telemetry event without opting in (stat error %v)", err)
+	}
+
+	stdout, _, code := testsupport.RunCommand(t, "telemetry", "status")
+	if code != ExitSuccess || !strings.Contains(stdout, "Telemetry:  off (default)") || !strings.Contains(stdout, "Queued:     0 events") {
+		t.Errorf("telemetry status = %q", stdout)
+	}
+}
+
+func TestTelemetryQueuesAndSends(t *testing.T) {
+	queue, received := telemetryEnv(t, nil)
+
+	stdout, stderr, code := testsupport.RunCommand(t, "telemetry", "on")
+	if code != ExitSuccess || !strings.Contains(stdout, "Telemetry is on.") {
+		t.Fatalf("telemetry on = %q, %q (exit %d)", stdout, stderr, code)
+	}
+
+	// Neither telemetry nor help commands are recorded
+	testsupport.RunCommand(t, "telemetry", "status")
+	testsupport.RunCommand(t, "help", "greet")
+	testsupport.RunCommand(t, "proverb", "list")
+	events, err := telemetry.Load(queue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Command != "proverb list" || events[0].Version != version {
+		t.Fatalf("queued events = %+v, want one for proverb list", events)
+	}
+
+	for i := 1; i < telemetry.BatchSize; i++ {
+		if _, stderr, code := testsupport.RunCommand(t, "greet", "--name", "Alice"); code != ExitSuccess {
+			t.Fatalf("greet failed: %s", stderr)
+		}
+	}
+	if len(*received) != telemetry.BatchSize || (*received)[1].Command != "greet" {
+		t.Errorf("endpoint received %d events, want a batch of %d", len(*received), telemetry.BatchSize)
+	}
+	for _, e := range *received {
+		if strings.Contains(e.Command, "Alice") {
+			t.Errorf("event %+v leaks an argument", e)
+		}
+	}
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("queue file still exists after sending the batch: %v", err)
+	}
+
+	testsupport.RunCommand(t, "greet")
+	stdout, stderr, code = testsupport.RunCommand(t, "telemetry", "off")
+	if code != ExitSuccess || !strings.Contains(stdout, "Telemetry is off.") {
+		t.Fatalf("telemetry off = %q, %q (exit %d)", stdout, stderr, code)
+	}
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("telemetry off kept the queued events: %v", err)
+	}
+	testsupport.RunCommand(t, "greet")
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("greet queued an event after telemetry off: %v", err)
+	}
+}
+
+func TestTelemetryDoNotTrack(t *testing.T) {
+	queue, _ := telemetryEnv(t, map[string]string{"HELLO_GOPHER_TELEMETRY": "true", "DO_NOT_TRACK": "1"})
+
+	testsupport.RunCommand(t, "greet")
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("greet queued an event despite DO_NOT_TRACK (stat error %v)", err)
+	}
+
+	stdout, stderr, code := testsupport.RunCommand(t, "telemetry", "status", "--output", "json")
+	if code != ExitSuccess {
+		t.Fatalf("telemetry status failed: %s", stderr)
+	}
+	var result telemetryResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("telemetry status output isn't JSON: %v\n%s", err, stdout)
+	}
+	if result.Enabled || result.Source != sourceDoNotTrack || result.Event.Command != "telemetry status" {
+		t.Errorf("telemetry status = %+v, want off because of DO_NOT_TRACK", result)
+	}
+
+	_, stderr, _ = testsupport.RunCommand(t, "telemetry", "on")
+	if !strings.Contains(stderr, "$DO_NOT_TRACK turns telemetry off") {
+		t.Errorf("telemetry on with DO_NOT_TRACK warned %q", stderr)
+	}
+}
+
+func TestTelemetryNeedsEndpoint(t *testing.T) {
+	queue, _ := telemetryEnv(t, map[string]string{"HELLO_GOPHER_TELEMETRY_ENDPOINT": ""})
+
+	_, stderr, code := testsupport.RunCommand(t, "telemetry", "on")
+	if code != ExitSuccess || !strings.Contains(stderr, "nothing will be sent until the telemetry_endpoint setting is set") {
+		t.Errorf("telemetry on without an endpoint = %q (exit %d)", stderr, code)
+	}
+	testsupport.RunCommand(t, "greet")
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("greet queued an event without an endpoint (stat error %v)", err)
+	}
+
+	stdout, _, _ := testsupport.RunCommand(t, "telemetry", "status")
+	if !strings.Contains(stdout, "Telemetry:  off (no telemetry_endpoint set)") || !strings.Contains(stdout, "Endpoint:   none") {
+		t.Errorf("telemetry status = %q", stdout)
+	}
+}
+
+func TestTelemetrySendsInBackground(t *testing.T) {
+	queue, received := telemetryEnv(t, map[string]string{"HELLO_GOPHER_TELEMETRY": "true"})
+	started := 0
+	deps := Deps{StartTelemetryFlush: func(cmd *cobra.Command) error {
+		started++
+		return nil
+	}}
+
+	for i := 0; i < telemetry.BatchSize; i++ {
+		runWithDeps(t, deps, "greet")
+	}
+	if started != 1 || len(*received) != 0 {
+		t.Errorf("a full batch started %d flushes and sent %d events, want 1 flush and nothing sent by greet", started, len(*received))
+	}
+
+	stdout, stderr, code := testsupport.RunCommand(t, "telemetry", "flush")
+	if code != ExitSuccess || stdout != "" {
+		t.Fatalf("telemetry flush = %q, %q (exit %d)", stdout, stderr, code)
+	}
+	if len(*received) != telemetry.BatchSize {
+		t.Errorf("telemetry flush sent %d events, want %d", len(*received), telemetry.BatchSize)
+	}
+	if _, err := os.Stat(queue); !os.IsNotExist(err) {
+		t.Errorf("queue file still exists after telemetry flush: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/favorites"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// newTuiCmd creates the tui command
+func newTuiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Explore the proverb collection in a full-screen terminal UI",
+		Long: `TUI command opens a full-screen proverb explorer.
+
+Browse the collection with the arrow keys, search with '/', cycle through tag
+filters with 't' and 'T', and mark favorites with 'f'. Press 'F' to show only
+favorites, 'esc' to clear all filters and 'q' to quit.
+
+Favorites are saved in favorites.json next to the configuration file.`,
+		Example: `  hello-gopher tui                      # Start the proverb explorer`,
+		Args:    exactArgs(0, "tui doesn't accept arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !color.IsTerminal(cmd.OutOrStdout()) {
+				return NewUsageError(
+					"The tui command requires an interactive terminal",
+					"Use 'hello-gopher proverb list' for non-interactive output",
+				)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			if accessible(cmd) {
+				return NewUsageError(
+					"The tui command draws a full-screen interface that screen readers can't follow",
+					"Use 'hello-gopher proverb list' to read the proverbs, or run without --accessible",
+				)
+			}
+
+			styler, err := newStyler(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			service, err := proverbService(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			proverbs, err := service.Proverbs()
+			if err != nil {
+				return NewDataError("Failed to load proverbs", err, "")
+			}
+
+			favs, err := loadFavorites(cmd)
+			if err != nil {
+				return err
+			}
+
+			program := tea.NewProgram(
+				tui.New(proverbs, favs, styler),
+				tea.WithAltScreen(),
+				tea.WithInput(cmd.InOrStdin()),
+				tea.WithOutput(cmd.OutOrStdout()),
+			)
+			if _, err := program.Run(); errors.Is(err, tea.ErrInterrupted) {
+				return ErrInterrupted
+			} else if err != nil {
+				return NewSystemError("Terminal UI failed", err, "")
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// loadFavorites reads the favorites stored next to the config file used by cmd
+func loadFavorites(cmd *cobra.Command) (*favorites.Store, error) {
+	path, err := configPath(cmd)
+	if err != nil {
+		return nil, NewSystemError("Failed to locate the configuration directory", err, "Pass an explicit file with --config")
+	}
+	path = filepath.Join(filepath.Dir(path), favorites.FileName)
+
+	favs, err := favorites.Load(path)
+	if err != nil {
+		return nil, NewDataError(
+			fmt.Sprintf("Failed to read favorites: %v", err),
+			err,
+			fmt.Sprintf("Remove %s to start over", path),
+		)
+	}
+	return favs, nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/spf13/cobra"
+)
+
+func TestTUIRequiresTerminal(t *testing.T) {
+	_, stderr, code := testsupport.RunCommand(t, "tui")
+	if code != ExitUsageError {
+		t.Errorf("Expected usage error without a terminal, got code %d (stderr %q)", code, stderr)
+	}
+}
+
+func TestLoadFavoritesNextToConfig(t *testing.T) {
+	dir := t.TempDir()
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": filepath.Join(dir, "config.yaml")})
+	if err := os.WriteFile(filepath.Join(dir, "favorites.json"), []byte("[4, 2]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	favs, err := loadFavorites(useTestDeps(t, &cobra.Command{}))
+	if err != nil {
+		t.Fatalf("loadFavorites() unexpected error: %v", err)
+	}
+	if !favs.Has(2) || !favs.Has(4) {
+		t.Errorf("loadFavorites() = %v, want [2 4]", favs.IDs())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "favorites.json"), []byte("oops"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = loadFavorites(useTestDeps(t, &cobra.Command{}))
+	if !errors.Is(err, ErrData) {
+		t.Errorf("Expected data error for malformed favorites, got %v", err)
+	}
+}
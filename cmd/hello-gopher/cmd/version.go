@@ -1,25 +1,211 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"runtime"
+	"time"
 
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/httpclient"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/locale"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/logging"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/update"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
 	"github.com/spf13/cobra"
 )
 
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print version information",
-	Long:  `Print detailed version information including build date and git commit.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("hello-gopher version %s\n", version)
-		fmt.Printf("Build date: %s\n", buildDate)
-		fmt.Printf("Git commit: %s\n", gitCommit)
-		fmt.Printf("Go version: %s\n", runtime.Version())
-		fmt.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(versionCmd)
-}
\ No newline at end of file
+// defaultCheckTimeout bounds the network lookup done by version --check
+const defaultCheckTimeout = 2 * time.Second
+
+// newUpdateChecker creates the checker used by version --check; tests replace
+// it to point at a fake GitHub API
+var newUpdateChecker = func(cmd *cobra.Command) *update.Checker {
+	dir, err := cacheDir(cmd)
+	if err != nil {
+		return update.NewChecker("") // Check without a cache
+	}
+	return update.NewChecker(filepath.Join(dir, update.CacheFileName))
+}
+
+// newVersionCmd creates the version command
+func newVersionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print version information",
+		Long: `Print detailed version information including build date and git commit.
+
+With --check the latest GitHub release is looked up and an upgrade hint is
+printed when a newer version is available. The result is cached for 24 hours.
+When GitHub can't be reached within --timeout the check is skipped silently.`,
+		Example: `  hello-gopher version                  # Show version information
+  hello-gopher version --short          # Print only the version, e.g. v1.2.3
+  hello-gopher version --json           # Build metadata as JSON
+  hello-gopher version --check          # Also check for a newer release`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			check, _ := cmd.Flags().GetBool("check")
+			if format, _ := versionFormat(cmd); check && format != versionText {
+				return NewUsageError(
+					"--check cannot be combined with --json or --short",
+					"Run 'hello-gopher version --check' on its own",
+				)
+			}
+
+			if err := printVersion(cmd); err != nil {
+				return err
+			}
+
+			if check {
+				checkForUpdate(cmd, networkTimeout(cmd, defaultCheckTimeout))
+			}
+			return nil
+		},
+	}
+
+	addVersionFormatFlags(cmd)
+	cmd.Flags().Bool("check", false, "Check GitHub for a newer release")
+	return cmd
+}
+
+// Version output formats selected with --json and --short
+const (
+	versionText  = "text"
+	versionJSON  = "json"
+	versionShort = "short"
+)
+
+// VersionInfo is the build metadata printed by version --json
+type VersionInfo struct {
+	Version   string `json:"version"`
+	BuildDate string `json:"build_date"`
+	GitCommit string `json:"git_commit"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// buildInfo returns the metadata of the running binary
+func buildInfo() VersionInfo {
+	return VersionInfo{
+		Version:   version,
+		BuildDate: buildDate,
+		GitCommit: gitCommit,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+}
+
+// versionFormat returns the output format selected by the --json and --short
+// flags of cmd
+func versionFormat(cmd *cobra.Command) (string, error) {
+	jsonFlag, _ := cmd.Flags().GetBool("json")
+	short, _ := cmd.Flags().GetBool("short")
+	switch {
+	case jsonFlag && short:
+		return "", NewUsageError(
+			"--json and --short cannot be combined",
+			"Use --json for build metadata or --short for just the version",
+		)
+	case jsonFlag:
+		return versionJSON, nil
+	case short:
+		return versionShort, nil
+	default:
+		return versionText, nil
+	}
+}
+
+// printVersion writes the build information to the command output in the
+// format selected by --json or --short
+func printVersion(cmd *cobra.Command) error {
+	format, err := versionFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	info := buildInfo()
+	out := cmd.OutOrStdout()
+	switch format {
+	case versionJSON:
+		return writeJSON(out, info)
+	case versionShort:
+		fmt.Fprintln(out, info.Version)
+	default:
+		fmt.Fprintf(out, "hello-gopher version %s\n", info.Version)
+		fmt.Fprintf(out, "Build date: %s\n", localBuildDate(cmd, info.BuildDate))
+		fmt.Fprintf(out, "Git commit: %s\n", info.GitCommit)
+		fmt.Fprintf(out, "Go version: %s\n", info.GoVersion)
+		fmt.Fprintf(out, "OS/Arch: %s/%s\n", info.OS, info.Arch)
+	}
+	return nil
+}
+
+// localBuildDate formats date, the RFC 3339 build time set by the release
+// build, in the language of greetings. Other values, such as "unknown" in
+// development builds, are returned as they are. Like the rest of version it
+// works with a broken configuration, in English.
+func localBuildDate(cmd *cobra.Command, date string) string {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+	f := locale.New(greeting.DefaultLanguage)
+	if cfg, err := peekConfig(cmd); err == nil {
+		if lf, err := resolveLocale(cmd, cfg); err == nil {
+			f = lf
+		}
+	}
+	return f.Date(t.UTC())
+}
+
+// addVersionFormatFlags registers the --json and --short flags on cmd
+func addVersionFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("json", false, "Print build metadata as JSON")
+	cmd.Flags().Bool("short", false, "Print only the version number")
+}
+
+// checkForUpdate prints an upgrade hint when a newer release exists. Network
+// and cache failures are deliberately ignored: an update check must never
+// break or slow down the command it is attached to.
+func checkForUpdate(cmd *cobra.Command, timeout time.Duration) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	logger := commandLogger(cmd)
+	checker := newUpdateChecker(cmd)
+	client := *checker.Client
+	if client.Transport == nil {
+		client.Transport = networkTransport(cmd)
+	}
+	client.Transport = &httpclient.Transport{
+		Base:    logging.Transport(logger, client.Transport),
+		Retries: networkRetries(cmd),
+	}
+	checker.Client = &client
+
+	latest, err := checker.Latest(ctx)
+	if err != nil {
+		logger.Debug("update check skipped", "error", err)
+		return
+	}
+
+	out := cmd.OutOrStdout()
+	if !update.IsNewer(version, latest.Version) {
+		if _, ok := update.Compare(version, latest.Version); ok {
+			fmt.Fprintf(out, "\nhello-gopher is up to date (latest release: %s)\n", latest.Version)
+		}
+		return
+	}
+
+	fmt.Fprintf(out, "\nA new version of hello-gopher is available: %s (you have %s)\n", latest.Version, version)
+	fmt.Fprintln(out, "Upgrade with: brew upgrade hello-gopher")
+	fmt.Fprintln(out, "          or: go install github.com/louiellywton/go-portfolio/01-hello-gopher/cmd/hello-gopher@latest")
+	if latest.URL != "" {
+		fmt.Fprintf(out, "Release notes: %s\n", latest.URL)
+	}
+}
@@ -2,8 +2,17 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/testsupport"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/update"
 
 	"github.com/spf13/cobra"
 )
@@ -30,56 +39,32 @@ func TestVersionCommand(t *testing.T) {
 				}
 			},
 		},
-		{
-			name: "version command help",
-			args: []string{"version", "--help"},
-			validate: func(t *testing.T, output string) {
-				t.Logf("Version help output: %q", output)
-				if !strings.Contains(output, "Version command displays") {
-					t.Error("Expected help text to contain version description")
-				}
-				if !strings.Contains(output, "version of hello-gopher") {
-					t.Error("Expected help text to contain version information")
-				}
-			},
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a test version command
-			testCmd := &cobra.Command{
-				Use:   "version",
-				Short: "Show version information",
-				Long: `Version command displays the current version of hello-gopher along with
-build information including build date and Git commit hash when available.
-
-This information is useful for debugging and ensuring you're running the
-expected version of the tool.`,
-				Example: `  hello-gopher version                  # Show version information`,
-				RunE:    versionCmd.RunE, // Use the same RunE function
-			}
-			
-			// Capture output
-			var buf bytes.Buffer
-			testCmd.SetOut(&buf)
-			testCmd.SetErr(&buf)
-			testCmd.SetArgs(tt.args[1:]) // Remove "version" from args
-
-			err := testCmd.Execute()
-			if err != nil {
-				t.Fatalf("Version command execution failed: %v", err)
+			stdout, stderr, code := testsupport.RunCommand(t, tt.args...)
+			if code != ExitSuccess {
+				t.Fatalf("Version command exited with %d: %s", code, stderr)
 			}
 
-			output := buf.String()
 			if tt.validate != nil {
-				tt.validate(t, output)
+				tt.validate(t, stdout)
 			}
 		})
 	}
 }
 
+func TestVersionCommandHelp(t *testing.T) {
+	stdout, stderr, code := testsupport.RunCommand(t, "version", "--help")
+	if code != ExitSuccess {
+		t.Fatalf("Help command exited with %d: %s", code, stderr)
+	}
+	testsupport.AssertGolden(t, "version-help", stdout)
+}
+
 func TestVersionCommandIntegration(t *testing.T) {
+	rootCmd := NewRootCmd(Deps{})
 	// Test that the version command is properly registered with the root command
 	found := false
 	for _, cmd := range rootCmd.Commands() {
@@ -116,11 +101,166 @@ func TestVersionVariables(t *testing.T) {
 	}
 }
 
+func TestVersionFormats(t *testing.T) {
+	original := version
+	version = "v1.2.3"
+	defer func() { version = original }()
+
+	for _, prefix := range [][]string{{"version"}, {"--version"}} {
+		name := prefix[0]
+		run := func(args ...string) (string, int) {
+			stdout, _, code := testsupport.RunCommand(t, append(prefix, args...)...)
+			return stdout, code
+		}
+
+		t.Run(name+" short", func(t *testing.T) {
+			output, code := run("--short")
+			if code != ExitSuccess {
+				t.Fatalf("exit code = %d", code)
+			}
+			if output != "v1.2.3\n" {
+				t.Errorf("--short output = %q, want %q", output, "v1.2.3\n")
+			}
+		})
+
+		t.Run(name+" json", func(t *testing.T) {
+			output, code := run("--json")
+			if code != ExitSuccess {
+				t.Fatalf("exit code = %d", code)
+			}
+			var info VersionInfo
+			if err := json.Unmarshal([]byte(output), &info); err != nil {
+				t.Fatalf("--json output is not valid JSON: %v\n%s", err, output)
+			}
+			if info != buildInfo() {
+				t.Errorf("--json output = %+v, want %+v", info, buildInfo())
+			}
+		})
+
+		t.Run(name+" json and short", func(t *testing.T) {
+			if _, code := run("--json", "--short"); code != ExitUsageError {
+				t.Errorf("expected usage error, got exit code %d", code)
+			}
+		})
+	}
+
+	t.Run("check with short", func(t *testing.T) {
+		if _, _, code := testsupport.RunCommand(t, "version", "--check", "--short"); code == ExitSuccess {
+			t.Error("expected --check with --short to fail")
+		}
+	})
+
+	t.Run("json without --version", func(t *testing.T) {
+		if _, _, code := testsupport.RunCommand(t, "--json"); code == ExitSuccess {
+			t.Error("expected --json without --version to fail")
+		}
+	})
+}
+
+// withUpdateServer points version --check at a fake GitHub API serving handler
+func withUpdateServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	original := newUpdateChecker
+	newUpdateChecker = func(*cobra.Command) *update.Checker {
+		c := update.NewChecker(filepath.Join(t.TempDir(), update.CacheFileName))
+		c.APIURL = ts.URL
+		c.Client = ts.Client()
+		return c
+	}
+	t.Cleanup(func() { newUpdateChecker = original })
+}
+
+func TestVersionCheck(t *testing.T) {
+	latest := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v1.5.0", "html_url": "https://github.com/louiellywton/go-portfolio/releases/tag/v1.5.0"}`)
+	}
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	tests := []struct {
+		name     string
+		version  string
+		handler  http.HandlerFunc
+		contains string
+		absent   string
+	}{
+		{
+			name:     "newer release available",
+			version:  "v1.4.2",
+			handler:  latest,
+			contains: "A new version of hello-gopher is available: v1.5.0 (you have v1.4.2)",
+		},
+		{
+			name:     "up to date",
+			version:  "v1.5.0",
+			handler:  latest,
+			contains: "hello-gopher is up to date",
+			absent:   "new version",
+		},
+		{
+			name:    "development build",
+			version: "dev",
+			handler: latest,
+			absent:  "v1.5.0",
+		},
+		{
+			name:    "GitHub unavailable",
+			version: "v1.4.2",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "rate limited", http.StatusForbidden)
+			},
+			absent: "new version",
+		},
+		{
+			name:    "timeout",
+			version: "v1.4.2",
+			handler: slow,
+			absent:  "new version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withUpdateServer(t, tt.handler)
+
+			original := version
+			version = tt.version
+			defer func() { version = original }()
+
+			start := time.Now()
+			output, stderr, code := testsupport.RunCommand(t, "version", "--check", "--timeout", "100ms")
+			if code != ExitSuccess {
+				t.Fatalf("version --check should never fail, got exit code %d: %s", code, stderr)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("version --check took %s despite --timeout", elapsed)
+			}
+
+			if !strings.Contains(output, "hello-gopher version "+tt.version) {
+				t.Errorf("output should still contain the version, got %q", output)
+			}
+			if tt.contains != "" && !strings.Contains(output, tt.contains) {
+				t.Errorf("output should contain %q, got %q", tt.contains, output)
+			}
+			if tt.absent != "" && strings.Contains(output, tt.absent) {
+				t.Errorf("output should not contain %q, got %q", tt.absent, output)
+			}
+		})
+	}
+}
+
 // BenchmarkVersionCommand benchmarks version command execution
 func BenchmarkVersionCommand(b *testing.B) {
 	testCmd := &cobra.Command{
 		Use:  "version",
-		RunE: versionCmd.RunE,
+		RunE: newVersionCmd().RunE,
 	}
 	
 	for i := 0; i < b.N; i++ {
@@ -134,4 +274,28 @@ func BenchmarkVersionCommand(b *testing.B) {
 			b.Fatalf("Version command benchmark failed: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestVersionBuildDateLocale(t *testing.T) {
+	original := buildDate
+	buildDate = "2026-11-10T08:30:00Z"
+	t.Cleanup(func() { buildDate = original })
+
+	tests := map[string]string{"": "Build date: November 10, 2026", "de": "Build date: 10. November 2026"}
+	for lang, want := range tests {
+		vars := map[string]string{"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml")}
+		if lang != "" {
+			vars["HELLO_GOPHER_LANG"] = lang
+		}
+		withEnv(t, vars)
+		stdout, stderr, code := testsupport.RunCommand(t, "version")
+		if code != ExitSuccess || !strings.Contains(stdout, want) {
+			t.Errorf("version with language %q = %q, %q; want %q", lang, stdout, stderr, want)
+		}
+	}
+
+	// A broken language setting doesn't break version
+	withEnv(t, map[string]string{"HELLO_GOPHER_CONFIG": filepath.Join(t.TempDir(), "missing.yaml"), "HELLO_GOPHER_LANG": "xx"})
+	if stdout, _, code := testsupport.RunCommand(t, "--version"); code != ExitSuccess || !strings.Contains(stdout, "November 10, 2026") {
+		t.Errorf("--version with an invalid language = %q (exit %d)", stdout, code)
+	}
+}
@@ -1,7 +1,13 @@
 package main
 
-import "github.com/louiellywton/go-portfolio/01-hello-gopher/cmd/hello-gopher/cmd"
+import (
+	// Embed the time zone database for --tz: the Docker image is built
+	// from scratch and Windows has no zoneinfo files
+	_ "time/tzdata"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/cmd/hello-gopher/cmd"
+)
 
 func main() {
 	cmd.Execute()
-}
\ No newline at end of file
+}
@@ -0,0 +1,119 @@
+// Package art provides the embedded ASCII-art gophers and helpers to lay
+// them out next to text.
+package art
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+//go:embed gophers/*.txt
+var assets embed.FS
+
+// assetDir is the embedded directory holding one .txt file per variant
+const assetDir = "gophers"
+
+// DefaultVariant is the art shown when no variant is requested
+const DefaultVariant = "classic"
+
+// Art is a multi-line ASCII-art picture
+type Art struct {
+	Name  string
+	Lines []string
+	// Width is the display width of the widest line
+	Width int
+}
+
+// Variants returns the names of every embedded art variant in sorted order
+func Variants() []string {
+	entries, err := fs.ReadDir(assets, assetDir)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".txt"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the named art variant
+func Load(name string) (Art, error) {
+	if name == "" {
+		name = DefaultVariant
+	}
+	data, err := assets.ReadFile(path.Join(assetDir, name+".txt"))
+	if err != nil {
+		return Art{}, fmt.Errorf("unknown art variant %q (available: %s)", name, strings.Join(Variants(), ", "))
+	}
+	return Parse(name, string(data)), nil
+}
+
+// Parse builds an Art from raw text, dropping trailing blank lines
+func Parse(name, text string) Art {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	a := Art{Name: name, Lines: make([]string, len(lines))}
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		a.Lines[i] = line
+		if w := displayWidth(line); w > a.Width {
+			a.Width = w
+		}
+	}
+	return a
+}
+
+// String returns the art as newline-terminated text
+func (a Art) String() string {
+	return strings.Join(a.Lines, "\n") + "\n"
+}
+
+// Beside renders text to the right of the art, separated by gap columns.
+// The text block is vertically centered against the art; art lines are
+// padded to a common width so the text column stays aligned.
+func Beside(a Art, text string, gap int) string {
+	textLines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	height := len(a.Lines)
+	if len(textLines) > height {
+		height = len(textLines)
+	}
+	artTop := (height - len(a.Lines)) / 2
+	textTop := (height - len(textLines)) / 2
+
+	var b strings.Builder
+	for row := 0; row < height; row++ {
+		artLine := ""
+		if i := row - artTop; i >= 0 && i < len(a.Lines) {
+			artLine = a.Lines[i]
+		}
+		textLine := ""
+		if i := row - textTop; i >= 0 && i < len(textLines) {
+			textLine = textLines[i]
+		}
+
+		line := artLine
+		if textLine != "" {
+			line += strings.Repeat(" ", a.Width-displayWidth(artLine)+gap) + textLine
+		}
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// displayWidth returns the number of terminal columns used by s
+func displayWidth(s string) int {
+	return textwidth.String(s)
+}
@@ -0,0 +1,83 @@
+package art
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVariantsLoad(t *testing.T) {
+	variants := Variants()
+	if len(variants) < 3 {
+		t.Fatalf("Expected several art variants, got %v", variants)
+	}
+
+	for _, name := range variants {
+		a, err := Load(name)
+		if err != nil {
+			t.Errorf("Load(%q) unexpected error: %v", name, err)
+			continue
+		}
+		if len(a.Lines) == 0 || a.Width == 0 {
+			t.Errorf("Load(%q) returned empty art", name)
+		}
+		for i, line := range a.Lines {
+			if strings.TrimRight(line, " ") != line {
+				t.Errorf("%s line %d has trailing spaces", name, i)
+			}
+		}
+	}
+}
+
+func TestLoadDefaultAndUnknown(t *testing.T) {
+	a, err := Load("")
+	if err != nil || a.Name != DefaultVariant {
+		t.Errorf("Load(\"\") = %q, %v; want default variant", a.Name, err)
+	}
+	if _, err := Load("does-not-exist"); err == nil {
+		t.Error("Load() of unknown variant expected error")
+	}
+}
+
+func TestBeside(t *testing.T) {
+	a := Parse("test", "ab\nabcd\na\r\n\n")
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "single line text is vertically centered",
+			text: "Hi!",
+			want: "ab\nabcd  Hi!\na\n",
+		},
+		{
+			name: "text taller than art centers the art",
+			text: "1\n2\n3\n4\n5",
+			want: "      1\nab    2\nabcd  3\na     4\n      5\n",
+		},
+		{
+			name: "multi-line text aligns to one column",
+			text: "one\ntwo",
+			want: "ab    one\nabcd  two\na\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Beside(a, tt.text, 2); got != tt.want {
+				t.Errorf("Beside() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWidthCountsRunes(t *testing.T) {
+	a := Parse("unicode", "ʕ◔ϖ◔ʔ\nab")
+	if a.Width != 5 {
+		t.Errorf("Width = %d, want 5", a.Width)
+	}
+	if got := a.String(); got != "ʕ◔ϖ◔ʔ\nab\n" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package art
+
+import (
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+// DefaultBubbleWidth is the default maximum text width inside a speech bubble
+const DefaultBubbleWidth = 40
+
+// tailIndent is how far the bubble's tail is indented above the art
+const tailIndent = 6
+
+// Bubble renders text word-wrapped to width columns inside a cowsay-style
+// speech bubble. Widths are measured in terminal columns, so accented
+// letters, wide characters and emoji keep the frame aligned.
+func Bubble(text string, width int) []string {
+	lines := textwidth.Wrap(strings.TrimRight(text, "\n"), width)
+
+	inner := 0
+	for _, line := range lines {
+		if w := textwidth.String(line); w > inner {
+			inner = w
+		}
+	}
+
+	out := make([]string, 0, len(lines)+2)
+	out = append(out, " "+strings.Repeat("_", inner+2))
+	for i, line := range lines {
+		left, right := bubbleBorders(i, len(lines))
+		out = append(out, left+" "+textwidth.Pad(line, inner)+" "+right)
+	}
+	out = append(out, " "+strings.Repeat("-", inner+2))
+	return out
+}
+
+// bubbleBorders returns the left and right border characters for line i of n
+func bubbleBorders(i, n int) (string, string) {
+	switch {
+	case n == 1:
+		return "<", ">"
+	case i == 0:
+		return "/", "\\"
+	case i == n-1:
+		return "\\", "/"
+	default:
+		return "|", "|"
+	}
+}
+
+// Say renders the art with a speech bubble containing text above it,
+// connected by a short tail
+func Say(a Art, text string, width int) string {
+	var b strings.Builder
+	for _, line := range Bubble(text, width) {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	for i := 0; i < 2; i++ {
+		b.WriteString(strings.Repeat(" ", tailIndent+i))
+		b.WriteString("\\\n")
+	}
+	b.WriteString(a.String())
+	return b.String()
+}
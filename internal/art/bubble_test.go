@@ -0,0 +1,75 @@
+package art
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+func TestBubbleSingleLine(t *testing.T) {
+	got := strings.Join(Bubble("Hello, Gopher!", 40), "\n")
+	want := strings.Join([]string{
+		" ________________",
+		"< Hello, Gopher! >",
+		" ----------------",
+	}, "\n")
+	if got != want {
+		t.Errorf("Bubble() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBubbleMultiLine(t *testing.T) {
+	got := Bubble("Clear is better than clever and simple beats complex.", 12)
+	want := []string{
+		" ______________",
+		"/ Clear is     \\",
+		"| better than  |",
+		"| clever and   |",
+		"| simple beats |",
+		"\\ complex.     /",
+		" --------------",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Bubble() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestBubbleFrameAlignmentWithUnicode(t *testing.T) {
+	names := []string{"José", "山田太郎", "Zoë 🎉", "\x1b[1;36mAna\x1b[0m", "Dev \U0001F469\u200d\U0001F4BB", "\U0001F44B\U0001F3FD \u2068\u05e9\u05e8\u05d4\u2069"}
+
+	for _, name := range names {
+		lines := Bubble("Hello, "+name+"! Welcome to the gopher party.", 16)
+		want := textwidth.String(lines[1])
+		for i, line := range lines[1 : len(lines)-1] {
+			if got := textwidth.String(line); got != want {
+				t.Errorf("%q: line %d has width %d, want %d:\n%s", name, i+1, got, want, strings.Join(lines, "\n"))
+			}
+		}
+		if got := textwidth.String(lines[0]); got != want-1 {
+			t.Errorf("%q: top border width %d, want %d", name, got, want-1)
+		}
+	}
+}
+
+func TestBubbleEmojiSequence(t *testing.T) {
+	// The ZWJ sequence is drawn as one two-column emoji
+	got := strings.Join(Bubble("Hi \U0001F469\u200d\U0001F4BB", 40), "\n")
+	want := strings.Join([]string{
+		" _______",
+		"< Hi \U0001F469\u200d\U0001F4BB >",
+		" -------",
+	}, "\n")
+	if got != want {
+		t.Errorf("Bubble() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSay(t *testing.T) {
+	a := Parse("test", "(o_o)")
+	got := Say(a, "Hi", 10)
+	want := " ____\n< Hi >\n ----\n      \\\n       \\\n(o_o)\n"
+	if got != want {
+		t.Errorf("Say() =\n%q\nwant\n%q", got, want)
+	}
+}
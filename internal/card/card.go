@@ -0,0 +1,188 @@
+// Package card renders greeting cards for team celebrations: a bordered
+// card with a title, a gopher and a message, as text for terminals and as
+// SVG or PNG images for chat bots.
+package card
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/art"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+// DefaultWidth is the default width of the inside of a card in columns
+const DefaultWidth = 44
+
+// Occasion is something a card celebrates
+type Occasion struct {
+	Name  string
+	Title string
+	// Message is the wish on the card, with %s for the name
+	Message string
+	// Decoration frames the title
+	Decoration string
+}
+
+// occasions lists the supported occasions in display order
+var occasions = []Occasion{
+	{"birthday", "Happy Birthday", "Happy birthday, %s! May your builds be fast and your tests green.", "*"},
+	{"anniversary", "Happy Anniversary", "Happy work anniversary, %s! Thank you for another year of great code.", "+"},
+	{"welcome", "Welcome Aboard", "Welcome to the team, %s! We're glad to have you with us.", "~"},
+	{"farewell", "Farewell", "Goodbye and good luck, %s! It was a pleasure shipping with you.", "~"},
+	{"congrats", "Congratulations", "Congratulations, %s! Well deserved.", "*"},
+	{"thanks", "Thank You", "Thank you, %s! Your help made all the difference.", "+"},
+}
+
+// Occasions returns the names of the supported occasions
+func Occasions() []string {
+	names := make([]string, len(occasions))
+	for i, o := range occasions {
+		names[i] = o.Name
+	}
+	return names
+}
+
+// ParseOccasion returns the occasion called name
+func ParseOccasion(name string) (Occasion, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, o := range occasions {
+		if o.Name == name {
+			return o, nil
+		}
+	}
+	return Occasion{}, fmt.Errorf("unknown occasion %q (available: %s)", name, strings.Join(Occasions(), ", "))
+}
+
+// Card is a greeting card for Name
+type Card struct {
+	Occasion Occasion
+	Name     string
+	// From signs the card when it isn't empty
+	From string
+	Art  art.Art
+	// Width is the width of the inside of the card, DefaultWidth if 0. The
+	// card grows to fit the title and the art.
+	Width int
+}
+
+// rowKind is the part of a card a row belongs to, which decides its color
+type rowKind int
+
+const (
+	rowBorder rowKind = iota
+	rowTitle
+	rowArt
+	rowMessage
+	rowBlank
+)
+
+// row is a line of a card without its border
+type row struct {
+	kind rowKind
+	text string
+}
+
+// Style colors the parts of a card rendered as text. A nil function leaves
+// its part plain.
+type Style struct {
+	Border  func(string) string
+	Title   func(string) string
+	Message func(string) string
+}
+
+// paint applies f to s if f is set
+func paint(f func(string) string, s string) string {
+	if f == nil {
+		return s
+	}
+	return f(s)
+}
+
+// width returns the width of the inside of the card
+func (c Card) width() int {
+	width := c.Width
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	return max(width, c.Art.Width, textwidth.String(c.title()))
+}
+
+// title returns the title line framed by the decoration of the occasion
+func (c Card) title() string {
+	deco := strings.TrimSpace(strings.Repeat(c.Occasion.Decoration+" ", 3))
+	return deco + "  " + strings.ToUpper(c.Occasion.Title) + "  " + deco
+}
+
+// rows lays out the inside of the card, each row padded to its width
+func (c Card) rows() []row {
+	width := c.width()
+	center := func(s string) string {
+		left := (width - textwidth.String(s)) / 2
+		return textwidth.Pad(strings.Repeat(" ", max(left, 0))+s, width)
+	}
+	blank := row{rowBlank, strings.Repeat(" ", width)}
+
+	rows := []row{blank, {rowTitle, center(c.title())}, blank}
+	if len(c.Art.Lines) > 0 {
+		// Center the art as a block so its lines stay aligned
+		left := strings.Repeat(" ", (width-c.Art.Width)/2)
+		for _, line := range c.Art.Lines {
+			rows = append(rows, row{rowArt, textwidth.Pad(left+line, width)})
+		}
+		rows = append(rows, blank)
+	}
+	for _, line := range textwidth.Wrap(fmt.Sprintf(c.Occasion.Message, c.Name), width-4) {
+		rows = append(rows, row{rowMessage, center(line)})
+	}
+	if c.From != "" {
+		sign := "- " + c.From
+		rows = append(rows, blank, row{rowMessage, textwidth.Pad(strings.Repeat(" ", max(width-textwidth.String(sign)-2, 0))+sign, width)})
+	}
+	return append(rows, blank)
+}
+
+// Render returns the card as text with a border, colored by style
+func (c Card) Render(style Style) string {
+	rows := c.rows()
+	edge := paint(style.Border, "+"+strings.Repeat("-", c.width()+2)+"+")
+	side := paint(style.Border, "|")
+
+	var b strings.Builder
+	b.WriteString(edge + "\n")
+	for _, r := range rows {
+		text := r.text
+		switch r.kind {
+		case rowTitle:
+			text = paint(style.Title, text)
+		case rowMessage:
+			text = paint(style.Message, text)
+		}
+		b.WriteString(side + " " + text + " " + side + "\n")
+	}
+	b.WriteString(edge + "\n")
+	return b.String()
+}
+
+// Plain returns the card as plain sentences without border, art or
+// decoration, for screen readers
+func (c Card) Plain() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s card for %s.\n", c.Occasion.Title, c.Name)
+	fmt.Fprintf(&b, c.Occasion.Message+"\n", c.Name)
+	if c.From != "" {
+		fmt.Fprintf(&b, "From %s.\n", c.From)
+	}
+	return b.String()
+}
+
+// lines returns the card as plain text lines together with the kind of
+// each, the border rows included
+func (c Card) lines() []row {
+	edge := row{rowBorder, "+" + strings.Repeat("-", c.width()+2) + "+"}
+	lines := []row{edge}
+	for _, r := range c.rows() {
+		lines = append(lines, row{r.kind, "| " + r.text + " |"})
+	}
+	return append(lines, edge)
+}
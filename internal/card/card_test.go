@@ -0,0 +1,154 @@
+package card
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/art"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+func testCard(t *testing.T, name string) Card {
+	t.Helper()
+	occasion, err := ParseOccasion("birthday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := art.Load(art.DefaultVariant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Card{Occasion: occasion, Name: name, From: "The Team", Art: a}
+}
+
+func TestRender(t *testing.T) {
+	for _, name := range []string{"Alice", "José", "世界", "A Very Long Name That Needs Wrapping On The Card"} {
+		text := testCard(t, name).Render(Style{})
+		lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+		want := DefaultWidth + 4
+		for i, line := range lines {
+			if w := textwidth.String(line); w != want {
+				t.Errorf("%s: line %d is %d columns wide, want %d: %q", name, i, w, want, line)
+			}
+		}
+		if !strings.HasPrefix(lines[0], "+-") || lines[0] != lines[len(lines)-1] {
+			t.Errorf("%s: card isn't framed by a border:\n%s", name, text)
+		}
+		for _, want := range []string{"HAPPY BIRTHDAY", "- The Team"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("%s: card doesn't contain %q:\n%s", name, want, text)
+			}
+		}
+	}
+
+	text := testCard(t, "Alice").Render(Style{})
+	if !strings.Contains(text, "Happy birthday, Alice!") {
+		t.Errorf("card doesn't contain the message:\n%s", text)
+	}
+}
+
+func TestRenderStyleAndWidth(t *testing.T) {
+	c := testCard(t, "Alice")
+	c.Width = 1
+	mark := func(s string) string { return "<" + s + ">" }
+	text := c.Render(Style{Title: mark})
+	if !strings.Contains(text, "<") || strings.Count(text, "<") != 1 {
+		t.Errorf("Title style wasn't applied once:\n%s", text)
+	}
+
+	// The card grows to fit the art and the title
+	plain := c.Render(Style{})
+	first := strings.SplitN(plain, "\n", 2)[0]
+	if w := textwidth.String(first); w < c.Art.Width+4 {
+		t.Errorf("card is %d columns wide, narrower than its art", w)
+	}
+}
+
+func TestParseOccasion(t *testing.T) {
+	for _, name := range Occasions() {
+		o, err := ParseOccasion(strings.ToUpper(name))
+		if err != nil || o.Name != name {
+			t.Errorf("ParseOccasion(%q) = %q, %v", name, o.Name, err)
+		}
+		if !strings.Contains(o.Message, "%s") {
+			t.Errorf("message of %s has no place for the name", name)
+		}
+	}
+	if _, err := ParseOccasion("wedding"); err == nil || !strings.Contains(err.Error(), "birthday") {
+		t.Errorf("ParseOccasion(wedding) error = %v, want one listing the occasions", err)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]string{"card.png": "png", "out/Card.SVG": "svg"}
+	for path, want := range tests {
+		if got, err := FormatOf(path); err != nil || got != want {
+			t.Errorf("FormatOf(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	for _, path := range []string{"card.jpg", "card"} {
+		if _, err := FormatOf(path); err == nil {
+			t.Errorf("FormatOf(%q) expected error", path)
+		}
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	c := testCard(t, "José")
+	var buf bytes.Buffer
+	if err := c.WriteImage(&buf, "png"); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("WritePNG wrote an invalid PNG: %v", err)
+	}
+	lines := c.lines()
+	bounds := img.Bounds()
+	if want := (len(lines) + 2*margin) * cellHeight * pixelScale; bounds.Dy() != want {
+		t.Errorf("image is %d pixels high, want %d", bounds.Dy(), want)
+	}
+
+	// The top left corner of the border is drawn in the border color
+	x, y := (margin*cellWidth+glyphWidth/2)*pixelScale, (margin*cellHeight+glyphHeight/2)*pixelScale
+	r, g, b, _ := img.At(x, y).RGBA()
+	want := palette[rowBorder]
+	if uint8(r>>8) != want.R || uint8(g>>8) != want.G || uint8(b>>8) != want.B {
+		t.Errorf("corner pixel = %v, want the border color %v", img.At(x, y), want)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	c := testCard(t, "<Alice & Bob>")
+	var buf bytes.Buffer
+	if err := c.WriteImage(&buf, "svg"); err != nil {
+		t.Fatal(err)
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err != io.EOF {
+				t.Fatalf("WriteSVG wrote invalid XML: %v\n%s", err, buf.String())
+			}
+			break
+		}
+	}
+	if !strings.Contains(buf.String(), "&lt;Alice &amp; Bob&gt;") {
+		t.Errorf("SVG doesn't contain the escaped name:\n%s", buf.String())
+	}
+	if err := c.WriteImage(&buf, "gif"); err == nil {
+		t.Error("WriteImage(gif) expected error")
+	}
+}
+
+func TestPlain(t *testing.T) {
+	got := testCard(t, "Alice").Plain()
+	want := "Happy Birthday card for Alice.\nHappy birthday, Alice! May your builds be fast and your tests green.\nFrom The Team.\n"
+	if got != want {
+		t.Errorf("Plain() = %q, want %q", got, want)
+	}
+}
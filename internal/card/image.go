@@ -0,0 +1,175 @@
+package card
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+const (
+	// glyphWidth and glyphHeight are the size of a character of the pixel
+	// font; cells add a column and two rows of spacing
+	glyphWidth  = 5
+	glyphHeight = 7
+	cellWidth   = glyphWidth + 1
+	cellHeight  = glyphHeight + 2
+	// pixelScale is the size of a font pixel in the PNG image
+	pixelScale = 3
+	// margin is the space around the card in cells
+	margin = 2
+
+	// svgFontSize is the font size of SVG images in pixels; monospace
+	// characters are about 0.6 of it wide
+	svgFontSize   = 16
+	svgCellWidth  = svgFontSize * 0.6
+	svgCellHeight = svgFontSize * 1.25
+)
+
+// Formats lists the image formats cards can be written in
+var Formats = []string{"png", "svg"}
+
+// FormatOf returns the image format for path from its extension
+func FormatOf(path string) (string, error) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	for _, f := range Formats {
+		if ext == f {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported image format %q (use a .png or .svg file)", ext)
+}
+
+// palette holds the colors of the parts of a card image
+var palette = map[rowKind]color.RGBA{
+	rowBorder:  {0x00, 0xAD, 0xD8, 0xFF}, // Go blue
+	rowTitle:   {0xCE, 0x32, 0x62, 0xFF}, // Go fuchsia
+	rowArt:     {0x55, 0x55, 0x55, 0xFF},
+	rowMessage: {0x20, 0x20, 0x20, 0xFF},
+	rowBlank:   {0x20, 0x20, 0x20, 0xFF},
+}
+
+// background is the paper color of card images
+var background = color.RGBA{0xFF, 0xFA, 0xF0, 0xFF}
+
+// WriteImage writes the card as an image in format, png or svg
+func (c Card) WriteImage(w io.Writer, format string) error {
+	switch format {
+	case "png":
+		return c.WritePNG(w)
+	case "svg":
+		return c.WriteSVG(w)
+	default:
+		return fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// cellColor returns the color of column col of a line of kind with n
+// columns: the sides of the border are drawn in its color
+func cellColor(kind rowKind, col, n int) color.RGBA {
+	if col == 0 || col == n-1 {
+		return palette[rowBorder]
+	}
+	return palette[kind]
+}
+
+// WritePNG writes the card as a PNG image drawn with a built-in pixel font.
+// Characters the font lacks are drawn as '?'.
+func (c Card) WritePNG(w io.Writer) error {
+	lines := c.lines()
+	cols := textwidth.String(lines[0].text)
+	img := image.NewRGBA(image.Rect(0, 0,
+		(cols+2*margin)*cellWidth*pixelScale,
+		(len(lines)+2*margin)*cellHeight*pixelScale))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for y, line := range lines {
+		col := 0
+		for _, r := range line.text {
+			width := textwidth.RuneWidth(r)
+			if width == 0 {
+				continue
+			}
+			drawGlyph(img, (margin+col)*cellWidth, (margin+y)*cellHeight, glyph(r), cellColor(line.kind, col, cols))
+			col += width
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// glyph returns the pixels of r in the font, with ASCII look-alikes for
+// common punctuation
+func glyph(r rune) [glyphHeight]byte {
+	switch r {
+	case '—', '–':
+		r = '-'
+	case '‘', '’':
+		r = '\''
+	case '“', '”':
+		r = '"'
+	}
+	if r < ' ' || r > '~' {
+		r = '?'
+	}
+	return glyphs[r-' ']
+}
+
+// drawGlyph draws g with its top left corner at the font pixel x, y
+func drawGlyph(img *image.RGBA, x, y int, g [glyphHeight]byte, c color.RGBA) {
+	for row, bits := range g {
+		for col := 0; col < glyphWidth; col++ {
+			if bits&(1<<(glyphWidth-1-col)) == 0 {
+				continue
+			}
+			for dy := 0; dy < pixelScale; dy++ {
+				for dx := 0; dx < pixelScale; dx++ {
+					img.SetRGBA((x+col)*pixelScale+dx, (y+row)*pixelScale+dy, c)
+				}
+			}
+		}
+	}
+}
+
+// WriteSVG writes the card as an SVG image with a line of monospace text
+// per line of the card
+func (c Card) WriteSVG(w io.Writer) error {
+	lines := c.lines()
+	cols := textwidth.String(lines[0].text)
+	width := float64(cols+2*margin) * svgCellWidth
+	height := float64(len(lines)+2*margin) * svgCellHeight
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n", width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(background))
+	fmt.Fprintf(bw, `<g font-family="ui-monospace, Menlo, Consolas, monospace" font-size="%d" xml:space="preserve">`+"\n", svgFontSize)
+	for i, line := range lines {
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" textLength="%.1f" fill="%s">`,
+			margin*svgCellWidth, (float64(margin+i)+0.8)*svgCellHeight, float64(cols)*svgCellWidth, hexColor(palette[line.kind]))
+		if line.kind == rowBorder {
+			xml.EscapeText(bw, []byte(line.text))
+		} else {
+			// The sides belong to the border
+			inner := strings.TrimSuffix(strings.TrimPrefix(line.text, "|"), "|")
+			side := fmt.Sprintf(`<tspan fill="%s">|</tspan>`, hexColor(palette[rowBorder]))
+			bw.WriteString(side)
+			xml.EscapeText(bw, []byte(inner))
+			bw.WriteString(side)
+		}
+		bw.WriteString("</text>\n")
+	}
+	bw.WriteString("</g>\n</svg>\n")
+	return bw.Flush()
+}
+
+// hexColor returns c in the #rrggbb notation
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,178 @@
+// Package color adds ANSI styling to terminal output.
+//
+// Whether color is used is decided by a Mode (auto, always or never). In auto
+// mode color is enabled only when the output is a terminal, $NO_COLOR is
+// unset (see https://no-color.org) and $TERM is not "dumb". On Windows the
+// console's virtual terminal processing is switched on when needed.
+package color
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Mode controls when color is used
+type Mode string
+
+// Supported color modes
+const (
+	Auto   Mode = "auto"
+	Always Mode = "always"
+	Never  Mode = "never"
+)
+
+// ParseMode validates a color mode name
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case Auto, Always, Never:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unsupported color mode %q (supported: auto, always, never)", s)
+	}
+}
+
+// Detector decides whether a writer should receive colored output. Its
+// fields are injectable so the auto mode can be tested without a terminal.
+type Detector struct {
+	// LookupEnv reads environment variables, typically os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+	// IsTerminal reports whether w is an interactive terminal
+	IsTerminal func(w io.Writer) bool
+	// EnableVT prepares w for ANSI sequences; it reports false if that is impossible
+	EnableVT func(w io.Writer) bool
+}
+
+// DefaultDetector inspects the real process environment and file descriptors
+func DefaultDetector() Detector {
+	return Detector{
+		LookupEnv:  os.LookupEnv,
+		IsTerminal: IsTerminal,
+		EnableVT:   enableVirtualTerminal,
+	}
+}
+
+// Enabled reports whether output written to w should be colored in mode
+func (d Detector) Enabled(mode Mode, w io.Writer) bool {
+	switch mode {
+	case Always:
+		return true
+	case Never:
+		return false
+	}
+
+	if v, ok := d.lookup("NO_COLOR"); ok && v != "" {
+		return false
+	}
+	if v, _ := d.lookup("TERM"); v == "dumb" {
+		return false
+	}
+	if d.IsTerminal == nil || !d.IsTerminal(w) {
+		return false
+	}
+	if d.EnableVT != nil && !d.EnableVT(w) {
+		return false
+	}
+	return true
+}
+
+// UTF8 reports whether the terminal encoding is UTF-8, so that emoji and
+// other non-ASCII symbols display correctly. The encoding comes from the
+// first set variable of $LC_ALL, $LC_CTYPE and $LANG, as in the C library;
+// Windows consoles always accept UTF-8 from Go programs.
+func (d Detector) UTF8() bool {
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v, _ := d.lookup(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// lookup reads an environment variable, tolerating a nil LookupEnv
+func (d Detector) lookup(key string) (string, bool) {
+	if d.LookupEnv == nil {
+		return "", false
+	}
+	return d.LookupEnv(key)
+}
+
+// IsTerminal reports whether w is a character device such as an interactive terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Code is an SGR (Select Graphic Rendition) parameter
+type Code int
+
+// Supported SGR codes
+const (
+	Reset   Code = 0
+	Bold    Code = 1
+	Dim     Code = 2
+	Italic  Code = 3
+	Red     Code = 31
+	Green   Code = 32
+	Yellow  Code = 33
+	Blue    Code = 34
+	Magenta Code = 35
+	Cyan    Code = 36
+)
+
+// Styler applies styles when enabled and returns text unchanged otherwise.
+// The zero value is a disabled Styler.
+type Styler struct {
+	enabled bool
+}
+
+// NewStyler returns a Styler that emits escape sequences only if enabled is true
+func NewStyler(enabled bool) Styler {
+	return Styler{enabled: enabled}
+}
+
+// Enabled reports whether the styler emits escape sequences
+func (s Styler) Enabled() bool {
+	return s.enabled
+}
+
+// Paint wraps text in the escape sequences for codes
+func (s Styler) Paint(text string, codes ...Code) string {
+	if !s.enabled || len(codes) == 0 || text == "" {
+		return text
+	}
+	params := make([]string, len(codes))
+	for i, c := range codes {
+		params[i] = strconv.Itoa(int(c))
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m" + text + "\x1b[0m"
+}
+
+// Highlight emphasizes important text such as names
+func (s Styler) Highlight(text string) string {
+	return s.Paint(text, Bold, Cyan)
+}
+
+// Quote styles quoted text such as proverbs
+func (s Styler) Quote(text string) string {
+	return s.Paint(text, Italic, Yellow)
+}
+
+// Muted de-emphasizes secondary text such as IDs
+func (s Styler) Muted(text string) string {
+	return s.Paint(text, Dim)
+}
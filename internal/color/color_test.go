@@ -0,0 +1,189 @@
+package color
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Mode
+		wantErr bool
+	}{
+		{"auto", Auto, false},
+		{"ALWAYS", Always, false},
+		{" never ", Never, false},
+		{"sometimes", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMode(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMode(%q) = %q, %v; want %q, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDetectorEnabled(t *testing.T) {
+	terminal := func(io.Writer) bool { return true }
+	notTerminal := func(io.Writer) bool { return false }
+	env := func(vars map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			v, ok := vars[key]
+			return v, ok
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mode     Mode
+		detector Detector
+		want     bool
+	}{
+		{"always ignores terminal", Always, Detector{IsTerminal: notTerminal}, true},
+		{"always ignores NO_COLOR", Always, Detector{LookupEnv: env(map[string]string{"NO_COLOR": "1"})}, true},
+		{"never ignores terminal", Never, Detector{IsTerminal: terminal}, false},
+		{"auto on terminal", Auto, Detector{IsTerminal: terminal}, true},
+		{"auto without terminal", Auto, Detector{IsTerminal: notTerminal}, false},
+		{"auto with nil terminal check", Auto, Detector{}, false},
+		{"auto with NO_COLOR", Auto, Detector{IsTerminal: terminal, LookupEnv: env(map[string]string{"NO_COLOR": "1"})}, false},
+		{"auto with empty NO_COLOR", Auto, Detector{IsTerminal: terminal, LookupEnv: env(map[string]string{"NO_COLOR": ""})}, true},
+		{"auto with dumb terminal", Auto, Detector{IsTerminal: terminal, LookupEnv: env(map[string]string{"TERM": "dumb"})}, false},
+		{"auto when VT cannot be enabled", Auto, Detector{IsTerminal: terminal, EnableVT: func(io.Writer) bool { return false }}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.detector.Enabled(tt.mode, &bytes.Buffer{}); got != tt.want {
+				t.Errorf("Enabled(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Error("IsTerminal(bytes.Buffer) = true, want false")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if IsTerminal(f) {
+		t.Error("IsTerminal(regular file) = true, want false")
+	}
+}
+
+func TestStyler(t *testing.T) {
+	enabled := NewStyler(true)
+	if got, want := enabled.Paint("hi", Bold, Cyan), "\x1b[1;36mhi\x1b[0m"; got != want {
+		t.Errorf("Paint() = %q, want %q", got, want)
+	}
+	if got := enabled.Paint("", Bold); got != "" {
+		t.Errorf("Paint(\"\") = %q, want empty", got)
+	}
+	if got := enabled.Paint("plain"); got != "plain" {
+		t.Errorf("Paint() without codes = %q, want %q", got, "plain")
+	}
+
+	var disabled Styler
+	for _, got := range []string{disabled.Highlight("x"), disabled.Quote("x"), disabled.Muted("x")} {
+		if got != "x" {
+			t.Errorf("disabled styler modified text: %q", got)
+		}
+	}
+	if disabled.Enabled() || !enabled.Enabled() {
+		t.Error("Enabled() does not reflect construction")
+	}
+}
+
+func TestRainbow(t *testing.T) {
+	if got := NewStyler(false).Rainbow("Hello", 0); got != "Hello" {
+		t.Errorf("disabled Rainbow() = %q, want text unchanged", got)
+	}
+
+	s := NewStyler(true)
+	got := s.Rainbow("Hi there\nGo", 0)
+	want := "\x1b[38;5;" + strconv.Itoa(rainbowColor(0)) + "mH" +
+		"\x1b[38;5;" + strconv.Itoa(rainbowColor(1)) + "mi" +
+		" " +
+		"\x1b[38;5;" + strconv.Itoa(rainbowColor(3)) + "mt"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("Rainbow() = %q, want prefix %q", got, want)
+	}
+	// The second line starts further along the gradient
+	if secondLine := "\n\x1b[38;5;" + strconv.Itoa(rainbowColor(rainbowSpread)) + "mG"; !strings.Contains(got, secondLine) {
+		t.Errorf("Rainbow() = %q, want second line to start with %q", got, secondLine)
+	}
+	if !strings.HasSuffix(got, "\x1b[0m") || !strings.Contains(got, "\x1b[0m\n") {
+		t.Errorf("Rainbow() = %q, want a reset at the end of every line", got)
+	}
+	if got := s.Rainbow("Go\n", 0); strings.HasSuffix(got, "\n\x1b[0m") {
+		t.Errorf("Rainbow() = %q, want no reset after the final line break", got)
+	}
+
+	if s.Rainbow("Go", 1) != s.Rainbow("\nGo", 0)[1:] {
+		t.Error("Rainbow() should continue the gradient from firstLine")
+	}
+
+	// Existing escape sequences are kept intact
+	highlighted := s.Highlight("Ana")
+	if got := s.Rainbow(highlighted, 0); !strings.Contains(got, "\x1b[1;36m") || !strings.Contains(got, "\x1b[0m\x1b[0m") {
+		t.Errorf("Rainbow() = %q, want the highlight sequences kept", got)
+	}
+}
+
+func TestRainbowColorStaysInCube(t *testing.T) {
+	seen := make(map[int]bool)
+	for n := 0; n < 100; n++ {
+		c := rainbowColor(n)
+		if c < 16 || c > 231 {
+			t.Fatalf("rainbowColor(%d) = %d, outside the 6x6x6 color cube", n, c)
+		}
+		seen[c] = true
+	}
+	if len(seen) < 10 {
+		t.Errorf("gradient uses only %d colors", len(seen))
+	}
+}
+
+func TestDetectorUTF8(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows consoles always use UTF-8")
+	}
+	tests := []struct {
+		name string
+		vars map[string]string
+		want bool
+	}{
+		{"utf-8 lang", map[string]string{"LANG": "en_US.UTF-8"}, true},
+		{"utf8 spelling", map[string]string{"LANG": "de_DE.utf8"}, true},
+		{"c locale", map[string]string{"LANG": "C"}, false},
+		{"latin-1", map[string]string{"LANG": "fr_FR.ISO-8859-1"}, false},
+		{"lc_all wins", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, false},
+		{"lc_ctype wins over lang", map[string]string{"LC_CTYPE": "C.UTF-8", "LANG": "C"}, true},
+		{"empty variables skipped", map[string]string{"LC_ALL": "", "LANG": "en_US.UTF-8"}, true},
+		{"unset", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Detector{LookupEnv: func(key string) (string, bool) {
+				v, ok := tt.vars[key]
+				return v, ok
+			}}
+			if got := d.UTF8(); got != tt.want {
+				t.Errorf("UTF8() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
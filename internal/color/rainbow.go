@@ -0,0 +1,92 @@
+package color
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rainbow gradient parameters, as popularized by lolcat: the hue advances by
+// rainbowFreq radians per character and each line starts rainbowSpread
+// characters further along, giving a diagonal gradient
+const (
+	rainbowFreq   = 0.1
+	rainbowSpread = 3
+)
+
+// Rainbow paints every visible character of text in the next color of a
+// rolling rainbow gradient, using the 256-color palette. Each line starts
+// further along the gradient than the one before; firstLine is the number of
+// lines already painted, so output written in pieces continues the gradient.
+// Escape sequences already in text, such as those added by Highlight, are
+// kept. A disabled Styler returns text unchanged.
+func (s Styler) Rainbow(text string, firstLine int) string {
+	if !s.enabled || text == "" {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text) * 12)
+	line, col := firstLine, 0
+	painted := false
+	for i := 0; i < len(text); {
+		if n := escapeLen(text[i:]); n > 0 {
+			b.WriteString(text[i : i+n])
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\n':
+			// Reset before the line break so every line stands on its own
+			if painted {
+				b.WriteString("\x1b[0m")
+				painted = false
+			}
+			line++
+			col = 0
+		case unicode.IsSpace(r):
+			col++
+		default:
+			b.WriteString("\x1b[38;5;")
+			b.WriteString(strconv.Itoa(rainbowColor(line*rainbowSpread + col)))
+			b.WriteByte('m')
+			painted = true
+			col++
+		}
+		b.WriteString(text[i : i+size])
+		i += size
+	}
+	if painted {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// rainbowColor returns the 256-color palette index of step n of the gradient
+func rainbowColor(n int) int {
+	phase := rainbowFreq * float64(n)
+	channel := func(shift float64) int {
+		// Scale the sine wave to the 0-5 steps of the 6x6x6 color cube
+		return int(math.Round((math.Sin(phase+shift) + 1) / 2 * 5))
+	}
+	r, g, b := channel(0), channel(2*math.Pi/3), channel(4*math.Pi/3)
+	return 16 + 36*r + 6*g + b
+}
+
+// escapeLen returns the byte length of the ANSI CSI sequence at the start of
+// s, or 0
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if c := s[i]; c >= 0x40 && c <= 0x7E {
+			return i + 1
+		}
+	}
+	return len(s)
+}
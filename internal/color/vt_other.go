@@ -0,0 +1,10 @@
+//go:build !windows
+
+package color
+
+import "io"
+
+// enableVirtualTerminal is a no-op: Unix terminals understand ANSI sequences natively
+func enableVirtualTerminal(io.Writer) bool {
+	return true
+}
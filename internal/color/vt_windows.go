@@ -0,0 +1,35 @@
+//go:build windows
+
+package color
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// enableVirtualTerminalProcessing is ENABLE_VIRTUAL_TERMINAL_PROCESSING from wincon.h
+const enableVirtualTerminalProcessing = 0x0004
+
+var procSetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+// enableVirtualTerminal turns on ANSI escape sequence processing for the
+// console behind w. Consoles older than Windows 10 don't support it.
+func enableVirtualTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	handle := syscall.Handle(f.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+
+	r, _, _ := procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AliasPrefix starts the keys of command aliases in the config file:
+//
+//	alias.hi: greet --style casual
+//	alias.wisdom: proverb --daily
+const AliasPrefix = "alias."
+
+// Alias is a user-defined command name that expands to a command line
+type Alias struct {
+	Name    string
+	Command string
+}
+
+// ValidateAlias checks that name can be typed as a command and that command
+// is not empty
+func ValidateAlias(name, command string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.Trim(name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+		return fmt.Errorf("invalid alias name %q (use letters, digits, '-' and '_')", name)
+	}
+	if strings.TrimSpace(command) == "" {
+		return fmt.Errorf("alias %q has no command", name)
+	}
+	return nil
+}
+
+// isAliasKey reports whether key holds an alias
+func isAliasKey(key string) bool {
+	return strings.HasPrefix(key, AliasPrefix)
+}
+
+// Aliases returns the configured aliases sorted by name
+func (c *Config) Aliases() []Alias {
+	var aliases []Alias
+	for key, value := range c.values {
+		if isAliasKey(key) {
+			aliases = append(aliases, Alias{Name: strings.TrimPrefix(key, AliasPrefix), Command: value})
+		}
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases
+}
+
+// Alias returns the command the alias name expands to
+func (c *Config) Alias(name string) (string, bool) {
+	command, ok := c.values[AliasPrefix+name]
+	return command, ok
+}
+
+// SetAlias validates and stores an alias, replacing one with the same name
+func (c *Config) SetAlias(name, command string) error {
+	if err := ValidateAlias(name, command); err != nil {
+		return err
+	}
+	c.values[AliasPrefix+name] = strings.TrimSpace(command)
+	return nil
+}
+
+// RemoveAlias deletes the alias name and reports whether it existed
+func (c *Config) RemoveAlias(name string) bool {
+	if _, ok := c.values[AliasPrefix+name]; !ok {
+		return false
+	}
+	delete(c.values, AliasPrefix+name)
+	return true
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	cfg, err := Parse(strings.NewReader("name: Alice\nalias.wisdom: proverb --daily\nalias.hi: greet --style casual\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unknown := cfg.UnknownKeys(); len(unknown) != 0 {
+		t.Errorf("UnknownKeys() = %v, want aliases to be known", unknown)
+	}
+
+	want := []Alias{{"hi", "greet --style casual"}, {"wisdom", "proverb --daily"}}
+	if got := cfg.Aliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Aliases() = %v, want %v", got, want)
+	}
+	if command, ok := cfg.Alias("hi"); !ok || command != "greet --style casual" {
+		t.Errorf("Alias(hi) = %q, %v", command, ok)
+	}
+
+	if err := cfg.SetAlias("mary", `greet --name "Mary Ann"`); err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.RemoveAlias("wisdom") || cfg.RemoveAlias("wisdom") {
+		t.Error("RemoveAlias() didn't remove the alias exactly once")
+	}
+
+	// Aliases survive a round trip through the file format
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse(WriteTo()) error = %v\n%s", err, buf.String())
+	}
+	want = []Alias{{"hi", "greet --style casual"}, {"mary", `greet --name "Mary Ann"`}}
+	if got := reloaded.Aliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded Aliases() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		name, command string
+		ok            bool
+	}{
+		{"hi", "greet", true},
+		{"daily-2_x", "proverb --daily", true},
+		{"", "greet", false},
+		{"-x", "greet", false},
+		{"two words", "greet", false},
+		{"hi", "  ", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateAlias(tt.name, tt.command); (err == nil) != tt.ok {
+			t.Errorf("ValidateAlias(%q, %q) = %v, want ok %v", tt.name, tt.command, err, tt.ok)
+		}
+	}
+
+	if _, err := Parse(strings.NewReader("alias.bad name: greet\n")); err == nil {
+		t.Error("Parse() accepted an invalid alias name")
+	}
+}
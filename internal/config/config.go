@@ -0,0 +1,451 @@
+// Package config loads and saves the hello-gopher configuration file.
+//
+// The file lives at <user config dir>/hello-gopher/config.yaml (for example
+// ~/.config/hello-gopher/config.yaml on Linux) and uses a flat YAML subset:
+//
+//	# comments are allowed
+//	name: Alice
+//	language: de
+//	style: formal
+//
+// Settings are resolved with flag > environment > file > default precedence.
+// This package provides the environment (see ApplyEnv), file and default
+// layers; command-line flags are layered on top by the CLI.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// Known configuration keys
+const (
+	KeyName          = "name"
+	KeyLanguage      = "language"
+	KeyStyle         = "style"
+	KeyOutput        = "output"
+	KeyColor         = "color"
+	KeyAccessible    = "accessible"
+	KeyRegion        = "region"
+	KeyTimezone      = "timezone"
+	KeyAutoName      = "auto_name"
+	KeyPromptName    = "prompt_name"
+	KeyMaxNameLength = "max_name_length"
+	KeyHistory       = "history"
+	KeyTelemetry     = "telemetry"
+	KeyTelemetryURL  = "telemetry_endpoint"
+	KeyPushgateway   = "pushgateway"
+	KeySMTPServer    = "smtp_server"
+	KeySMTPUsername  = "smtp_username"
+	KeySMTPFrom      = "smtp_from"
+	KeyProvider      = "provider"
+	KeyServer        = "server"
+	KeyStrict        = "strict"
+	KeyExclude       = "exclude"
+)
+
+// AppName is the directory name used below the user config directory
+const AppName = "hello-gopher"
+
+// FileName is the name of the configuration file
+const FileName = "config.yaml"
+
+// Spec describes a known configuration key
+type Spec struct {
+	Key         string
+	Default     string
+	Description string
+	// Validate returns an error if value is not acceptable for the key.
+	// A nil Validate accepts any value.
+	Validate func(value string) error
+}
+
+// specs lists the known keys in display order
+var specs = []Spec{
+	{Key: KeyName, Default: "Gopher", Description: "Default name used by greet"},
+	{Key: KeyLanguage, Default: greeting.DefaultLanguage, Description: "Greeting language code", Validate: validateLanguage},
+	{Key: KeyStyle, Default: string(greeting.DefaultStyle), Description: "Greeting style", Validate: validateStyle},
+	{Key: KeyOutput, Default: "text", Description: "Output format (text, json or table)", Validate: oneOf("text", "json", "table")},
+	{Key: KeyColor, Default: "auto", Description: "Color mode (auto, always or never)", Validate: oneOf("auto", "always", "never")},
+	{Key: KeyAccessible, Default: "false", Description: "Screen-reader friendly output without art, borders or color", Validate: validateBool},
+	{Key: KeyRegion, Default: greeting.GlobalRegion, Description: "Holiday region used by greet --festive", Validate: validateRegion},
+	{Key: KeyTimezone, Description: "IANA time zone of the proverb of the day and holidays, such as Europe/Lisbon (default: the system zone)", Validate: validateTimezone},
+	{Key: KeyAutoName, Default: "false", Description: "Greet the current user when no name is set", Validate: validateBool},
+	{Key: KeyPromptName, Default: "false", Description: "Ask for a name on a terminal when no name is set", Validate: validateBool},
+	{Key: KeyMaxNameLength, Default: strconv.Itoa(greeting.DefaultMaxNameLength), Description: "Truncate longer names (0 disables)", Validate: validateNonNegative},
+	{Key: KeyHistory, Default: "false", Description: "Record greet and proverb runs for the stats command", Validate: validateBool},
+	{Key: KeyTelemetry, Default: "false", Description: "Send anonymous usage events; see 'hello-gopher telemetry'", Validate: validateBool},
+	{Key: KeyTelemetryURL, Description: "Endpoint receiving the usage events; telemetry stays off until it is set", Validate: validateServerURL},
+	{Key: KeyPushgateway, Description: "Prometheus Pushgateway URL receiving the metrics of proverb --push-metrics", Validate: validateServerURL},
+	{Key: KeyProvider, Default: greeting.BuiltinProvider, Description: "Registered provider of greetings and proverbs", Validate: validateProvider},
+	{Key: KeyServer, Description: "URL of a hello-gopher server used by greet and proverb instead of local data", Validate: validateServerURL},
+	{Key: KeyStrict, Default: "false", Description: "Fail on problems that are otherwise only warned about", Validate: validateBool},
+	{Key: KeyExclude, Description: "Proverb IDs and tags to hide, such as [cgo, syscall, 12]", Validate: validateExclude},
+	{Key: KeySMTPServer, Description: "SMTP server (host:port) used by send", Validate: validateHostPort},
+	{Key: KeySMTPUsername, Description: "SMTP username used by send; the password is read from the environment"},
+	{Key: KeySMTPFrom, Description: "Sender address of send (default: the SMTP username)", Validate: validateAddress},
+}
+
+// oneOf returns a validator accepting only the listed values
+func oneOf(allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, a := range allowed {
+			if value == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value %q (allowed: %s)", value, strings.Join(allowed, ", "))
+	}
+}
+
+// validateLanguage accepts the languages supported by the greeting package
+func validateLanguage(value string) error {
+	_, err := greeting.ParseLanguage(value)
+	return err
+}
+
+// validateStyle accepts the styles supported by the greeting package
+func validateStyle(value string) error {
+	_, err := greeting.ParseStyle(value)
+	return err
+}
+
+// validateRegion accepts the holiday regions supported by the greeting package
+func validateRegion(value string) error {
+	_, err := greeting.ParseRegion(value)
+	return err
+}
+
+// validateTimezone accepts IANA time zone names such as Europe/Lisbon, or an
+// empty value for the system zone
+func validateTimezone(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return fmt.Errorf("unknown time zone %q (expected an IANA name such as Europe/Lisbon)", value)
+	}
+	return nil
+}
+
+// validateProvider accepts the providers registered with the greeting package
+func validateProvider(value string) error {
+	_, err := greeting.ParseProvider(value)
+	return err
+}
+
+// validateExclude accepts a list of proverb IDs and tags, or an empty value
+func validateExclude(value string) error {
+	_, err := greeting.ParseExclusion(SplitList(value))
+	return err
+}
+
+// SplitList splits a list value such as "[cgo, syscall]" or "cgo,syscall"
+// into its trimmed, non-empty items
+func SplitList(value string) []string {
+	value = strings.TrimSpace(value)
+	if inner, ok := strings.CutPrefix(value, "["); ok {
+		value, _ = strings.CutSuffix(inner, "]")
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validateBool accepts the values understood by strconv.ParseBool
+func validateBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("invalid value %q (expected true or false)", value)
+	}
+	return nil
+}
+
+// validateNonNegative accepts whole numbers of 0 or more
+func validateNonNegative(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return fmt.Errorf("invalid value %q (expected a number of 0 or more)", value)
+	}
+	return nil
+}
+
+// validateHostPort accepts a host and port such as smtp.example.com:587, or
+// an empty value
+func validateHostPort(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, port, err := net.SplitHostPort(value); err != nil || port == "" {
+		return fmt.Errorf("invalid value %q (expected host:port)", value)
+	}
+	return nil
+}
+
+// validateServerURL accepts an http or https URL such as
+// https://proverbs.example.com, or an empty value
+func validateServerURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid value %q (expected an http:// or https:// URL)", value)
+	}
+	return nil
+}
+
+// validateAddress accepts an email address such as "Gopher <gopher@example.com>",
+// or an empty value
+func validateAddress(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(value); err != nil {
+		return fmt.Errorf("invalid value %q (expected an email address)", value)
+	}
+	return nil
+}
+
+// Specs returns the known configuration keys in display order
+func Specs() []Spec {
+	out := make([]Spec, len(specs))
+	copy(out, specs)
+	return out
+}
+
+// lookupSpec returns the spec for key
+func lookupSpec(key string) (Spec, bool) {
+	for _, s := range specs {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Spec{}, false
+}
+
+// Config holds explicitly configured values on top of the defaults
+type Config struct {
+	// values holds settings read from the file or changed with Set
+	values map[string]string
+	// env holds overrides applied by ApplyEnv; they are never saved
+	env map[string]envOverride
+}
+
+// New returns an empty configuration where every key has its default value
+func New() *Config {
+	return &Config{values: make(map[string]string)}
+}
+
+// DefaultPath returns the platform-specific location of the config file
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user config directory: %w", err)
+	}
+	return filepath.Join(dir, AppName, FileName), nil
+}
+
+// Load reads the config file at path. A missing file is not an error and
+// yields the defaults.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own config file
+	if err != nil {
+		if os.IsNotExist(err) {
+			return New(), nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse reads configuration in the flat "key: value" YAML subset. Values may
+// be wrapped in single or double quotes. Unknown keys are kept so they can be
+// reported, but known keys are validated.
+func Parse(r io.Reader) (*Config, error) {
+	cfg := New()
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\", got %q", lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(stripComment(strings.TrimSpace(value)))
+
+		if spec, known := lookupSpec(key); known && spec.Validate != nil {
+			if err := spec.Validate(value); err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", lineNo, key, err)
+			}
+		}
+		if isAliasKey(key) {
+			if err := ValidateAlias(strings.TrimPrefix(key, AliasPrefix), value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+		cfg.values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// stripComment removes a trailing " # comment" from an unquoted value
+func stripComment(value string) string {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return value
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		return strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// unquote removes matching surrounding quotes, interpreting escape
+// sequences inside double quotes
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if first == '"' && last == '"' {
+			if s, err := strconv.Unquote(value); err == nil {
+				return s
+			}
+		}
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// Get returns the effective value for key: an environment override, the
+// configured value, or its default
+func (c *Config) Get(key string) (string, error) {
+	spec, ok := lookupSpec(key)
+	if !ok {
+		return "", fmt.Errorf("unknown config key %q", key)
+	}
+	if v, ok := c.env[key]; ok {
+		return v.value, nil
+	}
+	if v, ok := c.values[key]; ok {
+		return v, nil
+	}
+	return spec.Default, nil
+}
+
+// Value returns the value for a known key, or its default. It is a
+// convenience for callers using the Key constants.
+func (c *Config) Value(key string) string {
+	v, _ := c.Get(key)
+	return v
+}
+
+// IsSet reports whether key was explicitly configured in the file or environment
+func (c *Config) IsSet(key string) bool {
+	return c.Source(key) != SourceDefault
+}
+
+// Set validates and stores a value for a known key
+func (c *Config) Set(key, value string) error {
+	spec, ok := lookupSpec(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	if spec.Validate != nil {
+		if err := spec.Validate(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	c.values[key] = value
+	return nil
+}
+
+// UnknownKeys returns keys present in the file that are not recognized
+func (c *Config) UnknownKeys() []string {
+	var unknown []string
+	for key := range c.values {
+		if _, ok := lookupSpec(key); !ok && !isAliasKey(key) && !isPlaylistKey(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// WriteTo writes the explicitly configured values in the file format
+func (c *Config) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	b.WriteString("# hello-gopher configuration\n")
+
+	for _, spec := range specs {
+		if v, ok := c.values[spec.Key]; ok {
+			fmt.Fprintf(&b, "%s: %s\n", spec.Key, quote(v))
+		}
+	}
+	for _, alias := range c.Aliases() {
+		fmt.Fprintf(&b, "%s%s: %s\n", AliasPrefix, alias.Name, quote(alias.Command))
+	}
+	for _, name := range c.PlaylistNames() {
+		fmt.Fprintf(&b, "%s%s: %s\n", PlaylistPrefix, name, quote(c.values[PlaylistPrefix+name]))
+	}
+	for _, key := range c.UnknownKeys() {
+		fmt.Fprintf(&b, "%s: %s\n", key, quote(c.values[key]))
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// quote wraps values that would otherwise be misread by Parse
+func quote(value string) string {
+	if value == "" || strings.TrimSpace(value) != value || strings.ContainsAny(value, "#:'\"") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
+
+// Save writes the configuration to path, creating parent directories
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) // #nosec G304 -- user config path
+	if err != nil {
+		return err
+	}
+	if _, err := c.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `# hello-gopher configuration
+name: "Ada Lovelace"
+language: de   # German greetings
+style: 'formal'
+
+color: never
+timezone: Europe/Lisbon
+future_key: kept
+`
+	cfg, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{KeyName, "Ada Lovelace"},
+		{KeyLanguage, "de"},
+		{KeyStyle, "formal"},
+		{KeyColor, "never"},
+		{KeyTimezone, "Europe/Lisbon"},
+		{KeyOutput, "text"}, // default
+	}
+	for _, tt := range tests {
+		if got := cfg.Value(tt.key); got != tt.want {
+			t.Errorf("Value(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if cfg.IsSet(KeyOutput) {
+		t.Error("IsSet(output) = true for a key missing from the file")
+	}
+	if unknown := cfg.UnknownKeys(); len(unknown) != 1 || unknown[0] != "future_key" {
+		t.Errorf("UnknownKeys() = %v, want [future_key]", unknown)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing separator", "name Alice"},
+		{"invalid output", "output: xml"},
+		{"invalid color", "color: sometimes"},
+		{"unsupported language", "language: tlh"},
+		{"unsupported style", "style: grumpy"},
+		{"unregistered provider", "provider: wiki"},
+		{"smtp server without port", "smtp_server: smtp.example.com"},
+		{"server without scheme", "server: proverbs.example.com"},
+		{"strict not a bool", "strict: sometimes"},
+		{"invalid sender", "smtp_from: gopher"},
+		{"exclude with a bad tag", "exclude: [cgo, Sys Call]"},
+		{"exclude with a bad ID", "exclude: [0]"},
+		{"unknown time zone", "timezone: Mars/Olympus_Mons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tt.input)
+			}
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := map[string][]string{
+		"[cgo, syscall, 12]": {"cgo", "syscall", "12"},
+		"cgo,syscall":        {"cgo", "syscall"},
+		`['cgo', "unsafe",]`: {"cgo", "unsafe"},
+		"[]":                 nil,
+		"":                   nil,
+	}
+	for value, want := range tests {
+		got := SplitList(value)
+		if strings.Join(got, "|") != strings.Join(want, "|") || len(got) != len(want) {
+			t.Errorf("SplitList(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestConfigGetSet(t *testing.T) {
+	cfg := New()
+
+	if err := cfg.Set(KeyName, "Bob"); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if got, _ := cfg.Get(KeyName); got != "Bob" {
+		t.Errorf("Get(name) = %q, want %q", got, "Bob")
+	}
+
+	if err := cfg.Set("unknown", "x"); err == nil {
+		t.Error("Set() with unknown key expected error")
+	}
+	if err := cfg.Set(KeyOutput, "yaml"); err == nil {
+		t.Error("Set() with invalid value expected error")
+	}
+	if _, err := cfg.Get("unknown"); err == nil {
+		t.Error("Get() with unknown key expected error")
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+
+	cfg := New()
+	values := map[string]string{
+		KeyName:     `Dr. "Quote" #1: Smith`,
+		KeyLanguage: "pt",
+		KeyColor:    "always",
+	}
+	for k, v := range values {
+		if err := cfg.Set(k, v); err != nil {
+			t.Fatalf("Set(%q) unexpected error: %v", k, err)
+		}
+	}
+
+	if err := cfg.Save(path); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	for k, want := range values {
+		if got := loaded.Value(k); got != want {
+			t.Errorf("round trip %s = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() of missing file unexpected error: %v", err)
+	}
+	for _, spec := range Specs() {
+		if got := cfg.Value(spec.Key); got != spec.Default {
+			t.Errorf("Value(%q) = %q, want default %q", spec.Key, got, spec.Default)
+		}
+	}
+}
+
+func TestWriteToOnlyExplicitValues(t *testing.T) {
+	cfg := New()
+	_ = cfg.Set(KeyStyle, "casual")
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "style: casual") {
+		t.Errorf("WriteTo() missing explicit value, got:\n%s", out)
+	}
+	if strings.Contains(out, "name:") {
+		t.Errorf("WriteTo() should not write defaults, got:\n%s", out)
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// DataDir returns the platform-specific directory for data the user creates
+// through hello-gopher, such as imported proverbs. It follows the XDG base
+// directory specification on Unix ($XDG_DATA_HOME, default ~/.local/share)
+// and shares the user config directory on macOS and Windows, which have no
+// separate location for it.
+func DataDir() (string, error) {
+	dir, err := xdgDir("XDG_DATA_HOME", ".local", "share")
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user data directory: %w", err)
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// StateDir returns the platform-specific directory for state kept between
+// runs that is worth less than data but more than a cache, such as the
+// proverbs shown recently. It follows the XDG base directory specification
+// on Unix ($XDG_STATE_HOME, default ~/.local/state) and shares the user
+// config directory on macOS and Windows.
+func StateDir() (string, error) {
+	dir, err := xdgDir("XDG_STATE_HOME", ".local", "state")
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user state directory: %w", err)
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// CacheDir returns the platform-specific directory for cached files, such
+// as the result of the last update check
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user cache directory: %w", err)
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// xdgDir returns the directory named by the XDG environment variable env,
+// or the default below $HOME made of elem. Platforms without XDG directories
+// use the user config directory.
+func xdgDir(env string, elem ...string) (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+
+	if dir := os.Getenv(env); dir != "" {
+		if !filepath.IsAbs(dir) {
+			return "", fmt.Errorf("path in $%s is relative", env)
+		}
+		return dir, nil
+	}
+	home := os.Getenv("HOME")
+	if home == "" {
+		return "", fmt.Errorf("neither $%s nor $HOME are defined", env)
+	}
+	return filepath.Join(append([]string{home}, elem...)...), nil
+}
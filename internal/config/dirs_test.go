@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDataDir(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		t.Skip("the data directory follows XDG on Unix only")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	if got, err := DataDir(); err != nil || got != filepath.Join(home, ".local", "share", AppName) {
+		t.Errorf("DataDir() = %q, %v; want it below ~/.local/share", got, err)
+	}
+
+	xdg := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", xdg)
+	if got, err := DataDir(); err != nil || got != filepath.Join(xdg, AppName) {
+		t.Errorf("DataDir() = %q, %v; want it below $XDG_DATA_HOME", got, err)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "relative")
+	if _, err := DataDir(); err == nil {
+		t.Error("DataDir() should reject a relative $XDG_DATA_HOME")
+	}
+}
+
+func TestStateAndCacheDir(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		t.Skip("the state directory follows XDG on Unix only")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	if got, err := StateDir(); err != nil || got != filepath.Join(home, ".local", "state", AppName) {
+		t.Errorf("StateDir() = %q, %v; want it below ~/.local/state", got, err)
+	}
+	if got, err := CacheDir(); err != nil || got != filepath.Join(home, ".cache", AppName) {
+		t.Errorf("CacheDir() = %q, %v; want it below ~/.cache", got, err)
+	}
+
+	t.Setenv("XDG_STATE_HOME", "relative")
+	if _, err := StateDir(); err == nil {
+		t.Error("StateDir() should reject a relative $XDG_STATE_HOME")
+	}
+}
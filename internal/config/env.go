@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is prepended to every environment variable read by hello-gopher
+const EnvPrefix = "HELLO_GOPHER_"
+
+// EnvConfig names the environment variable selecting the config file
+const EnvConfig = EnvPrefix + "CONFIG"
+
+// Environment variables overriding the directories hello-gopher writes to
+const (
+	EnvCacheDir = EnvPrefix + "CACHE_DIR"
+	EnvDataDir  = EnvPrefix + "DATA_DIR"
+	EnvStateDir = EnvPrefix + "STATE_DIR"
+)
+
+// EnvNoColor disables colored output when set to a true value
+const EnvNoColor = EnvPrefix + "NO_COLOR"
+
+// LookupEnvFunc has the signature of os.LookupEnv so tests can inject a fake environment
+type LookupEnvFunc func(key string) (string, bool)
+
+// Sources reported by Config.Source
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// envNames maps configuration keys to their environment variables
+var envNames = map[string]string{
+	KeyName:          EnvPrefix + "NAME",
+	KeyLanguage:      EnvPrefix + "LANG",
+	KeyStyle:         EnvPrefix + "STYLE",
+	KeyOutput:        EnvPrefix + "OUTPUT",
+	KeyColor:         EnvPrefix + "COLOR",
+	KeyAccessible:    EnvPrefix + "ACCESSIBLE",
+	KeyRegion:        EnvPrefix + "REGION",
+	KeyTimezone:      EnvPrefix + "TZ",
+	KeyAutoName:      EnvPrefix + "AUTO_NAME",
+	KeyPromptName:    EnvPrefix + "PROMPT_NAME",
+	KeyMaxNameLength: EnvPrefix + "MAX_NAME_LENGTH",
+	KeyHistory:       EnvPrefix + "HISTORY",
+	KeyTelemetry:     EnvPrefix + "TELEMETRY",
+	KeyTelemetryURL:  EnvPrefix + "TELEMETRY_ENDPOINT",
+	KeyPushgateway:   EnvPrefix + "PUSHGATEWAY",
+	KeyProvider:      EnvPrefix + "PROVIDER",
+	KeyServer:        EnvPrefix + "SERVER",
+	KeyStrict:        EnvPrefix + "STRICT",
+	KeyExclude:       EnvPrefix + "EXCLUDE",
+	KeySMTPServer:    EnvPrefix + "SMTP_SERVER",
+	KeySMTPUsername:  EnvPrefix + "SMTP_USERNAME",
+	KeySMTPFrom:      EnvPrefix + "SMTP_FROM",
+}
+
+// EnvName returns the environment variable overriding key, or "" if none
+func EnvName(key string) string {
+	return envNames[key]
+}
+
+// ApplyEnv overlays environment variables on top of the file values.
+// Empty variables are ignored. HELLO_GOPHER_NO_COLOR=1 is shorthand for
+// HELLO_GOPHER_COLOR=never and takes priority over it.
+//
+// Environment values are never written back by Save.
+func (c *Config) ApplyEnv(lookup LookupEnvFunc) error {
+	if c.env == nil {
+		c.env = make(map[string]envOverride)
+	}
+
+	for _, spec := range specs {
+		name := envNames[spec.Key]
+		value, ok := lookup(name)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if spec.Validate != nil {
+			if err := spec.Validate(value); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		c.env[spec.Key] = envOverride{value: value, variable: name}
+	}
+
+	if value, ok := lookup(EnvNoColor); ok && strings.TrimSpace(value) != "" {
+		noColor, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%s: expected a boolean, got %q", EnvNoColor, value)
+		}
+		if noColor {
+			c.env[KeyColor] = envOverride{value: "never", variable: EnvNoColor}
+		}
+	}
+
+	return nil
+}
+
+// envOverride is a value taken from an environment variable
+type envOverride struct {
+	value    string
+	variable string
+}
+
+// EnvSource returns the environment variable that overrides key, or "" if
+// the key is not overridden by the environment
+func (c *Config) EnvSource(key string) string {
+	return c.env[key].variable
+}
+
+// Source reports which layer provides the effective value of key
+func (c *Config) Source(key string) string {
+	if _, ok := c.env[key]; ok {
+		return SourceEnv
+	}
+	if _, ok := c.values[key]; ok {
+		return SourceFile
+	}
+	return SourceDefault
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeEnv returns a LookupEnvFunc backed by a map
+func fakeEnv(vars map[string]string) LookupEnvFunc {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func TestApplyEnvPrecedence(t *testing.T) {
+	cfg, err := Parse(strings.NewReader("name: FromFile\nlanguage: de\nstyle: formal\n"))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	err = cfg.ApplyEnv(fakeEnv(map[string]string{
+		"HELLO_GOPHER_NAME":   "FromEnv",
+		"HELLO_GOPHER_LANG":   "  ",
+		"HELLO_GOPHER_OUTPUT": "json",
+	}))
+	if err != nil {
+		t.Fatalf("ApplyEnv() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key        string
+		wantValue  string
+		wantSource string
+	}{
+		{KeyName, "FromEnv", SourceEnv},
+		{KeyLanguage, "de", SourceFile}, // blank variables are ignored
+		{KeyStyle, "formal", SourceFile},
+		{KeyOutput, "json", SourceEnv},
+		{KeyColor, "auto", SourceDefault},
+	}
+	for _, tt := range tests {
+		if got := cfg.Value(tt.key); got != tt.wantValue {
+			t.Errorf("Value(%q) = %q, want %q", tt.key, got, tt.wantValue)
+		}
+		if got := cfg.Source(tt.key); got != tt.wantSource {
+			t.Errorf("Source(%q) = %q, want %q", tt.key, got, tt.wantSource)
+		}
+	}
+	if got := cfg.EnvSource(KeyName); got != "HELLO_GOPHER_NAME" {
+		t.Errorf("EnvSource(name) = %q, want HELLO_GOPHER_NAME", got)
+	}
+}
+
+func TestApplyEnvNoColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"no color wins over color", map[string]string{"HELLO_GOPHER_COLOR": "always", "HELLO_GOPHER_NO_COLOR": "1"}, "never", false},
+		{"false no color keeps color", map[string]string{"HELLO_GOPHER_COLOR": "always", "HELLO_GOPHER_NO_COLOR": "false"}, "always", false},
+		{"invalid no color", map[string]string{"HELLO_GOPHER_NO_COLOR": "maybe"}, "", true},
+		{"invalid color", map[string]string{"HELLO_GOPHER_COLOR": "rainbow"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := New()
+			err := cfg.ApplyEnv(fakeEnv(tt.vars))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.Value(KeyColor) != tt.want {
+				t.Errorf("color = %q, want %q", cfg.Value(KeyColor), tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvValuesAreNotSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	cfg := New()
+	if err := cfg.ApplyEnv(fakeEnv(map[string]string{"HELLO_GOPHER_NAME": "Transient"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.IsSet(KeyName) {
+		t.Errorf("environment value was persisted: %q", loaded.Value(KeyName))
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// PlaylistPrefix starts the keys of proverb playlists in the config file:
+//
+//	playlist.onboarding: [15, 4, 1, errors]
+//	playlist.concurrency-week: [concurrency=3, design]
+//
+// The values are parsed by the playlist package when they are used.
+const PlaylistPrefix = "playlist."
+
+// isPlaylistKey reports whether key holds a playlist
+func isPlaylistKey(key string) bool {
+	return strings.HasPrefix(key, PlaylistPrefix)
+}
+
+// Playlist returns the items of the playlist name
+func (c *Config) Playlist(name string) (string, bool) {
+	items, ok := c.values[PlaylistPrefix+name]
+	return items, ok
+}
+
+// SetPlaylist stores the items of the playlist name, replacing one with the
+// same name. Validate them with the playlist package first.
+func (c *Config) SetPlaylist(name, items string) {
+	c.values[PlaylistPrefix+name] = strings.TrimSpace(items)
+}
+
+// PlaylistNames returns the names of the configured playlists, sorted
+func (c *Config) PlaylistNames() []string {
+	var names []string
+	for key := range c.values {
+		if isPlaylistKey(key) {
+			names = append(names, strings.TrimPrefix(key, PlaylistPrefix))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlaylists(t *testing.T) {
+	cfg, err := Parse(strings.NewReader("playlist.onboarding: [15, 4, errors]\nplaylist.mix: [concurrency=3, design]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unknown := cfg.UnknownKeys(); len(unknown) != 0 {
+		t.Errorf("UnknownKeys() = %v, want playlists to be known", unknown)
+	}
+	if got := cfg.PlaylistNames(); !reflect.DeepEqual(got, []string{"mix", "onboarding"}) {
+		t.Errorf("PlaylistNames() = %v", got)
+	}
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse(WriteTo()) error = %v\n%s", err, buf.String())
+	}
+	if items, ok := reloaded.Playlist("mix"); !ok || items != "[concurrency=3, design]" {
+		t.Errorf("reloaded Playlist(mix) = %q, %v", items, ok)
+	}
+
+	reloaded.SetPlaylist("mix", " [4, 2] ")
+	reloaded.SetPlaylist("new", "[errors]")
+	if items, _ := reloaded.Playlist("mix"); items != "[4, 2]" {
+		t.Errorf("Playlist(mix) after SetPlaylist = %q, want [4, 2]", items)
+	}
+	if got := reloaded.PlaylistNames(); !reflect.DeepEqual(got, []string{"mix", "new", "onboarding"}) {
+		t.Errorf("PlaylistNames() after SetPlaylist = %v", got)
+	}
+}
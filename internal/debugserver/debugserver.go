@@ -0,0 +1,107 @@
+// Package debugserver serves runtime diagnostics of a long-running command,
+// such as "hello-gopher serve" or "hello-gopher proverb --watch", on a
+// listener of its own:
+//
+//	/debug/pprof/   CPU, heap, goroutine and other profiles (net/http/pprof)
+//	/debug/vars     memory statistics and published variables (expvar)
+//	/debug/config   the effective configuration as JSON
+//
+// The endpoints reveal internals of the process and profiling costs CPU, so
+// the listener should only be reachable from trusted hosts, such as
+// 127.0.0.1:6060. The command line, served by /debug/pprof/cmdline and
+// /debug/vars, comes from the caller, which should leave out secrets.
+package debugserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+)
+
+// shutdownTimeout bounds how long running requests, such as a CPU profile,
+// may delay stopping the server
+const shutdownTimeout = 2 * time.Second
+
+// NewHandler returns the debug endpoints. config is called for every request
+// of /debug/config and its result is written as indented JSON. cmdline
+// returns the command line shown instead of os.Args, which may hold secrets.
+func NewHandler(config func() interface{}, cmdline func() []string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", func(w http.ResponseWriter, r *http.Request) {
+		// The format of pprof.Cmdline
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, strings.Join(cmdline(), "\x00"))
+	})
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /debug/vars", func(w http.ResponseWriter, r *http.Request) {
+		writeVars(w, cmdline())
+	})
+	mux.HandleFunc("GET /debug/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(config())
+	})
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/debug/pprof/", http.StatusFound)
+	})
+	return mux
+}
+
+// writeVars writes the published expvar variables like expvar.Handler, but
+// with the "cmdline" variable, os.Args, replaced by cmdline
+func writeVars(w http.ResponseWriter, cmdline []string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprint(w, "{\n")
+	first := true
+	expvar.Do(func(kv expvar.KeyValue) {
+		if !first {
+			fmt.Fprint(w, ",\n")
+		}
+		first = false
+		value := kv.Value.String()
+		if kv.Key == "cmdline" {
+			args, _ := json.Marshal(cmdline)
+			value = string(args)
+		}
+		fmt.Fprintf(w, "%q: %s", kv.Key, value)
+	})
+	fmt.Fprint(w, "\n}\n")
+}
+
+// Serve serves h on ln until ctx is canceled
+func Serve(ctx context.Context, ln net.Listener, h http.Handler) error {
+	srv := &http.Server{Handler: h, ReadHeaderTimeout: 5 * time.Second}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
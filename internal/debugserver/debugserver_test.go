@@ -0,0 +1,95 @@
+package debugserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	ts := httptest.NewServer(NewHandler(func() interface{} {
+		return map[string]string{"language": "de"}
+	}, func() []string {
+		return []string{"hello-gopher", "serve", "--auth-token", "<redacted>"}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/debug/pprof/", "goroutine"},
+		{"/debug/pprof/goroutine?debug=1", "goroutine profile"},
+		{"/debug/vars", `"memstats"`},
+		{"/debug/vars", `"cmdline": ["hello-gopher","serve","--auth-token","\u003credacted\u003e"]`},
+		{"/debug/pprof/cmdline", "hello-gopher\x00serve\x00--auth-token\x00<redacted>"},
+		{"/debug/config", `"language": "de"`},
+		{"/", "goroutine"},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), tt.want) {
+			t.Errorf("GET %s = %d, want 200 containing %q:\n%.300s", tt.path, resp.StatusCode, tt.want, body)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/debug/config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var config map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil || config["language"] != "de" {
+		t.Errorf("GET /debug/config = %v, %v; want the configuration as JSON", config, err)
+	}
+
+	resp, err = http.Get(ts.URL + "/debug/vars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var vars map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil || vars["memstats"] == nil {
+		t.Errorf("GET /debug/vars = %v; want the variables as JSON", err)
+	}
+}
+
+func TestServe(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, ln, NewHandler(func() interface{} { return nil }, func() []string { return nil }))
+	}()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/debug/vars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() did not return after the context was canceled")
+	}
+}
@@ -0,0 +1,223 @@
+// Package email renders greetings and proverbs as HTML and plain-text email
+// and sends them through an SMTP server.
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// DefaultTimeout bounds the whole conversation with the SMTP server
+const DefaultTimeout = 30 * time.Second
+
+// implicitTLSPort is the submission port that expects TLS from the first
+// byte instead of upgrading the connection with STARTTLS
+const implicitTLSPort = "465"
+
+//go:embed templates
+var templateFS embed.FS
+
+// The body templates; both receive the Message being sent
+var (
+	textTemplate = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/message.txt"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/message.html"))
+)
+
+// Message is an email with the same content in an HTML and a plain-text
+// body
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Date    time.Time
+
+	// Text is the main content, such as a proverb or greeting
+	Text string
+	// Footer is optional context shown below the text, such as "Go Proverb #5"
+	Footer string
+	// Quote renders Text as a quotation
+	Quote bool
+}
+
+// Server is the SMTP server that relays messages
+type Server struct {
+	// Addr is the host:port of the server
+	Addr string
+	// Username and Password authenticate with the server when Username is
+	// not empty
+	Username string
+	Password string
+}
+
+// Bytes returns m as an RFC 5322 message with a multipart/alternative body
+func (m Message) Bytes() ([]byte, error) {
+	from, to, err := m.addresses()
+	if err != nil {
+		return nil, err
+	}
+
+	var text, html bytes.Buffer
+	if err := textTemplate.Execute(&text, m); err != nil {
+		return nil, fmt.Errorf("rendering text body: %w", err)
+	}
+	if err := htmlTemplate.Execute(&html, m); err != nil {
+		return nil, fmt.Errorf("rendering HTML body: %w", err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	toHeader := make([]string, len(to))
+	for i, addr := range to {
+		toHeader[i] = addr.String()
+	}
+
+	var msg bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&msg, "%s: %s\r\n", key, value)
+	}
+	header("From", from.String())
+	header("To", strings.Join(toHeader, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", m.Date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()}))
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// addresses parses the sender and recipients of m
+func (m Message) addresses() (*netmail.Address, []*netmail.Address, error) {
+	from, err := netmail.ParseAddress(m.From)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid sender %q: %w", m.From, err)
+	}
+	if len(m.To) == 0 {
+		return nil, nil, errors.New("no recipients")
+	}
+	to := make([]*netmail.Address, len(m.To))
+	for i, s := range m.To {
+		if to[i], err = netmail.ParseAddress(s); err != nil {
+			return nil, nil, fmt.Errorf("invalid recipient %q: %w", s, err)
+		}
+	}
+	return from, to, nil
+}
+
+// Send delivers m through server. Connections to port 465 use TLS from the
+// start; others are upgraded with STARTTLS when the server offers it.
+// Credentials are only sent over TLS, or to a server on the local machine.
+func Send(ctx context.Context, server Server, m Message) error {
+	data, err := m.Bytes()
+	if err != nil {
+		return err
+	}
+	from, to, err := m.addresses()
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(server.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP server %q: %w", server.Addr, err)
+	}
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	var conn net.Conn
+	if port == implicitTLSPort {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", server.Addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", server.Addr)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok && port != implicitTLSPort {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("starting TLS: %w", err)
+		}
+	}
+	if server.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("the server doesn't support authentication")
+		}
+		if err := c.Auth(smtp.PlainAuth("", server.Username, server.Password, host)); err != nil {
+			return fmt.Errorf("authenticating as %s: %w", server.Username, err)
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr.Address); err != nil {
+			return fmt.Errorf("recipient %s: %w", addr.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
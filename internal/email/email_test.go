@@ -0,0 +1,228 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testMessage = Message{
+	From:    "Gopher <gopher@example.com>",
+	To:      []string{"alice@example.com", "Bob <bob@example.com>"},
+	Subject: "Grüße",
+	Date:    time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	Text:    "Errors are <values>.",
+	Footer:  "Go Proverb #15",
+	Quote:   true,
+}
+
+// parts parses the text and HTML bodies of the message in data
+func parts(t *testing.T, data []byte) (*netmail.Message, map[string]string) {
+	t.Helper()
+	msg, err := netmail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q", msg.Header.Get("Content-Type"))
+	}
+
+	bodies := make(map[string]string)
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies[contentType] = string(body)
+	}
+	return msg, bodies
+}
+
+func TestMessageBytes(t *testing.T) {
+	data, err := testMessage.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, bodies := parts(t, data)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Grüße" {
+		t.Errorf("Subject = %q (err %v)", subject, err)
+	}
+	if to := msg.Header.Get("To"); to != `<alice@example.com>, "Bob" <bob@example.com>` {
+		t.Errorf("To = %q", to)
+	}
+	if date := msg.Header.Get("Date"); date != "Fri, 01 Mar 2024 09:00:00 +0000" {
+		t.Errorf("Date = %q", date)
+	}
+
+	if text := bodies["text/plain"]; !strings.HasPrefix(text, "\"Errors are <values>.\"\r\n\r\nGo Proverb #15\r\n") {
+		t.Errorf("text body = %q", text)
+	}
+	html := bodies["text/html"]
+	if !strings.Contains(html, "Errors are &lt;values&gt;.</blockquote>") || !strings.Contains(html, "Go Proverb #15") {
+		t.Errorf("HTML body doesn't contain the escaped quote and footer:\n%s", html)
+	}
+}
+
+func TestMessageBytesInvalidAddresses(t *testing.T) {
+	for _, m := range []Message{
+		{From: "gopher", To: []string{"alice@example.com"}},
+		{From: "gopher@example.com"},
+		{From: "gopher@example.com", To: []string{"alice@example.com", "bob"}},
+	} {
+		if _, err := m.Bytes(); err == nil {
+			t.Errorf("Bytes() of %+v succeeded", m)
+		}
+	}
+}
+
+// fakeServer is a minimal SMTP server accepting one message
+type fakeServer struct {
+	addr     string
+	auth     bool
+	commands chan string
+	data     chan string
+}
+
+// startServer starts a fake SMTP server, advertising AUTH PLAIN if auth is
+// true
+func startServer(t *testing.T, auth bool) *fakeServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	s := &fakeServer{addr: l.Addr().String(), auth: auth, commands: make(chan string, 32), data: make(chan string, 1)}
+	go s.serve(l)
+	return s
+}
+
+func (s *fakeServer) serve(l net.Listener) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.commands <- line
+
+		verb, _, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if s.auth {
+				reply("250-fake")
+				reply("250 AUTH PLAIN")
+			} else {
+				reply("250 fake")
+			}
+		case "AUTH":
+			reply("235 Authenticated")
+		case "MAIL", "RCPT":
+			if strings.Contains(line, "rejected@") {
+				reply("550 No such user")
+			} else {
+				reply("250 OK")
+			}
+		case "DATA":
+			reply("354 Go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data <- data.String()
+			reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Unknown command")
+		}
+	}
+}
+
+// received returns the commands the server received
+func (s *fakeServer) received() []string {
+	var commands []string
+	for {
+		select {
+		case c := <-s.commands:
+			commands = append(commands, c)
+		default:
+			return commands
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	s := startServer(t, true)
+	err := Send(context.Background(), Server{Addr: s.addr, Username: "gopher", Password: "secret"}, testMessage)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	commands := strings.Join(s.received(), "\n")
+	for _, want := range []string{"AUTH PLAIN", "MAIL FROM:<gopher@example.com>", "RCPT TO:<alice@example.com>", "RCPT TO:<bob@example.com>", "QUIT"} {
+		if !strings.Contains(commands, want) {
+			t.Errorf("server didn't receive %q:\n%s", want, commands)
+		}
+	}
+	if data := <-s.data; !strings.Contains(data, "Subject: =?utf-8?q?Gr=C3=BC=C3=9Fe?=") {
+		t.Errorf("message data misses the subject:\n%s", data)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	s := startServer(t, false)
+	err := Send(context.Background(), Server{Addr: s.addr, Username: "gopher"}, testMessage)
+	if err == nil || !strings.Contains(err.Error(), "doesn't support authentication") {
+		t.Errorf("Send() to a server without AUTH: error = %v", err)
+	}
+
+	s = startServer(t, false)
+	m := testMessage
+	m.To = []string{"rejected@example.com"}
+	if err := Send(context.Background(), Server{Addr: s.addr}, m); err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("Send() to a rejected recipient: error = %v", err)
+	}
+
+	if err := Send(context.Background(), Server{Addr: "localhost"}, testMessage); err == nil {
+		t.Error("Send() to a server without a port succeeded")
+	}
+}
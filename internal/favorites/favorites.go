@@ -0,0 +1,99 @@
+// Package favorites stores the IDs of proverbs the user has marked as
+// favorites.
+//
+// Favorites are kept as a JSON array of proverb IDs in
+// <user config dir>/hello-gopher/favorites.json, next to the config file.
+package favorites
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+)
+
+// FileName is the name of the favorites file
+const FileName = "favorites.json"
+
+// Store is a set of favorite proverb IDs backed by a file
+type Store struct {
+	path string
+	ids  map[int]bool
+}
+
+// DefaultPath returns the platform-specific location of the favorites file
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user config directory: %w", err)
+	}
+	return filepath.Join(dir, config.AppName, FileName), nil
+}
+
+// Load reads the favorites file at path. A missing file yields an empty store.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, ids: make(map[int]bool)}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's own favorites file
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, id := range ids {
+		s.ids[id] = true
+	}
+	return s, nil
+}
+
+// Path returns the file the store is saved to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Has reports whether the proverb with the given ID is a favorite
+func (s *Store) Has(id int) bool {
+	return s.ids[id]
+}
+
+// Toggle adds or removes id and reports whether it is now a favorite
+func (s *Store) Toggle(id int) bool {
+	if s.ids[id] {
+		delete(s.ids, id)
+		return false
+	}
+	s.ids[id] = true
+	return true
+}
+
+// IDs returns the favorite IDs in ascending order
+func (s *Store) IDs() []int {
+	ids := make([]int, 0, len(s.ids))
+	for id := range s.ids {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Save writes the favorites back to the file they were loaded from,
+// creating parent directories
+func (s *Store) Save() error {
+	data, err := json.Marshal(s.IDs())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,57 @@
+package favorites
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if ids := s.IDs(); len(ids) != 0 {
+		t.Errorf("IDs() = %v, want empty", ids)
+	}
+}
+
+func TestToggleSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.Toggle(7) || !s.Toggle(3) || !s.Toggle(12) {
+		t.Fatal("Toggle() on a new ID should report true")
+	}
+	if s.Toggle(12) {
+		t.Error("Toggle() on an existing ID should report false")
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if got, want := loaded.IDs(), []int{3, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IDs() = %v, want %v", got, want)
+	}
+	if !loaded.Has(3) || loaded.Has(12) {
+		t.Error("Has() does not match the saved favorites")
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() expected error for malformed file")
+	}
+}
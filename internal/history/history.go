@@ -0,0 +1,171 @@
+// Package history records greet and proverb runs and summarizes them for the
+// stats command.
+//
+// Runs are appended as JSON lines to <user state dir>/hello-gopher/history.jsonl
+// when the history setting is enabled; nothing is recorded by default.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FileName is the name of the history file
+const FileName = "history.jsonl"
+
+// Entry is a single recorded run
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	// ProverbID is the ID of the proverb shown, if any
+	ProverbID int `json:"proverb_id,omitempty"`
+}
+
+// Append adds e to the history file at path, creating the file and its
+// parent directories
+func Append(path string, e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) // #nosec G304 -- path is the user's own history file
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads the history file at path. A missing file yields no entries, and
+// lines that can't be decoded, such as one cut short by a crash, are skipped.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own history file
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Command == "" {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// ProverbCount is how often a proverb was shown
+type ProverbCount struct {
+	ID    int `json:"id"`
+	Count int `json:"count"`
+}
+
+// Stats summarizes the recorded runs
+type Stats struct {
+	// Total is the number of recorded runs
+	Total int `json:"total"`
+	// Commands counts the runs of every command
+	Commands map[string]int `json:"commands"`
+	// TopProverbs lists the most-shown proverbs, most frequent first
+	TopProverbs []ProverbCount `json:"top_proverbs"`
+	// CurrentStreak is the number of consecutive days with a run ending
+	// today, or yesterday when there was no run today yet
+	CurrentStreak int `json:"current_streak"`
+	// LongestStreak is the longest number of consecutive days with a run
+	LongestStreak int `json:"longest_streak"`
+	// First and Last are the times of the earliest and latest run
+	First time.Time `json:"first,omitzero"`
+	Last  time.Time `json:"last,omitzero"`
+}
+
+// Summarize computes the stats of entries as of now, listing at most top
+// proverbs. Days are counted in the time zone of now.
+func Summarize(entries []Entry, now time.Time, top int) Stats {
+	stats := Stats{Total: len(entries), Commands: make(map[string]int)}
+	seen := make(map[int]int)
+	days := make(map[time.Time]bool)
+
+	for _, e := range entries {
+		stats.Commands[e.Command]++
+		if e.ProverbID > 0 {
+			seen[e.ProverbID]++
+		}
+		days[day(e.Time, now.Location())] = true
+
+		if stats.First.IsZero() || e.Time.Before(stats.First) {
+			stats.First = e.Time
+		}
+		if e.Time.After(stats.Last) {
+			stats.Last = e.Time
+		}
+	}
+
+	for id, count := range seen {
+		stats.TopProverbs = append(stats.TopProverbs, ProverbCount{ID: id, Count: count})
+	}
+	sort.Slice(stats.TopProverbs, func(i, j int) bool {
+		a, b := stats.TopProverbs[i], stats.TopProverbs[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.ID < b.ID
+	})
+	if len(stats.TopProverbs) > top {
+		stats.TopProverbs = stats.TopProverbs[:top]
+	}
+
+	stats.CurrentStreak, stats.LongestStreak = streaks(days, day(now, now.Location()))
+	return stats
+}
+
+// day returns midnight of the day of t in loc
+func day(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// streaks returns the current streak of consecutive days ending today or
+// yesterday and the longest streak of consecutive days
+func streaks(days map[time.Time]bool, today time.Time) (current, longest int) {
+	for d := range days {
+		// Only the first day of a streak starts counting
+		if days[d.AddDate(0, 0, -1)] {
+			continue
+		}
+		n := 1
+		for days[d.AddDate(0, 0, n)] {
+			n++
+		}
+		if n > longest {
+			longest = n
+		}
+	}
+
+	start := today
+	if !days[start] {
+		start = start.AddDate(0, 0, -1)
+	}
+	for days[start.AddDate(0, 0, -current)] {
+		current++
+	}
+	return current, longest
+}
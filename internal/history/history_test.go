@@ -0,0 +1,76 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", FileName)
+	if entries, err := Load(path); err != nil || len(entries) != 0 {
+		t.Fatalf("Load() of a missing file = %v, %v", entries, err)
+	}
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	for _, e := range []Entry{
+		{Time: now, Command: "greet"},
+		{Time: now.Add(time.Minute), Command: "proverb", ProverbID: 18},
+	} {
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	// A line cut short by a crash is skipped
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"time":"2026-10-17T09:02:00Z","comm` + "\n")
+	f.Close()
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[1].ProverbID != 18 || !entries[0].Time.Equal(now) {
+		t.Errorf("Load() = %+v", entries)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC)
+	}
+	entries := []Entry{
+		{Time: at(1, 9), Command: "greet"},
+		{Time: at(2, 9), Command: "proverb", ProverbID: 3},
+		{Time: at(3, 9), Command: "proverb", ProverbID: 5},
+		{Time: at(3, 10), Command: "proverb", ProverbID: 3},
+		{Time: at(10, 9), Command: "greet"},
+		{Time: at(11, 9), Command: "proverb", ProverbID: 7},
+	}
+
+	stats := Summarize(entries, at(12, 8), 2)
+	if stats.Total != 6 || stats.Commands["greet"] != 2 || stats.Commands["proverb"] != 4 {
+		t.Errorf("totals = %d, %v", stats.Total, stats.Commands)
+	}
+	if len(stats.TopProverbs) != 2 || stats.TopProverbs[0] != (ProverbCount{ID: 3, Count: 2}) || stats.TopProverbs[1].ID != 5 {
+		t.Errorf("TopProverbs = %+v", stats.TopProverbs)
+	}
+	if stats.CurrentStreak != 2 || stats.LongestStreak != 3 {
+		t.Errorf("streaks = current %d, longest %d; want 2, 3", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if !stats.First.Equal(at(1, 9)) || !stats.Last.Equal(at(11, 9)) {
+		t.Errorf("First, Last = %v, %v", stats.First, stats.Last)
+	}
+
+	if broken := Summarize(entries, at(13, 8), 2); broken.CurrentStreak != 0 {
+		t.Errorf("CurrentStreak after a day without runs = %d, want 0", broken.CurrentStreak)
+	}
+	if empty := Summarize(nil, at(13, 8), 2); empty.Total != 0 || empty.LongestStreak != 0 {
+		t.Errorf("Summarize(nil) = %+v", empty)
+	}
+}
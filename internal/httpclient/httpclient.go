@@ -0,0 +1,211 @@
+// Package httpclient builds the HTTP clients of the CLI's network
+// operations: the --server client, update checks and webhooks. Every client
+// bounds a call, including its retries, to a timeout, and retries requests
+// that fail because of the network, rate limiting (429) or a server error
+// (5xx) with exponential backoff and jitter. Requests go through the proxy
+// chosen by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables, or one set
+// explicitly; see Proxy.
+//
+//	c := httpclient.New(nil, 10*time.Second, 2)
+//	resp, err := c.Get("https://api.github.com/")
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Defaults of the network flags
+const (
+	// DefaultTimeout bounds a call including its retries
+	DefaultTimeout = 10 * time.Second
+	// DefaultRetries is how often a failed request is retried
+	DefaultRetries = 2
+	// DefaultBackoff is the delay before the first retry; it doubles for
+	// every further retry
+	DefaultBackoff = 250 * time.Millisecond
+)
+
+// MaxBackoff caps the delay between two attempts, including delays asked
+// for with Retry-After
+const MaxBackoff = 5 * time.Second
+
+// New returns a client sending requests through base, or
+// http.DefaultTransport if base is nil. A call, including its retries, may
+// take timeout; zero disables the timeout. Failed requests are retried up
+// to retries times.
+func New(base http.RoundTripper, timeout time.Duration, retries int) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &Transport{Base: base, Retries: retries},
+	}
+}
+
+// ProxyFunc returns the proxy of a request to a URL, or nil to connect
+// directly
+type ProxyFunc func(*url.URL) (*url.URL, error)
+
+// Proxy returns the ProxyFunc sending requests through proxy, or, if proxy
+// is empty, through the proxies set by the HTTP_PROXY and HTTPS_PROXY
+// variables read with lookupEnv. Either way hosts listed in NO_PROXY are
+// reached directly, as are localhost and loopback addresses. The lowercase
+// spellings of the variables are accepted too.
+func Proxy(proxy string, lookupEnv func(string) (string, bool)) (ProxyFunc, error) {
+	cfg := &httpproxy.Config{
+		HTTPProxy:  getenv(lookupEnv, "HTTP_PROXY"),
+		HTTPSProxy: getenv(lookupEnv, "HTTPS_PROXY"),
+		NoProxy:    getenv(lookupEnv, "NO_PROXY"),
+	}
+	if proxy != "" {
+		u, err := ParseProxy(proxy)
+		if err != nil {
+			return nil, err
+		}
+		cfg.HTTPProxy, cfg.HTTPSProxy = u.String(), u.String()
+	}
+	return cfg.ProxyFunc(), nil
+}
+
+// ParseProxy parses a proxy URL such as http://proxy.example.com:3128 or
+// socks5://localhost:1080. A bare host and port is an HTTP proxy.
+func ParseProxy(proxy string) (*url.URL, error) {
+	raw := proxy
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: unsupported scheme %q (use http, https or socks5)", proxy, u.Scheme)
+	}
+}
+
+// getenv returns the variable key, or its lowercase spelling, read with
+// lookupEnv
+func getenv(lookupEnv func(string) (string, bool), key string) string {
+	if value, ok := lookupEnv(key); ok && value != "" {
+		return value
+	}
+	value, _ := lookupEnv(strings.ToLower(key))
+	return value
+}
+
+// NewTransport returns a transport with the settings of
+// http.DefaultTransport that connects through the proxies proxy chooses
+func NewTransport(proxy ProxyFunc) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+	return t
+}
+
+// Transport is an http.RoundTripper retrying failed requests. Requests with
+// a body are only retried if the body can be read again through GetBody, as
+// it can for requests created with a bytes or strings reader.
+type Transport struct {
+	// Base sends the requests; nil uses http.DefaultTransport
+	Base http.RoundTripper
+	// Retries is how often a failed request is retried
+	Retries int
+	// Backoff is the delay before the first retry; zero uses DefaultBackoff
+	Backoff time.Duration
+	// Rand returns the jitter, a number in [0, 1); nil uses math/rand
+	Rand func() float64
+}
+
+// RoundTrip implements http.RoundTripper. It returns the response or error
+// of the last attempt.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+		if attempt >= t.Retries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.delay(attempt, resp)
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable reports whether a request that got resp or err is worth
+// retrying. Network errors are, unless the caller gave up.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// delay returns how long to wait before retrying the attempt that got resp.
+// The exponential backoff is randomized between half and all of its value
+// so clients failing together don't retry together; a longer Retry-After
+// of the response is honored.
+func (t *Transport) delay(attempt int, resp *http.Response) time.Duration {
+	backoff := t.Backoff
+	if backoff <= 0 {
+		backoff = DefaultBackoff
+	}
+	d := backoff << attempt
+	if d > MaxBackoff || d <= 0 {
+		d = MaxBackoff
+	}
+
+	random := t.Rand
+	if random == nil {
+		random = rand.Float64
+	}
+	d = d/2 + time.Duration(random()*float64(d/2))
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			d = max(d, time.Duration(seconds)*time.Second)
+		}
+	}
+	return min(d, MaxBackoff)
+}
@@ -0,0 +1,226 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client of h retrying up to retries times without
+// waiting long between attempts
+func newTestClient(t *testing.T, h http.HandlerFunc, timeout time.Duration, retries int) (*http.Client, string) {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+	c := New(nil, timeout, retries)
+	c.Transport.(*Transport).Backoff = time.Millisecond
+	return c, ts.URL
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	c, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}, time.Second, 2)
+
+	resp, err := c.Post(url, "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("got %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+	for i, body := range bodies {
+		if body != "hello" {
+			t.Errorf("attempt %d sent body %q, want it sent again", i+1, body)
+		}
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int32
+	}{
+		{http.StatusTooManyRequests, 2},
+		{http.StatusBadGateway, 2},
+		{http.StatusNotFound, 1},
+		{http.StatusBadRequest, 1},
+	}
+	for _, tt := range tests {
+		var calls atomic.Int32
+		c, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(tt.status)
+		}, time.Second, 1)
+
+		resp, err := c.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status || calls.Load() != tt.want {
+			t.Errorf("status %d: got %d after %d calls, want %d calls", tt.status, resp.StatusCode, calls.Load(), tt.want)
+		}
+	}
+}
+
+func TestRetriesNetworkErrors(t *testing.T) {
+	var calls atomic.Int32
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return nil, errors.New("connection refused")
+	})
+	c := &http.Client{Transport: &Transport{Base: failing, Retries: 2, Backoff: time.Millisecond}}
+
+	if _, err := c.Get("http://example.invalid/"); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Get() error = %v, want the network error", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("sent %d requests, want 3", n)
+	}
+
+	// A body that can't be read again is sent only once
+	calls.Store(0)
+	req, _ := http.NewRequest(http.MethodPost, "http://example.invalid/", io.NopCloser(strings.NewReader("x")))
+	if _, err := c.Do(req); err == nil || calls.Load() != 1 {
+		t.Errorf("Do() with a one-shot body sent %d requests (error %v), want 1", calls.Load(), err)
+	}
+}
+
+func TestTimeoutIncludesRetries(t *testing.T) {
+	var calls atomic.Int32
+	c, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, 100*time.Millisecond, 5)
+
+	start := time.Now()
+	_, err := c.Get(url)
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() error = %v, want the deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Get() took %v despite the timeout", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("sent %d requests, want 1 before the timeout cut the wait short", n)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+	tests := []struct {
+		attempt int
+		rand    float64
+		resp    *http.Response
+		want    time.Duration
+	}{
+		{0, 0, nil, 50 * time.Millisecond},
+		{0, 0.5, nil, 75 * time.Millisecond},
+		{2, 0, nil, 200 * time.Millisecond},
+		{2, 0.999, nil, 399800 * time.Microsecond},
+		{0, 0, retryAfter("2"), 2 * time.Second},
+		{0, 0, retryAfter("3600"), MaxBackoff},
+		{0, 0, retryAfter("soon"), 50 * time.Millisecond},
+		{10, 0, nil, MaxBackoff / 2},
+		{70, 1, nil, MaxBackoff},
+	}
+	for _, tt := range tests {
+		tr := &Transport{Backoff: 100 * time.Millisecond, Rand: func() float64 { return tt.rand }}
+		if got := tr.delay(tt.attempt, tt.resp); got != tt.want {
+			t.Errorf("delay(%d) with jitter %v = %v, want %v", tt.attempt, tt.rand, got, tt.want)
+		}
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestProxy(t *testing.T) {
+	env := map[string]string{
+		"HTTP_PROXY":  "http://env-proxy:3128",
+		"https_proxy": "http://secure-proxy:3128",
+		"NO_PROXY":    "internal.example.com",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	tests := []struct {
+		flag, target, want string
+	}{
+		{"", "http://proverbs.example.com/", "http://env-proxy:3128"},
+		{"", "https://proverbs.example.com/", "http://secure-proxy:3128"},
+		{"", "http://api.internal.example.com/", ""},
+		{"", "http://localhost:8080/", ""},
+		{"proxy.example.com:8080", "http://proverbs.example.com/", "http://proxy.example.com:8080"},
+		{"socks5://127.0.0.1:1080", "https://proverbs.example.com/", "socks5://127.0.0.1:1080"},
+		{"http://proxy.example.com:8080", "http://internal.example.com/", ""},
+	}
+	for _, tt := range tests {
+		proxy, err := Proxy(tt.flag, lookupEnv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		target, _ := url.Parse(tt.target)
+		got, err := proxy(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (got == nil && tt.want != "") || (got != nil && got.String() != tt.want) {
+			t.Errorf("Proxy(%q) for %s = %v, want %q", tt.flag, tt.target, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"ftp://proxy.example.com", "http://"} {
+		if _, err := Proxy(bad, lookupEnv); err == nil {
+			t.Errorf("Proxy(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestNewTransportUsesProxy(t *testing.T) {
+	var requested string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxy receives the absolute URL of the target
+		requested = r.URL.String()
+		io.WriteString(w, "via proxy")
+	}))
+	defer stub.Close()
+
+	proxy, err := Proxy(stub.URL, func(string) (string, bool) { return "", false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(NewTransport(proxy), time.Second, 0)
+	resp, err := c.Get("http://proverbs.example.com/proverb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "via proxy" || requested != "http://proverbs.example.com/proverb" {
+		t.Errorf("got %q for %q, want the request to go through the proxy", body, requested)
+	}
+}
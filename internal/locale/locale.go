@@ -0,0 +1,81 @@
+// Package locale formats counts and dates for the languages greetings are
+// available in, so figures such as the runs in stats read naturally:
+//
+//	f := locale.New("de")
+//	f.Number(12345)                                       // "12.345"
+//	f.Date(time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)) // "10. November 2026"
+//
+// Numbers are formatted with golang.org/x/text/message; dates use the long
+// date format of each language. Unknown languages are formatted like English.
+package locale
+
+import (
+	"strconv"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// dateFormat is the long date format of a language. In its layout %d is the
+// day, %s the month name and %y the year.
+type dateFormat struct {
+	layout string
+	months [12]string
+}
+
+// dateFormats holds the long date format of every supported language
+var dateFormats = map[string]dateFormat{
+	"en": {"%s %d, %y", [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}},
+	"es": {"%d de %s de %y", [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}},
+	"fr": {"%d %s %y", [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}},
+	"de": {"%d. %s %y", [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}},
+	"pt": {"%d de %s de %y", [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}},
+	"it": {"%d %s %y", [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"}},
+}
+
+// Formatter formats counts and dates for a language
+type Formatter struct {
+	printer *message.Printer
+	date    dateFormat
+}
+
+// New returns the formatter of lang, a base language code such as "de" as
+// returned by greeting.ParseLanguage
+func New(lang string) Formatter {
+	date, ok := dateFormats[lang]
+	if !ok {
+		lang, date = "en", dateFormats["en"]
+	}
+	return Formatter{printer: message.NewPrinter(language.Make(lang)), date: date}
+}
+
+// Number formats n with the digit grouping of the language, such as
+// "12,345" in English and "12 345" in French
+func (f Formatter) Number(n int) string {
+	return f.printer.Sprint(number.Decimal(n))
+}
+
+// Date formats the date of t, such as "November 10, 2026" in English and
+// "10 novembre 2026" in Italian. The year is not grouped.
+func (f Formatter) Date(t time.Time) string {
+	var b []byte
+	layout := f.date.layout
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i+1 == len(layout) {
+			b = append(b, layout[i])
+			continue
+		}
+		i++
+		switch layout[i] {
+		case 'd':
+			b = strconv.AppendInt(b, int64(t.Day()), 10)
+		case 's':
+			b = append(b, f.date.months[t.Month()-1]...)
+		case 'y':
+			b = strconv.AppendInt(b, int64(t.Year()), 10)
+		}
+	}
+	return string(b)
+}
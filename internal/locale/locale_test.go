@@ -0,0 +1,38 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatter(t *testing.T) {
+	gopherDay := time.Date(2026, 11, 10, 23, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lang, number, date string
+	}{
+		{"en", "1,234,567", "November 10, 2026"},
+		{"es", "1.234.567", "10 de noviembre de 2026"},
+		{"fr", "1 234 567", "10 novembre 2026"},
+		{"de", "1.234.567", "10. November 2026"},
+		{"pt", "1.234.567", "10 de novembro de 2026"},
+		{"it", "1.234.567", "10 novembre 2026"},
+		{"xx", "1,234,567", "November 10, 2026"},
+	}
+	for _, tt := range tests {
+		f := New(tt.lang)
+		if got := f.Number(1234567); got != tt.number {
+			t.Errorf("New(%q).Number() = %q, want %q", tt.lang, got, tt.number)
+		}
+		if got := f.Date(gopherDay); got != tt.date {
+			t.Errorf("New(%q).Date() = %q, want %q", tt.lang, got, tt.date)
+		}
+	}
+}
+
+func TestNumberSmall(t *testing.T) {
+	for _, n := range []int{0, 7, 999, -3} {
+		if got, want := New("de").Number(n), map[int]string{0: "0", 7: "7", 999: "999", -3: "-3"}[n]; got != want {
+			t.Errorf("Number(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package logging builds the diagnostic logger enabled with --verbose and
+// --debug.
+//
+// Diagnostics are written with log/slog to stderr so they never mix with the
+// command output on stdout. The logger travels through the command's context.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Log formats accepted by --log-format
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported log formats
+func Formats() []string {
+	return []string{FormatText, FormatJSON}
+}
+
+// New returns a logger writing records at level or above to w in format
+func New(w io.Writer, format string, level slog.Level) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	switch format {
+	case FormatText, "":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", format)
+	}
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// contextKey is the context key of the logger
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or a discarding logger if
+// there is none. ctx may be nil.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return Discard()
+}
+
+// transport logs every request passing through it
+type transport struct {
+	logger *slog.Logger
+	base   http.RoundTripper
+}
+
+// Transport wraps base, or http.DefaultTransport if base is nil, so that
+// every outgoing request is logged at info level with its status and duration
+func Transport(logger *slog.Logger, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{logger: logger, base: base}
+}
+
+// RoundTrip performs the request and logs its outcome. Only the host is
+// logged because webhook URLs carry their secret in the path.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Microsecond)
+
+	if err != nil {
+		t.logger.InfoContext(req.Context(), "http request failed",
+			"method", req.Method, "host", req.URL.Host, "duration", elapsed, "error", err)
+		return nil, err
+	}
+	t.logger.InfoContext(req.Context(), "http request",
+		"method", req.Method, "host", req.URL.Host, "status", resp.StatusCode, "duration", elapsed)
+	return resp, nil
+}
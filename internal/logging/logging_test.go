@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatJSON, slog.LevelInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("hidden")
+	logger.Info("shown", "count", 3)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "shown" || record["count"] != float64(3) {
+		t.Errorf("unexpected record %v", record)
+	}
+
+	if _, err := New(&buf, "xml", slog.LevelInfo); err == nil {
+		t.Error("New() expected error for unsupported format")
+	}
+}
+
+func TestContext(t *testing.T) {
+	if FromContext(nil) == nil || FromContext(context.Background()) == nil {
+		t.Fatal("FromContext() should fall back to a discarding logger")
+	}
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	if got := FromContext(NewContext(context.Background(), logger)); got != logger {
+		t.Error("FromContext() did not return the stored logger")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger, _ := New(&buf, FormatText, slog.LevelInfo)
+	client := &http.Client{Transport: Transport(logger, nil)}
+
+	resp, err := client.Get(ts.URL + "/services/T000/secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	out := buf.String()
+	if !strings.Contains(out, "msg=\"http request\"") || !strings.Contains(out, "status=418") {
+		t.Errorf("expected the request to be logged, got %q", out)
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("the request path must not be logged, got %q", out)
+	}
+}
@@ -0,0 +1,210 @@
+// Package motd installs a login message that prints the proverb of the day.
+//
+// Two mechanisms are supported:
+//
+//   - an update-motd.d script (Debian, Ubuntu and derivatives), shown by
+//     pam_motd to every user at login
+//   - a snippet in the user's shell profile, delimited by marker comments so
+//     it can be updated or removed without touching the rest of the file
+//
+// Both installers are idempotent: installing twice leaves a single copy.
+package motd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScriptDir is the directory scanned by pam_motd for message scripts
+const ScriptDir = "/etc/update-motd.d"
+
+// ScriptName is the file name of the installed update-motd.d script. The
+// number orders it after the system's own messages.
+const ScriptName = "60-hello-gopher"
+
+// Markers delimiting the profile snippet
+const (
+	beginMarker = "# >>> hello-gopher motd >>>"
+	endMarker   = "# <<< hello-gopher motd <<<"
+)
+
+// Result describes what an install or uninstall changed
+type Result string
+
+// Possible results
+const (
+	Installed    Result = "installed"
+	Updated      Result = "updated"
+	Unchanged    Result = "unchanged"
+	Removed      Result = "removed"
+	NotInstalled Result = "not installed"
+)
+
+// command returns the shell command printing the proverb of the day with
+// the hello-gopher binary at binary
+func command(binary string) string {
+	return shellQuote(binary) + " proverb --daily --color never"
+}
+
+// Script returns the update-motd.d script running binary
+func Script(binary string) string {
+	return fmt.Sprintf(`#!/bin/sh
+# Installed by 'hello-gopher motd install'; remove with 'hello-gopher motd uninstall'.
+[ -x %s ] || exit 0
+echo
+%s
+`, shellQuote(binary), command(binary))
+}
+
+// Snippet returns the profile snippet running binary in interactive shells
+func Snippet(binary string) string {
+	return fmt.Sprintf(`%s
+case $- in
+  *i*) [ -x %s ] && %s ;;
+esac
+%s
+`, beginMarker, shellQuote(binary), command(binary), endMarker)
+}
+
+// shellQuote quotes s for POSIX shells
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// InstallScript writes the update-motd.d script for binary to path
+func InstallScript(path, binary string) (Result, error) {
+	content := []byte(Script(binary))
+
+	existing, err := os.ReadFile(path) // #nosec G304 -- path is chosen by the user
+	switch {
+	case err == nil && bytes.Equal(existing, content):
+		return Unchanged, nil
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return "", err
+	}
+
+	result := Installed
+	if err == nil {
+		result = Updated
+	}
+	// #nosec G306 -- update-motd.d scripts must be world-readable and executable
+	if err := os.WriteFile(path, content, 0o755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of an existing file; make sure it is executable
+	if err := os.Chmod(path, 0o755); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// UninstallScript removes the update-motd.d script at path
+func UninstallScript(path string) (Result, error) {
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return NotInstalled, nil
+		}
+		return "", err
+	}
+	return Removed, nil
+}
+
+// InstallSnippet adds the snippet for binary to the profile at path, or
+// replaces a snippet installed earlier
+func InstallSnippet(path, binary string) (Result, error) {
+	existing, err := os.ReadFile(path) // #nosec G304 -- path is the user's own profile
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	snippet := Snippet(binary)
+	content := string(existing)
+	result := Installed
+
+	if rest, found := removeBlock(content); found {
+		if strings.Contains(content, snippet) {
+			return Unchanged, nil
+		}
+		content, result = rest, Updated
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	content += snippet
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+	if err := writeKeepingMode(path, []byte(content)); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// UninstallSnippet removes the snippet from the profile at path
+func UninstallSnippet(path string) (Result, error) {
+	existing, err := os.ReadFile(path) // #nosec G304 -- path is the user's own profile
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return NotInstalled, nil
+		}
+		return "", err
+	}
+
+	content, found := removeBlock(string(existing))
+	if !found {
+		return NotInstalled, nil
+	}
+	if err := writeKeepingMode(path, []byte(content)); err != nil {
+		return "", err
+	}
+	return Removed, nil
+}
+
+// removeBlock cuts the marked snippet, and the blank line separating it from
+// the preceding content, out of content
+func removeBlock(content string) (string, bool) {
+	start := strings.Index(content, beginMarker)
+	if start < 0 {
+		return content, false
+	}
+	end := strings.Index(content[start:], endMarker)
+	if end < 0 {
+		return content, false
+	}
+	end += start + len(endMarker)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	before := strings.TrimSuffix(content[:start], "\n")
+	if content[end:] == "" {
+		before = strings.TrimRight(before, "\n")
+		if before != "" {
+			before += "\n"
+		}
+		return before, true
+	}
+	return before + "\n" + content[end:], true
+}
+
+// writeKeepingMode replaces the contents of path, keeping the permissions of
+// an existing file
+func writeKeepingMode(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(path, data, mode)
+}
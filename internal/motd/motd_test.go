@@ -0,0 +1,115 @@
+package motd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	script := Script("/usr/local/bin/hello-gopher")
+	if !strings.HasPrefix(script, "#!/bin/sh\n") {
+		t.Error("Script() should start with a shebang")
+	}
+	if !strings.Contains(script, "/usr/local/bin/hello-gopher proverb --daily") {
+		t.Errorf("Script() does not run the daily proverb:\n%s", script)
+	}
+
+	if quoted := Script("/opt/my tools/hello-gopher"); !strings.Contains(quoted, "'/opt/my tools/hello-gopher' proverb") {
+		t.Errorf("paths with spaces must be quoted:\n%s", quoted)
+	}
+}
+
+func TestInstallScript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ScriptName)
+
+	steps := []struct {
+		binary string
+		want   Result
+	}{
+		{"/usr/bin/hello-gopher", Installed},
+		{"/usr/bin/hello-gopher", Unchanged},
+		{"/usr/local/bin/hello-gopher", Updated},
+	}
+	for _, step := range steps {
+		got, err := InstallScript(path, step.binary)
+		if err != nil || got != step.want {
+			t.Fatalf("InstallScript(%s) = %q, %v; want %q", step.binary, got, err, step.want)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("script mode %v is not executable", info.Mode())
+	}
+
+	if got, _ := UninstallScript(path); got != Removed {
+		t.Errorf("UninstallScript() = %q, want removed", got)
+	}
+	if got, _ := UninstallScript(path); got != NotInstalled {
+		t.Errorf("second UninstallScript() = %q, want not installed", got)
+	}
+}
+
+func TestInstallSnippet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".profile")
+	original := "export PATH=$HOME/bin:$PATH\n"
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := InstallSnippet(path, "/usr/bin/hello-gopher"); err != nil || got != Installed {
+		t.Fatalf("InstallSnippet() = %q, %v", got, err)
+	}
+	if got, _ := InstallSnippet(path, "/usr/bin/hello-gopher"); got != Unchanged {
+		t.Errorf("second InstallSnippet() = %q, want unchanged", got)
+	}
+	if got, _ := InstallSnippet(path, "/opt/hello-gopher"); got != Updated {
+		t.Errorf("InstallSnippet() with a new binary = %q, want updated", got)
+	}
+
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	if strings.Count(content, beginMarker) != 1 || !strings.Contains(content, "/opt/hello-gopher proverb") {
+		t.Errorf("profile should contain exactly one up-to-date snippet:\n%s", content)
+	}
+	if !strings.HasPrefix(content, original) {
+		t.Errorf("existing profile content was changed:\n%s", content)
+	}
+
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("profile mode changed to %v", info.Mode().Perm())
+	}
+
+	if got, _ := UninstallSnippet(path); got != Removed {
+		t.Errorf("UninstallSnippet() = %q, want removed", got)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != original {
+		t.Errorf("uninstall should restore the original profile, got:\n%q", data)
+	}
+	if got, _ := UninstallSnippet(path); got != NotInstalled {
+		t.Errorf("second UninstallSnippet() = %q, want not installed", got)
+	}
+}
+
+func TestInstallSnippetCreatesProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new", ".zprofile")
+
+	if got, err := InstallSnippet(path, "hello-gopher"); err != nil || got != Installed {
+		t.Fatalf("InstallSnippet() = %q, %v", got, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), beginMarker) {
+		t.Errorf("new profile should start with the snippet:\n%s", data)
+	}
+
+	if got, _ := UninstallSnippet(filepath.Join(t.TempDir(), "missing")); got != NotInstalled {
+		t.Errorf("UninstallSnippet() on a missing file = %q", got)
+	}
+}
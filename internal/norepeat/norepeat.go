@@ -0,0 +1,105 @@
+// Package norepeat remembers the proverbs shown by proverb --no-repeat, so
+// that none is shown twice before the whole collection has been.
+//
+// The IDs shown in the current cycle are kept as a JSON array in
+// <user state dir>/hello-gopher/shown.json.
+package norepeat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// FileName is the name of the file with the shown proverb IDs
+const FileName = "shown.json"
+
+// Store is the set of proverbs shown in the current cycle, backed by a file
+type Store struct {
+	path  string
+	shown map[int]bool
+}
+
+// Load reads the shown proverbs from path. A missing file yields an empty
+// store, which starts a new cycle.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, shown: make(map[int]bool)}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's own state file
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, id := range ids {
+		s.shown[id] = true
+	}
+	return s, nil
+}
+
+// Path returns the file the store is saved to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Reset forgets every shown proverb and starts a new cycle
+func (s *Store) Reset() {
+	s.shown = make(map[int]bool)
+}
+
+// Remaining returns the proverbs of collection not shown in this cycle
+func (s *Store) Remaining(collection []greeting.Proverb) []greeting.Proverb {
+	var remaining []greeting.Proverb
+	for _, p := range collection {
+		if !s.shown[p.ID] {
+			remaining = append(remaining, p)
+		}
+	}
+	return remaining
+}
+
+// Next picks a random proverb of collection that wasn't shown in this cycle
+// and marks it as shown. Once every proverb has been shown a new cycle
+// starts. collection must not be empty.
+func (s *Store) Next(collection []greeting.Proverb, rnd greeting.Rand) greeting.Proverb {
+	remaining := s.Remaining(collection)
+	if len(remaining) == 0 {
+		s.Reset()
+		remaining = collection
+	}
+
+	p := remaining[rnd.Intn(len(remaining))]
+	s.shown[p.ID] = true
+	return p
+}
+
+// Save writes the shown proverbs back to the file they were loaded from,
+// creating parent directories
+func (s *Store) Save() error {
+	ids := make([]int, 0, len(s.shown))
+	for id := range s.shown {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,59 @@
+package norepeat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestNextCyclesThroughCollection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", FileName)
+	collection := []greeting.Proverb{{ID: 1, Text: "a"}, {ID: 2, Text: "b"}, {ID: 3, Text: "c"}}
+	rnd := greeting.NewRand(1)
+
+	seen := make(map[int]bool)
+	for i := 0; i < len(collection); i++ {
+		// Reload every time, like separate runs do
+		s, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		p := s.Next(collection, rnd)
+		if seen[p.ID] {
+			t.Fatalf("proverb %d repeated within a cycle", p.ID)
+		}
+		seen[p.ID] = true
+		if err := s.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.Remaining(collection)); n != 0 {
+		t.Fatalf("%d proverbs remaining after a full cycle", n)
+	}
+	s.Next(collection, rnd)
+	if n := len(s.Remaining(collection)); n != 2 {
+		t.Errorf("a new cycle should have started, %d proverbs remaining", n)
+	}
+
+	s.Reset()
+	if n := len(s.Remaining(collection)); n != 3 {
+		t.Errorf("Reset() left %d proverbs remaining, want 3", n)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("oops"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() should reject a malformed file")
+	}
+}
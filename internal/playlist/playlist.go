@@ -0,0 +1,317 @@
+// Package playlist selects proverbs from playlists: curated selections an
+// admin defines for a purpose, such as an onboarding sequence for new hires.
+//
+// A playlist lists proverb IDs and tags:
+//
+//	onboarding: [15, 4, 1, errors]
+//	concurrency-week: [concurrency=3, design, 19=2]
+//
+// Without weights a playlist is ordered: runs step through its proverbs one
+// after the other, a tag standing for its proverbs in collection order, and
+// start over after the last. A weight on any item makes it weighted: every
+// run picks an item at random in proportion to its weight, 1 unless given,
+// and a random proverb of that item.
+//
+// Playlists are kept in the config file as playlist.<name> settings, or in
+// the format above in playlists.yaml in the data directory. The positions
+// in ordered playlists are kept as a JSON object in
+// <user state dir>/hello-gopher/playlists.json.
+package playlist
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+const (
+	// FileName is the name of the playlist file in the data directory
+	FileName = "playlists.yaml"
+	// StateFileName is the name of the file with the positions in ordered
+	// playlists
+	StateFileName = "playlists.json"
+)
+
+// Item is a proverb ID or a tag of a playlist
+type Item struct {
+	ID     int
+	Tag    string
+	Weight int
+}
+
+// String returns the item as it is written in a playlist
+func (it Item) String() string {
+	s := it.Tag
+	if it.ID != 0 {
+		s = "#" + strconv.Itoa(it.ID)
+	}
+	return s
+}
+
+// matches reports whether p belongs to the item
+func (it Item) matches(p greeting.Proverb) bool {
+	if it.ID != 0 {
+		return p.ID == it.ID
+	}
+	return p.HasTag(it.Tag)
+}
+
+// Playlist is a named selection of proverbs
+type Playlist struct {
+	Name  string
+	Items []Item
+	// Weighted is set when any item has a weight; the playlist is ordered
+	// otherwise
+	Weighted bool
+}
+
+// ValidateName checks that name can be passed to --playlist
+func ValidateName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.Trim(name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+		return fmt.Errorf("invalid playlist name %q (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// Parse parses the playlist name from a list of items such as
+// "[15, 4, errors]" or "[concurrency=3, design]"
+func Parse(name, value string) (*Playlist, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	p := &Playlist{Name: name}
+	for _, field := range config.SplitList(value) {
+		item, err := parseItem(field)
+		if err != nil {
+			return nil, fmt.Errorf("playlist %q: %w", name, err)
+		}
+		if item.Weight != 0 {
+			p.Weighted = true
+		}
+		p.Items = append(p.Items, item)
+	}
+	if len(p.Items) == 0 {
+		return nil, fmt.Errorf("playlist %q is empty", name)
+	}
+	if p.Weighted {
+		for i := range p.Items {
+			if p.Items[i].Weight == 0 {
+				p.Items[i].Weight = 1
+			}
+		}
+	}
+	return p, nil
+}
+
+// parseItem parses a proverb ID or tag with an optional "=<weight>"
+func parseItem(field string) (Item, error) {
+	var item Item
+	field, weight, weighted := strings.Cut(strings.ToLower(field), "=")
+	field = strings.TrimSpace(field)
+	if weighted {
+		n, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || n < 1 {
+			return Item{}, fmt.Errorf("weight %q of %s is not a positive number", weight, field)
+		}
+		item.Weight = n
+	}
+
+	if id, err := strconv.Atoi(field); err == nil {
+		if id < 1 {
+			return Item{}, fmt.Errorf("invalid proverb ID %d (IDs start at 1)", id)
+		}
+		item.ID = id
+		return item, nil
+	}
+	if field == "" || strings.Trim(field, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+		return Item{}, fmt.Errorf("invalid tag %q (use a-z, 0-9 and '-', or a proverb ID)", field)
+	}
+	item.Tag = field
+	return item, nil
+}
+
+// ParseFile reads playlists from r, one "<name>: [items]" per line. Blank
+// lines and '#' comments are ignored.
+func ParseFile(r io.Reader) ([]*Playlist, error) {
+	var playlists []*Playlist
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"name: [items]\", got %q", lineNo, line)
+		}
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		p, err := Parse(strings.TrimSpace(name), value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("line %d: playlist %q is repeated", lineNo, p.Name)
+		}
+		seen[p.Name] = true
+		playlists = append(playlists, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return playlists, nil
+}
+
+// LoadFile reads the playlists of the file at path. A missing file holds no
+// playlists.
+func LoadFile(path string) ([]*Playlist, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own playlist file
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	playlists, err := ParseFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return playlists, nil
+}
+
+// Proverbs returns the proverbs of the ordered playlist p in its order,
+// without repeats. Every item must match a proverb of collection.
+func (p *Playlist) Proverbs(collection []greeting.Proverb) ([]greeting.Proverb, error) {
+	var proverbs []greeting.Proverb
+	seen := make(map[int]bool)
+	for _, item := range p.Items {
+		matches, err := p.match(item, collection)
+		if err != nil {
+			return nil, err
+		}
+		for _, proverb := range matches {
+			if !seen[proverb.ID] {
+				seen[proverb.ID] = true
+				proverbs = append(proverbs, proverb)
+			}
+		}
+	}
+	return proverbs, nil
+}
+
+// Pick returns a random proverb of the weighted playlist p, choosing its
+// item with rnd in proportion to the weights. Every item must match a
+// proverb of collection.
+func (p *Playlist) Pick(collection []greeting.Proverb, rnd greeting.Rand) (greeting.Proverb, error) {
+	matches := make([][]greeting.Proverb, len(p.Items))
+	total := 0
+	for i, item := range p.Items {
+		m, err := p.match(item, collection)
+		if err != nil {
+			return greeting.Proverb{}, err
+		}
+		matches[i] = m
+		total += item.Weight
+	}
+
+	n := rnd.Intn(total)
+	for i, item := range p.Items {
+		if n < item.Weight {
+			return matches[i][rnd.Intn(len(matches[i]))], nil
+		}
+		n -= item.Weight
+	}
+	return greeting.Proverb{}, fmt.Errorf("playlist %q has no weights", p.Name)
+}
+
+// match returns the proverbs of collection belonging to item
+func (p *Playlist) match(item Item, collection []greeting.Proverb) ([]greeting.Proverb, error) {
+	var matches []greeting.Proverb
+	for _, proverb := range collection {
+		if item.matches(proverb) {
+			matches = append(matches, proverb)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("playlist %q: %s matches no proverb", p.Name, item)
+	}
+	return matches, nil
+}
+
+// State holds the positions in ordered playlists, backed by a file
+type State struct {
+	path      string
+	positions map[string]int
+}
+
+// LoadState reads the positions from path. A missing file starts every
+// playlist at its first proverb.
+func LoadState(path string) (*State, error) {
+	s := &State{path: path, positions: make(map[string]int)}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's own state file
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.positions); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Reset starts the playlist name over at its first proverb
+func (s *State) Reset(name string) {
+	delete(s.positions, name)
+}
+
+// Next returns the next proverb of p from collection: the one after the
+// last shown for an ordered playlist, which it remembers, or a random pick
+// for a weighted one.
+func (s *State) Next(p *Playlist, collection []greeting.Proverb, rnd greeting.Rand) (greeting.Proverb, error) {
+	if p.Weighted {
+		return p.Pick(collection, rnd)
+	}
+	proverbs, err := p.Proverbs(collection)
+	if err != nil {
+		return greeting.Proverb{}, err
+	}
+	// The playlist may have shrunk since the position was saved, and a
+	// hand-edited file may hold a negative position
+	n := len(proverbs)
+	pos := (s.positions[p.Name]%n + n) % n
+	s.positions[p.Name] = pos + 1
+	return proverbs[pos], nil
+}
+
+// Save writes the positions back to the file they were loaded from,
+// creating parent directories
+func (s *State) Save() error {
+	data, err := json.Marshal(s.positions)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,143 @@
+package playlist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+var collection = []greeting.Proverb{
+	{ID: 1, Text: "a", Tags: []string{"errors"}},
+	{ID: 2, Text: "b", Tags: []string{"concurrency"}},
+	{ID: 3, Text: "c", Tags: []string{"errors", "design"}},
+	{ID: 4, Text: "d", Tags: []string{"concurrency"}},
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse("onboarding", "[3, Errors]")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := &Playlist{Name: "onboarding", Items: []Item{{ID: 3}, {Tag: "errors"}}}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Parse() = %+v, want %+v", p, want)
+	}
+
+	p, err = Parse("mix", "[concurrency=3, 1]")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want = &Playlist{Name: "mix", Items: []Item{{Tag: "concurrency", Weight: 3}, {ID: 1, Weight: 1}}, Weighted: true}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Parse() = %+v, want %+v", p, want)
+	}
+
+	for _, tt := range []struct{ name, value string }{
+		{"new hires", "[1]"},
+		{"empty", "[]"},
+		{"zero", "[0]"},
+		{"weight", "[errors=0]"},
+		{"tag", "[no_tag]"},
+	} {
+		if _, err := Parse(tt.name, tt.value); err == nil {
+			t.Errorf("Parse(%q, %q) succeeded", tt.name, tt.value)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	playlists, err := ParseFile(strings.NewReader("# Curated by the platform team\n\nonboarding: [3, 1]  # new hires\nmix: [errors=2, 4]\n"))
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if len(playlists) != 2 || playlists[0].Name != "onboarding" || len(playlists[0].Items) != 2 || !playlists[1].Weighted {
+		t.Errorf("ParseFile() = %+v", playlists)
+	}
+
+	for _, data := range []string{"onboarding [1]\n", "a: [1]\na: [2]\n", "a: [x y]\n"} {
+		if _, err := ParseFile(strings.NewReader(data)); err == nil || !strings.HasPrefix(err.Error(), "line ") {
+			t.Errorf("ParseFile(%q) error = %v, want an error with a line number", data, err)
+		}
+	}
+
+	if playlists, err := LoadFile(filepath.Join(t.TempDir(), FileName)); err != nil || playlists != nil {
+		t.Errorf("LoadFile() of a missing file = %v, %v", playlists, err)
+	}
+}
+
+func TestNextOrdered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", StateFileName)
+	p, _ := Parse("onboarding", "[4, errors, 1]")
+
+	var ids []int
+	for i := 0; i < 4; i++ {
+		// Reload every time, like separate runs do
+		s, err := LoadState(path)
+		if err != nil {
+			t.Fatalf("LoadState() error = %v", err)
+		}
+		proverb, err := s.Next(p, collection, greeting.NewRand(1))
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		ids = append(ids, proverb.ID)
+		if err := s.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if want := []int{4, 1, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Next() picked %v, want %v", ids, want)
+	}
+
+	s, _ := LoadState(path)
+	s.Reset("onboarding")
+	if proverb, _ := s.Next(p, collection, nil); proverb.ID != 4 {
+		t.Errorf("Next() after Reset() = #%d, want #4", proverb.ID)
+	}
+}
+
+func TestNextNegativePosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StateFileName)
+	if err := os.WriteFile(path, []byte(`{"onboarding": -4}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := Parse("onboarding", "[4, errors, 1]")
+
+	s, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	proverb, err := s.Next(p, collection, nil)
+	if err != nil || proverb.ID != 3 {
+		t.Errorf("Next() from position -4 = #%d, %v; want #3", proverb.ID, err)
+	}
+}
+
+func TestPickWeighted(t *testing.T) {
+	p, _ := Parse("mix", "[concurrency=9, 1]")
+	rnd := greeting.NewRand(7)
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		proverb, err := p.Pick(collection, rnd)
+		if err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+		counts[proverb.ID]++
+	}
+	if counts[3] != 0 || counts[1] == 0 || counts[2]+counts[4] < 5*counts[1] {
+		t.Errorf("Pick() counts = %v, want mostly concurrency proverbs", counts)
+	}
+}
+
+func TestUnmatchedItem(t *testing.T) {
+	for _, value := range []string{"[1, 99]", "[errors=2, generics]"} {
+		p, _ := Parse("typo", value)
+		if _, err := (&State{positions: map[string]int{}}).Next(p, collection, greeting.NewRand(1)); err == nil || !strings.Contains(err.Error(), "matches no proverb") {
+			t.Errorf("Next() of %s error = %v, want an unmatched item", value, err)
+		}
+	}
+}
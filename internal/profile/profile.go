@@ -0,0 +1,186 @@
+// Package profile reads profile files: lists of people to greet, each with
+// their own language, honorific and greeting style, for batch personalized
+// greetings.
+//
+// Profile files are a YAML list of mappings:
+//
+//	# people.yaml
+//	- name: Alice
+//	  language: de
+//	  honorific: Dr.
+//	  style: formal
+//	- name: Bob
+//	  style: casual
+//
+// Only name is required; the other keys fall back to the settings of the
+// greet command. Values may be quoted, and '#' starts a comment.
+package profile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// Profile is a person to greet
+type Profile struct {
+	Name string `json:"name"`
+	// Language, Honorific and Style are validated; empty values leave the
+	// choice to the caller
+	Language  string         `json:"language,omitempty"`
+	Honorific string         `json:"honorific,omitempty"`
+	Style     greeting.Style `json:"style,omitempty"`
+	// Line is the line of the file the profile starts on
+	Line int `json:"-"`
+}
+
+// Options returns the greeting options for the fields set in p
+func (p Profile) Options() []greeting.Option {
+	var opts []greeting.Option
+	if p.Language != "" {
+		opts = append(opts, greeting.WithLanguage(p.Language))
+	}
+	if p.Style != "" {
+		opts = append(opts, greeting.WithStyle(p.Style))
+	}
+	if p.Honorific != "" {
+		opts = append(opts, greeting.WithTitle(p.Honorific))
+	}
+	return opts
+}
+
+// Parse reads the profiles from r, reporting the line of the first problem
+func Parse(r io.Reader) ([]Profile, error) {
+	var profiles []Profile
+	var current *Profile
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := stripComment(scanner.Text())
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			if err := finish(current); err != nil {
+				return nil, err
+			}
+			profiles = append(profiles, Profile{Line: lineNo})
+			current = &profiles[len(profiles)-1]
+			seen = make(map[string]bool)
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			if trimmed == "" {
+				continue
+			}
+		} else if current == nil {
+			return nil, fmt.Errorf("line %d: expected a list entry starting with \"- name:\", got %q", lineNo, trimmed)
+		} else if line == trimmed {
+			return nil, fmt.Errorf("line %d: indent the keys of an entry, got %q", lineNo, trimmed)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\", got %q", lineNo, trimmed)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if seen[key] {
+			return nil, fmt.Errorf("line %d: %s is repeated", lineNo, key)
+		}
+		seen[key] = true
+		if err := set(current, key, unquote(strings.TrimSpace(value))); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(current); err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no profiles found")
+	}
+	return profiles, nil
+}
+
+// set validates value and stores it as key of p
+func set(p *Profile, key, value string) error {
+	var err error
+	switch key {
+	case "name":
+		p.Name = value
+	case "language":
+		p.Language, err = greeting.ParseLanguage(value)
+	case "honorific":
+		p.Honorific, err = greeting.ParseTitle(value)
+	case "style":
+		p.Style, err = greeting.ParseStyle(value)
+	default:
+		return fmt.Errorf("unknown key %q (known: name, language, honorific, style)", key)
+	}
+	return err
+}
+
+// finish checks the profile read last, if any
+func finish(p *Profile) error {
+	if p != nil && strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("line %d: the entry has no name", p.Line)
+	}
+	return nil
+}
+
+// stripComment removes a '#' comment that starts the line or follows
+// whitespace, outside of quotes
+func stripComment(line string) string {
+	quote := rune(0)
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquote removes the double or single quotes around value
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		if s, err := strconv.Unquote(value); err == nil {
+			return s
+		}
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
+
+// Load reads the profile file at path
+func Load(path string) ([]Profile, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is a file the user passed
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return profiles, nil
+}
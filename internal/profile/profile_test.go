@@ -0,0 +1,83 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestParse(t *testing.T) {
+	input := `# The team
+- name: Alice
+  language: DE
+  honorific: Dr.
+  style: formal   # for the board
+-
+  name: "Bob # not a comment"
+  style: casual
+- name: 'O''Brien'
+`
+	profiles, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Profile{
+		{Name: "Alice", Language: "de", Honorific: "Dr.", Style: greeting.StyleFormal, Line: 2},
+		{Name: "Bob # not a comment", Style: greeting.StyleCasual, Line: 6},
+		{Name: "O'Brien", Line: 9},
+	}
+	if !reflect.DeepEqual(profiles, want) {
+		t.Errorf("Parse() = %+v, want %+v", profiles, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"name: Alice\n":                          "line 1: expected a list entry",
+		"- name: Alice\nlanguage: de\n":          "line 2: indent the keys",
+		"- name: Alice\n  language: xx\n":        "line 2: unsupported language",
+		"- name: Alice\n  style: grumpy\n":       "line 2:",
+		"- name: Alice\n  honorific: Dr.1\n":     "line 2: title",
+		"- name: Alice\n  age: 30\n":             `line 2: unknown key "age"`,
+		"- name: Alice\n  name: Bob\n":           "line 2: name is repeated",
+		"- name: Alice\n  style\n":               "line 2: expected \"key: value\"",
+		"- language: de\n- name: Bob\n":          "line 1: the entry has no name",
+		"# nobody\n":                             "no profiles found",
+		"- name: Alice\n- style: formal\n":       "line 2: the entry has no name",
+		"- name: Alice\n  honorific: \"Dr.\"x\n": "line 2: title",
+		"- name: Alice\n  language: de # ok\n- ": "line 3: the entry has no name",
+	}
+	for input, want := range tests {
+		_, err := Parse(strings.NewReader(input))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want one containing %q", input, err, want)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	g := greeting.NewService(Profile{Name: "Alice", Language: "de", Honorific: "Dr.", Style: greeting.StyleFormal}.Options()...)
+	if got, want := g.Greet("Alice"), "Guten Tag, Dr. Alice."; got != want {
+		t.Errorf("Greet() with profile options = %q, want %q", got, want)
+	}
+	if opts := (Profile{Name: "Bob"}).Options(); len(opts) != 0 {
+		t.Errorf("Options() of a profile with only a name = %d options, want none", len(opts))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.yaml")
+	if err := os.WriteFile(path, []byte("- name: Alice\n  style: bogus\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path+": line 2") {
+		t.Errorf("Load() error = %v, want one naming the file and line", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); !os.IsNotExist(err) {
+		t.Errorf("Load() of a missing file error = %v, want a not-exist error", err)
+	}
+}
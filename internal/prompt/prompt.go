@@ -0,0 +1,39 @@
+// Package prompt asks the user questions on an interactive terminal.
+//
+// Questions are written to one stream and answers read from another, so
+// tests can drive a prompt with an in-memory reader and writer.
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ask writes question to w, followed by def in brackets when it is not
+// empty, and reads a one-line answer from r. An empty answer or end of input
+// selects def. Surrounding whitespace is trimmed from the answer.
+func Ask(r io.Reader, w io.Writer, question, def string) (string, error) {
+	if def != "" {
+		question = fmt.Sprintf("%s [%s]", question, def)
+	}
+	if _, err := fmt.Fprintf(w, "%s ", question); err != nil {
+		return "", err
+	}
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if errors.Is(err, io.EOF) && line == "" {
+		// Keep the terminal tidy when the user answers with Ctrl-D
+		fmt.Fprintln(w)
+	}
+
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
@@ -0,0 +1,50 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAsk(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		def       string
+		want      string
+		wantAsked string
+	}{
+		{"answer", "Alice\n", "Gopher", "Alice", "Who? [Gopher] "},
+		{"trimmed answer", "  Bob \r\n", "Gopher", "Bob", "Who? [Gopher] "},
+		{"empty answer", "\n", "Gopher", "Gopher", "Who? [Gopher] "},
+		{"no newline", "Carol", "Gopher", "Carol", "Who? [Gopher] "},
+		{"end of input", "", "Gopher", "Gopher", "Who? [Gopher] \n"},
+		{"no default", "Dave\n", "", "Dave", "Who? "},
+		{"only first line", "Eve\nMallory\n", "", "Eve", "Who? "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := Ask(strings.NewReader(tt.input), &out, "Who?", tt.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Ask() = %q, want %q", got, tt.want)
+			}
+			if out.String() != tt.wantAsked {
+				t.Errorf("prompt = %q, want %q", out.String(), tt.wantAsked)
+			}
+		})
+	}
+}
+
+func TestAskReadError(t *testing.T) {
+	readErr := errors.New("broken terminal")
+	if _, err := Ask(iotest.ErrReader(readErr), &bytes.Buffer{}, "Who?", "Gopher"); !errors.Is(err, readErr) {
+		t.Errorf("Ask() error = %v, want %v", err, readErr)
+	}
+}
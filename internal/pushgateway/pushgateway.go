@@ -0,0 +1,77 @@
+// Package pushgateway pushes the metrics of batch jobs, such as a daily cron
+// run of hello-gopher, to a Prometheus Pushgateway, so operators can alert
+// when a job stops running or fails. See
+// https://github.com/prometheus/pushgateway.
+//
+// Metrics are sent in the Prometheus text exposition format with a POST to
+// <gateway>/metrics/job/<job>/instance/<instance>, which replaces the pushed
+// metrics of the same name and keeps the others, so the time of the last
+// success survives failed runs:
+//
+//	err := pushgateway.Push(ctx, client, "http://pushgateway:9091", "hello_gopher", host, metrics)
+package pushgateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultJob is the job label of the pushed metrics
+const DefaultJob = "hello_gopher"
+
+// Metric is a gauge with its current value
+type Metric struct {
+	// Name is a Prometheus metric name, such as hello_gopher_last_run_success
+	Name string
+	// Help describes the metric
+	Help  string
+	Value float64
+}
+
+// Encode returns metrics in the Prometheus text exposition format
+func Encode(metrics []Metric) []byte {
+	var buf bytes.Buffer
+	for _, m := range metrics {
+		help := strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(m.Help)
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n",
+			m.Name, help, m.Name, m.Name, strconv.FormatFloat(m.Value, 'g', -1, 64))
+	}
+	return buf.Bytes()
+}
+
+// Push sends metrics to the Pushgateway at gateway, grouped by job and, if
+// it isn't empty, instance. It fails unless the gateway answers with a 2xx
+// status.
+func Push(ctx context.Context, client *http.Client, gateway, job, instance string, metrics []Metric) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	target := strings.TrimSuffix(gateway, "/") + "/metrics/job/" + url.PathEscape(job)
+	if instance != "" {
+		target += "/instance/" + url.PathEscape(instance)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(Encode(metrics)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pushgateway returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
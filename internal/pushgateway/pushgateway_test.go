@@ -0,0 +1,54 @@
+package pushgateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	got := string(Encode([]Metric{
+		{Name: "job_success", Help: "Whether the job\nsucceeded", Value: 1},
+		{Name: "job_timestamp_seconds", Help: "When it ran", Value: 1793919600.5},
+	}))
+	want := `# HELP job_success Whether the job\nsucceeded
+# TYPE job_success gauge
+job_success 1
+# HELP job_timestamp_seconds When it ran
+# TYPE job_timestamp_seconds gauge
+job_timestamp_seconds 1.7939196005e+09
+`
+	if got != want {
+		t.Errorf("Encode() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPush(t *testing.T) {
+	var method, path, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.EscapedPath(), string(data)
+		if strings.Contains(path, "broken") {
+			http.Error(w, "pushed metrics are invalid", http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	metrics := []Metric{{Name: "job_success", Help: "ok", Value: 1}}
+	if err := Push(context.Background(), nil, ts.URL+"/", DefaultJob, "cron host", metrics); err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+	if method != http.MethodPost || path != "/metrics/job/hello_gopher/instance/cron%20host" || !strings.Contains(body, "job_success 1\n") {
+		t.Errorf("gateway received %s %s with %q", method, path, body)
+	}
+
+	if err := Push(context.Background(), nil, ts.URL, "broken", "", metrics); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Push() error = %v, want the status", err)
+	}
+	if path != "/metrics/job/broken" {
+		t.Errorf("Push() without instance went to %s", path)
+	}
+}
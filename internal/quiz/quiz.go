@@ -0,0 +1,221 @@
+// Package quiz turns proverbs into fill-in-the-blank and multiple-choice
+// questions and keeps the progress of past quizzes.
+//
+// Progress is kept as JSON in <user state dir>/hello-gopher/quiz.json.
+package quiz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// FileName is the name of the file with the quiz progress
+const FileName = "quiz.json"
+
+// Question kinds
+const (
+	// Fill asks for the missing word of a proverb
+	Fill = "fill"
+	// Choice asks to pick the missing word among a few options
+	Choice = "choice"
+)
+
+// Blank replaces the missing word in the prompt of a question
+const Blank = "_____"
+
+// choices is how many options a multiple-choice question offers
+const choices = 4
+
+// minWordLength is the length of the shortest word worth asking for
+const minWordLength = 4
+
+// Question asks for a word missing from a proverb
+type Question struct {
+	Proverb greeting.Proverb
+	Kind    string
+	// Prompt is the proverb text with the missing word replaced by Blank
+	Prompt string
+	// Answer is the missing word
+	Answer string
+	// Choices are the options of a Choice question, including Answer
+	Choices []string
+}
+
+// New returns a question of kind about p. The distractors of a Choice
+// question are words of the other proverbs of collection. It reports false
+// when p has no word worth asking for, or a Choice question can't be given
+// enough distractors.
+func New(kind string, p greeting.Proverb, collection []greeting.Proverb, rnd greeting.Rand) (Question, bool) {
+	words := strings.Fields(p.Text)
+	var candidates []int
+	for i, w := range words {
+		if isQuizWord(trimWord(w)) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return Question{}, false
+	}
+
+	i := candidates[rnd.Intn(len(candidates))]
+	answer := trimWord(words[i])
+	words[i] = strings.Replace(words[i], answer, Blank, 1)
+	q := Question{Proverb: p, Kind: kind, Prompt: strings.Join(words, " "), Answer: answer}
+	if kind != Choice {
+		return q, true
+	}
+
+	distractors := distractors(answer, p.ID, collection)
+	if len(distractors) < choices-1 {
+		return Question{}, false
+	}
+	for i := range choices - 1 {
+		j := i + rnd.Intn(len(distractors)-i)
+		distractors[i], distractors[j] = distractors[j], distractors[i]
+	}
+	q.Choices = append(distractors[:choices-1:choices-1], answer)
+	for i := len(q.Choices) - 1; i > 0; i-- {
+		j := rnd.Intn(i + 1)
+		q.Choices[i], q.Choices[j] = q.Choices[j], q.Choices[i]
+	}
+	return q, true
+}
+
+// Check reports whether answer is right. Case and surrounding punctuation
+// are ignored; Choice questions also accept the number of the option.
+func (q Question) Check(answer string) bool {
+	answer = strings.TrimSpace(answer)
+	if q.Kind == Choice {
+		if n, err := strconv.Atoi(answer); err == nil {
+			return n >= 1 && n <= len(q.Choices) && q.Choices[n-1] == q.Answer
+		}
+	}
+	return strings.EqualFold(trimWord(answer), q.Answer)
+}
+
+// distractors returns the distinct quiz words of the proverbs of collection
+// other than the one with id, leaving out answer
+func distractors(answer string, id int, collection []greeting.Proverb) []string {
+	seen := map[string]bool{strings.ToLower(answer): true}
+	var words []string
+	for _, p := range collection {
+		if p.ID == id {
+			continue
+		}
+		for _, w := range strings.Fields(p.Text) {
+			w = trimWord(w)
+			if key := strings.ToLower(w); isQuizWord(w) && !seen[key] {
+				seen[key] = true
+				words = append(words, w)
+			}
+		}
+	}
+	return words
+}
+
+// trimWord strips the punctuation around a word of a proverb
+func trimWord(w string) string {
+	return strings.TrimFunc(w, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// isQuizWord reports whether w is long enough to be asked for and only made
+// of letters, which leaves out contractions and identifiers such as x.Y
+func isQuizWord(w string) bool {
+	n := 0
+	for _, r := range w {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+		n++
+	}
+	return n >= minWordLength
+}
+
+// Score counts the questions asked about a proverb and the right answers
+type Score struct {
+	Asked   int `json:"asked"`
+	Correct int `json:"correct"`
+}
+
+// Progress sums up every saved quiz, backed by a file
+type Progress struct {
+	path string
+
+	Sessions int `json:"sessions"`
+	Score
+	// Proverbs maps proverb IDs to the score of their questions
+	Proverbs map[int]Score `json:"proverbs,omitempty"`
+}
+
+// Load reads the progress from path. A missing file yields empty progress.
+func Load(path string) (*Progress, error) {
+	p := &Progress{path: path}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's own state file
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, p); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if p.Proverbs == nil {
+		p.Proverbs = make(map[int]Score)
+	}
+	return p, nil
+}
+
+// Path returns the file the progress is saved to
+func (p *Progress) Path() string {
+	return p.path
+}
+
+// Record adds the answer to a question about the proverb with id
+func (p *Progress) Record(id int, correct bool) {
+	s := p.Proverbs[id]
+	s.Asked++
+	p.Asked++
+	if correct {
+		s.Correct++
+		p.Correct++
+	}
+	p.Proverbs[id] = s
+}
+
+// Learned returns how many proverbs were answered right at least once
+func (p *Progress) Learned() int {
+	n := 0
+	for _, s := range p.Proverbs {
+		if s.Correct > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Save counts a finished session and writes the progress back to the file
+// it was loaded from, creating parent directories
+func (p *Progress) Save() error {
+	p.Sessions++
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(p.path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,111 @@
+package quiz
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+var collection = []greeting.Proverb{
+	{ID: 1, Text: "Errors are values."},
+	{ID: 2, Text: "Clear is better than clever."},
+	{ID: 3, Text: "Don't be shy."},
+	{ID: 4, Text: "Make the zero value useful."},
+}
+
+func TestNewFill(t *testing.T) {
+	rnd := greeting.NewRand(1)
+	for i := 0; i < 20; i++ {
+		q, ok := New(Fill, collection[0], collection, rnd)
+		if !ok {
+			t.Fatal("New() found no word to ask for")
+		}
+		// "are" is too short to be asked for
+		if q.Answer != "Errors" && q.Answer != "values" {
+			t.Fatalf("Answer = %q", q.Answer)
+		}
+		if want := strings.Replace(collection[0].Text, q.Answer, Blank, 1); q.Prompt != want {
+			t.Errorf("Prompt = %q, want %q", q.Prompt, want)
+		}
+		if len(q.Choices) != 0 {
+			t.Errorf("fill question has choices %q", q.Choices)
+		}
+	}
+
+	if _, ok := New(Fill, collection[2], collection, rnd); ok {
+		t.Error("New() asked about a proverb without quiz words")
+	}
+}
+
+func TestNewChoice(t *testing.T) {
+	q, ok := New(Choice, collection[1], collection, greeting.NewRand(2))
+	if !ok {
+		t.Fatal("New() found no question")
+	}
+	if len(q.Choices) != choices {
+		t.Fatalf("Choices = %q, want %d", q.Choices, choices)
+	}
+	seen := make(map[string]bool)
+	for _, c := range q.Choices {
+		if seen[c] {
+			t.Errorf("choice %q repeated", c)
+		}
+		seen[c] = true
+	}
+	if !seen[q.Answer] {
+		t.Errorf("Choices %q miss the answer %q", q.Choices, q.Answer)
+	}
+
+	if _, ok := New(Choice, collection[1], collection[:2], greeting.NewRand(2)); ok {
+		t.Error("New() asked a choice question without enough distractors")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	q := Question{Kind: Choice, Answer: "clever", Choices: []string{"values", "clever", "useful", "Errors"}}
+	for answer, want := range map[string]bool{
+		"clever":    true,
+		" Clever. ": true,
+		"2":         true,
+		"1":         false,
+		"5":         false,
+		"useful":    false,
+		"":          false,
+	} {
+		if got := q.Check(answer); got != want {
+			t.Errorf("Check(%q) = %v, want %v", answer, got, want)
+		}
+	}
+
+	q.Kind = Fill
+	if q.Check("2") {
+		t.Error("a fill question accepted an option number")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", FileName)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Record(1, false)
+	p.Record(1, true)
+	p.Record(2, false)
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Sessions != 1 || p.Asked != 3 || p.Correct != 1 || p.Learned() != 1 {
+		t.Errorf("progress = %+v, learned %d", *p, p.Learned())
+	}
+	if s := p.Proverbs[1]; s != (Score{Asked: 2, Correct: 1}) {
+		t.Errorf("score of proverb 1 = %+v", s)
+	}
+}
@@ -0,0 +1,185 @@
+// Package site renders the proverb collection as a static website.
+//
+// The generated site is plain HTML with relative links, so it can be hosted
+// from any directory, for example on GitHub Pages:
+//
+//	index.html           every proverb with client-side search
+//	proverbs/<id>.html   one page per proverb
+//	tags/<tag>.html      one page per tag
+//	search.json          the search index used by index.html
+//	style.css
+package site
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// templates holds the parsed page templates
+var templates = template.Must(template.New("").Funcs(template.FuncMap{"slug": slug}).ParseFS(templateFS, "templates/*.html"))
+
+// SearchEntry is an element of search.json
+type SearchEntry struct {
+	ID   int      `json:"id"`
+	Text string   `json:"text"`
+	Tags []string `json:"tags"`
+	URL  string   `json:"url"`
+}
+
+// page is the data passed to every template
+type page struct {
+	Title string
+	// Root is the relative path from the page back to the site root
+	Root     string
+	Proverbs []greeting.Proverb
+	AllTags  []string
+
+	// Set on proverb pages
+	Proverb    greeting.Proverb
+	Tags       []string
+	Prev, Next *greeting.Proverb
+
+	// Set on tag pages
+	Tag string
+}
+
+// Generate writes the site for proverbs into dir, creating it if needed, and
+// returns the number of files written
+func Generate(dir string, proverbs []greeting.Proverb) (int, error) {
+	g := &generator{dir: dir}
+	tags := collectTags(proverbs)
+
+	g.render("index.html", "index.html", page{Title: "Go Proverbs", Proverbs: proverbs, AllTags: tags})
+
+	for i, p := range proverbs {
+		data := page{Title: fmt.Sprintf("Go Proverb #%d", p.ID), Root: "../", Proverb: p, Tags: p.Tags}
+		if i > 0 {
+			data.Prev = &proverbs[i-1]
+		}
+		if i < len(proverbs)-1 {
+			data.Next = &proverbs[i+1]
+		}
+		g.render(filepath.Join("proverbs", strconv.Itoa(p.ID)+".html"), "proverb.html", data)
+	}
+
+	for _, tag := range tags {
+		tagged := greeting.Filter{Tag: tag}.Apply(proverbs)
+		g.render(filepath.Join("tags", slug(tag)+".html"), "tag.html", page{Title: "Go Proverbs: " + tag, Root: "../", Proverbs: tagged, Tag: tag})
+	}
+
+	g.writeJSON("search.json", searchIndex(proverbs))
+
+	css, err := templateFS.ReadFile("templates/style.css")
+	if err != nil && g.err == nil {
+		g.err = err
+	}
+	g.write("style.css", css)
+
+	return g.files, g.err
+}
+
+// generator writes files below dir and remembers the first error
+type generator struct {
+	dir   string
+	files int
+	err   error
+}
+
+// render executes the named template into the file at name
+func (g *generator) render(name, tmpl string, data page) {
+	if g.err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+		g.err = fmt.Errorf("rendering %s: %w", name, err)
+		return
+	}
+	g.write(name, buf.Bytes())
+}
+
+// writeJSON encodes v into the file at name
+func (g *generator) writeJSON(name string, v interface{}) {
+	if g.err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		g.err = err
+		return
+	}
+	g.write(name, append(data, '\n'))
+}
+
+// write stores data in the file at name, creating parent directories
+func (g *generator) write(name string, data []byte) {
+	if g.err != nil {
+		return
+	}
+	path := filepath.Join(g.dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		g.err = err
+		return
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil { // #nosec G306 -- the site is meant to be published
+		g.err = err
+		return
+	}
+	g.files++
+}
+
+// searchIndex builds the entries of search.json
+func searchIndex(proverbs []greeting.Proverb) []SearchEntry {
+	index := make([]SearchEntry, 0, len(proverbs))
+	for _, p := range proverbs {
+		tags := p.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		index = append(index, SearchEntry{ID: p.ID, Text: p.Text, Tags: tags, URL: fmt.Sprintf("proverbs/%d.html", p.ID)})
+	}
+	return index
+}
+
+// collectTags returns the distinct tags of proverbs in sorted order
+func collectTags(proverbs []greeting.Proverb) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, p := range proverbs {
+		for _, t := range p.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// slug turns a tag into a safe file name
+func slug(tag string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(tag) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
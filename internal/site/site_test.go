@@ -0,0 +1,82 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+var testProverbs = []greeting.Proverb{
+	{ID: 1, Text: "Clear is better than clever.", Tags: []string{"style"}},
+	{ID: 2, Text: "Errors are values.", Tags: []string{"errors", "design"}},
+	{ID: 3, Text: "<script>alert(1)</script> is not a proverb."},
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("reading %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	files, err := Generate(dir, testProverbs)
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	// index, 3 proverb pages, 3 tag pages, search.json and style.css
+	if files != 9 {
+		t.Errorf("Generate() wrote %d files, want 9", files)
+	}
+
+	index := readFile(t, dir, "index.html")
+	for _, want := range []string{`href="proverbs/2.html"`, "Errors are values.", `href="tags/design.html"`, `href="style.css"`} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html missing %q", want)
+		}
+	}
+	if strings.Contains(index, "<script>alert(1)") {
+		t.Error("proverb text must be HTML-escaped")
+	}
+
+	page := readFile(t, dir, "proverbs/2.html")
+	for _, want := range []string{"Errors are values.", `href="../tags/errors.html"`, `href="1.html"`, `href="3.html"`, `href="../style.css"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("proverbs/2.html missing %q", want)
+		}
+	}
+
+	tag := readFile(t, dir, "tags/style.html")
+	if !strings.Contains(tag, "Clear is better than clever.") || strings.Contains(tag, "Errors are values.") {
+		t.Errorf("tags/style.html lists the wrong proverbs:\n%s", tag)
+	}
+
+	var index2 []SearchEntry
+	if err := json.Unmarshal([]byte(readFile(t, dir, "search.json")), &index2); err != nil {
+		t.Fatalf("search.json is not valid JSON: %v", err)
+	}
+	if len(index2) != 3 || index2[1].URL != "proverbs/2.html" || index2[2].Tags == nil {
+		t.Errorf("search.json = %+v", index2)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"concurrency": "concurrency",
+		"Go 1.22":     "go-1-22",
+		"../etc":      "---etc",
+	}
+	for input, want := range tests {
+		if got := slug(input); got != want {
+			t.Errorf("slug(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package sshserver
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// HostKeyFileName is the name of the host key created in the data directory
+// when no other key is configured
+const HostKeyFileName = "ssh_host_ed25519_key"
+
+// LoadOrCreateHostKey reads the private host key at path, in PEM or OpenSSH
+// format. If the file doesn't exist, a new Ed25519 key is generated and saved
+// there readable only by the owner, and created reports true. Clients remember
+// the host key, so it must stay the same across restarts.
+func LoadOrCreateHostKey(path string) (signer ssh.Signer, created bool, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			return nil, false, fmt.Errorf("parse host key %s: %w", path, err)
+		}
+		return signer, false, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, false, err
+	}
+	block, err := ssh.MarshalPrivateKey(key, "hello-gopher host key")
+	if err != nil {
+		return nil, false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, false, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := pem.Encode(f, block); err != nil {
+		f.Close()
+		return nil, false, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, false, err
+	}
+
+	signer, err = ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	return signer, true, nil
+}
+
+// LoadAuthorizedKeys reads the public keys in an OpenSSH authorized_keys
+// file. Blank lines and comments are skipped; options such as "command=" are
+// ignored.
+func LoadAuthorizedKeys(path string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []ssh.PublicKey
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s contains no public keys", path)
+	}
+	return keys, nil
+}
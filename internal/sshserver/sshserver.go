@@ -0,0 +1,308 @@
+// Package sshserver runs interactive terminal programs for SSH clients, so
+// "ssh proverbs.example.com" can drop users straight into the proverb
+// explorer without a shell account on the host.
+//
+// Every session that requests a pseudo-terminal and a shell is handed to a
+// Handler, which reads keystrokes from the Session and writes the screen
+// back to it. Exec requests, port forwarding and subsystems such as SFTP are
+// refused.
+package sshserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// handshakeTimeout bounds how long a client may take to authenticate
+const handshakeTimeout = 10 * time.Second
+
+// Handler runs the program of one session until it exits or ctx is
+// canceled, which happens when the client disconnects or the server stops.
+// A non-nil error is logged and reported to the client as exit status 1.
+type Handler func(ctx context.Context, s *Session) error
+
+// WindowSize is the size of the client's terminal in characters
+type WindowSize struct {
+	Width  int
+	Height int
+}
+
+// Session is an interactive session with a pseudo-terminal. Reads return
+// the client's keystrokes; writes go to the client's terminal, with "\n"
+// translated to "\r\n" because no terminal driver does it on the server.
+type Session struct {
+	// User is the name the client logged in with
+	User string
+	// RemoteAddr is the client's network address
+	RemoteAddr net.Addr
+	// Term is the client's $TERM, such as "xterm-256color"
+	Term string
+	// Resize receives the terminal size, first when the session starts and
+	// then whenever the client's window changes. Sizes are dropped while
+	// the channel is full.
+	Resize <-chan WindowSize
+
+	ch  ssh.Channel
+	out io.Writer
+}
+
+// Read reads keystrokes from the client
+func (s *Session) Read(p []byte) (int, error) {
+	return s.ch.Read(p)
+}
+
+// Write writes to the client's terminal
+func (s *Session) Write(p []byte) (int, error) {
+	return s.out.Write(p)
+}
+
+// Server serves SSH sessions
+type Server struct {
+	config  *ssh.ServerConfig
+	handler Handler
+	logger  *log.Logger
+}
+
+// Option configures a Server created by New
+type Option func(*Server)
+
+// WithAuthorizedKeys lets only clients holding one of keys connect. Without
+// it any client may connect without authenticating.
+func WithAuthorizedKeys(keys ...ssh.PublicKey) Option {
+	return func(s *Server) {
+		allowed := make(map[string]bool, len(keys))
+		for _, key := range keys {
+			allowed[string(key.Marshal())] = true
+		}
+		s.config.NoClientAuth = false
+		s.config.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if !allowed[string(key.Marshal())] {
+				return nil, fmt.Errorf("unknown public key for %s", conn.User())
+			}
+			return &ssh.Permissions{}, nil
+		}
+	}
+}
+
+// New returns a server identifying itself with hostKey that runs handler for
+// every session. Connections are logged to logger; a nil logger disables
+// logging.
+func New(hostKey ssh.Signer, handler Handler, logger *log.Logger, opts ...Option) *Server {
+	s := &Server{
+		config:  &ssh.ServerConfig{NoClientAuth: true},
+		handler: handler,
+		logger:  logger,
+	}
+	s.config.AddHostKey(hostKey)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Serve accepts connections on ln until ctx is canceled. Running sessions
+// are then canceled, and Serve returns once they have ended.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// serveConn performs the handshake on conn and serves its sessions
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		s.logf("SSH handshake with %s failed: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	conn.SetDeadline(time.Time{})
+	s.logf("SSH connection from %s as %q", sshConn.RemoteAddr(), sshConn.User())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		sshConn.Close()
+	}()
+	go ssh.DiscardRequests(reqs)
+
+	var wg sync.WaitGroup
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveSession(ctx, sshConn, ch, requests)
+		}()
+	}
+	wg.Wait()
+	s.logf("SSH connection from %s closed", sshConn.RemoteAddr())
+}
+
+// serveSession answers the requests of one session channel and runs the
+// handler once the client asks for a shell
+func (s *Server) serveSession(ctx context.Context, conn *ssh.ServerConn, ch ssh.Channel, requests <-chan *ssh.Request) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resize := make(chan WindowSize, 8)
+	sess := &Session{
+		User:       conn.User(),
+		RemoteAddr: conn.RemoteAddr(),
+		Resize:     resize,
+		ch:         ch,
+		out:        &crlfWriter{w: ch},
+	}
+
+	var pty bool
+	done := make(chan struct{})
+	started := false
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			var payload struct {
+				Term          string
+				Width, Height uint32
+				PixelWidth    uint32
+				PixelHeight   uint32
+				Modes         string
+			}
+			ok := !started && ssh.Unmarshal(req.Payload, &payload) == nil
+			if ok {
+				pty = true
+				sess.Term = payload.Term
+				sendSize(resize, payload.Width, payload.Height)
+			}
+			req.Reply(ok, nil)
+		case "window-change":
+			var payload struct {
+				Width, Height uint32
+				PixelWidth    uint32
+				PixelHeight   uint32
+			}
+			if ssh.Unmarshal(req.Payload, &payload) == nil {
+				sendSize(resize, payload.Width, payload.Height)
+			}
+		case "env":
+			req.Reply(true, nil)
+		case "shell":
+			if started || !pty {
+				req.Reply(false, nil)
+				if !pty {
+					fmt.Fprint(ch, "This server needs a terminal, connect with 'ssh -t'.\r\n")
+					exit(ch, 1)
+				}
+				continue
+			}
+			req.Reply(true, nil)
+			started = true
+			go func() {
+				defer close(done)
+				status := 0
+				if err := s.handler(ctx, sess); err != nil {
+					s.logf("SSH session of %s failed: %v", sess.RemoteAddr, err)
+					status = 1
+				}
+				exit(ch, status)
+			}()
+		default:
+			// exec, subsystem and anything else would go beyond the
+			// interactive program
+			req.Reply(false, nil)
+		}
+	}
+
+	// The requests channel closes when the client goes away; stop the
+	// handler and wait for it so it never outlives its channel
+	cancel()
+	if started {
+		<-done
+	}
+	ch.Close()
+}
+
+// sendSize queues a new terminal size without blocking the session
+func sendSize(resize chan<- WindowSize, width, height uint32) {
+	select {
+	case resize <- WindowSize{Width: int(width), Height: int(height)}:
+	default:
+	}
+}
+
+// exit reports status to the client and closes ch
+func exit(ch ssh.Channel, status int) {
+	ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{uint32(status)}))
+	ch.Close()
+}
+
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.logger != nil {
+		s.logger.Printf(format, args...)
+	}
+}
+
+// crlfWriter translates "\n" not preceded by "\r" into "\r\n", which a
+// terminal driver would do for a program on a local terminal
+type crlfWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, 0, len(p)+len(p)/8)
+	for _, b := range p {
+		if b == '\n' && c.last != '\r' {
+			buf = append(buf, '\r')
+		}
+		buf = append(buf, b)
+		c.last = b
+	}
+	if _, err := c.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,286 @@
+package sshserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startServer serves handler on a local port until the test ends and returns
+// the address
+func startServer(t *testing.T, handler Handler, opts ...Option) string {
+	t.Helper()
+
+	hostKey, _, err := LoadOrCreateHostKey(filepath.Join(t.TempDir(), HostKeyFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		errc <- New(hostKey, handler, nil, opts...).Serve(ctx, ln)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-errc; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+	return ln.Addr().String()
+}
+
+func dial(t *testing.T, addr string, auth ...ssh.AuthMethod) (*ssh.Client, error) {
+	t.Helper()
+	return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            "gopher",
+		Auth:            auth,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         5 * time.Second,
+	})
+}
+
+func TestSession(t *testing.T) {
+	addr := startServer(t, func(ctx context.Context, s *Session) error {
+		size := <-s.Resize
+		fmt.Fprintf(s, "hello %s on %s %dx%d\n", s.User, s.Term, size.Width, size.Height)
+
+		line, err := bufio.NewReader(s).ReadString('\r')
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s, "got %q\n", line)
+
+		size = <-s.Resize
+		fmt.Fprintf(s, "resized to %dx%d\r\n", size.Width, size.Height)
+		return nil
+	})
+
+	client, err := dial(t, addr)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	session.Stdout = &out
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := session.RequestPty("xterm-256color", 24, 80, ssh.TerminalModes{}); err != nil {
+		t.Fatalf("RequestPty() error = %v", err)
+	}
+	if err := session.Shell(); err != nil {
+		t.Fatalf("Shell() error = %v", err)
+	}
+	io.WriteString(stdin, "q\r")
+	session.WindowChange(40, 120)
+
+	if err := session.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	want := "hello gopher on xterm-256color 80x24\r\ngot \"q\\r\"\r\nresized to 120x40\r\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSessionHandlerError(t *testing.T) {
+	addr := startServer(t, func(ctx context.Context, s *Session) error {
+		return errors.New("boom")
+	})
+
+	client, err := dial(t, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.RequestPty("xterm", 24, 80, ssh.TerminalModes{})
+	session.Shell()
+
+	var exitErr *ssh.ExitError
+	if err := session.Wait(); !errors.As(err, &exitErr) || exitErr.ExitStatus() != 1 {
+		t.Errorf("Wait() error = %v, want exit status 1", err)
+	}
+}
+
+func TestSessionRequiresTerminal(t *testing.T) {
+	addr := startServer(t, func(ctx context.Context, s *Session) error {
+		t.Error("handler ran without a terminal")
+		return nil
+	})
+
+	client, err := dial(t, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Shell(); err == nil {
+		t.Error("Shell() without a pty succeeded")
+	}
+	session.Close()
+
+	session, err = client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.Output("cat /etc/passwd"); err == nil {
+		t.Error("exec request succeeded")
+	}
+}
+
+func TestSessionCanceledOnDisconnect(t *testing.T) {
+	canceled := make(chan struct{})
+	addr := startServer(t, func(ctx context.Context, s *Session) error {
+		<-ctx.Done()
+		close(canceled)
+		return nil
+	})
+
+	client, err := dial(t, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.RequestPty("xterm", 24, 80, ssh.TerminalModes{})
+	if err := session.Shell(); err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler context not canceled after the client disconnected")
+	}
+}
+
+func TestWithAuthorizedKeys(t *testing.T) {
+	_, allowedKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	allowed, _ := ssh.NewSignerFromKey(allowedKey)
+	other, _ := ssh.NewSignerFromKey(otherKey)
+
+	addr := startServer(t, func(ctx context.Context, s *Session) error { return nil },
+		WithAuthorizedKeys(allowed.PublicKey()))
+
+	if _, err := dial(t, addr); err == nil {
+		t.Error("Dial() without a key succeeded")
+	}
+	if _, err := dial(t, addr, ssh.PublicKeys(other)); err == nil {
+		t.Error("Dial() with an unknown key succeeded")
+	}
+	client, err := dial(t, addr, ssh.PublicKeys(allowed))
+	if err != nil {
+		t.Fatalf("Dial() with an authorized key error = %v", err)
+	}
+	client.Close()
+}
+
+func TestLoadOrCreateHostKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", HostKeyFileName)
+
+	first, created, err := LoadOrCreateHostKey(path)
+	if err != nil || !created {
+		t.Fatalf("LoadOrCreateHostKey() = %v, %v; want a new key", created, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("host key mode = %v, want it readable by the owner only", perm)
+	}
+
+	second, created, err := LoadOrCreateHostKey(path)
+	if err != nil || created {
+		t.Fatalf("LoadOrCreateHostKey() = %v, %v; want the existing key", created, err)
+	}
+	if !bytes.Equal(first.PublicKey().Marshal(), second.PublicKey().Marshal()) {
+		t.Error("reloaded host key differs from the created one")
+	}
+
+	if err := os.WriteFile(path, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadOrCreateHostKey(path); err == nil {
+		t.Error("LoadOrCreateHostKey() accepted an invalid key")
+	}
+}
+
+func TestLoadAuthorizedKeys(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	signer, _ := ssh.NewSignerFromKey(key)
+	line := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authorized_keys")
+	content := "# team keys\n\n" + line + " alice@example.com\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := LoadAuthorizedKeys(path)
+	if err != nil {
+		t.Fatalf("LoadAuthorizedKeys() error = %v", err)
+	}
+	if len(keys) != 1 || !bytes.Equal(keys[0].Marshal(), signer.PublicKey().Marshal()) {
+		t.Errorf("LoadAuthorizedKeys() = %d keys, want the one in the file", len(keys))
+	}
+
+	bad := filepath.Join(dir, "bad")
+	os.WriteFile(bad, []byte(line+"\nssh-ed25519 garbage\n"), 0o600)
+	if _, err := LoadAuthorizedKeys(bad); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("LoadAuthorizedKeys() error = %v, want one naming line 2", err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	os.WriteFile(empty, []byte("# nobody yet\n"), 0o600)
+	if _, err := LoadAuthorizedKeys(empty); err == nil {
+		t.Error("LoadAuthorizedKeys() accepted a file without keys")
+	}
+}
+
+func TestCRLFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &crlfWriter{w: &buf}
+	io.WriteString(w, "a\nb\r\n")
+	io.WriteString(w, "\r")
+	io.WriteString(w, "\nc\n")
+	if got, want := buf.String(), "a\r\nb\r\n\r\nc\r\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+// Package tablewriter renders rows of text as a table with aligned columns.
+// Cells are measured by their display width, so accented letters, CJK
+// characters and emoji keep the columns aligned. Tables wider than a limit
+// are fitted by truncating their widest columns with an ellipsis.
+package tablewriter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+// Border selects the frame drawn around a table and between its columns
+type Border int
+
+const (
+	// BorderNone separates columns with two spaces and draws no frame
+	BorderNone Border = iota
+	// BorderASCII frames the table with '+', '-' and '|'
+	BorderASCII
+	// BorderUnicode frames the table with box-drawing characters
+	BorderUnicode
+)
+
+// Align is the alignment of the cells of a column
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+)
+
+// minWidth is the narrowest a column is truncated to
+const minWidth = 3
+
+// frame holds the characters drawing a border: the horizontal line, the
+// vertical line, and the joints of the top, middle and bottom lines from
+// left to right
+type frame struct {
+	horizontal, vertical string
+	top, middle, bottom  [3]string
+}
+
+var frames = map[Border]frame{
+	BorderASCII: {"-", "|",
+		[3]string{"+", "+", "+"}, [3]string{"+", "+", "+"}, [3]string{"+", "+", "+"}},
+	BorderUnicode: {"─", "│",
+		[3]string{"┌", "┬", "┐"}, [3]string{"├", "┼", "┤"}, [3]string{"└", "┴", "┘"}},
+}
+
+// Table is a table of text cells under a header row
+type Table struct {
+	header []string
+	rows   [][]string
+	align  []Align
+	border Border
+	width  int
+}
+
+// New returns a table with the given column headers
+func New(header ...string) *Table {
+	return &Table{header: header, align: make([]Align, len(header))}
+}
+
+// SetBorder selects the frame of the table, BorderNone by default
+func (t *Table) SetBorder(b Border) {
+	t.border = b
+}
+
+// SetAlign sets the alignment of column col, counted from 0
+func (t *Table) SetAlign(col int, a Align) {
+	t.align[col] = a
+}
+
+// SetWidth limits the width of the table, frame included, to width columns.
+// Wider tables are fitted by truncating their widest columns. 0 removes the
+// limit.
+func (t *Table) SetWidth(width int) {
+	t.width = width
+}
+
+// Append adds a row of cells. Missing cells are left empty and extra cells
+// are dropped. Newlines and tabs in cells are replaced by spaces so every
+// row stays on one line.
+func (t *Table) Append(cells ...string) {
+	row := make([]string, len(t.header))
+	for i := range row {
+		if i < len(cells) {
+			row[i] = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(cells[i])
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+// widths returns the width of every column, fitted to the width limit
+func (t *Table) widths() []int {
+	widths := make([]int, len(t.header))
+	for _, row := range append([][]string{t.header}, t.rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], textwidth.String(cell))
+		}
+	}
+	if t.width <= 0 {
+		return widths
+	}
+
+	// The frame or the gaps between columns take up the rest of the width
+	overhead := 2 * (len(widths) - 1)
+	if t.border != BorderNone {
+		overhead = 3*len(widths) + 1
+	}
+	total := overhead
+	for _, w := range widths {
+		total += w
+	}
+	for total > t.width {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minWidth {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// Render writes the table to w
+func (t *Table) Render(w io.Writer) error {
+	widths := t.widths()
+	bw := bufio.NewWriter(w)
+
+	f, framed := frames[t.border]
+	line := func(joints [3]string) {
+		if !framed {
+			return
+		}
+		parts := make([]string, len(widths))
+		for i, width := range widths {
+			parts[i] = strings.Repeat(f.horizontal, width+2)
+		}
+		fmt.Fprintln(bw, joints[0]+strings.Join(parts, joints[1])+joints[2])
+	}
+	row := func(cells []string) {
+		parts := make([]string, len(cells))
+		for i, cell := range cells {
+			parts[i] = t.cell(cell, i, widths[i])
+		}
+		if framed {
+			fmt.Fprintln(bw, f.vertical+" "+strings.Join(parts, " "+f.vertical+" ")+" "+f.vertical)
+			return
+		}
+		// Without a frame lines carry no trailing padding
+		fmt.Fprintln(bw, strings.TrimRight(strings.Join(parts, "  "), " "))
+	}
+
+	line(f.top)
+	row(t.header)
+	line(f.middle)
+	for _, r := range t.rows {
+		row(r)
+	}
+	line(f.bottom)
+	return bw.Flush()
+}
+
+// cell truncates and pads text to width for column col
+func (t *Table) cell(text string, col, width int) string {
+	text = textwidth.Truncate(text, width)
+	pad := strings.Repeat(" ", max(width-textwidth.String(text), 0))
+	if t.align[col] == AlignRight {
+		return pad + text
+	}
+	return text + pad
+}
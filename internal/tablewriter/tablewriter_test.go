@@ -0,0 +1,90 @@
+package tablewriter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+)
+
+func render(t *testing.T, table *Table) string {
+	t.Helper()
+	var b strings.Builder
+	if err := table.Render(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestRender(t *testing.T) {
+	table := New("ID", "NAME")
+	table.SetAlign(0, AlignRight)
+	table.Append("1", "Alice")
+	table.Append("12", "José")
+
+	want := "ID  NAME\n 1  Alice\n12  José\n"
+	if got := render(t, table); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderWideCharacters(t *testing.T) {
+	table := New("NAME", "GREETING")
+	table.SetBorder(BorderUnicode)
+	table.Append("世界", "こんにちは")
+	table.Append("Bob", "Hi 👋")
+	table.Append("multi\nline", "x")
+
+	lines := strings.Split(strings.TrimSuffix(render(t, table), "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("got %d lines, want 7:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	want := textwidth.String(lines[0])
+	for i, line := range lines {
+		if w := textwidth.String(line); w != want {
+			t.Errorf("line %d is %d columns wide, want %d: %q", i, w, want, line)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "┌") || !strings.HasPrefix(lines[6], "└") {
+		t.Errorf("table isn't framed:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestRenderASCIIBorder(t *testing.T) {
+	table := New("A", "B")
+	table.SetBorder(BorderASCII)
+	table.Append("x", "y")
+
+	want := "+---+---+\n| A | B |\n+---+---+\n| x | y |\n+---+---+\n"
+	if got := render(t, table); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderWidth(t *testing.T) {
+	for _, border := range []Border{BorderNone, BorderASCII, BorderUnicode} {
+		table := New("ID", "PROVERB", "TAGS")
+		table.SetBorder(border)
+		table.SetWidth(40)
+		table.Append("1", "Don't communicate by sharing memory, share memory by communicating.", "concurrency")
+		table.Append("2", "Concurrency is not parallelism.", "concurrency")
+
+		got := render(t, table)
+		for i, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+			if w := textwidth.String(line); w > 40 {
+				t.Errorf("border %d: line %d is %d columns wide, want at most 40: %q", border, i, w, line)
+			}
+		}
+		if !strings.Contains(got, "…") || !strings.Contains(got, "TAGS") {
+			t.Errorf("border %d: the proverbs weren't truncated:\n%s", border, got)
+		}
+	}
+
+	// Columns aren't truncated below a few characters
+	table := New("NAME")
+	table.SetWidth(1)
+	table.Append("Alice")
+	if got := render(t, table); !strings.Contains(got, "A…") {
+		t.Errorf("Render() at width 1 = %q, want the name truncated to a few characters", got)
+	}
+}
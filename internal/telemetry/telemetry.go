@@ -0,0 +1,181 @@
+// Package telemetry queues and sends anonymous usage events, so the
+// maintainers can learn which features matter. Nothing is recorded or sent
+// unless the user opted in with "hello-gopher telemetry on" and set the
+// endpoint receiving the events; there is no default endpoint.
+//
+// An event holds the command name, the hello-gopher version and the
+// operating system and architecture, and nothing else:
+//
+//	{"command": "proverb list", "version": "v1.5.0", "os": "linux", "arch": "amd64"}
+//
+// No names, arguments, proverbs, addresses or identifiers are collected.
+// Events are queued as JSON lines in <user state dir>/hello-gopher/telemetry.jsonl
+// and sent in batches of BatchSize as a JSON array in the body of a POST to
+// the endpoint.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// QueueFileName is the name of the queue file below the user state directory
+const QueueFileName = "telemetry.jsonl"
+
+// BatchSize is the number of queued events that triggers sending them
+const BatchSize = 20
+
+// MaxQueued caps the queue when the endpoint can't be reached; the oldest
+// events are dropped
+const MaxQueued = 500
+
+// Event is the record of a single run
+type Event struct {
+	Command string `json:"command"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+}
+
+// NewEvent returns the event of a run of command, such as "proverb list",
+// by the given hello-gopher version on this platform
+func NewEvent(command, version string) Event {
+	return Event{Command: command, Version: version, OS: runtime.GOOS, Arch: runtime.GOARCH}
+}
+
+// Enqueue adds e to the queue file at path, creating the file and its
+// parent directories, and returns the number of queued events
+func Enqueue(path string, e Event) (int, error) {
+	events, err := Load(path)
+	if err != nil {
+		return 0, err
+	}
+	events = append(events, e)
+	if len(events) > MaxQueued {
+		events = events[len(events)-MaxQueued:]
+	}
+	if err := save(path, events); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// Load reads the queue file at path. A missing file yields no events, and
+// lines that can't be decoded are skipped.
+func Load(path string) ([]Event, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own queue file
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Command == "" {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// save replaces the queue file at path with events
+func save(path string, events []Event) error {
+	var buf bytes.Buffer
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// Clear removes the queue file at path. A missing file is not an error.
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Flush sends the events queued at path to endpoint with client and removes
+// them from the queue. The events stay queued if sending fails, and events
+// queued while sending stay queued for the next flush.
+func Flush(ctx context.Context, client *http.Client, endpoint, path string) (int, error) {
+	events, err := Load(path)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	body, err := json.Marshal(events)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+	return len(events), remove(path, events)
+}
+
+// remove removes the sent events from the queue file at path. Runs that
+// finished while they were sent appended events to the queue, and may have
+// dropped its oldest events to stay within MaxQueued, so the queue now
+// starts with the unsent end of sent.
+func remove(path string, sent []Event) error {
+	events, err := Load(path)
+	if err != nil {
+		return err
+	}
+	for skip := range sent {
+		if hasPrefix(events, sent[skip:]) {
+			events = events[len(sent)-skip:]
+			break
+		}
+	}
+	if len(events) == 0 {
+		return Clear(path)
+	}
+	return save(path, events)
+}
+
+// hasPrefix reports whether events starts with prefix
+func hasPrefix(events, prefix []Event) bool {
+	if len(prefix) > len(events) {
+		return false
+	}
+	for i, e := range prefix {
+		if events[i] != e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestEnqueueAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", QueueFileName)
+	if events, err := Load(path); err != nil || len(events) != 0 {
+		t.Fatalf("Load() of a missing queue = %v, %v; want no events", events, err)
+	}
+
+	for i := 0; i < MaxQueued+2; i++ {
+		command := "greet"
+		if i == MaxQueued+1 {
+			command = "proverb list"
+		}
+		n, err := Enqueue(path, NewEvent(command, "v1.5.0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := min(i+1, MaxQueued); n != want {
+			t.Fatalf("Enqueue() #%d queued %d events, want %d", i+1, n, want)
+		}
+	}
+
+	events, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Event{Command: "proverb list", Version: "v1.5.0", OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if len(events) != MaxQueued || events[len(events)-1] != want {
+		t.Errorf("Load() = %d events ending in %+v, want %d ending in %+v", len(events), events[len(events)-1], MaxQueued, want)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	var received []Event
+	status := http.StatusServiceUnavailable
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), QueueFileName)
+	Enqueue(path, NewEvent("greet", "dev"))
+	Enqueue(path, NewEvent("tui", "dev"))
+
+	// A failed delivery keeps the events queued
+	if _, err := Flush(context.Background(), ts.Client(), ts.URL, path); err == nil {
+		t.Error("Flush() to a failing endpoint succeeded")
+	}
+	if events, _ := Load(path); len(events) != 2 {
+		t.Errorf("queue holds %d events after a failed flush, want 2", len(events))
+	}
+
+	status = http.StatusAccepted
+	n, err := Flush(context.Background(), ts.Client(), ts.URL, path)
+	if err != nil || n != 2 {
+		t.Fatalf("Flush() = %d, %v; want 2 events sent", n, err)
+	}
+	if len(received) != 2 || received[1].Command != "tui" {
+		t.Errorf("endpoint received %+v, want both events", received)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("queue file still exists after a flush: %v", err)
+	}
+
+	// Nothing queued, nothing sent
+	received = nil
+	if n, err := Flush(context.Background(), ts.Client(), ts.URL, path); n != 0 || err != nil || received != nil {
+		t.Errorf("Flush() of an empty queue = %d, %v; want nothing sent", n, err)
+	}
+}
+
+func TestFlushKeepsNewEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), QueueFileName)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Another run finishes while the batch is sent
+		Enqueue(path, NewEvent("proverb", "dev"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	Enqueue(path, NewEvent("greet", "dev"))
+	Enqueue(path, NewEvent("greet", "dev"))
+	if n, err := Flush(context.Background(), ts.Client(), ts.URL, path); err != nil || n != 2 {
+		t.Fatalf("Flush() = %d, %v; want 2 events sent", n, err)
+	}
+	events, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Command != "proverb" {
+		t.Errorf("queue holds %+v after the flush, want the event queued while sending", events)
+	}
+}
+
+func TestRemoveAfterTrim(t *testing.T) {
+	path := filepath.Join(t.TempDir(), QueueFileName)
+	a, b, c := NewEvent("a", "dev"), NewEvent("b", "dev"), NewEvent("c", "dev")
+	// a was dropped from the full queue while a, b were sent
+	save(path, []Event{b, c})
+	if err := remove(path, []Event{a, b}); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := Load(path); len(events) != 1 || events[0] != c {
+		t.Errorf("queue holds %+v, want only c", events)
+	}
+}
+
+func TestClear(t *testing.T) {
+	path := filepath.Join(t.TempDir(), QueueFileName)
+	if err := Clear(path); err != nil {
+		t.Errorf("Clear() of a missing queue: %v", err)
+	}
+	Enqueue(path, NewEvent("greet", "dev"))
+	if err := Clear(path); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := Load(path); len(events) != 0 {
+		t.Errorf("queue holds %d events after Clear()", len(events))
+	}
+}
@@ -0,0 +1,78 @@
+// Package testsupport runs the hello-gopher CLI in tests and compares its
+// output with golden files.
+//
+// The command package registers how to run the CLI with SetRunner, so this
+// package doesn't import it. Tests then run commands like a user would:
+//
+//	stdout, stderr, code := testsupport.RunCommand(t, "greet", "--name", "Alice")
+//	testsupport.AssertGolden(t, "greet-help", stdout)
+//
+// Run the tests with -update to rewrite the golden files from the actual
+// output, then review the diff.
+package testsupport
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the actual output")
+
+// Runner runs the CLI with args, writing its output to stdout and stderr,
+// and returns the process exit code
+type Runner func(t testing.TB, args []string, stdout, stderr io.Writer) int
+
+var runner Runner
+
+// SetRunner registers the function that RunCommand uses to run the CLI
+func SetRunner(r Runner) {
+	runner = r
+}
+
+// RunCommand runs the CLI with args and returns what it wrote to stdout and
+// stderr together with its exit code
+func RunCommand(t testing.TB, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	if runner == nil {
+		t.Fatal("testsupport: no runner registered; call SetRunner first")
+	}
+
+	var out, errOut bytes.Buffer
+	code = runner(t, args, &out, &errOut)
+	return out.String(), errOut.String(), code
+}
+
+// GoldenPath returns the path of the golden file called name, which lives in
+// the testdata directory of the package under test
+func GoldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// AssertGolden fails t if got differs from the golden file called name. With
+// -update it writes got to the golden file instead.
+func AssertGolden(t testing.TB, name, got string) {
+	t.Helper()
+	path := GoldenPath(name)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run the tests with -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run the tests with -update to accept it)\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package testsupport
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCommand(t *testing.T) {
+	original := runner
+	t.Cleanup(func() { runner = original })
+
+	SetRunner(func(t testing.TB, args []string, stdout, stderr io.Writer) int {
+		fmt.Fprintln(stdout, args)
+		fmt.Fprint(stderr, "warning")
+		return 3
+	})
+
+	stdout, stderr, code := RunCommand(t, "greet", "--name", "Alice")
+	if stdout != "[greet --name Alice]\n" || stderr != "warning" || code != 3 {
+		t.Errorf("RunCommand() = %q, %q, %d", stdout, stderr, code)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	original := *update
+	t.Cleanup(func() { *update = original })
+
+	*update = true
+	AssertGolden(t, "sample", "Hello, Gopher!\n")
+	if data, err := os.ReadFile(filepath.Join("testdata", "sample.golden")); err != nil || string(data) != "Hello, Gopher!\n" {
+		t.Fatalf("-update wrote %q, %v", data, err)
+	}
+
+	*update = false
+	AssertGolden(t, "sample", "Hello, Gopher!\n")
+
+	r := &recorder{TB: t}
+	AssertGolden(r, "sample", "Hello, Alice!\n")
+	if !r.failed {
+		t.Error("AssertGolden should fail when the output differs")
+	}
+
+	r = &recorder{TB: t}
+	AssertGolden(r, "missing", "")
+	if !r.failed {
+		t.Error("AssertGolden should fail without a golden file")
+	}
+}
+
+// recorder notes failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper()                        {}
+func (r *recorder) Errorf(format string, a ...any) { r.failed = true }
+func (r *recorder) Fatalf(format string, a ...any) { r.failed = true }
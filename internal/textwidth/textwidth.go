@@ -0,0 +1,297 @@
+// Package textwidth measures and wraps text by terminal display width.
+//
+// Unlike len or utf8.RuneCountInString, display width accounts for wide East
+// Asian characters and emoji (two columns), combining marks and other
+// zero-width characters, and ANSI escape sequences added by the color package.
+// Emoji built from several code points, such as ZWJ sequences, skin tones and
+// flags, are measured and cut as a single character.
+package textwidth
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges lists code point ranges rendered two columns wide
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x231A, 0x231B},   // watch, hourglass
+	{0x23E9, 0x23EC},   // media controls
+	{0x23F0, 0x23F0},   // alarm clock
+	{0x23F3, 0x23F3},   // hourglass with flowing sand
+	{0x25FD, 0x25FE},   // medium small squares
+	{0x2614, 0x2615},   // umbrella, hot beverage
+	{0x2648, 0x2653},   // zodiac
+	{0x267F, 0x267F},   // wheelchair
+	{0x2693, 0x2693},   // anchor
+	{0x26A1, 0x26A1},   // high voltage
+	{0x26AA, 0x26AB},   // circles
+	{0x26BD, 0x26BE},   // soccer, baseball
+	{0x26C4, 0x26C5},   // snowman, sun behind cloud
+	{0x26CE, 0x26CE},   // ophiuchus
+	{0x26D4, 0x26D4},   // no entry
+	{0x26EA, 0x26EA},   // church
+	{0x26F2, 0x26F3},   // fountain, golf
+	{0x26F5, 0x26F5},   // sailboat
+	{0x26FA, 0x26FA},   // tent
+	{0x26FD, 0x26FD},   // fuel pump
+	{0x2705, 0x2705},   // check mark button
+	{0x270A, 0x270B},   // raised fist, hand
+	{0x2728, 0x2728},   // sparkles
+	{0x274C, 0x274C},   // cross mark
+	{0x274E, 0x274E},   // cross mark button
+	{0x2753, 0x2755},   // question and exclamation marks
+	{0x2757, 0x2757},   // exclamation mark
+	{0x2795, 0x2797},   // plus, minus, divide
+	{0x27B0, 0x27B0},   // curly loop
+	{0x27BF, 0x27BF},   // double curly loop
+	{0x2B1B, 0x2B1C},   // large squares
+	{0x2B50, 0x2B50},   // star
+	{0x2B55, 0x2B55},   // hollow red circle
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // fullwidth forms
+	{0xFFE0, 0xFFE6},   // fullwidth signs
+	{0x1F004, 0x1F004}, // mahjong tile
+	{0x1F0CF, 0x1F0CF}, // joker
+	{0x1F18E, 0x1F18E}, // AB button
+	{0x1F191, 0x1F19A}, // squared words
+	{0x1F200, 0x1F2FF}, // enclosed ideographic supplement
+	{0x1F300, 0x1F64F}, // pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // transport and map symbols
+	{0x1F7E0, 0x1F7EB}, // colored circles and squares
+	{0x1F90C, 0x1F9FF}, // supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // symbols and pictographs extended-A
+	{0x20000, 0x2FFFD}, // CJK extension B and beyond
+	{0x30000, 0x3FFFD}, // CJK extension G and beyond
+}
+
+// RuneWidth returns the number of columns r occupies in a terminal
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0 // control characters
+	case r < 0x300:
+		return 1 // fast path for Latin text
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0 // combining marks, joiners, variation selectors, bidi controls
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether r falls in one of the wide ranges
+func isWide(r rune) bool {
+	lo, hi := 0, len(wideRanges)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < wideRanges[mid][0]:
+			hi = mid
+		case r > wideRanges[mid][1]:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Code points that join others into a single displayed character
+const (
+	zeroWidthJoiner = '\u200d'
+	emojiVariation  = '\ufe0f'
+)
+
+// isRegionalIndicator reports whether r is one of the letters that form
+// flag emoji in pairs
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isSkinTone reports whether r is an emoji skin tone modifier
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// cluster returns the byte length and display width of the character at the
+// start of s, including the combining marks, variation selectors, skin tones
+// and ZWJ-joined code points that belong to it
+func cluster(s string) (size, width int) {
+	r, size := utf8.DecodeRuneInString(s)
+	width = RuneWidth(r)
+
+	if isRegionalIndicator(r) {
+		if next, n := utf8.DecodeRuneInString(s[size:]); isRegionalIndicator(next) {
+			return size + n, 2
+		}
+		return size, width
+	}
+
+	for size < len(s) {
+		next, n := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case next == zeroWidthJoiner:
+			// The joined code point is drawn as part of this character
+			_, joined := utf8.DecodeRuneInString(s[size+n:])
+			size += n + joined
+		case next == emojiVariation:
+			// Emoji presentation makes narrow symbols such as ❤ wide
+			size += n
+			if width == 1 {
+				width = 2
+			}
+		case isSkinTone(next) && width == 2:
+			size += n
+		case RuneWidth(next) == 0 && next >= 0x20:
+			size += n
+		default:
+			return size, width
+		}
+	}
+	return size, width
+}
+
+// String returns the display width of s, ignoring ANSI escape sequences
+func String(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		size, w := cluster(s[i:])
+		width += w
+		i += size
+	}
+	return width
+}
+
+// escapeLen returns the byte length of the ANSI CSI sequence at the start of s, or 0
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if c := s[i]; c >= 0x40 && c <= 0x7E {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// Pad right-pads s with spaces to the given display width
+func Pad(s string, width int) string {
+	if w := String(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// Truncate shortens s to at most width columns, replacing the cut-off tail
+// with an ellipsis. Escape sequences are kept.
+func Truncate(s string, width int) string {
+	if String(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+
+	var b strings.Builder
+	w := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		size, cw := cluster(s[i:])
+		if w+cw > width-1 {
+			break
+		}
+		b.WriteString(s[i : i+size])
+		w += cw
+		i += size
+	}
+	b.WriteString("…")
+	return b.String()
+}
+
+// Wrap breaks text into lines no wider than width columns. Lines are broken
+// at whitespace; words wider than width are split. Existing newlines are kept.
+func Wrap(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line, lineWidth := "", 0
+		for _, word := range words {
+			for _, part := range splitWord(word, width) {
+				partWidth := String(part)
+				switch {
+				case lineWidth == 0:
+					line, lineWidth = part, partWidth
+				case lineWidth+1+partWidth <= width:
+					line += " " + part
+					lineWidth += 1 + partWidth
+				default:
+					lines = append(lines, line)
+					line, lineWidth = part, partWidth
+				}
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitWord cuts a word into chunks no wider than width columns. Escape
+// sequences are carried along without counting towards the width.
+func splitWord(word string, width int) []string {
+	if String(word) <= width {
+		return []string{word}
+	}
+
+	var parts []string
+	var b strings.Builder
+	w := 0
+	for i := 0; i < len(word); {
+		if n := escapeLen(word[i:]); n > 0 {
+			b.WriteString(word[i : i+n])
+			i += n
+			continue
+		}
+		size, cw := cluster(word[i:])
+		if w+cw > width && w > 0 {
+			parts = append(parts, b.String())
+			b.Reset()
+			w = 0
+		}
+		b.WriteString(word[i : i+size])
+		w += cw
+		i += size
+	}
+	if b.Len() > 0 {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
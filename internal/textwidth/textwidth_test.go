@@ -0,0 +1,110 @@
+package textwidth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"ascii", "Hello", 5},
+		{"empty", "", 0},
+		{"precomposed accent", "José", 4},
+		{"combining accent", "Jose\u0301", 4},
+		{"cjk", "山田", 4},
+		{"emoji", "🎉", 2},
+		{"emoji with variation selector", "\u2764\ufe0f", 2},
+		{"text symbol", "\u2764", 1},
+		{"zwj sequence", "\U0001F469\u200d\U0001F4BB", 2},
+		{"family", "\U0001F468\u200d\U0001F469\u200d\U0001F467", 2},
+		{"skin tone", "\U0001F44B\U0001F3FD", 2},
+		{"flag", "\U0001F1E9\U0001F1EA", 2},
+		{"two flags", "\U0001F1E9\U0001F1EA\U0001F1EB\U0001F1F7", 4},
+		{"lone regional indicator", "\U0001F1E9", 1},
+		{"bidi isolates", "\u2068\u05e9\u05dc\u05d5\u05dd\u2069", 4},
+		{"fullwidth", "ＡＢ", 4},
+		{"ansi escapes are ignored", "\x1b[1;36mAna\x1b[0m!", 4},
+		{"zero width space", "a\u200bb", 2},
+		{"control characters", "a\tb", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := String(tt.input); got != tt.want {
+				t.Errorf("String(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	if got := Pad("山", 4); got != "山  " {
+		t.Errorf("Pad(山, 4) = %q", got)
+	}
+	if got := Pad("toolong", 3); got != "toolong" {
+		t.Errorf("Pad() should not truncate, got %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{"Gopher", 10, "Gopher"},
+		{"Gopher", 6, "Gopher"},
+		{"Gopher", 4, "Gop…"},
+		{"山田太郎", 5, "山田…"},
+		{"山田太郎", 4, "山…"},
+		{"Gopher", 0, ""},
+		{"Hi \U0001F469\u200d\U0001F4BB!!", 6, "Hi \U0001F469\u200d\U0001F4BB…"},
+		{"Hi \U0001F469\u200d\U0001F4BB!", 5, "Hi …"},
+		{"Jose\u0301 Gopher", 5, "Jose\u0301…"},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.input, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"fits", "Hello, Gopher!", 20, []string{"Hello, Gopher!"}},
+		{"breaks at spaces", "Clear is better than clever.", 12, []string{"Clear is", "better than", "clever."}},
+		{"collapses whitespace", "a   b\t c", 10, []string{"a b c"}},
+		{"keeps newlines", "one\n\ntwo", 10, []string{"one", "", "two"}},
+		{"splits long words", "abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"wide runes count double", "山田 太郎", 4, []string{"山田", "太郎"}},
+		{"wide rune never split", "山田太", 3, []string{"山", "田", "太"}},
+		{"emoji sequence never split", "\U0001F44B\U0001F3FD\U0001F44B\U0001F3FD", 3, []string{"\U0001F44B\U0001F3FD", "\U0001F44B\U0001F3FD"}},
+		{"non-positive width", "ab", 0, []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsEscapesWhenSplitting(t *testing.T) {
+	got := Wrap("\x1b[1mabcdef\x1b[0m", 3)
+	want := []string{"\x1b[1mabc", "def\x1b[0m"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+// Package tracing exports OpenTelemetry traces of "hello-gopher serve" to a
+// collector over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ServiceName identifies the program in exported traces
+const ServiceName = "hello-gopher"
+
+// ParseEndpoint validates the URL of an OTLP/HTTP collector, such as
+// http://localhost:4318, and returns the URL traces are posted to:
+// /v1/traces below the URL's path unless it already ends there.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: use an http or https URL such as http://localhost:4318", endpoint)
+	}
+	if !strings.HasSuffix(u.Path, tracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + tracesPath
+		u.RawPath = ""
+	}
+	return u, nil
+}
+
+// tracesPath is the path of the OTLP/HTTP traces endpoint below a collector
+const tracesPath = "/v1/traces"
+
+// NewProvider returns a tracer provider that batches spans and exports them
+// to the collector at endpoint, tagged with the service name and version.
+// Call Shutdown on the provider to flush the remaining spans before exit.
+func NewProvider(ctx context.Context, endpoint, version string) (*sdktrace.TracerProvider, error) {
+	u, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		)),
+	), nil
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"http://localhost:4318":                "http://localhost:4318/v1/traces",
+		"http://localhost:4318/":               "http://localhost:4318/v1/traces",
+		"https://otel.example.com/v1/traces":   "https://otel.example.com/v1/traces",
+		"http://collector:4318/otlp":           "http://collector:4318/otlp/v1/traces",
+		"http://collector:4318/otlp/":          "http://collector:4318/otlp/v1/traces",
+		"http://collector:4318/otlp/v1/traces": "http://collector:4318/otlp/v1/traces",
+	}
+	for endpoint, want := range tests {
+		u, err := ParseEndpoint(endpoint)
+		if err != nil || u.String() != want {
+			t.Errorf("ParseEndpoint(%q) = %v, %v; want %s", endpoint, u, err, want)
+		}
+	}
+	for _, endpoint := range []string{"", "localhost:4318", "ftp://localhost", "http://", "://"} {
+		if _, err := ParseEndpoint(endpoint); err == nil {
+			t.Errorf("ParseEndpoint(%q) accepted an invalid endpoint", endpoint)
+		}
+	}
+}
+
+func TestNewProviderExportsSpans(t *testing.T) {
+	for path, want := range map[string]string{"": "/v1/traces", "/otlp": "/otlp/v1/traces"} {
+		t.Run(want, func(t *testing.T) {
+			testExport(t, path, want)
+		})
+	}
+}
+
+// testExport exports a span to a collector at path and checks that it
+// arrives at want
+func testExport(t *testing.T, path, want string) {
+	paths := make(chan string, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.Method + " " + r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	ctx := context.Background()
+	tp, err := NewProvider(ctx, collector.URL+path, "v1.2.3")
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	_, span := tp.Tracer("test").Start(ctx, "work")
+	span.End()
+	if err := tp.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	select {
+	case got := <-paths:
+		if got != "POST "+want {
+			t.Errorf("collector received %s, want POST %s", got, want)
+		}
+	default:
+		t.Error("collector received no spans")
+	}
+}
@@ -0,0 +1,380 @@
+// Package tui implements the full-screen proverb explorer started by
+// "hello-gopher tui".
+//
+// The explorer is a Bubble Tea model: a scrollable proverb list with a detail
+// pane for the selected proverb, incremental search, tag filters and
+// favorites that are persisted through the favorites package.
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/favorites"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/textwidth"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// Default screen size used until the terminal reports its real size
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// chromeLines is the number of lines used by everything except the list
+// and detail panes: header, search line, separator and help line
+const chromeLines = 4
+
+// detailLines is the height of the detail pane
+const detailLines = 6
+
+// helpText lists the key bindings in the footer
+const helpText = "↑/↓ move  / search  t/T tag  f favorite  F favorites only  esc clear  q quit"
+
+// Model is the Bubble Tea model of the proverb explorer
+type Model struct {
+	proverbs  []greeting.Proverb
+	visible   []greeting.Proverb
+	tags      []string
+	favorites *favorites.Store
+	styler    color.Styler
+
+	// tag indexes tags; -1 shows every tag
+	tag           int
+	search        string
+	searching     bool
+	favoritesOnly bool
+
+	cursor int
+	offset int
+	width  int
+	height int
+	status string
+}
+
+// New returns an explorer for proverbs. favs receives favorite toggles and
+// is saved after every change.
+func New(proverbs []greeting.Proverb, favs *favorites.Store, styler color.Styler) Model {
+	m := Model{
+		proverbs:  proverbs,
+		tags:      collectTags(proverbs),
+		favorites: favs,
+		styler:    styler,
+		tag:       -1,
+		width:     defaultWidth,
+		height:    defaultHeight,
+	}
+	m.refilter()
+	return m
+}
+
+// collectTags returns the distinct tags of proverbs in sorted order
+func collectTags(proverbs []greeting.Proverb) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, p := range proverbs {
+		for _, t := range p.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Init implements tea.Model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Some terminals report 0x0; keep the defaults for those
+		if msg.Width > 0 && msg.Height > 0 {
+			m.width, m.height = msg.Width, msg.Height
+			m.scroll()
+		}
+	case tea.KeyMsg:
+		m.status = ""
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Interrupt
+		}
+		if m.searching {
+			m.updateSearch(msg)
+			return m, nil
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateSearch edits the search query while the search line has focus
+func (m *Model) updateSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.search = ""
+	case tea.KeyBackspace:
+		if r := []rune(m.search); len(r) > 0 {
+			m.search = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search += string(msg.Runes)
+	default:
+		return
+	}
+	m.refilter()
+}
+
+// updateBrowse handles keys while the list has focus
+func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.listHeight())
+	case "pgdown":
+		m.move(m.listHeight())
+	case "home", "g":
+		m.move(-len(m.visible))
+	case "end", "G":
+		m.move(len(m.visible))
+	case "/":
+		m.searching = true
+	case "t":
+		m.cycleTag(1)
+	case "T":
+		m.cycleTag(-1)
+	case "f", " ":
+		m.toggleFavorite()
+	case "F":
+		m.favoritesOnly = !m.favoritesOnly
+		m.refilter()
+	case "esc":
+		m.search, m.tag, m.favoritesOnly = "", -1, false
+		m.refilter()
+	}
+	return m, nil
+}
+
+// move shifts the cursor by delta rows, clamped to the visible proverbs
+func (m *Model) move(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scroll()
+}
+
+// cycleTag selects the next (dir > 0) or previous tag filter, passing
+// through "all tags" between the last and the first tag
+func (m *Model) cycleTag(dir int) {
+	n := len(m.tags) + 1
+	m.tag = (m.tag+1+dir+n)%n - 1
+	m.refilter()
+}
+
+// toggleFavorite flips the favorite state of the selected proverb and saves it
+func (m *Model) toggleFavorite() {
+	p, ok := m.Selected()
+	if !ok || m.favorites == nil {
+		return
+	}
+
+	if m.favorites.Toggle(p.ID) {
+		m.status = fmt.Sprintf("Added #%d to favorites", p.ID)
+	} else {
+		m.status = fmt.Sprintf("Removed #%d from favorites", p.ID)
+	}
+	if err := m.favorites.Save(); err != nil {
+		m.status = fmt.Sprintf("Failed to save favorites: %v", err)
+	}
+	if m.favoritesOnly {
+		m.refilter()
+	}
+}
+
+// refilter recomputes the visible proverbs, keeping the selection if possible
+func (m *Model) refilter() {
+	selected, hadSelection := m.Selected()
+
+	filter := greeting.Filter{Search: m.search}
+	if m.tag >= 0 {
+		filter.Tag = m.tags[m.tag]
+	}
+	m.visible = filter.Apply(m.proverbs)
+	if m.favoritesOnly {
+		kept := m.visible[:0]
+		for _, p := range m.visible {
+			if m.isFavorite(p) {
+				kept = append(kept, p)
+			}
+		}
+		m.visible = kept
+	}
+
+	m.cursor = 0
+	if hadSelection {
+		for i, p := range m.visible {
+			if p.ID == selected.ID {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.scroll()
+}
+
+// scroll adjusts the list offset so the cursor stays on screen
+func (m *Model) scroll() {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// listHeight returns the number of list rows that fit on screen
+func (m Model) listHeight() int {
+	if h := m.height - chromeLines - detailLines; h > 1 {
+		return h
+	}
+	return 1
+}
+
+// isFavorite reports whether p is marked as a favorite
+func (m Model) isFavorite(p greeting.Proverb) bool {
+	return m.favorites != nil && m.favorites.Has(p.ID)
+}
+
+// Selected returns the proverb under the cursor
+func (m Model) Selected() (greeting.Proverb, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return greeting.Proverb{}, false
+	}
+	return m.visible[m.cursor], true
+}
+
+// Visible returns the proverbs matching the current search and filters
+func (m Model) Visible() []greeting.Proverb {
+	return m.visible
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	var lines []string
+	lines = append(lines, m.header(), m.searchLine())
+	lines = append(lines, m.list()...)
+	lines = append(lines, m.styler.Muted(strings.Repeat("─", m.width)))
+	lines = append(lines, m.detail()...)
+	lines = append(lines, m.footer())
+	return strings.Join(lines, "\n")
+}
+
+// header renders the title with the active filters
+func (m Model) header() string {
+	tag := "all"
+	if m.tag >= 0 {
+		tag = m.tags[m.tag]
+	}
+	title := fmt.Sprintf("Go Proverbs  %d/%d  tag: %s", len(m.visible), len(m.proverbs), tag)
+	if m.favoritesOnly {
+		title += "  ★ favorites only"
+	}
+	return m.styler.Paint(textwidth.Truncate(title, m.width), color.Bold)
+}
+
+// searchLine renders the search query, with a cursor while editing
+func (m Model) searchLine() string {
+	switch {
+	case m.searching:
+		return textwidth.Truncate("/"+m.search+"█", m.width)
+	case m.search != "":
+		return textwidth.Truncate("search: "+m.search, m.width)
+	default:
+		return ""
+	}
+}
+
+// list renders the visible window of the proverb list
+func (m Model) list() []string {
+	height := m.listHeight()
+	lines := make([]string, 0, height)
+
+	if len(m.visible) == 0 {
+		lines = append(lines, m.styler.Muted("  No proverbs match."))
+	}
+	for i := m.offset; i < len(m.visible) && len(lines) < height; i++ {
+		p := m.visible[i]
+		star := "  "
+		if m.isFavorite(p) {
+			star = "★ "
+		}
+		row := textwidth.Truncate(fmt.Sprintf("%s%3d  %s", star, p.ID, p.Text), m.width-2)
+		if i == m.cursor {
+			lines = append(lines, m.styler.Highlight("> "+row))
+		} else {
+			lines = append(lines, "  "+row)
+		}
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// detail renders the full text, tags and favorite state of the selection
+func (m Model) detail() []string {
+	lines := make([]string, 0, detailLines)
+
+	if p, ok := m.Selected(); ok {
+		meta := fmt.Sprintf("#%d", p.ID)
+		if len(p.Tags) > 0 {
+			meta += "  tags: " + strings.Join(p.Tags, ", ")
+		}
+		if m.isFavorite(p) {
+			meta += "  ★ favorite"
+		}
+		lines = append(lines, m.styler.Muted(textwidth.Truncate(meta, m.width)))
+
+		for _, line := range textwidth.Wrap(p.Text, m.width) {
+			if len(lines) == detailLines {
+				break
+			}
+			lines = append(lines, m.styler.Quote(line))
+		}
+	}
+	for len(lines) < detailLines {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// footer renders the status message or the key bindings
+func (m Model) footer() string {
+	if m.status != "" {
+		return textwidth.Truncate(m.status, m.width)
+	}
+	return m.styler.Muted(textwidth.Truncate(helpText, m.width))
+}
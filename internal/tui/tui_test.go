@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/color"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/favorites"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+var testProverbs = []greeting.Proverb{
+	{ID: 1, Text: "Don't communicate by sharing memory, share memory by communicating.", Tags: []string{"concurrency"}},
+	{ID: 2, Text: "Concurrency is not parallelism.", Tags: []string{"concurrency"}},
+	{ID: 3, Text: "The bigger the interface, the weaker the abstraction.", Tags: []string{"interfaces", "design"}},
+	{ID: 4, Text: "Errors are values.", Tags: []string{"errors"}},
+}
+
+func newTestModel(t *testing.T) (Model, *favorites.Store) {
+	t.Helper()
+	favs, err := favorites.Load(filepath.Join(t.TempDir(), favorites.FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(testProverbs, favs, color.Styler{}), favs
+}
+
+// press feeds keys to the model as if they were typed
+func press(m Model, keys ...string) Model {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func visibleIDs(m Model) []int {
+	var ids []int
+	for _, p := range m.Visible() {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	m, _ := newTestModel(t)
+	m = press(m, "/", "c", "o", "n", "c")
+	if got := visibleIDs(m); len(got) != 1 || got[0] != 2 {
+		t.Errorf("search 'conc' visible = %v, want [2]", got)
+	}
+
+	m = press(m, "backspace", "backspace", "enter")
+	if got := visibleIDs(m); len(got) != 2 {
+		t.Errorf("search 'co' visible = %v, want 2 proverbs", got)
+	}
+
+	// Keys are commands again once the search is confirmed
+	m = press(m, "esc")
+	if got := visibleIDs(m); len(got) != len(testProverbs) {
+		t.Errorf("esc should clear filters, visible = %v", got)
+	}
+}
+
+func TestTagCycling(t *testing.T) {
+	m, _ := newTestModel(t)
+
+	wantTags := []string{"concurrency", "design", "errors", "interfaces"}
+	for _, tag := range wantTags {
+		m = press(m, "t")
+		for _, p := range m.Visible() {
+			if !p.HasTag(tag) {
+				t.Errorf("tag %q shows untagged proverb %d", tag, p.ID)
+			}
+		}
+	}
+
+	m = press(m, "t")
+	if got := visibleIDs(m); len(got) != len(testProverbs) {
+		t.Errorf("cycling past the last tag should show all proverbs, got %v", got)
+	}
+	m = press(m, "T")
+	if got := visibleIDs(m); len(got) != 1 || got[0] != 3 {
+		t.Errorf("T from all should select the last tag, visible = %v", got)
+	}
+}
+
+func TestToggleFavorite(t *testing.T) {
+	m, favs := newTestModel(t)
+	m = press(m, "down", "f")
+
+	if !favs.Has(2) {
+		t.Fatal("f should mark the selected proverb as favorite")
+	}
+	if !strings.Contains(m.View(), "★") {
+		t.Error("View() should mark favorites with a star")
+	}
+
+	reloaded, err := favorites.Load(favs.Path())
+	if err != nil || !reloaded.Has(2) {
+		t.Errorf("favorite was not saved: %v", err)
+	}
+
+	m = press(m, "F")
+	if got := visibleIDs(m); len(got) != 1 || got[0] != 2 {
+		t.Errorf("favorites only visible = %v, want [2]", got)
+	}
+
+	m = press(m, "f")
+	if favs.Has(2) || len(m.Visible()) != 0 {
+		t.Error("second f should remove the favorite and hide it in favorites-only mode")
+	}
+}
+
+func TestSelectionSurvivesFiltering(t *testing.T) {
+	m, _ := newTestModel(t)
+	m = press(m, "down", "t")
+
+	p, ok := m.Selected()
+	if !ok || p.ID != 2 {
+		t.Errorf("Selected() = %v, want proverb 2 to stay selected", p)
+	}
+}
+
+func TestViewFitsWindow(t *testing.T) {
+	m, _ := newTestModel(t)
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 30, Height: 14})
+	m = next.(Model)
+
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 14 {
+		t.Errorf("View() has %d lines, want 14", len(lines))
+	}
+	for _, line := range lines {
+		if w := len([]rune(line)); w > 30 {
+			t.Errorf("line wider than window (%d): %q", w, line)
+		}
+	}
+	if !strings.Contains(m.View(), "#1  tags: concurrency") {
+		t.Error("View() should show the detail pane")
+	}
+}
+
+func TestQuit(t *testing.T) {
+	m, _ := newTestModel(t)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Error("q should quit")
+	}
+
+	// While searching, q is part of the query
+	m = press(m, "/")
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd != nil {
+		t.Error("q should not quit while searching")
+	}
+}
+
+func TestCtrlCInterrupts(t *testing.T) {
+	m, _ := newTestModel(t)
+	m = press(m, "/")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("Ctrl-C should stop the explorer, even while searching")
+	}
+	if _, ok := cmd().(tea.InterruptMsg); !ok {
+		t.Errorf("Ctrl-C sent %T, want tea.InterruptMsg so the CLI exits with 130", cmd())
+	}
+}
@@ -0,0 +1,256 @@
+// Package update checks GitHub for newer hello-gopher releases.
+//
+// The result of a check is cached on disk for CacheTTL so that repeated
+// invocations don't hit the GitHub API, which also keeps the check fast when
+// the network is slow or unavailable.
+package update
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+)
+
+// Repository is the GitHub repository hello-gopher is released from
+const Repository = "louiellywton/go-portfolio"
+
+// DefaultAPIURL is the base URL of the GitHub REST API
+const DefaultAPIURL = "https://api.github.com"
+
+// CacheTTL is how long a successful check is reused
+const CacheTTL = 24 * time.Hour
+
+// CacheFileName is the name of the cache file below the user cache directory
+const CacheFileName = "latest-release.json"
+
+// Release describes a published release
+type Release struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+// cacheEntry is the on-disk cache format
+type cacheEntry struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Release
+}
+
+// Checker looks up the latest release. The zero value is not usable; use
+// NewChecker.
+type Checker struct {
+	// APIURL is the base URL of the GitHub API
+	APIURL string
+	// Repository is the "owner/name" of the GitHub repository
+	Repository string
+	// Client performs the HTTP requests
+	Client *http.Client
+	// CachePath is the cache file; an empty path disables caching
+	CachePath string
+	// Now returns the current time
+	Now func() time.Time
+}
+
+// DefaultCachePath returns the platform-specific location of the cache file
+func DefaultCachePath() (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CacheFileName), nil
+}
+
+// NewChecker returns a checker for the hello-gopher repository caching
+// results at cachePath
+func NewChecker(cachePath string) *Checker {
+	return &Checker{
+		APIURL:     DefaultAPIURL,
+		Repository: Repository,
+		Client:     http.DefaultClient,
+		CachePath:  cachePath,
+		Now:        time.Now,
+	}
+}
+
+// Latest returns the latest release, from the cache if it is fresh. Bound the
+// time spent on the network with ctx.
+func (c *Checker) Latest(ctx context.Context) (Release, error) {
+	if entry, ok := c.readCache(); ok && c.Now().Sub(entry.CheckedAt) < CacheTTL {
+		return entry.Release, nil
+	}
+
+	release, err := c.fetch(ctx)
+	if err != nil {
+		return Release{}, err
+	}
+
+	// A failure to cache only costs another request next time
+	_ = c.writeCache(cacheEntry{CheckedAt: c.Now(), Release: release})
+	return release, nil
+}
+
+// fetch asks the GitHub API for the latest release
+func (c *Checker) fetch(ctx context.Context) (Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimSuffix(c.APIURL, "/"), c.Repository)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return Release{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("GitHub API returned %s", resp.Status)
+	}
+
+	var body struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Release{}, fmt.Errorf("invalid GitHub API response: %w", err)
+	}
+	if body.TagName == "" {
+		return Release{}, fmt.Errorf("GitHub API response has no tag name")
+	}
+	return Release{Version: body.TagName, URL: body.HTMLURL}, nil
+}
+
+// readCache returns the cached entry, if any
+func (c *Checker) readCache() (cacheEntry, bool) {
+	if c.CachePath == "" {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(c.CachePath)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version == "" {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// writeCache stores entry in the cache file
+func (c *Checker) writeCache(entry cacheEntry) error {
+	if c.CachePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.CachePath), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(c.CachePath, data, 0o600)
+}
+
+// IsNewer reports whether latest is a newer version than current. Versions
+// that can't be compared, such as "dev" builds, never report an update.
+func IsNewer(current, latest string) bool {
+	cmp, ok := Compare(current, latest)
+	return ok && cmp < 0
+}
+
+// Compare compares two "vMAJOR.MINOR.PATCH[-PRERELEASE]" versions and returns
+// -1, 0 or 1. The boolean is false if either version can't be parsed.
+// A pre-release sorts before the corresponding release.
+func Compare(a, b string) (int, bool) {
+	va, okA := parse(a)
+	vb, okB := parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	for i := 0; i < 3; i++ {
+		if va.numbers[i] != vb.numbers[i] {
+			if va.numbers[i] < vb.numbers[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+
+	switch {
+	case va.pre == vb.pre:
+		return 0, true
+	case va.pre == "":
+		return 1, true
+	case vb.pre == "":
+		return -1, true
+	default:
+		return comparePre(va.pre, vb.pre), true
+	}
+}
+
+// comparePre compares two pre-release versions such as "rc.9" and "rc.10"
+// as SemVer §11 orders them: dot-separated identifiers are compared from
+// left to right, numerically if both are numbers and as strings otherwise,
+// numbers sort before other identifiers, and a shorter list of identifiers
+// sorts first if they are otherwise equal
+func comparePre(a, b string) int {
+	ids, others := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(ids) && i < len(others); i++ {
+		x, y := ids[i], others[i]
+		if x == y {
+			continue
+		}
+		nx, errX := strconv.ParseUint(x, 10, 64)
+		ny, errY := strconv.ParseUint(y, 10, 64)
+		switch {
+		case errX == nil && errY == nil:
+			return cmp.Compare(nx, ny)
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		default:
+			return strings.Compare(x, y)
+		}
+	}
+	return cmp.Compare(len(ids), len(others))
+}
+
+// version is a parsed semantic version
+type version struct {
+	numbers [3]int
+	pre     string
+}
+
+// parse reads a version such as "v1.2.3", "1.2" or "1.2.3-rc.1+build"
+func parse(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, _ := strings.Cut(s, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return version{}, false
+	}
+
+	var v version
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.numbers[i] = n
+	}
+	v.pre = pre
+	return v, true
+}
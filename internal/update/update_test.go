@@ -0,0 +1,138 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"v1.2.3", "v1.2.3", 0, true},
+		{"1.2.3", "v1.2.4", -1, true},
+		{"v1.10.0", "v1.9.9", 1, true},
+		{"v2", "v1.9", 1, true},
+		{"v1.2.3-rc.1", "v1.2.3", -1, true},
+		{"v1.2.3-rc.2", "v1.2.3-rc.1", 1, true},
+		{"v1.2.0-rc.9", "v1.2.0-rc.10", -1, true},
+		{"v1.2.0-rc.10", "v1.2.0-rc.9", 1, true},
+		{"v1.2.0-alpha", "v1.2.0-alpha.1", -1, true},
+		{"v1.2.0-alpha.1", "v1.2.0-alpha.beta", -1, true},
+		{"v1.2.0-beta.11", "v1.2.0-rc.1", -1, true},
+		{"v1.2.3+build.5", "v1.2.3", 0, true},
+		{"dev", "v1.0.0", 0, false},
+		{"v1.0.0", "", 0, false},
+		{"v1.2.3.4", "v1.0.0", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Compare(tt.a, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Compare(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if !IsNewer("1.0.0", "v1.1.0") || IsNewer("v1.1.0", "v1.1.0") || IsNewer("dev", "v9.0.0") || !IsNewer("v1.2.0-rc.9", "v1.2.0-rc.10") {
+		t.Error("IsNewer() returned unexpected results")
+	}
+}
+
+func newTestChecker(t *testing.T, handler http.HandlerFunc) (*Checker, *int32) {
+	t.Helper()
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/repos/louiellywton/go-portfolio/releases/latest" {
+			t.Errorf("unexpected request path %s", r.URL.Path)
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewChecker(filepath.Join(t.TempDir(), "cache", CacheFileName))
+	c.APIURL = ts.URL
+	c.Client = ts.Client()
+	return c, &calls
+}
+
+func TestLatestUsesCache(t *testing.T) {
+	c, calls := newTestChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v1.4.0", "html_url": "https://example.com/v1.4.0"}`)
+	})
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		release, err := c.Latest(context.Background())
+		if err != nil {
+			t.Fatalf("Latest() unexpected error: %v", err)
+		}
+		if release.Version != "v1.4.0" || release.URL != "https://example.com/v1.4.0" {
+			t.Errorf("Latest() = %+v", release)
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("API called %d times, want 1 thanks to the cache", *calls)
+	}
+
+	now = now.Add(CacheTTL + time.Minute)
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 2 {
+		t.Errorf("API called %d times, want a refresh after the cache expired", *calls)
+	}
+}
+
+func TestLatestErrors(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"server error": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		},
+		"invalid json": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "not json")
+		},
+		"missing tag": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{}`)
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newTestChecker(t, handler)
+			if _, err := c.Latest(context.Background()); err == nil {
+				t.Error("Latest() expected error")
+			}
+		})
+	}
+}
+
+func TestLatestTimeout(t *testing.T) {
+	c, _ := newTestChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.Latest(ctx); err == nil {
+		t.Error("Latest() expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Latest() took %s despite the timeout", elapsed)
+	}
+}
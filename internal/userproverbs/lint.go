@@ -0,0 +1,180 @@
+package userproverbs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// DefaultMaxLineLength is the longest line, in characters, Lint accepts by
+// default
+const DefaultMaxLineLength = 280
+
+// Severity tells how serious a lint finding is
+type Severity string
+
+// Severities of lint findings. Errors make Parse reject the file; warnings
+// point out lines that load, but probably not as intended.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a problem Lint found in a proverb file
+type Finding struct {
+	// Line is the 1-based line number, or 0 for the file as a whole
+	Line     int      `json:"line"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Lint checks every line of the proverb file read from r, unlike Parse,
+// which stops at the first malformed one. Besides the errors Parse reports
+// it finds duplicate proverbs, lines longer than maxLineLength characters
+// (0 disables the check), malformed tags and proverbs without tags or
+// author. Findings about a proverb spanning several lines are reported at
+// its first line, and are in line order.
+//
+// A file in the v2 format is checked by lintV2 instead.
+func Lint(r io.Reader, maxLineLength int) ([]Finding, error) {
+	var findings []Finding
+	report := func(n int, severity Severity, format string, args ...any) {
+		findings = append(findings, Finding{Line: n, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	all, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data := strings.TrimPrefix(string(all), "\ufeff")
+	if greeting.IsProverbsV2(data) {
+		return lintV2(data), nil
+	}
+
+	long := make(map[int]int)
+	for i, line := range strings.Split(data, "\n") {
+		if length := utf8.RuneCountInString(strings.TrimSpace(line)); maxLineLength > 0 && length > maxLineLength {
+			long[i+1] = length
+		}
+	}
+
+	seen := make(map[string]int)
+	for _, line := range greeting.ProverbLines(data) {
+		n := line.Number
+		p, ok, err := greeting.ParseProverbLine(line.Text)
+		if err != nil {
+			report(n, SeverityError, "%v", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		// Continuation lines are checked with the proverb they belong to
+		for i := n; i <= line.End; i++ {
+			if length, ok := long[i]; ok {
+				report(i, SeverityWarning, "line is %d characters long (max %d)", length, maxLineLength)
+			}
+		}
+		if first, ok := seen[key(p.Text)]; ok {
+			report(n, SeverityError, "duplicate of line %d", first)
+		} else {
+			seen[key(p.Text)] = n
+		}
+		lintTags(line.Text, func(severity Severity, format string, args ...any) {
+			report(n, severity, format, args...)
+		})
+		if len(p.Tags) == 0 {
+			report(n, SeverityWarning, "no tags")
+		}
+		if p.Author == "" {
+			report(n, SeverityWarning, "no author")
+		}
+	}
+
+	if len(seen) == 0 {
+		report(0, SeverityError, "no proverbs")
+	}
+	return findings, nil
+}
+
+// lintV2 checks a proverb file in the v2 format. A syntax error is reported
+// at its line and ends the check; otherwise duplicates, malformed tags and
+// proverbs without tags or author are reported for the file as a whole, by
+// proverb ID.
+func lintV2(data string) []Finding {
+	proverbs, err := greeting.ParseProverbsV2(data)
+	var perr *greeting.ParseError
+	if errors.As(err, &perr) {
+		return []Finding{{Line: perr.Line, Severity: SeverityError, Message: perr.Err.Error()}}
+	}
+
+	var findings []Finding
+	report := func(severity Severity, format string, args ...any) {
+		findings = append(findings, Finding{Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+	seen := make(map[string]int)
+	for _, p := range proverbs {
+		if first, ok := seen[key(p.Text)]; ok {
+			report(SeverityError, "proverb %d: duplicate of proverb %d", p.ID, first)
+		} else {
+			seen[key(p.Text)] = p.ID
+		}
+		for _, tag := range p.Tags {
+			if strings.Trim(tag, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+				report(SeverityError, "proverb %d: malformed tag %q (use a-z, 0-9 and '-')", p.ID, tag)
+			}
+		}
+		if len(p.Tags) == 0 {
+			report(SeverityWarning, "proverb %d: no tags", p.ID)
+		}
+		if p.Author == "" {
+			report(SeverityWarning, "proverb %d: no author", p.ID)
+		}
+	}
+	if len(proverbs) == 0 {
+		report(SeverityError, "no proverbs")
+	}
+	return findings
+}
+
+// lintTags reports tags of a valid proverb line that aren't made of a-z, 0-9
+// and '-', that aren't lower case, or that are repeated
+func lintTags(line string, report func(severity Severity, format string, args ...any)) {
+	fields := strings.Split(line, "|")
+	if len(fields) < 2 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(fields[1], ",") {
+		tag = strings.TrimSpace(tag)
+		lower := strings.ToLower(tag)
+		switch {
+		case tag == "":
+			// An empty tag list before the author
+		case strings.Trim(lower, "abcdefghijklmnopqrstuvwxyz0123456789-") != "":
+			report(SeverityError, "malformed tag %q (use a-z, 0-9 and '-')", tag)
+		case lower != tag:
+			report(SeverityWarning, "tag %q is not lower case", tag)
+		case seen[tag]:
+			report(SeverityWarning, "tag %q is repeated", tag)
+		}
+		seen[lower] = true
+	}
+}
+
+// Errors returns the number of findings with SeverityError
+func Errors(findings []Finding) int {
+	count := 0
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
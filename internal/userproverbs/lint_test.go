@@ -0,0 +1,96 @@
+package userproverbs
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	data := strings.Join([]string{
+		"# team wisdom",
+		"Ship small. | release | The Team",
+		"Errors are values.",
+		"ship  SMALL. | release | Me",
+		"Bad \xff bytes. | testing | Me",
+		"Tags matter. | Release, error handling, release | Me",
+		"Empty tags. | tag, | Me",
+		"This line is quite long. | style | Me",
+	}, "\n")
+
+	findings, err := Lint(strings.NewReader(data), 35)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Finding{
+		{3, SeverityWarning, "no tags"},
+		{3, SeverityWarning, "no author"},
+		{4, SeverityError, "duplicate of line 2"},
+		{5, SeverityError, "invalid UTF-8"},
+		{6, SeverityWarning, "line is 52 characters long (max 35)"},
+		{6, SeverityWarning, `tag "Release" is not lower case`},
+		{6, SeverityError, `malformed tag "error handling" (use a-z, 0-9 and '-')`},
+		{6, SeverityWarning, `tag "release" is repeated`},
+		{7, SeverityError, "empty tag"},
+		{8, SeverityWarning, "line is 37 characters long (max 35)"},
+	}
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("Lint() =\n%v\nwant\n%v", findings, want)
+	}
+	if got := Errors(findings); got != 4 {
+		t.Errorf("Errors() = %d, want 4", got)
+	}
+
+	// Continued proverbs are checked as a whole, but lines one by one
+	findings, _ = Lint(strings.NewReader("A long proverb\n  spanning lines that are too long. | style | Me\n"), 35)
+	if !reflect.DeepEqual(findings, []Finding{{2, SeverityWarning, "line is 46 characters long (max 35)"}}) {
+		t.Errorf("Lint() of a continued proverb = %v", findings)
+	}
+
+	findings, _ = Lint(strings.NewReader("# only comments\n"), 0)
+	if !reflect.DeepEqual(findings, []Finding{{0, SeverityError, "no proverbs"}}) {
+		t.Errorf("Lint() of an empty file = %v", findings)
+	}
+}
+
+// The built-in collection must pass with warnings only, since many
+// proverbs have no author
+func TestLintEmbeddedProverbs(t *testing.T) {
+	f, err := os.Open("../../pkg/greeting/proverb.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	findings, err := Lint(f, DefaultMaxLineLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, finding := range findings {
+		if finding.Severity == SeverityError || finding.Message != "no author" {
+			t.Errorf("proverb.txt:%d: %s: %s", finding.Line, finding.Severity, finding.Message)
+		}
+	}
+}
+
+func TestLintV2(t *testing.T) {
+	data := "---\ntags: [release]\nauthor: Me\n---\nShip small.\n---\ntags: [bad_tag]\n---\nship  SMALL.\n"
+	findings, err := Lint(strings.NewReader(data), DefaultMaxLineLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Finding{
+		{0, SeverityError, "proverb 2: duplicate of proverb 1"},
+		{0, SeverityError, `proverb 2: malformed tag "bad_tag" (use a-z, 0-9 and '-')`},
+		{0, SeverityWarning, "proverb 2: no author"},
+	}
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("Lint() = %+v, want %+v", findings, want)
+	}
+
+	findings, _ = Lint(strings.NewReader("---\nauthor: Me\n---\nShip small.\n---\ncolor: red\n---\nText.\n"), 0)
+	if want := []Finding{{6, SeverityError, `unknown key "color"`}}; !reflect.DeepEqual(findings, want) {
+		t.Errorf("Lint() of a syntax error = %+v, want %+v", findings, want)
+	}
+}
@@ -0,0 +1,221 @@
+// Package userproverbs stores the proverbs a user has imported into their
+// own collection.
+//
+// The collection is kept in <user data dir>/hello-gopher/proverbs.txt, in the
+// "<text> | <tags> | <author> | <source>" line format of the embedded proverb.txt, so it
+// can be edited by hand. Once a proverb carries metadata that format can't
+// hold, such as translations, the file is saved in the v2 format instead.
+package userproverbs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/config"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// FileName is the name of the user's proverb file
+const FileName = "proverbs.txt"
+
+// header starts every saved proverb file, and headerV2 one in the v2 format
+const (
+	header = `# Proverbs imported with "hello-gopher proverb import".
+# Format: <proverb text> | <comma-separated tags> | <author> | <source URL>
+`
+	headerV2 = `# Proverbs imported with "hello-gopher proverb import".
+# Format: v2, every proverb is preceded by front matter with its metadata
+`
+)
+
+// Store is the user's proverb collection backed by a file
+type Store struct {
+	path     string
+	proverbs []greeting.Proverb
+}
+
+// DefaultPath returns the platform-specific location of the proverb file
+func DefaultPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Load reads the proverb file at path. A missing file yields an empty store.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own proverb file
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	s.proverbs, err = Parse(path, f)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Parse strictly reads proverbs in the line format of proverb.txt, or in
+// the v2 format, from r, which is named name in error messages. Indented
+// lines continue the line before them. The first malformed line fails
+// parsing with its line number. The proverbs have no IDs.
+func Parse(name string, r io.Reader) ([]greeting.Proverb, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if greeting.IsProverbsV2(string(data)) {
+		return parseV2(name, string(data))
+	}
+
+	var proverbs []greeting.Proverb
+	for _, line := range greeting.ProverbLines(strings.TrimPrefix(string(data), "\ufeff")) {
+		p, ok, err := greeting.ParseProverbLine(line.Text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, line.Number, err)
+		}
+		if ok {
+			proverbs = append(proverbs, p)
+		}
+	}
+	return proverbs, nil
+}
+
+// parseV2 reads proverbs in the v2 format, see greeting.ParseProverbsV2
+func parseV2(name, data string) ([]greeting.Proverb, error) {
+	proverbs, err := greeting.ParseProverbsV2(data)
+	var perr *greeting.ParseError
+	if errors.As(err, &perr) {
+		return nil, fmt.Errorf("%s:%d: %w", name, perr.Line, perr.Err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	for i := range proverbs {
+		proverbs[i].ID = 0
+	}
+	return proverbs, nil
+}
+
+// Path returns the file the store is saved to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Proverbs returns the stored proverbs in the order they were added
+func (s *Store) Proverbs() []greeting.Proverb {
+	return s.proverbs
+}
+
+// Clear removes every stored proverb
+func (s *Store) Clear() {
+	s.proverbs = nil
+}
+
+// Merge adds the proverbs that are neither stored yet nor in known, such as
+// the embedded collection, and reports how many were added and how many
+// were skipped as duplicates. Proverbs are compared by their text, ignoring
+// case and runs of white space.
+func (s *Store) Merge(proverbs, known []greeting.Proverb) (added, skipped int) {
+	seen := make(map[string]bool, len(known)+len(s.proverbs)+len(proverbs))
+	for _, p := range known {
+		seen[key(p.Text)] = true
+	}
+	for _, p := range s.proverbs {
+		seen[key(p.Text)] = true
+	}
+
+	for _, p := range proverbs {
+		k := key(p.Text)
+		if seen[k] {
+			skipped++
+			continue
+		}
+		seen[k] = true
+		p.ID = 0
+		s.proverbs = append(s.proverbs, p)
+		added++
+	}
+	return added, skipped
+}
+
+// Find returns the first of proverbs with the same text as text, compared
+// like Merge does, and whether there is one
+func Find(proverbs []greeting.Proverb, text string) (greeting.Proverb, bool) {
+	k := key(text)
+	for _, p := range proverbs {
+		if key(p.Text) == k {
+			return p, true
+		}
+	}
+	return greeting.Proverb{}, false
+}
+
+// key returns the text proverbs are de-duplicated by
+func key(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// Save writes the proverbs back to the file they were loaded from,
+// creating parent directories. The v2 format is used when a proverb has
+// metadata the line format can't hold.
+func (s *Store) Save() error {
+	var buf bytes.Buffer
+	if s.needsV2() {
+		buf.WriteString(headerV2)
+		buf.WriteByte('\n')
+		if err := greeting.WriteProverbsV2(&buf, s.proverbs); err != nil {
+			return err
+		}
+	} else {
+		buf.WriteString(header)
+		for _, p := range s.proverbs {
+			buf.WriteString(FormatLine(p))
+			buf.WriteByte('\n')
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, buf.Bytes(), 0o600)
+}
+
+// needsV2 reports whether a stored proverb has an added date or
+// translations
+func (s *Store) needsV2() bool {
+	for _, p := range s.proverbs {
+		if p.Added != "" || len(p.Translations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatLine returns p in the line format read by Parse
+func FormatLine(p greeting.Proverb) string {
+	fields := []string{p.Text}
+	if len(p.Tags) > 0 || p.Author != "" || p.Source != "" {
+		fields = append(fields, strings.Join(p.Tags, ", "))
+	}
+	if p.Author != "" || p.Source != "" {
+		fields = append(fields, p.Author)
+	}
+	if p.Source != "" {
+		fields = append(fields, p.Source)
+	}
+	return strings.Join(fields, " | ")
+}
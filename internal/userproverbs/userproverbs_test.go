@@ -0,0 +1,131 @@
+package userproverbs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(s.Proverbs()) != 0 {
+		t.Errorf("missing file loaded %d proverbs", len(s.Proverbs()))
+	}
+}
+
+func TestParse(t *testing.T) {
+	data := "\ufeff# mine\r\nShip small. | release | Me\r\n\r\nTest the edges. | testing\n"
+	proverbs, err := Parse("in.txt", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(proverbs) != 2 || proverbs[0].Author != "Me" || proverbs[1].Tags[0] != "testing" {
+		t.Errorf("Parse() = %+v", proverbs)
+	}
+
+	proverbs, err = Parse("in.txt", strings.NewReader("A long proverb\n  on two lines. | style\n"))
+	if err != nil || len(proverbs) != 1 || proverbs[0].Text != "A long proverb on two lines." {
+		t.Errorf("Parse() of a continued line = %+v, %v", proverbs, err)
+	}
+
+	_, err = Parse("in.txt", strings.NewReader("Fine.\n| no text\n"))
+	if err == nil || !strings.Contains(err.Error(), "in.txt:2: proverb text is empty") {
+		t.Errorf("Parse() error = %v, want the malformed line", err)
+	}
+}
+
+func TestMergeAndSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	known := []greeting.Proverb{{ID: 1, Text: "Errors are values."}}
+	incoming := []greeting.Proverb{
+		{Text: "Ship small.", Tags: []string{"release"}},
+		{Text: "errors  are VALUES."},
+		{Text: "Ship  small."},
+		{Text: "Read the source.", Author: "Someone"},
+		{Text: "Trace the quote.", Source: "https://go.dev/"},
+	}
+	added, skipped := s.Merge(incoming, known)
+	if added != 3 || skipped != 2 {
+		t.Errorf("Merge() = %d added, %d skipped; want 3, 2", added, skipped)
+	}
+	if added, skipped := s.Merge(incoming[:1], known); added != 0 || skipped != 1 {
+		t.Errorf("second Merge() = %d added, %d skipped; want 0, 1", added, skipped)
+	}
+
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Ship small. | release\n", "Read the source. |  | Someone\n", "Trace the quote. |  |  | https://go.dev/\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved file misses %q:\n%s", want, data)
+		}
+	}
+
+	reloaded, err := Load(path)
+	if err != nil || len(reloaded.Proverbs()) != 3 || reloaded.Proverbs()[2].Source != "https://go.dev/" {
+		t.Fatalf("Load() after Save() = %v, %v", reloaded, err)
+	}
+
+	reloaded.Clear()
+	if len(reloaded.Proverbs()) != 0 {
+		t.Error("Clear() kept proverbs")
+	}
+}
+
+func TestParseV2(t *testing.T) {
+	data := "# mine\n---\ntags: [release]\ntranslations:\n  de: Klein ausliefern.\n---\nShip small.\n"
+	proverbs, err := Parse("in.txt", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(proverbs) != 1 || proverbs[0].ID != 0 || proverbs[0].Translations["de"] != "Klein ausliefern." {
+		t.Errorf("Parse() = %+v", proverbs)
+	}
+
+	_, err = Parse("in.txt", strings.NewReader("---\nadded: soon\n---\nShip small.\n"))
+	if err == nil || !strings.Contains(err.Error(), `in.txt:2: added date "soon" is not YYYY-MM-DD`) {
+		t.Errorf("Parse() error = %v, want the malformed line", err)
+	}
+}
+
+func TestSaveV2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Merge([]greeting.Proverb{
+		{Text: "Ship small.", Tags: []string{"release"}},
+		{Text: "Read the source.", Translations: map[string]string{"de": "Lies den Quelltext."}},
+	}, nil)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !greeting.IsProverbsV2(string(data)) {
+		t.Errorf("proverbs with translations weren't saved in the v2 format:\n%s", data)
+	}
+	reloaded, err := Load(path)
+	if err != nil || len(reloaded.Proverbs()) != 2 || reloaded.Proverbs()[1].Translations["de"] == "" {
+		t.Errorf("Load() after Save() = %+v, %v", reloaded, err)
+	}
+}
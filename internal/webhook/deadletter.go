@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DeadLetterFileName is the name of the dead-letter file below the user
+// state directory
+const DeadLetterFileName = "webhook-dlq.jsonl"
+
+// MaxDeadLetters caps the dead-letter file; the oldest deliveries are dropped
+const MaxDeadLetters = 1000
+
+// DeadLetter is a delivery that failed, kept to be redelivered
+type DeadLetter struct {
+	Delivery
+	// Attempts counts the deliveries that failed, including redeliveries
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+	// Error is the reason of the last failure
+	Error string `json:"error"`
+}
+
+// AddDeadLetter records the failure of d with err in the dead-letter file at
+// path, creating the file and its parent directories, and returns the
+// number of dead letters. The file is only readable by the user, since
+// webhook URLs usually embed a secret.
+func AddDeadLetter(path string, d Delivery, err error) (int, error) {
+	letters, loadErr := LoadDeadLetters(path)
+	if loadErr != nil {
+		return 0, loadErr
+	}
+	letters = append(letters, DeadLetter{Delivery: d, Attempts: 1, FailedAt: time.Now().UTC(), Error: err.Error()})
+	if len(letters) > MaxDeadLetters {
+		letters = letters[len(letters)-MaxDeadLetters:]
+	}
+	if err := saveDeadLetters(path, letters); err != nil {
+		return 0, err
+	}
+	return len(letters), nil
+}
+
+// LoadDeadLetters reads the dead-letter file at path. A missing file yields
+// no dead letters, and lines that can't be decoded are skipped.
+func LoadDeadLetters(path string) ([]DeadLetter, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is the user's own dead-letter file
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var letters []DeadLetter
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var l DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil || l.URL == "" || len(l.Payload) == 0 {
+			continue
+		}
+		letters = append(letters, l)
+	}
+	return letters, scanner.Err()
+}
+
+// saveDeadLetters replaces the dead-letter file at path with letters, or
+// removes it if there are none
+func saveDeadLetters(path string, letters []DeadLetter) error {
+	if len(letters) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, l := range letters {
+		line, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// Redeliver delivers the dead letters at path again with client, oldest
+// first and with their original idempotency keys, and keeps only those that
+// fail again. It returns how many were delivered and the last delivery
+// error. Redelivery stops when ctx is canceled.
+func Redeliver(ctx context.Context, client *http.Client, path string) (delivered int, remaining []DeadLetter, err error) {
+	letters, err := LoadDeadLetters(path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var lastErr error
+	for i, l := range letters {
+		if ctx.Err() != nil {
+			remaining = append(remaining, letters[i:]...)
+			lastErr = ctx.Err()
+			break
+		}
+		if err := Deliver(ctx, client, l.Delivery); err != nil {
+			l.Attempts++
+			l.FailedAt = time.Now().UTC()
+			l.Error = err.Error()
+			remaining = append(remaining, l)
+			lastErr = err
+			continue
+		}
+		delivered++
+	}
+	if err := saveDeadLetters(path, remaining); err != nil {
+		return delivered, remaining, err
+	}
+	return delivered, remaining, lastErr
+}
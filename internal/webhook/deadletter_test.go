@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeadLetters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", DeadLetterFileName)
+	if letters, err := LoadDeadLetters(path); err != nil || len(letters) != 0 {
+		t.Fatalf("LoadDeadLetters() of a missing file = %v, %v", letters, err)
+	}
+
+	first := NewDelivery("https://hooks.example.com/a", Slack, []byte("{\n  \"text\": \"one\"\n}"))
+	second := NewDelivery("https://hooks.example.com/b", Discord, []byte(`{"content":"two"}`))
+	for i, d := range []Delivery{first, second} {
+		if n, err := AddDeadLetter(path, d, errors.New("webhook returned 503")); err != nil || n != i+1 {
+			t.Fatalf("AddDeadLetter() = %d, %v; want %d", n, err, i+1)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("dead-letter file mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	letters, err := LoadDeadLetters(path)
+	if err != nil || len(letters) != 2 {
+		t.Fatalf("LoadDeadLetters() = %d letters, %v; want 2", len(letters), err)
+	}
+	got := letters[0]
+	if got.URL != first.URL || got.Key != first.Key || got.Platform != Slack || string(got.Payload) != `{"text":"one"}` ||
+		got.Attempts != 1 || got.Error != "webhook returned 503" || got.FailedAt.IsZero() {
+		t.Errorf("first dead letter = %+v", got)
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	received := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.Error(w, "no_service", http.StatusNotFound)
+			return
+		}
+		received[r.Header.Get(IdempotencyHeader)] = r.URL.Path
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), DeadLetterFileName)
+	ok := NewDelivery(ts.URL+"/ok", Slack, []byte(`{"text":"hi"}`))
+	gone := NewDelivery(ts.URL+"/gone", Slack, []byte(`{"text":"hi"}`))
+	for _, d := range []Delivery{ok, gone} {
+		if _, err := AddDeadLetter(path, d, errors.New("timeout")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	delivered, remaining, err := Redeliver(context.Background(), nil, path)
+	if delivered != 1 || len(remaining) != 1 || err == nil {
+		t.Fatalf("Redeliver() = %d, %d remaining, %v; want 1, 1 and the error", delivered, len(remaining), err)
+	}
+	if received[ok.Key] != "/ok" {
+		t.Errorf("webhook received %v, want the original key %s", received, ok.Key)
+	}
+	letters, _ := LoadDeadLetters(path)
+	if len(letters) != 1 || letters[0].Key != gone.Key || letters[0].Attempts != 2 {
+		t.Errorf("dead letters after redelivery = %+v, want the failed one with 2 attempts", letters)
+	}
+
+	// Once everything is delivered the file is removed
+	if err := saveDeadLetters(path, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("dead-letter file still exists: %v", err)
+	}
+}
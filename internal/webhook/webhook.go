@@ -0,0 +1,194 @@
+// Package webhook formats messages for chat platforms and posts them to
+// incoming webhooks. Every delivery carries an Idempotency-Key header, which
+// stays the same when the delivery is retried, so receivers can drop
+// duplicates. Deliveries that fail for good can be kept in a dead-letter
+// file and redelivered later; see AddDeadLetter and Redeliver.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Platform is a chat service with its own webhook payload schema
+type Platform string
+
+// Supported platforms
+const (
+	Slack   Platform = "slack"
+	Discord Platform = "discord"
+)
+
+// DefaultTimeout bounds a delivery, including its retries
+const DefaultTimeout = 10 * time.Second
+
+// DefaultRetries is how often a delivery that fails because of the network,
+// rate limiting or a server error is retried, with exponential backoff
+const DefaultRetries = 4
+
+// IdempotencyHeader carries the key identifying a delivery
+const IdempotencyHeader = "Idempotency-Key"
+
+// username is shown as the sender on platforms that allow overriding it
+const username = "hello-gopher"
+
+// goBlue is the Go gopher blue used to accent Discord embeds
+const goBlue = 0x00ADD8
+
+// ParsePlatform validates a platform name
+func ParsePlatform(s string) (Platform, error) {
+	switch p := Platform(strings.ToLower(strings.TrimSpace(s))); p {
+	case Slack, Discord:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unsupported platform %q (supported: slack, discord)", s)
+	}
+}
+
+// Message is the platform-independent content of a post
+type Message struct {
+	// Text is the main content, such as a proverb or greeting
+	Text string
+	// Footer is optional context shown below the text, such as "Go Proverb #5"
+	Footer string
+	// Quote renders Text as a quotation
+	Quote bool
+}
+
+// Slack payloads, see https://api.slack.com/messaging/webhooks
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Discord payloads, see https://discord.com/developers/docs/resources/webhook
+type discordPayload struct {
+	Username string         `json:"username"`
+	Content  string         `json:"content,omitempty"`
+	Embeds   []discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// Payload returns the JSON body for posting msg to platform
+func Payload(platform Platform, msg Message) ([]byte, error) {
+	var payload interface{}
+
+	switch platform {
+	case Slack:
+		text := msg.Text
+		if msg.Quote {
+			text = "> " + text
+		}
+		p := slackPayload{
+			Text:   msg.Text, // notification fallback
+			Blocks: []slackBlock{{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}}},
+		}
+		if msg.Footer != "" {
+			p.Blocks = append(p.Blocks, slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: msg.Footer}}})
+		}
+		payload = p
+	case Discord:
+		p := discordPayload{Username: username}
+		if msg.Quote || msg.Footer != "" {
+			embed := discordEmbed{Description: msg.Text, Color: goBlue}
+			if msg.Footer != "" {
+				embed.Footer = &discordFooter{Text: msg.Footer}
+			}
+			p.Embeds = []discordEmbed{embed}
+		} else {
+			p.Content = msg.Text
+		}
+		payload = p
+	default:
+		return nil, fmt.Errorf("unsupported platform %q", platform)
+	}
+
+	return json.MarshalIndent(payload, "", "  ")
+}
+
+// Delivery is a payload to post to a webhook
+type Delivery struct {
+	URL      string          `json:"url"`
+	Platform Platform        `json:"platform"`
+	Payload  json.RawMessage `json:"payload"`
+	// Key identifies the delivery in the IdempotencyHeader; see NewKey
+	Key string `json:"idempotency_key"`
+}
+
+// NewDelivery returns the delivery of payload to the webhook of platform at
+// url with a new idempotency key
+func NewDelivery(url string, platform Platform, payload []byte) Delivery {
+	return Delivery{URL: url, Platform: platform, Payload: payload, Key: NewKey()}
+}
+
+// NewKey returns a random idempotency key in the form of a UUID
+func NewKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 9562 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Post sends payload to the webhook at url and fails unless the platform
+// answers with a 2xx status
+func Post(ctx context.Context, client *http.Client, url string, payload []byte) error {
+	return Deliver(ctx, client, Delivery{URL: url, Payload: payload})
+}
+
+// Deliver posts the payload of d to its webhook, with its idempotency key if
+// it has one, and fails unless the platform answers with a 2xx status.
+// Retries are up to client, whose transport resends the same key.
+func Deliver(ctx context.Context, client *http.Client, d Delivery) error {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.Key != "" {
+		req.Header.Set(IdempotencyHeader, d.Key)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
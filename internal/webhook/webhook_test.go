@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/internal/httpclient"
+)
+
+func TestParsePlatform(t *testing.T) {
+	for input, want := range map[string]Platform{"slack": Slack, " Discord ": Discord} {
+		if got, err := ParsePlatform(input); err != nil || got != want {
+			t.Errorf("ParsePlatform(%q) = %q, %v", input, got, err)
+		}
+	}
+	if _, err := ParsePlatform("teams"); err == nil {
+		t.Error("ParsePlatform(teams) expected error")
+	}
+}
+
+func TestSlackPayload(t *testing.T) {
+	data, err := Payload(Slack, Message{Text: "Errors are values.", Footer: "Go Proverb #4", Quote: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var p struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+			Elements []struct {
+				Text string `json:"text"`
+			} `json:"elements"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Text != "Errors are values." || len(p.Blocks) != 2 {
+		t.Fatalf("unexpected Slack payload: %s", data)
+	}
+	if p.Blocks[0].Text.Text != "> Errors are values." || p.Blocks[1].Elements[0].Text != "Go Proverb #4" {
+		t.Errorf("unexpected Slack blocks: %s", data)
+	}
+}
+
+func TestDiscordPayload(t *testing.T) {
+	data, err := Payload(Discord, Message{Text: "Errors are values.", Footer: "Go Proverb #4", Quote: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p discordPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Username != "hello-gopher" || len(p.Embeds) != 1 || p.Embeds[0].Footer.Text != "Go Proverb #4" {
+		t.Errorf("unexpected Discord payload: %s", data)
+	}
+
+	data, _ = Payload(Discord, Message{Text: "Hello, Alice!"})
+	if !strings.Contains(string(data), `"content": "Hello, Alice!"`) {
+		t.Errorf("plain Discord messages should use content: %s", data)
+	}
+}
+
+func TestPost(t *testing.T) {
+	var got []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		got, _ = io.ReadAll(r.Body)
+		if strings.Contains(string(got), "fail") {
+			http.Error(w, "invalid_payload", http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	if err := Post(context.Background(), nil, ts.URL, []byte(`{"text":"hi"}`)); err != nil {
+		t.Fatalf("Post() unexpected error: %v", err)
+	}
+	if string(got) != `{"text":"hi"}` {
+		t.Errorf("server received %q", got)
+	}
+
+	err := Post(context.Background(), nil, ts.URL, []byte(`{"text":"fail"}`))
+	if err == nil || !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "invalid_payload") {
+		t.Errorf("Post() error = %v, want the status and body", err)
+	}
+}
+
+func TestDeliverRetriesWithTheSameKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	d := NewDelivery(ts.URL, Slack, []byte(`{"text":"hi"}`))
+	client := &http.Client{Transport: &httpclient.Transport{Retries: 1, Backoff: time.Millisecond}}
+	if err := Deliver(context.Background(), client, d); err != nil {
+		t.Fatalf("Deliver() unexpected error: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != d.Key || keys[1] != d.Key {
+		t.Errorf("webhook received keys %q, want %q twice", keys, d.Key)
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	key := NewKey()
+	if len(key) != 36 || key[14] != '4' || strings.Count(key, "-") != 4 {
+		t.Errorf("NewKey() = %q, want a version 4 UUID", key)
+	}
+	if NewKey() == key {
+		t.Error("NewKey() returned the same key twice")
+	}
+}
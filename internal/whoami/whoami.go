@@ -0,0 +1,89 @@
+// Package whoami guesses the name of the person running hello-gopher.
+//
+// The name is taken from the first of these sources that yields one:
+//
+//  1. the operating system account (os/user): its full name, or else its
+//     login name
+//  2. the $USER environment variable
+//  3. git config user.name
+package whoami
+
+import (
+	"os"
+	"os/exec"
+	"os/user"
+	"strings"
+)
+
+// Sources reported by Detector.Detect
+const (
+	SourceOS  = "os"
+	SourceEnv = "env"
+	SourceGit = "git"
+)
+
+// Detector looks up the current user's name. Its fields are injectable so
+// the detection order can be tested without a real account or git.
+type Detector struct {
+	// CurrentUser returns the operating system account, typically user.Current
+	CurrentUser func() (*user.User, error)
+	// LookupEnv reads environment variables, typically os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+	// GitUserName returns the configured git user.name
+	GitUserName func() (string, error)
+}
+
+// DefaultDetector inspects the real account, environment and git configuration
+func DefaultDetector() Detector {
+	return Detector{
+		CurrentUser: user.Current,
+		LookupEnv:   os.LookupEnv,
+		GitUserName: GitUserName,
+	}
+}
+
+// Detect returns the user's name and the source it came from. It returns
+// ok == false when no source yields a name; nil lookups are skipped.
+func (d Detector) Detect() (name, source string, ok bool) {
+	if d.CurrentUser != nil {
+		if u, err := d.CurrentUser(); err == nil {
+			if name := accountName(u); name != "" {
+				return name, SourceOS, true
+			}
+		}
+	}
+	if d.LookupEnv != nil {
+		if name, _ := d.LookupEnv("USER"); strings.TrimSpace(name) != "" {
+			return strings.TrimSpace(name), SourceEnv, true
+		}
+	}
+	if d.GitUserName != nil {
+		if name, err := d.GitUserName(); err == nil && strings.TrimSpace(name) != "" {
+			return strings.TrimSpace(name), SourceGit, true
+		}
+	}
+	return "", "", false
+}
+
+// accountName returns the display name of u. The full name is cut at the
+// first comma, which separates the GECOS fields on Unix, and the login name
+// loses its DOMAIN\ prefix on Windows.
+func accountName(u *user.User) string {
+	if full, _, _ := strings.Cut(u.Name, ","); strings.TrimSpace(full) != "" {
+		return strings.TrimSpace(full)
+	}
+	login := u.Username
+	if i := strings.LastIndex(login, `\`); i >= 0 {
+		login = login[i+1:]
+	}
+	return strings.TrimSpace(login)
+}
+
+// GitUserName runs git config user.name
+func GitUserName() (string, error) {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
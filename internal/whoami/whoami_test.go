@@ -0,0 +1,50 @@
+package whoami
+
+import (
+	"errors"
+	"os/user"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	failUser := func() (*user.User, error) { return nil, errors.New("no account") }
+	account := func(name, login string) func() (*user.User, error) {
+		return func() (*user.User, error) { return &user.User{Name: name, Username: login}, nil }
+	}
+	env := func(vars map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			v, ok := vars[key]
+			return v, ok
+		}
+	}
+	git := func(name string, err error) func() (string, error) {
+		return func() (string, error) { return name, err }
+	}
+	noGit := git("", errors.New("git not found"))
+
+	tests := []struct {
+		name       string
+		detector   Detector
+		wantName   string
+		wantSource string
+	}{
+		{"full name", Detector{CurrentUser: account("Ada Lovelace", "ada"), GitUserName: git("Someone", nil)}, "Ada Lovelace", SourceOS},
+		{"gecos fields", Detector{CurrentUser: account("Ada Lovelace,,,", "ada")}, "Ada Lovelace", SourceOS},
+		{"login name", Detector{CurrentUser: account("", "ada")}, "ada", SourceOS},
+		{"windows login", Detector{CurrentUser: account("", `CORP\ada`)}, "ada", SourceOS},
+		{"env", Detector{CurrentUser: failUser, LookupEnv: env(map[string]string{"USER": " bob "}), GitUserName: git("Someone", nil)}, "bob", SourceEnv},
+		{"empty env", Detector{CurrentUser: failUser, LookupEnv: env(map[string]string{"USER": ""}), GitUserName: git("Grace Hopper\n", nil)}, "Grace Hopper", SourceGit},
+		{"git", Detector{CurrentUser: failUser, LookupEnv: env(nil), GitUserName: git("Grace Hopper", nil)}, "Grace Hopper", SourceGit},
+		{"nothing", Detector{CurrentUser: failUser, LookupEnv: env(nil), GitUserName: noGit}, "", ""},
+		{"nil lookups", Detector{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, source, ok := tt.detector.Detect()
+			if name != tt.wantName || source != tt.wantSource || ok != (tt.wantName != "") {
+				t.Errorf("Detect() = %q, %q, %v; want %q, %q", name, source, ok, tt.wantName, tt.wantSource)
+			}
+		})
+	}
+}
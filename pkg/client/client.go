@@ -0,0 +1,248 @@
+// Package client calls the JSON API of a hello-gopher server, such as one
+// started with "hello-gopher serve", so teams can share one curated proverb
+// collection instead of every installation using its own data.
+//
+//	c, err := client.New("https://proverbs.example.com")
+//	if err != nil {
+//		// invalid URL
+//	}
+//	proverb, err := c.RandomProverb(ctx)
+//
+// Requests that fail because of the network, rate limiting (429) or a server
+// error (5xx) are retried with exponential backoff. Other error statuses are
+// returned at once as an *Error.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/server"
+)
+
+// Defaults of a Client created by New
+const (
+	// DefaultTimeout bounds a call including its retries
+	DefaultTimeout = 10 * time.Second
+	// DefaultRetries is how often a failed request is retried
+	DefaultRetries = 2
+	// DefaultBackoff is the delay before the first retry; it doubles for
+	// every further retry
+	DefaultBackoff = 250 * time.Millisecond
+)
+
+// maxBackoff caps the delay between two attempts, including delays asked
+// for with Retry-After
+const maxBackoff = 5 * time.Second
+
+// Error is returned when the server answers with an error status
+type Error struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Message is the error reported by the server, or the status text
+	Message string
+	// RetryAfter is the delay the server asked for before trying again
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("server returned %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Temporary reports whether the request may succeed when retried
+func (e *Error) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Client calls a hello-gopher server. It is safe for concurrent use.
+type Client struct {
+	base    *url.URL
+	http    *http.Client
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+}
+
+// Option configures a Client created by New
+type Option func(*Client)
+
+// WithHTTPClient sends requests with hc instead of http.DefaultClient
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithTimeout bounds every call, including its retries, to d. Zero
+// disables the timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetries retries a failed request up to n times. Zero disables
+// retries.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.retries = n
+	}
+}
+
+// WithBackoff waits d before the first retry, doubling the delay for every
+// further one
+func WithBackoff(d time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = d
+	}
+}
+
+// New returns a client of the server at baseURL, an http or https URL that
+// may include the path the API is mounted under
+func New(baseURL string, opts ...Option) (*Client, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL %q: %w", baseURL, err)
+	}
+	if (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q: expected http:// or https:// followed by a host", baseURL)
+	}
+
+	c := &Client{
+		base:    base,
+		http:    http.DefaultClient,
+		timeout: DefaultTimeout,
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Greet asks the server to greet name in its configured language and style
+func (c *Client) Greet(ctx context.Context, name string) (server.GreetResponse, error) {
+	var resp server.GreetResponse
+	err := c.get(ctx, "greet", url.Values{"name": {name}}, &resp)
+	return resp, err
+}
+
+// RandomProverb returns a random proverb from the server's collection
+func (c *Client) RandomProverb(ctx context.Context) (greeting.Proverb, error) {
+	var proverb greeting.Proverb
+	err := c.get(ctx, "proverb", nil, &proverb)
+	return proverb, err
+}
+
+// DailyProverb returns the server's proverb of the day
+func (c *Client) DailyProverb(ctx context.Context) (greeting.Proverb, error) {
+	var proverb greeting.Proverb
+	err := c.get(ctx, "proverb", url.Values{"daily": {"true"}}, &proverb)
+	return proverb, err
+}
+
+// get requests path below the base URL with query and decodes the JSON
+// response into v, retrying temporary failures
+func (c *Client) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	u := c.base.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		err := c.do(ctx, u.String(), v)
+		if err == nil || attempt >= c.retries || !retryable(ctx, err) {
+			return err
+		}
+
+		timer := time.NewTimer(c.delay(attempt, err))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// do performs a single request
+func (c *Client) do(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", url, err)
+	}
+	return nil
+}
+
+// responseError builds the Error for a response with an error status from
+// the ErrorResponse in its body
+func responseError(resp *http.Response) *Error {
+	e := &Error{StatusCode: resp.StatusCode}
+	var body server.ErrorResponse
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		e.Message = body.Error
+	} else {
+		e.Message = strings.ToLower(http.StatusText(resp.StatusCode))
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return e
+}
+
+// retryable reports whether a request that failed with err is worth
+// retrying. Network errors are, unless the caller gave up.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Temporary()
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// delay returns how long to wait before retrying the attempt that failed
+// with err
+func (c *Client) delay(attempt int, err error) time.Duration {
+	d := c.backoff << attempt
+	var e *Error
+	if errors.As(err, &e) && e.RetryAfter > d {
+		d = e.RetryAfter
+	}
+	if d > maxBackoff || d < 0 {
+		d = maxBackoff
+	}
+	return d
+}
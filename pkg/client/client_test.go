@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/server"
+)
+
+func newTestClient(t *testing.T, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+	c, err := New(ts.URL, append([]Option{WithBackoff(time.Millisecond)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClient(t *testing.T) {
+	svc := greeting.NewService()
+	c := newTestClient(t, server.New(svc, nil).Handler())
+	ctx := context.Background()
+
+	greet, err := c.Greet(ctx, "Alice")
+	if err != nil || greet.Greeting != "Hello, Alice!" || greet.Name != "Alice" {
+		t.Errorf("Greet() = %+v, %v", greet, err)
+	}
+
+	proverb, err := c.RandomProverb(ctx)
+	if err != nil || proverb.ID == 0 || proverb.Text == "" {
+		t.Errorf("RandomProverb() = %+v, %v", proverb, err)
+	}
+
+	want, _ := svc.TodaysProverb()
+	if got, err := c.DailyProverb(ctx); err != nil || got.ID != want.ID {
+		t.Errorf("DailyProverb() = %+v, %v; want %+v", got, err, want)
+	}
+}
+
+func TestClientBasePath(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", server.New(greeting.NewService(), nil).Handler()))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	c, err := New(ts.URL + "/api/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Greet(context.Background(), "Bob"); err != nil {
+		t.Errorf("Greet() below a path error = %v", err)
+	}
+}
+
+func TestNewInvalidURL(t *testing.T) {
+	for _, u := range []string{"", "localhost:8080", "ftp://example.com", "http://", "http://[::1"} {
+		if _, err := New(u); err == nil {
+			t.Errorf("New(%q) succeeded", u)
+		}
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 7, "text": "Clear is better than clever."}`))
+	}))
+
+	proverb, err := c.RandomProverb(context.Background())
+	if err != nil || proverb.ID != 7 {
+		t.Fatalf("RandomProverb() = %+v, %v", proverb, err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("server called %d times, want 3", n)
+	}
+}
+
+func TestClientGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": "failed to load proverbs"}`))
+	}), WithRetries(1))
+
+	_, err := c.RandomProverb(context.Background())
+	var e *Error
+	if !errors.As(err, &e) || e.StatusCode != http.StatusInternalServerError || e.Message != "failed to load proverbs" {
+		t.Fatalf("RandomProverb() error = %v, want the server's error", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("server called %d times, want 2", n)
+	}
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, r)
+	}))
+
+	_, err := c.Greet(context.Background(), "Alice")
+	var e *Error
+	if !errors.As(err, &e) || e.StatusCode != http.StatusNotFound || e.Temporary() {
+		t.Fatalf("Greet() error = %v, want a permanent 404", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server called %d times, want 1", n)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}), WithTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	if _, err := c.RandomProverb(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RandomProverb() error = %v, want the deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("RandomProverb() took %v despite the timeout", elapsed)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	c := &Client{backoff: 100 * time.Millisecond}
+	tests := []struct {
+		attempt int
+		err     error
+		want    time.Duration
+	}{
+		{0, errors.New("network"), 100 * time.Millisecond},
+		{2, errors.New("network"), 400 * time.Millisecond},
+		{0, &Error{StatusCode: 429, RetryAfter: 2 * time.Second}, 2 * time.Second},
+		{0, &Error{StatusCode: 429, RetryAfter: time.Hour}, maxBackoff},
+		{10, errors.New("network"), maxBackoff},
+	}
+	for _, tt := range tests {
+		if got := c.delay(tt.attempt, tt.err); got != tt.want {
+			t.Errorf("delay(%d, %v) = %v, want %v", tt.attempt, tt.err, got, tt.want)
+		}
+	}
+}
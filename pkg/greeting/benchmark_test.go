@@ -1,92 +1,10 @@
 package greeting
 
 import (
-	"errors"
 	"strings"
 	"testing"
 )
 
-// BenchmarkMockGreeter benchmarks mock greeter performance
-func BenchmarkMockGreeter(b *testing.B) {
-	mock := NewMockGreeter()
-	b.ResetTimer()
-	
-	for i := 0; i < b.N; i++ {
-		_ = mock.Greet("BenchUser")
-	}
-}
-
-// BenchmarkMockProverbProvider benchmarks mock proverb provider performance
-func BenchmarkMockProverbProvider(b *testing.B) {
-	mock := NewMockProverbProvider()
-	b.ResetTimer()
-	
-	for i := 0; i < b.N; i++ {
-		_ = mock.RandomProverb()
-	}
-}
-
-// BenchmarkMockService benchmarks combined mock service performance
-func BenchmarkMockService(b *testing.B) {
-	mock := NewMockService()
-	b.ResetTimer()
-	
-	b.Run("Greet", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			_ = mock.Greet("BenchUser")
-		}
-	})
-	
-	b.Run("RandomProverb", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			_ = mock.RandomProverb()
-		}
-	})
-}
-
-// BenchmarkMockCallLogging benchmarks the overhead of call logging
-func BenchmarkMockCallLogging(b *testing.B) {
-	mock := NewMockGreeter()
-	
-	b.Run("WithLogging", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			_ = mock.Greet("User")
-		}
-	})
-	
-	b.Run("ClearLog", func(b *testing.B) {
-		// Fill up the log first
-		for i := 0; i < 100; i++ {
-			mock.Greet("User")
-		}
-		
-		b.ResetTimer()
-		for i := 0; i < b.N; i++ {
-			mock.ClearCallLog()
-		}
-	})
-}
-
-// BenchmarkErrorMockProverbProvider benchmarks error mock performance
-func BenchmarkErrorMockProverbProvider(b *testing.B) {
-	mock := NewErrorMockProverbProvider(
-		errors.New("benchmark error"),
-		"Error: benchmark proverb error",
-	)
-	
-	b.Run("RandomProverb", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			_ = mock.RandomProverb()
-		}
-	})
-	
-	b.Run("LoadProverbs", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			_ = mock.LoadProverbs()
-		}
-	})
-}
-
 // BenchmarkStringOperations benchmarks string operations used in greeting
 func BenchmarkStringOperations(b *testing.B) {
 	names := []string{"", "Alice", "Bob", "VeryLongNameForBenchmarking", "José"}
@@ -127,19 +45,22 @@ func BenchmarkProverbSelection(b *testing.B) {
 		"Cgo must always be guarded with build tags.",
 	}
 	
-	mock := NewMockProverbProvider()
-	mock.SetProverbs(proverbs)
+	service := NewService()
+	if err := service.LoadProverbsFromReader(strings.NewReader(strings.Join(proverbs, "\n"))); err != nil {
+		b.Fatal(err)
+	}
 	
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = mock.RandomProverb()
+		_ = service.RandomProverb()
 	}
 }
 
 // BenchmarkInterfaceMethodCalls benchmarks interface method call overhead
 func BenchmarkInterfaceMethodCalls(b *testing.B) {
-	var greeter Greeter = NewMockGreeter()
-	var provider ProverbProvider = NewMockProverbProvider()
+	service := NewService()
+	var greeter Greeter = service
+	var provider ProverbProvider = service
 	
 	b.Run("GreeterInterface", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -156,24 +77,9 @@ func BenchmarkInterfaceMethodCalls(b *testing.B) {
 
 // BenchmarkMemoryAllocations benchmarks memory allocation patterns
 func BenchmarkMemoryAllocations(b *testing.B) {
-	b.Run("MockCreation", func(b *testing.B) {
+	b.Run("ServiceCreation", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_ = NewMockGreeter()
+			_ = NewService()
 		}
 	})
-	
-	b.Run("MockServiceCreation", func(b *testing.B) {
-		for i := 0; i < b.N; i++ {
-			_ = NewMockService()
-		}
-	})
-	
-	b.Run("CallLogGrowth", func(b *testing.B) {
-		mock := NewMockGreeter()
-		b.ResetTimer()
-		
-		for i := 0; i < b.N; i++ {
-			mock.Greet("User")
-		}
-	})
-}
\ No newline at end of file
+}
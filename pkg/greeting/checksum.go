@@ -0,0 +1,48 @@
+package greeting
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCorruptProverbs is returned by VerifyEmbeddedProverbs when the embedded
+// collection doesn't match the checksum recorded by go generate
+var ErrCorruptProverbs = errors.New("embedded proverbs don't match their checksum")
+
+// ProverbsChecksum returns the checksum of proverbs as "sha256:<hex>". It
+// covers the ID, text, tags, author and source of every proverb, so the collection
+// compiled in compressed and as Go source has the same checksum.
+func ProverbsChecksum(proverbs []Proverb) string {
+	h := sha256.New()
+	for _, p := range proverbs {
+		fmt.Fprintf(h, "%d\t%s\t%s\t%s\t%s\n", p.ID, p.Text, strings.Join(p.Tags, ","), p.Author, p.Source)
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
+// EmbeddedChecksum returns the checksum of proverb.txt recorded when the
+// embedded collection was generated
+func EmbeddedChecksum() string {
+	return embeddedChecksum
+}
+
+// VerifyEmbeddedProverbs checks the embedded collection against
+// EmbeddedChecksum, so corrupted or tampered builds are detected instead of
+// serving partial data. The error wraps ErrCorruptProverbs on a mismatch.
+func VerifyEmbeddedProverbs() error {
+	proverbs, err := embeddedData()
+	if err != nil {
+		return err
+	}
+	return verifyProverbs(proverbs, embeddedChecksum)
+}
+
+// verifyProverbs checks proverbs against the checksum want
+func verifyProverbs(proverbs []Proverb, want string) error {
+	if sum := ProverbsChecksum(proverbs); sum != want {
+		return fmt.Errorf("%w: got %s, want %s", ErrCorruptProverbs, sum, want)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package greeting
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestVerifyEmbeddedProverbs(t *testing.T) {
+	if err := VerifyEmbeddedProverbs(); err != nil {
+		t.Fatalf("VerifyEmbeddedProverbs() = %v", err)
+	}
+
+	// The recorded checksum is the one of proverb.txt, so it is up to date
+	// after go generate
+	data, err := os.ReadFile("proverb.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum := ProverbsChecksum(parseProverbs(string(data))); sum != EmbeddedChecksum() {
+		t.Errorf("EmbeddedChecksum() = %s, proverb.txt has %s; run go generate", EmbeddedChecksum(), sum)
+	}
+}
+
+func TestVerifyProverbsTampered(t *testing.T) {
+	proverbs, err := embeddedData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyProverbs(proverbs[:len(proverbs)-1], EmbeddedChecksum()); !errors.Is(err, ErrCorruptProverbs) {
+		t.Errorf("verifyProverbs() of partial data = %v, want ErrCorruptProverbs", err)
+	}
+}
+
+func TestProverbsChecksum(t *testing.T) {
+	a := []Proverb{{ID: 1, Text: "Don't panic.", Tags: []string{"errors"}, Author: "Rob Pike"}}
+	b := []Proverb{{ID: 1, Text: "Don't panic.", Tags: []string{"errors"}}}
+	if ProverbsChecksum(a) == ProverbsChecksum(b) {
+		t.Error("ProverbsChecksum() ignores the author")
+	}
+	if got := ProverbsChecksum(a); got != ProverbsChecksum(a) || len(got) != len("sha256:")+64 {
+		t.Errorf("ProverbsChecksum() = %q", got)
+	}
+}
@@ -0,0 +1,44 @@
+package greeting
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Clock tells the current time. The service reads it to pick the proverb of
+// the day, so tests can fix the date with a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock reading the system time
+type SystemClock struct{}
+
+// Now returns the current local time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// WithLocation makes the service tell the date in loc instead of the zone of
+// its clock, so the proverb of the day and holiday greetings change at
+// midnight in loc
+func WithLocation(loc *time.Location) Option {
+	return func(s *Service) {
+		s.location = loc
+	}
+}
+
+// Rand is a source of random numbers for proverb selection. *math/rand.Rand
+// implements it; NewRand creates one from a seed.
+type Rand interface {
+	// Intn returns a number in [0, n). It panics if n <= 0.
+	Intn(n int) int
+	// Int63n returns a number in [0, n). It panics if n <= 0.
+	Int63n(n int64) int64
+}
+
+// NewRand returns a Rand seeded with seed. Like the source it wraps it is
+// not safe for concurrent use.
+func NewRand(seed int64) Rand {
+	return rand.New(rand.NewSource(seed)) // #nosec G404 -- proverb selection doesn't need a secure source
+}
@@ -0,0 +1,28 @@
+//go:build !tinygo
+
+package greeting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+)
+
+// decompressProverbs decodes the gzip-compressed, gob-encoded proverbs
+// written by proverbgen. They were parsed and validated when generated, so
+// the text format isn't parsed again.
+func decompressProverbs(data []byte) ([]Proverb, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing embedded proverbs: %w", err)
+	}
+	var proverbs []Proverb
+	if err := gob.NewDecoder(zr).Decode(&proverbs); err != nil {
+		return nil, fmt.Errorf("decoding embedded proverbs: %w", err)
+	}
+	if len(proverbs) == 0 {
+		return nil, fmt.Errorf("no valid proverbs found in embedded data")
+	}
+	return proverbs, nil
+}
@@ -0,0 +1,132 @@
+//go:build !tinygo
+
+package greeting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDecompressProverbs(t *testing.T) {
+	want := []Proverb{
+		{ID: 1, Text: "Don't panic.", Tags: []string{"errors"}},
+		// Decoded as is, not split like a line of proverb.txt
+		{ID: 2, Text: "Clear | is better than clever."},
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	gob.NewEncoder(zw).Encode(want)
+	zw.Close()
+
+	proverbs, err := decompressProverbs(buf.Bytes())
+	if err != nil {
+		t.Fatalf("decompressProverbs() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(proverbs, want) {
+		t.Errorf("decompressProverbs() = %+v, want %+v", proverbs, want)
+	}
+
+	if _, err := decompressProverbs([]byte("not gzip")); err == nil {
+		t.Error("decompressProverbs() should fail on data that isn't gzip")
+	}
+	if _, err := decompressProverbs(buf.Bytes()[:buf.Len()/2]); err == nil {
+		t.Error("decompressProverbs() should fail on truncated data")
+	}
+
+	buf.Reset()
+	zw = gzip.NewWriter(&buf)
+	zw.Write([]byte("Don't panic. | errors\n"))
+	zw.Close()
+	if _, err := decompressProverbs(buf.Bytes()); err == nil {
+		t.Error("decompressProverbs() should fail on proverb text that isn't gob-encoded")
+	}
+}
+
+// TestEmbeddedDataSkipsParser checks that nothing the default build runs to
+// load the embedded proverbs refers to parseProverbs, whose work go generate
+// already did.
+func TestEmbeddedDataSkipsParser(t *testing.T) {
+	ctx := build.Default
+	ctx.BuildTags = nil
+	pkg, err := ctx.ImportDir(".", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// refs maps every package-level name to the identifiers its
+	// declaration refers to
+	refs := make(map[string][]string)
+	fset := token.NewFileSet()
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			var names []string
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				names = []string{d.Name.Name}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							names = append(names, n.Name)
+						}
+					case *ast.TypeSpec:
+						names = append(names, s.Name.Name)
+					}
+				}
+			}
+			ast.Inspect(decl, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok {
+					for _, name := range names {
+						refs[name] = append(refs[name], id.Name)
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	if _, ok := refs["embeddedData"]; !ok {
+		t.Fatal("embeddedData is not declared by the default build")
+	}
+	seen := map[string]bool{"embeddedData": true}
+	queue := []string{"embeddedData"}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, ref := range refs[name] {
+			if ref == "parseProverbs" {
+				t.Fatalf("embeddedData reaches parseProverbs through %s", name)
+			}
+			if _, ok := refs[ref]; ok && !seen[ref] {
+				seen[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+}
+
+func BenchmarkDecompressProverbs(b *testing.B) {
+	data, err := os.ReadFile("proverbs.gob.gz")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := decompressProverbs(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
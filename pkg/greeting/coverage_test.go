@@ -9,7 +9,7 @@ import (
 func TestRandomProverbEdgeCases(t *testing.T) {
 	t.Run("empty proverbs slice", func(t *testing.T) {
 		service := &Service{
-			proverbs: []string{}, // Empty slice
+			proverbs: []Proverb{}, // Empty slice
 		}
 		
 		// This should trigger the auto-load path
@@ -55,7 +55,7 @@ func TestRandomProverbEdgeCases(t *testing.T) {
 		}
 		
 		// Should be a valid proverb, not an error message
-		if strings.Contains(result, "Error") || strings.Contains(result, "No proverbs") {
+		if strings.HasPrefix(result, "Error loading proverbs") || strings.Contains(result, "No proverbs") {
 			t.Errorf("Expected valid proverb, got: %s", result)
 		}
 	})
@@ -102,13 +102,13 @@ func TestLoadProverbsEdgeCases(t *testing.T) {
 		
 		// Check that no empty strings made it through
 		for i, proverb := range service.proverbs {
-			if strings.TrimSpace(proverb) == "" {
+			if strings.TrimSpace(proverb.Text) == "" {
 				t.Errorf("Found empty proverb at index %d", i)
 			}
 			
 			// Check that no comment lines made it through
-			if strings.HasPrefix(proverb, "#") {
-				t.Errorf("Found comment line in proverbs at index %d: %s", i, proverb)
+			if strings.HasPrefix(proverb.Text, "#") {
+				t.Errorf("Found comment line in proverbs at index %d: %s", i, proverb.Text)
 			}
 		}
 	})
@@ -163,7 +163,7 @@ func TestServiceProverbContent(t *testing.T) {
 	foundGoContent := false
 	for _, proverb := range service.proverbs {
 		// Look for common Go-related terms
-		lowerProverb := strings.ToLower(proverb)
+		lowerProverb := strings.ToLower(proverb.Text)
 		if strings.Contains(lowerProverb, "go") || 
 		   strings.Contains(lowerProverb, "gopher") ||
 		   strings.Contains(lowerProverb, "channel") ||
@@ -0,0 +1,27 @@
+package greeting
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// DailyProverb returns the proverb of the day for the calendar date of t in
+// t's location. Every call for the same date returns the same proverb, so
+// the daily proverb is stable across runs and machines.
+func (s *Service) DailyProverb(t time.Time) (Proverb, error) {
+	proverbs, err := s.loaded()
+	if err != nil {
+		return Proverb{}, err
+	}
+
+	// Hash the date so consecutive days don't walk the collection in order
+	h := fnv.New32a()
+	h.Write([]byte(t.Format(time.DateOnly)))
+	return proverbs[h.Sum32()%uint32(len(proverbs))], nil
+}
+
+// TodaysProverb returns the proverb of the day for the current date of the
+// service's clock
+func (s *Service) TodaysProverb() (Proverb, error) {
+	return s.DailyProverb(s.now())
+}
@@ -0,0 +1,91 @@
+package greeting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyProverb(t *testing.T) {
+	service := NewService()
+
+	morning := time.Date(2024, 3, 14, 7, 0, 0, 0, time.UTC)
+	evening := time.Date(2024, 3, 14, 23, 59, 0, 0, time.UTC)
+
+	first, err := service.DailyProverb(morning)
+	if err != nil {
+		t.Fatalf("DailyProverb() unexpected error: %v", err)
+	}
+	second, _ := NewService(WithSeed(99)).DailyProverb(evening)
+	if first.ID != second.ID {
+		t.Errorf("same date gave different proverbs: %d and %d", first.ID, second.ID)
+	}
+
+	// Over a month the daily proverb should change
+	seen := make(map[int]bool)
+	for day := 0; day < 30; day++ {
+		p, _ := service.DailyProverb(morning.AddDate(0, 0, day))
+		seen[p.ID] = true
+	}
+	if len(seen) < 10 {
+		t.Errorf("only %d distinct proverbs in 30 days", len(seen))
+	}
+}
+
+func TestDailyProverbUsesLocalDate(t *testing.T) {
+	service := NewService()
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	// 20:00 UTC on March 14 is already March 15 in Tokyo
+	instant := time.Date(2024, 3, 14, 20, 0, 0, 0, time.UTC)
+	inTokyo, _ := service.DailyProverb(instant.In(tokyo))
+	nextDay, _ := service.DailyProverb(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	if inTokyo.ID != nextDay.ID {
+		t.Errorf("DailyProverb() should use the date in t's location")
+	}
+}
+
+// fixedClock is a Clock that always reads the same time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func TestTodaysProverbUsesClock(t *testing.T) {
+	day := time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)
+	service := NewService(WithClock(fixedClock(day)))
+
+	got, err := service.TodaysProverb()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := service.DailyProverb(day)
+	if got.ID != want.ID {
+		t.Errorf("TodaysProverb() = #%d, want #%d for %s", got.ID, want.ID, day.Format(time.DateOnly))
+	}
+}
+
+func TestTodaysProverbUsesLocation(t *testing.T) {
+	// 23:30 UTC on March 14 is March 15 in Tokyo and still March 14 in Lisbon
+	instant := time.Date(2024, 3, 14, 23, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	service := NewService(WithClock(fixedClock(instant)), WithLocation(tokyo))
+
+	if got := service.Now(); got.Location() != tokyo || !got.Equal(instant) {
+		t.Errorf("Now() = %s, want %s in Tokyo", got, instant)
+	}
+	got, _ := service.TodaysProverb()
+	want, _ := service.DailyProverb(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	if got.ID != want.ID {
+		t.Errorf("TodaysProverb() in Tokyo = #%d, want #%d of March 15", got.ID, want.ID)
+	}
+
+	// Holidays follow the location, too
+	eve := time.Date(2024, 11, 9, 20, 0, 0, 0, time.UTC)
+	festive := NewService(WithClock(fixedClock(eve)), WithLocation(tokyo), WithFestive(GlobalRegion))
+	if got := festive.Greet("Alice"); got != "Happy Gopher Day, Alice!" {
+		t.Errorf("Greet() on Gopher Day in Tokyo = %q, want the Gopher Day greeting", got)
+	}
+	if got := NewService(WithClock(fixedClock(eve)), WithFestive(GlobalRegion)).Greet("Alice"); got != "Hello, Alice!" {
+		t.Errorf("Greet() the day before in UTC = %q, want the plain greeting", got)
+	}
+}
@@ -0,0 +1,18 @@
+package greeting
+
+import "sync"
+
+var (
+	defaultOnce    sync.Once
+	defaultService *Service
+)
+
+// Default returns the process-wide Service with default options. It is
+// created on first use and safe for concurrent use. Use NewService for an
+// instance with its own options, such as a seed or logger.
+func Default() *Service {
+	defaultOnce.Do(func() {
+		defaultService = NewService()
+	})
+	return defaultService
+}
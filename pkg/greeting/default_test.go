@@ -0,0 +1,43 @@
+package greeting
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	services := make([]*Service, 8)
+	var wg sync.WaitGroup
+	for i := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			services[i] = Default()
+		}()
+	}
+	wg.Wait()
+
+	for _, s := range services {
+		if s != services[0] {
+			t.Fatal("Default() returned different services")
+		}
+	}
+	if _, err := Default().RandomEntry(); err != nil {
+		t.Errorf("Default().RandomEntry() unexpected error: %v", err)
+	}
+}
+
+func TestServicesShareEmbeddedProverbs(t *testing.T) {
+	a, b := NewService(), NewService()
+	if err := a.LoadProverbs(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.LoadProverbs(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both services share the generated collection instead of copying it
+	if &a.proverbs[0] != &b.proverbs[0] {
+		t.Error("LoadProverbs copied the embedded proverbs")
+	}
+}
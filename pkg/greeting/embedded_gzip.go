@@ -0,0 +1,20 @@
+//go:build !proverbs_raw && !tinygo
+
+package greeting
+
+import (
+	_ "embed"
+	"sync"
+)
+
+// compressedProverbs is the parsed proverbs of proverb.txt, gob-encoded and
+// compressed by go generate. Build with -tags proverbs_raw to embed the
+// collection as plain Go source instead.
+//
+//go:embed proverbs.gob.gz
+var compressedProverbs []byte
+
+// embeddedData returns the embedded proverbs, decoding them on first use
+var embeddedData = sync.OnceValues(func() ([]Proverb, error) {
+	return decompressProverbs(compressedProverbs)
+})
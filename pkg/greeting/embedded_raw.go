@@ -0,0 +1,11 @@
+//go:build proverbs_raw || tinygo
+
+package greeting
+
+// embeddedData returns the embedded proverbs, which proverbs_raw and TinyGo
+// builds compile in as the plain Go source of proverbs_gen.go. On
+// microcontrollers the collection then stays in flash instead of being
+// decompressed into RAM.
+func embeddedData() ([]Proverb, error) {
+	return embeddedProverbs, nil
+}
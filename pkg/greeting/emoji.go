@@ -0,0 +1,62 @@
+package greeting
+
+import "strings"
+
+// EmojiMode selects how WithEmoji draws its emoji
+type EmojiMode string
+
+// Supported emoji modes
+const (
+	// EmojiUnicode uses emoji characters such as 👋
+	EmojiUnicode EmojiMode = "unicode"
+	// EmojiASCII uses shortcodes such as :wave: for terminals and channels
+	// that can't show Unicode emoji
+	EmojiASCII EmojiMode = "ascii"
+)
+
+// emojiSet holds the emoji used by one EmojiMode
+type emojiSet struct {
+	wave   string // precedes everyday greetings
+	party  string // follows holiday greetings
+	gopher string // follows the name Gopher
+}
+
+// emojiSets maps each mode to its emoji
+var emojiSets = map[EmojiMode]emojiSet{
+	EmojiUnicode: {wave: "\U0001F44B", party: "\U0001F389", gopher: "\U0001F439"},
+	EmojiASCII:   {wave: ":wave:", party: ":tada:", gopher: ":gopher:"},
+}
+
+// WithEmoji decorates greetings with emoji: a waving hand before everyday
+// greetings, a party popper after holiday greetings (see WithFestive) and a
+// gopher after the name Gopher. Unknown modes fall back to EmojiUnicode.
+func WithEmoji(mode EmojiMode) Option {
+	return func(s *Service) {
+		if _, ok := emojiSets[mode]; !ok {
+			mode = EmojiUnicode
+		}
+		s.emoji = mode
+	}
+}
+
+// gopherEmoji returns name followed by the gopher emoji if the service
+// decorates greetings and name is Gopher
+func (s *Service) gopherEmoji(name string) string {
+	if s.emoji == "" || !strings.EqualFold(name, "Gopher") {
+		return name
+	}
+	return name + " " + emojiSets[s.emoji].gopher
+}
+
+// decorate adds the service's emoji to message, a holiday greeting if
+// festive is true
+func (s *Service) decorate(message string, festive bool) string {
+	if s.emoji == "" {
+		return message
+	}
+	set := emojiSets[s.emoji]
+	if festive {
+		return message + " " + set.party
+	}
+	return set.wave + " " + message
+}
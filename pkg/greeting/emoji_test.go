@@ -0,0 +1,35 @@
+package greeting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithEmoji(t *testing.T) {
+	newYear := fixedClock(time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		opts []Option
+		in   string
+		want string
+	}{
+		{"off by default", nil, "Alice", "Hello, Alice!"},
+		{"wave", []Option{WithEmoji(EmojiUnicode)}, "Alice", "\U0001F44B Hello, Alice!"},
+		{"gopher", []Option{WithEmoji(EmojiUnicode)}, "", "\U0001F44B Hello, Gopher \U0001F439!"},
+		{"gopher with title", []Option{WithEmoji(EmojiUnicode), WithTitle("Dr.")}, "gopher", "\U0001F44B Hello, Dr. gopher \U0001F439!"},
+		{"festive", []Option{WithEmoji(EmojiUnicode), WithFestive(GlobalRegion), WithClock(newYear)}, "Alice", "Happy New Year, Alice! \U0001F389"},
+		{"not a holiday", []Option{WithEmoji(EmojiUnicode), WithFestive(GlobalRegion), WithClock(fixedClock(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)))}, "Alice", "\U0001F44B Hello, Alice!"},
+		{"ascii", []Option{WithEmoji(EmojiASCII)}, "", ":wave: Hello, Gopher :gopher:!"},
+		{"ascii festive", []Option{WithEmoji(EmojiASCII), WithFestive(GlobalRegion), WithClock(newYear)}, "Alice", "Happy New Year, Alice! :tada:"},
+		{"unknown mode", []Option{WithEmoji("sparkly")}, "Alice", "\U0001F44B Hello, Alice!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewService(tt.opts...).Greet(tt.in); got != tt.want {
+				t.Errorf("Greet(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
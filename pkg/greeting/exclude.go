@@ -0,0 +1,87 @@
+package greeting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Exclusion hides proverbs by ID or tag, for deployments that consider some
+// of them irrelevant. The zero value hides nothing.
+type Exclusion struct {
+	IDs  []int
+	Tags []string
+}
+
+// ParseExclusion parses a list of proverb IDs and tags such as
+// ["cgo", "syscall", "12"]: numbers are IDs, anything else is a tag.
+func ParseExclusion(items []string) (Exclusion, error) {
+	var e Exclusion
+	for _, item := range items {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		if id, err := strconv.Atoi(item); err == nil {
+			if id < 1 {
+				return Exclusion{}, fmt.Errorf("invalid proverb ID %d (IDs start at 1)", id)
+			}
+			e.IDs = append(e.IDs, id)
+			continue
+		}
+		if strings.Trim(item, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+			return Exclusion{}, fmt.Errorf("invalid tag %q (use a-z, 0-9 and '-', or a proverb ID)", item)
+		}
+		e.Tags = append(e.Tags, item)
+	}
+	return e, nil
+}
+
+// IsZero reports whether e hides nothing
+func (e Exclusion) IsZero() bool {
+	return len(e.IDs) == 0 && len(e.Tags) == 0
+}
+
+// Excludes reports whether e hides p
+func (e Exclusion) Excludes(p Proverb) bool {
+	for _, id := range e.IDs {
+		if p.ID == id {
+			return true
+		}
+	}
+	for _, tag := range e.Tags {
+		if p.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithExclusion hides the proverbs matching e from the service: they are
+// never selected, listed, searched or found by ID. The other proverbs keep
+// their IDs. Loading fails if every proverb is hidden.
+func WithExclusion(e Exclusion) Option {
+	return func(s *Service) {
+		s.exclude = e
+	}
+}
+
+// excludeProverbs removes the proverbs hidden by the service's exclusion
+// from the loaded collection and indexes the rest
+func (s *Service) excludeProverbs() error {
+	if s.exclude.IsZero() {
+		return nil
+	}
+	kept := make([]Proverb, 0, len(s.proverbs))
+	for _, p := range s.proverbs {
+		if !s.exclude.Excludes(p) {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		return fmt.Errorf("all %d proverbs are excluded", len(s.proverbs))
+	}
+	s.proverbs = kept
+	s.index = newSearchIndex(kept)
+	return nil
+}
@@ -0,0 +1,64 @@
+package greeting
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExclusion(t *testing.T) {
+	e, err := ParseExclusion([]string{"CGO", " syscall ", "12", ""})
+	if err != nil {
+		t.Fatalf("ParseExclusion() error = %v", err)
+	}
+	want := Exclusion{IDs: []int{12}, Tags: []string{"cgo", "syscall"}}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("ParseExclusion() = %+v, want %+v", e, want)
+	}
+
+	for _, items := range [][]string{{"0"}, {"-3"}, {"sys call"}, {"c_go"}} {
+		if _, err := ParseExclusion(items); err == nil {
+			t.Errorf("ParseExclusion(%q) succeeded", items)
+		}
+	}
+	if e, _ := ParseExclusion(nil); !e.IsZero() {
+		t.Errorf("ParseExclusion(nil) = %+v, want the zero value", e)
+	}
+}
+
+func TestWithExclusion(t *testing.T) {
+	all, err := NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(WithExclusion(Exclusion{IDs: []int{1}, Tags: []string{"cgo"}}))
+	proverbs, err := s.Proverbs()
+	if err != nil {
+		t.Fatalf("Proverbs() error = %v", err)
+	}
+	for _, p := range proverbs {
+		if p.ID == 1 || p.HasTag("cgo") {
+			t.Errorf("Proverbs() includes excluded proverb %+v", p)
+		}
+	}
+	if len(proverbs) >= len(all)-1 || proverbs[0].ID != 2 {
+		t.Errorf("Proverbs() = %d proverbs starting at #%d, want fewer than %d starting at #2", len(proverbs), proverbs[0].ID, len(all)-1)
+	}
+
+	matches, err := s.FilterProverbs(Filter{Search: "cgo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range matches {
+		if p.HasTag("cgo") {
+			t.Errorf("FilterProverbs() includes excluded proverb %+v", p)
+		}
+	}
+
+	everything := NewService(WithExclusion(Exclusion{IDs: []int{1, 2}}))
+	err = everything.LoadProverbsFromReader(strings.NewReader("One.\nTwo.\n"))
+	if err == nil || !strings.Contains(err.Error(), "all 2 proverbs are excluded") {
+		t.Errorf("LoadProverbsFromReader() error = %v, want all proverbs excluded", err)
+	}
+}
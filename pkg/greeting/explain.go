@@ -0,0 +1,67 @@
+package greeting
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed explanations.txt
+var explanationData string
+
+// Explanation is the commentary on a proverb
+type Explanation struct {
+	// Text explains what the proverb means
+	Text string `json:"text"`
+	// Links point to talks or articles about the proverb
+	Links []string `json:"links,omitempty"`
+}
+
+// explanations maps proverb texts to their embedded explanations, parsed on
+// first use
+var explanations = sync.OnceValue(func() map[string]Explanation {
+	return parseExplanations(explanationData)
+})
+
+// parseExplanations parses explanation data made of blank-line separated
+// entries: the proverb text on the first line, then the explanation, whose
+// lines are joined, and links on lines starting with "https://". Comments
+// and entries without an explanation are ignored.
+func parseExplanations(data string) map[string]Explanation {
+	m := make(map[string]Explanation)
+	var (
+		key   string
+		text  []string
+		links []string
+	)
+	flush := func() {
+		if key != "" && len(text) > 0 {
+			m[key] = Explanation{Text: strings.Join(text, " "), Links: links}
+		}
+		key, text, links = "", nil, nil
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "#"):
+		case line == "":
+			flush()
+		case key == "":
+			key = line
+		case strings.HasPrefix(line, "https://"):
+			links = append(links, line)
+		default:
+			text = append(text, line)
+		}
+	}
+	flush()
+	return m
+}
+
+// Explain returns the embedded explanation of p, looked up by its text, and
+// whether there is one. Proverbs from other sources have none.
+func Explain(p Proverb) (Explanation, bool) {
+	e, ok := explanations()[p.Text]
+	return e, ok
+}
@@ -0,0 +1,51 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseExplanations(t *testing.T) {
+	data := "# comment\n\nErrors are values.\nThey can be\n  programmed with.\nhttps://go.dev/blog/errors-are-values\n\n\nNo explanation.\n\nDon't panic.\nUse errors.\n"
+	got := parseExplanations(data)
+
+	if len(got) != 2 {
+		t.Fatalf("parsed %d explanations, want 2: %+v", len(got), got)
+	}
+	e := got["Errors are values."]
+	if e.Text != "They can be programmed with." || len(e.Links) != 1 || e.Links[0] != "https://go.dev/blog/errors-are-values" {
+		t.Errorf("explanation = %+v", e)
+	}
+	if got["Don't panic."].Text != "Use errors." {
+		t.Errorf("last entry = %+v", got["Don't panic."])
+	}
+}
+
+func TestEveryProverbIsExplained(t *testing.T) {
+	proverbs, err := NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	known := make(map[string]bool, len(proverbs))
+	for _, p := range proverbs {
+		known[p.Text] = true
+		if _, ok := Explain(p); !ok {
+			t.Errorf("proverb %d has no explanation: %q", p.ID, p.Text)
+		}
+	}
+	for text, e := range explanations() {
+		if !known[text] {
+			t.Errorf("explanation for unknown proverb %q", text)
+		}
+		for _, link := range e.Links {
+			if strings.ContainsAny(link, " \t") {
+				t.Errorf("malformed link %q for %q", link, text)
+			}
+		}
+	}
+
+	if _, ok := Explain(Proverb{Text: "Not a real proverb."}); ok {
+		t.Error("Explain() found an explanation for an unknown proverb")
+	}
+}
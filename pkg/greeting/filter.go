@@ -0,0 +1,54 @@
+package greeting
+
+import "strings"
+
+// Filter narrows a proverb collection. The zero value matches every proverb.
+type Filter struct {
+	// Tag keeps only proverbs carrying this tag (case-insensitive)
+	Tag string
+	// Search keeps only proverbs whose text contains this substring (case-insensitive)
+	Search string
+}
+
+// Match reports whether the proverb satisfies every criterion of the filter
+func (f Filter) Match(p Proverb) bool {
+	if f.Tag != "" && !p.HasTag(f.Tag) {
+		return false
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(p.Text), strings.ToLower(f.Search)) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the proverbs matching the filter, preserving their order
+func (f Filter) Apply(proverbs []Proverb) []Proverb {
+	matched := make([]Proverb, 0, len(proverbs))
+	for _, p := range proverbs {
+		if f.Match(p) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// FilterProverbs returns the loaded proverbs matching f, loading the embedded data if needed.
+// Searches are answered from an index built at load time instead of scanning every proverb.
+func (s *Service) FilterProverbs(f Filter) ([]Proverb, error) {
+	proverbs, index, err := s.loadedIndexed()
+	if err != nil {
+		return nil, err
+	}
+	if f.Search == "" {
+		return f.Apply(proverbs), nil
+	}
+
+	tagOnly := Filter{Tag: f.Tag}
+	matched := make([]Proverb, 0)
+	for _, pos := range index.search(f.Search) {
+		if tagOnly.Match(proverbs[pos]) {
+			matched = append(matched, proverbs[pos])
+		}
+	}
+	return matched, nil
+}
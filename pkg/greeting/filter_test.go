@@ -0,0 +1,111 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProverbs(t *testing.T) {
+	data := `# comment line
+First proverb. | Design, errors
+
+Second proverb.
+| orphan-tags
+Third proverb. |  ,concurrency,  `
+
+	proverbs := parseProverbs(data)
+	if len(proverbs) != 3 {
+		t.Fatalf("parseProverbs() returned %d proverbs, want 3", len(proverbs))
+	}
+
+	tests := []struct {
+		index int
+		id    int
+		text  string
+		tags  []string
+	}{
+		{0, 1, "First proverb.", []string{"design", "errors"}},
+		{1, 2, "Second proverb.", nil},
+		{2, 3, "Third proverb.", []string{"concurrency"}},
+	}
+
+	for _, tt := range tests {
+		p := proverbs[tt.index]
+		if p.ID != tt.id || p.Text != tt.text {
+			t.Errorf("proverb %d = {%d %q}, want {%d %q}", tt.index, p.ID, p.Text, tt.id, tt.text)
+		}
+		if strings.Join(p.Tags, ",") != strings.Join(tt.tags, ",") {
+			t.Errorf("proverb %d tags = %v, want %v", tt.index, p.Tags, tt.tags)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	proverbs := []Proverb{
+		{ID: 1, Text: "Errors are values.", Tags: []string{"errors"}},
+		{ID: 2, Text: "Concurrency is not parallelism.", Tags: []string{"concurrency"}},
+		{ID: 3, Text: "Don't just check errors, handle them gracefully.", Tags: []string{"errors", "style"}},
+	}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantIDs []int
+	}{
+		{"zero value matches all", Filter{}, []int{1, 2, 3}},
+		{"tag filter", Filter{Tag: "errors"}, []int{1, 3}},
+		{"tag filter is case-insensitive", Filter{Tag: "CONCURRENCY"}, []int{2}},
+		{"search filter is case-insensitive", Filter{Search: "ERRORS"}, []int{1, 3}},
+		{"tag and search combined", Filter{Tag: "style", Search: "check"}, []int{3}},
+		{"no match", Filter{Tag: "missing"}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.Apply(proverbs)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Apply() returned %d proverbs, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, p := range got {
+				if p.ID != tt.wantIDs[i] {
+					t.Errorf("Apply()[%d].ID = %d, want %d", i, p.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestService_FilterProverbs(t *testing.T) {
+	service := NewService()
+
+	all, err := service.Proverbs()
+	if err != nil {
+		t.Fatalf("Proverbs() unexpected error: %v", err)
+	}
+
+	for i, p := range all {
+		if p.ID != i+1 {
+			t.Errorf("proverb at index %d has ID %d, want %d", i, p.ID, i+1)
+		}
+	}
+
+	cgo, err := service.FilterProverbs(Filter{Tag: "cgo"})
+	if err != nil {
+		t.Fatalf("FilterProverbs() unexpected error: %v", err)
+	}
+	if len(cgo) == 0 {
+		t.Fatal("Expected embedded data to contain proverbs tagged 'cgo'")
+	}
+	for _, p := range cgo {
+		if !p.HasTag("cgo") {
+			t.Errorf("FilterProverbs() returned untagged proverb: %q", p.Text)
+		}
+	}
+
+	// Mutating the returned slice must not affect the service
+	all[0].Text = "mutated"
+	again, _ := service.Proverbs()
+	if again[0].Text == "mutated" {
+		t.Error("Proverbs() should return a copy of the loaded proverbs")
+	}
+}
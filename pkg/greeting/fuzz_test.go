@@ -0,0 +1,84 @@
+package greeting
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func FuzzLoadProverbsFromReader(f *testing.F) {
+	if data, err := os.ReadFile("proverb.txt"); err == nil {
+		f.Add(string(data))
+	}
+	f.Add("Don't panic. | errors\n# comment\n\nClear is better than clever.")
+	f.Add("\ufeffA little copying is better than a little dependency. | deps\r\nGofmt's style is no one's favorite.\r\n")
+	f.Add("| only, tags\n   |   \n#")
+	f.Add("Bad \xff\xfe bytes | t\xffag")
+	f.Add("\x1b[31mRed\x1b[0m proverb | \x1b]0;title\a")
+	f.Add(strings.Repeat("long ", 100000) + "| tag")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		s := NewService()
+		if err := s.LoadProverbsFromReader(strings.NewReader(data)); err != nil {
+			if len(s.proverbs) != 0 {
+				t.Fatalf("LoadProverbsFromReader failed with %v but loaded %d proverbs", err, len(s.proverbs))
+			}
+			return
+		}
+
+		for i, p := range s.proverbs {
+			if p.ID != i+1 {
+				t.Fatalf("proverb %d has ID %d", i, p.ID)
+			}
+			if p.Text == "" || p.Text != strings.TrimSpace(p.Text) {
+				t.Fatalf("proverb %d has untrimmed or empty text %q", p.ID, p.Text)
+			}
+			checkPrintable(t, p.Text)
+			for _, tag := range p.Tags {
+				if tag == "" || tag != strings.ToLower(strings.TrimSpace(tag)) || strings.Contains(tag, ",") {
+					t.Fatalf("proverb %d has malformed tag %q", p.ID, tag)
+				}
+				checkPrintable(t, tag)
+			}
+		}
+	})
+}
+
+func FuzzGreet(f *testing.F) {
+	f.Add("Alice", "en", "friendly")
+	f.Add("", "de", "formal")
+	f.Add("José", "pt-BR", "casual")
+	f.Add("\x1b[2J\x1b[HEvil", "fr", "FORMAL")
+	f.Add("%s%d%!", "xx", "grumpy")
+	f.Add("\xff\ufeff\u202e", "", "")
+
+	f.Fuzz(func(t *testing.T, name, lang, style string) {
+		s := NewService(WithLanguage(lang), WithStyle(Style(style)))
+		got := s.Greet(name)
+		checkPrintable(t, got)
+
+		want := SanitizeName(name)
+		if want == "" {
+			want = "Gopher"
+		}
+		if expected := strings.Replace(s.template(), "%s", want, 1); got != expected {
+			t.Fatalf("Greet(%q) = %q, want %q", name, got, expected)
+		}
+	})
+}
+
+// checkPrintable fails t if s is not valid UTF-8 or contains characters that
+// would garble terminal output
+func checkPrintable(t *testing.T, s string) {
+	t.Helper()
+	if !utf8.ValidString(s) {
+		t.Fatalf("%q is not valid UTF-8", s)
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) || isBidiControl(r) {
+			t.Fatalf("%q contains %U", s, r)
+		}
+	}
+}
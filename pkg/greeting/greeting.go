@@ -7,7 +7,11 @@
 //   fmt.Println(service.RandomProverb())
 package greeting
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+	"time"
+)
 
 // Greeter interface defines the contract for greeting functionality
 type Greeter interface {
@@ -23,20 +27,162 @@ type ProverbProvider interface {
 
 // Service implements both Greeter and ProverbProvider interfaces
 type Service struct {
-	proverbs []string
+	proverbs []Proverb
+	index    *searchIndex
+	extra    []Proverb
+	exclude  Exclusion
+	language string
+	style    Style
+	logger   serviceLogger
+	rawNames bool
+
+	// extraFunc loads the extra proverbs instead of extra if set
+	extraFunc func() ([]Proverb, error)
+	// reload loads the collection again, unless it was loaded by
+	// LoadProverbs
+	reload func() error
+
+	normalizeNames bool
+	maxNameLen     int
+	emoji          EmojiMode
+
+	randomPhrase bool
+	festive      bool
+	region       string
+	title        string
+	neutral      bool
+
+	clock    Clock
+	location *time.Location
+
+	// mu guards rng, which is not safe for concurrent use on its own, and
+	// the lazy loading of proverbs
+	mu  sync.Mutex
+	rng Rand
+}
+
+// Option configures a Service created by NewService
+type Option func(*Service)
+
+// WithSeed makes random selection deterministic by seeding the service's
+// private random source. Services created with the same seed produce the
+// same sequence of random proverbs.
+func WithSeed(seed int64) Option {
+	return func(s *Service) {
+		s.rng = NewRand(seed)
+	}
+}
+
+// WithRand makes the service draw random numbers from r, such as a
+// *math/rand.Rand or a fake returning scripted numbers in tests
+func WithRand(r Rand) Option {
+	return func(s *Service) {
+		s.rng = r
+	}
+}
+
+// WithClock makes the service read the current time from clock instead of
+// the system clock
+func WithClock(clock Clock) Option {
+	return func(s *Service) {
+		s.clock = clock
+	}
+}
+
+// WithRawNames disables the sanitization of names passed to Greet. Use it
+// only when names come from a trusted source and escape sequences in them
+// are intended.
+func WithRawNames() Option {
+	return func(s *Service) {
+		s.rawNames = true
+	}
 }
 
 // NewService creates a new greeting service instance
-func NewService() *Service {
-	return &Service{}
+func NewService(opts ...Option) *Service {
+	s := &Service{maxNameLen: DefaultMaxNameLength}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// intn returns a random number in [0, n) from the service's random source,
+// creating a source seeded with defaultSeed on first use if none was
+// configured
+func (s *Service) intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rng == nil {
+		s.rng = NewRand(s.defaultSeed())
+	}
+	return s.rng.Intn(n)
+}
+
+// now returns the current time from the service's clock, in its location
+// if one was set
+func (s *Service) now() time.Time {
+	t := time.Now()
+	if s.clock != nil {
+		t = s.clock.Now()
+	}
+	if s.location != nil {
+		t = t.In(s.location)
+	}
+	return t
 }
 
-// Greet returns a greeting message for the given name
+// Now returns the current time as the service tells it: from its clock, in
+// the location set with WithLocation. Callers picking the proverb of the day
+// with DailyProverb pass it to agree with the service on the date.
+func (s *Service) Now() time.Time {
+	return s.now()
+}
+
+// Greet returns a greeting message for the given name. Terminal escape
+// sequences and control characters are removed from name unless the service
+// was created WithRawNames, and names are normalized for services created
+// WithNormalizedNames. Names longer than the service's maximum length (see
+// WithMaxNameLength) are truncated. A title set WithTitle precedes the name.
+// Services created WithFestive use the holiday greeting on holidays, which
+// takes precedence over WithRandomPhrase. WithEmoji adds emoji last.
 func (s *Service) Greet(name string) string {
+	return s.GreetIn(name, s.language, s.style)
+}
+
+// GreetIn greets name like Greet, but in the language lang and the given
+// style instead of the service's, such as for a request asking for its own.
+// Like WithLanguage and WithStyle, unsupported languages and unknown styles
+// fall back to DefaultLanguage and DefaultStyle; validate them first with
+// ParseLanguage and ParseStyle.
+func (s *Service) GreetIn(name, lang string, style Style) string {
+	lang = NormalizeLanguage(lang)
+	if !s.rawNames {
+		name = SanitizeName(name)
+	}
+	if s.normalizeNames {
+		name = NormalizeName(name)
+	}
+	name = s.truncateName(name)
 	if name == "" {
 		name = "Gopher"
 	}
-	return fmt.Sprintf("Hello, %s!", name)
+	name = s.gopherEmoji(name)
+	if s.normalizeNames {
+		name = IsolateName(name)
+	}
+	name = s.titled(name, lang)
+	if s.festive {
+		if h, ok := HolidayOn(s.now(), s.region); ok {
+			return s.decorate(fmt.Sprintf(h.Greeting, name), true)
+		}
+	}
+	tmpl := templateFor(lang, style)
+	if s.randomPhrase {
+		tmpl = s.randomTemplate(lang, style)
+	}
+	return s.decorate(fmt.Sprintf(tmpl, name), false)
 }
 
 // RandomProverb and LoadProverbs implementations are in proverb.go
\ No newline at end of file
@@ -0,0 +1,19 @@
+package greetingtest
+
+import "testing"
+
+// BenchmarkFakeGreeter measures the overhead of recording calls
+func BenchmarkFakeGreeter(b *testing.B) {
+	fake := NewFakeGreeter()
+	for i := 0; i < b.N; i++ {
+		_ = fake.Greet("BenchUser")
+	}
+}
+
+// BenchmarkFakeProverbProvider measures serving proverbs from the fake
+func BenchmarkFakeProverbProvider(b *testing.B) {
+	fake := NewFakeProverbProvider()
+	for i := 0; i < b.N; i++ {
+		_ = fake.RandomProverb()
+	}
+}
@@ -0,0 +1,78 @@
+package greetingtest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a greeting.Clock that stands still until it is moved with
+// Set or Advance. It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock reading t
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now implements greeting.Clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// FakeRand is a greeting.Rand returning scripted numbers in order, starting
+// over after the last one. A number is reduced modulo n to stay in range,
+// so NewFakeRand(2) picks the third proverb of a collection. Without
+// numbers it always returns 0. It is safe for concurrent use.
+type FakeRand struct {
+	mu      sync.Mutex
+	numbers []int64
+	next    int
+}
+
+// NewFakeRand creates a FakeRand returning numbers
+func NewFakeRand(numbers ...int64) *FakeRand {
+	return &FakeRand{numbers: numbers}
+}
+
+// Intn implements greeting.Rand
+func (r *FakeRand) Intn(n int) int {
+	return int(r.Int63n(int64(n)))
+}
+
+// Int63n implements greeting.Rand
+func (r *FakeRand) Int63n(n int64) int64 {
+	if n <= 0 {
+		panic("greetingtest: invalid argument to Int63n")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.numbers) == 0 {
+		return 0
+	}
+	v := r.numbers[r.next%len(r.numbers)]
+	r.next++
+	if v %= n; v < 0 {
+		v += n
+	}
+	return v
+}
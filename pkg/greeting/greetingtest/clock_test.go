@@ -0,0 +1,73 @@
+package greetingtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+var (
+	_ greeting.Clock = (*FakeClock)(nil)
+	_ greeting.Rand  = (*FakeRand)(nil)
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if got := clock.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+	clock.Advance(time.Hour)
+	if got, want := clock.Now(), start.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", got, want)
+	}
+	clock.Set(start)
+	if got := clock.Now(); !got.Equal(start) {
+		t.Errorf("Now() after Set = %v, want %v", got, start)
+	}
+}
+
+func TestFakeRand(t *testing.T) {
+	r := NewFakeRand(1, 5, -1)
+
+	var got []int
+	for i := 0; i < 4; i++ {
+		got = append(got, r.Intn(3))
+	}
+	want := []int{1, 2, 2, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Intn(3) returned %v, want %v", got, want)
+		}
+	}
+
+	if n := NewFakeRand().Int63n(10); n != 0 {
+		t.Errorf("Int63n() without numbers = %d, want 0", n)
+	}
+}
+
+func TestServiceWithFakes(t *testing.T) {
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service := greeting.NewService(
+		greeting.WithClock(NewFakeClock(day)),
+		greeting.WithRand(NewFakeRand(2)),
+	)
+
+	today, err := service.TodaysProverb()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := service.DailyProverb(day); today.ID != want.ID {
+		t.Errorf("TodaysProverb() = %v, want the proverb of %s: %v", today, day.Format(time.DateOnly), want)
+	}
+
+	entry, err := service.RandomEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != 3 {
+		t.Errorf("RandomEntry() with scripted number 2 returned proverb #%d, want #3", entry.ID)
+	}
+}
@@ -0,0 +1,59 @@
+package greetingtest_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
+)
+
+// welcome is code under test that depends on the greeting interfaces
+func welcome(g greeting.Greeter, p greeting.ProverbProvider, name string) (string, error) {
+	if err := p.LoadProverbs(); err != nil {
+		return "", fmt.Errorf("welcome: %w", err)
+	}
+	return g.Greet(name) + " " + p.RandomProverb(), nil
+}
+
+func ExampleFakeGreeter() {
+	greeter := greetingtest.NewFakeGreeter()
+	provider := greetingtest.NewFakeProverbProvider("Errors are values.")
+
+	msg, _ := welcome(greeter, provider, "Alice")
+	fmt.Println(msg)
+	fmt.Println(greeter.Calls())
+
+	// Output:
+	// Hello, Alice! Errors are values.
+	// [Greet("Alice")]
+}
+
+func ExampleFakeGreeter_customGreeting() {
+	greeter := greetingtest.NewFakeGreeter()
+	greeter.GreetFunc = func(name string) string {
+		return fmt.Sprintf("Custom greeting for %s", name)
+	}
+
+	fmt.Println(greeter.Greet("Bob"))
+
+	// Output:
+	// Custom greeting for Bob
+}
+
+func ExampleFakeProverbProvider_FailNext() {
+	provider := greetingtest.NewFakeProverbProvider("Don't panic.")
+	provider.FailNext(errors.New("proverbs unavailable"))
+
+	_, err := welcome(greetingtest.NewFakeGreeter(), provider, "Eve")
+	fmt.Println(err)
+
+	msg, _ := welcome(greetingtest.NewFakeGreeter(), provider, "Eve")
+	fmt.Println(msg)
+	fmt.Println(provider.Calls())
+
+	// Output:
+	// welcome: proverbs unavailable
+	// Hello, Eve! Don't panic.
+	// [LoadProverbs() LoadProverbs() RandomProverb()]
+}
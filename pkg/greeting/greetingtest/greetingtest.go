@@ -0,0 +1,205 @@
+// Package greetingtest provides fakes of the greeting interfaces for tests
+// of code that depends on package greeting.
+//
+// The fakes record every call they receive and can be scripted to fail, so
+// tests can assert how their code uses a greeter or proverb provider and
+// how it handles errors, without relying on random proverb selection:
+//
+//	provider := greetingtest.NewFakeProverbProvider("Clear is better than clever.")
+//	provider.FailNext(errors.New("disk on fire"))
+//	// first call fails, later calls return the proverb
+package greetingtest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// DefaultProverbs are served by a FakeProverbProvider created without proverbs
+var DefaultProverbs = []string{
+	"Don't communicate by sharing memory, share memory by communicating.",
+	"Concurrency is not parallelism.",
+	"Channels orchestrate; mutexes serialize.",
+}
+
+// callLog records method calls. It is safe for concurrent use.
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) record(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, fmt.Sprintf(format, args...))
+}
+
+// Calls returns the calls received so far in order, formatted like
+// `Greet("Alice")` or `RandomProverb()`
+func (l *callLog) Calls() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.calls...)
+}
+
+// ResetCalls clears the recorded calls
+func (l *callLog) ResetCalls() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = nil
+}
+
+// FakeGreeter is a greeting.Greeter that records the names it greets
+type FakeGreeter struct {
+	callLog
+
+	// GreetFunc, when set, produces the greeting. By default the fake
+	// returns "Hello, <name>!", greeting "Gopher" for an empty name.
+	GreetFunc func(name string) string
+}
+
+// NewFakeGreeter creates a FakeGreeter with the default greeting
+func NewFakeGreeter() *FakeGreeter {
+	return &FakeGreeter{}
+}
+
+// Greet implements greeting.Greeter
+func (f *FakeGreeter) Greet(name string) string {
+	f.record("Greet(%q)", name)
+	if f.GreetFunc != nil {
+		return f.GreetFunc(name)
+	}
+	if name == "" {
+		name = "Gopher"
+	}
+	return "Hello, " + name + "!"
+}
+
+// FakeProverbProvider is a greeting.ProverbProvider serving a fixed list of
+// proverbs in order, starting over after the last one. Besides the
+// interface it offers the RandomEntry and DailyProverb methods of
+// greeting.Service, so code that looks for them can be tested too.
+type FakeProverbProvider struct {
+	callLog
+
+	// Err, when set, is returned by every LoadProverbs, RandomEntry and
+	// DailyProverb call
+	Err error
+
+	mu       sync.Mutex
+	proverbs []greeting.Proverb
+	next     int
+	script   []error
+}
+
+// NewFakeProverbProvider creates a provider serving proverbs, or
+// DefaultProverbs when none are given. Proverbs get IDs from 1 in order.
+func NewFakeProverbProvider(proverbs ...string) *FakeProverbProvider {
+	f := &FakeProverbProvider{}
+	if len(proverbs) == 0 {
+		proverbs = DefaultProverbs
+	}
+	f.SetProverbs(proverbs...)
+	return f
+}
+
+// SetProverbs replaces the served proverbs and starts over from the first
+func (f *FakeProverbProvider) SetProverbs(proverbs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.proverbs = make([]greeting.Proverb, len(proverbs))
+	for i, text := range proverbs {
+		f.proverbs[i] = greeting.Proverb{ID: i + 1, Text: text}
+	}
+	f.next = 0
+}
+
+// FailNext scripts errors for the next LoadProverbs, RandomEntry and
+// DailyProverb calls: each call takes the next error in order, and a nil
+// entry lets that call succeed. Once the script is used up, calls fail only
+// if Err is set.
+func (f *FakeProverbProvider) FailNext(errs ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.script = append(f.script, errs...)
+}
+
+// err returns the error for the current call. f.mu must be held.
+func (f *FakeProverbProvider) err() error {
+	if len(f.script) > 0 {
+		err := f.script[0]
+		f.script = f.script[1:]
+		return err
+	}
+	return f.Err
+}
+
+// LoadProverbs implements greeting.ProverbProvider
+func (f *FakeProverbProvider) LoadProverbs() error {
+	f.record("LoadProverbs()")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err()
+}
+
+// RandomProverb implements greeting.ProverbProvider. Like greeting.Service
+// it reports a failure in the returned text.
+func (f *FakeProverbProvider) RandomProverb() string {
+	f.record("RandomProverb()")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	proverb, err := f.take()
+	if err != nil {
+		return "Error loading proverbs: " + err.Error()
+	}
+	return proverb.Text
+}
+
+// RandomEntry returns the next proverb, or the scripted error
+func (f *FakeProverbProvider) RandomEntry() (greeting.Proverb, error) {
+	f.record("RandomEntry()")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.take()
+}
+
+// DailyProverb returns the next proverb regardless of t, or the scripted
+// error
+func (f *FakeProverbProvider) DailyProverb(t time.Time) (greeting.Proverb, error) {
+	f.record("DailyProverb(%s)", t.Format(time.DateOnly))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.take()
+}
+
+// take returns the next proverb. f.mu must be held.
+func (f *FakeProverbProvider) take() (greeting.Proverb, error) {
+	if err := f.err(); err != nil {
+		return greeting.Proverb{}, err
+	}
+	if len(f.proverbs) == 0 {
+		return greeting.Proverb{}, fmt.Errorf("no proverbs available")
+	}
+	proverb := f.proverbs[f.next%len(f.proverbs)]
+	f.next++
+	return proverb, nil
+}
+
+// FakeService is a greeter and proverb provider in one, like
+// greeting.Service
+type FakeService struct {
+	*FakeGreeter
+	*FakeProverbProvider
+}
+
+// NewFakeService creates a FakeService with a default FakeGreeter and
+// FakeProverbProvider
+func NewFakeService() *FakeService {
+	return &FakeService{
+		FakeGreeter:         NewFakeGreeter(),
+		FakeProverbProvider: NewFakeProverbProvider(),
+	}
+}
@@ -0,0 +1,205 @@
+package greetingtest
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// Compile-time checks that the fakes implement the greeting interfaces
+var (
+	_ greeting.Greeter         = (*FakeGreeter)(nil)
+	_ greeting.ProverbProvider = (*FakeProverbProvider)(nil)
+	_ greeting.Greeter         = (*FakeService)(nil)
+	_ greeting.ProverbProvider = (*FakeService)(nil)
+)
+
+func TestFakeGreeter(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		setup    func(*FakeGreeter)
+	}{
+		{"default greeting", "Alice", "Hello, Alice!", nil},
+		{"default name", "", "Hello, Gopher!", nil},
+		{
+			name:     "custom greeting",
+			input:    "Bob",
+			expected: "Custom greeting for Bob",
+			setup: func(f *FakeGreeter) {
+				f.GreetFunc = func(name string) string { return "Custom greeting for " + name }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFakeGreeter()
+			if tt.setup != nil {
+				tt.setup(fake)
+			}
+
+			if got := fake.Greet(tt.input); got != tt.expected {
+				t.Errorf("Greet(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			want := []string{`Greet("` + tt.input + `")`}
+			if got := fake.Calls(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Calls() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFakeGreeterCalls(t *testing.T) {
+	fake := NewFakeGreeter()
+	fake.Greet("User1")
+	fake.Greet("User2")
+	fake.Greet("")
+
+	want := []string{`Greet("User1")`, `Greet("User2")`, `Greet("")`}
+	calls := fake.Calls()
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Calls() = %v, want %v", calls, want)
+	}
+
+	// The returned slice is a copy
+	calls[0] = "tampered"
+	if fake.Calls()[0] != want[0] {
+		t.Error("modifying the result of Calls() changed the recorded calls")
+	}
+
+	fake.ResetCalls()
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("Calls() after ResetCalls = %v, want none", calls)
+	}
+}
+
+func TestFakeProverbProvider(t *testing.T) {
+	t.Run("default proverbs", func(t *testing.T) {
+		fake := NewFakeProverbProvider()
+		if got := fake.RandomProverb(); got != DefaultProverbs[0] {
+			t.Errorf("RandomProverb() = %q, want %q", got, DefaultProverbs[0])
+		}
+	})
+
+	t.Run("serves proverbs in order and starts over", func(t *testing.T) {
+		fake := NewFakeProverbProvider("first", "second")
+		var got []greeting.Proverb
+		for i := 0; i < 3; i++ {
+			p, err := fake.RandomEntry()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, p)
+		}
+		want := []greeting.Proverb{{ID: 1, Text: "first"}, {ID: 2, Text: "second"}, {ID: 1, Text: "first"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("RandomEntry() returned %v, want %v", got, want)
+		}
+	})
+
+	t.Run("set proverbs", func(t *testing.T) {
+		fake := NewFakeProverbProvider()
+		fake.RandomProverb()
+		fake.SetProverbs("Custom test proverb")
+		if got := fake.RandomProverb(); got != "Custom test proverb" {
+			t.Errorf("RandomProverb() = %q, want the custom proverb", got)
+		}
+	})
+
+	t.Run("no proverbs", func(t *testing.T) {
+		fake := NewFakeProverbProvider()
+		fake.SetProverbs()
+		if _, err := fake.RandomEntry(); err == nil {
+			t.Error("RandomEntry() without proverbs should fail")
+		}
+	})
+
+	t.Run("daily proverb", func(t *testing.T) {
+		fake := NewFakeProverbProvider("today")
+		p, err := fake.DailyProverb(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+		if err != nil || p.Text != "today" {
+			t.Errorf("DailyProverb() = %v, %v", p, err)
+		}
+		if want := []string{"DailyProverb(2024-03-01)"}; !reflect.DeepEqual(fake.Calls(), want) {
+			t.Errorf("Calls() = %v, want %v", fake.Calls(), want)
+		}
+	})
+}
+
+func TestFakeProverbProviderErrors(t *testing.T) {
+	errFirst := errors.New("first call fails")
+	errThird := errors.New("third call fails")
+
+	fake := NewFakeProverbProvider("proverb")
+	fake.FailNext(errFirst, nil, errThird)
+
+	if err := fake.LoadProverbs(); err != errFirst {
+		t.Errorf("LoadProverbs() = %v, want %v", err, errFirst)
+	}
+	if _, err := fake.RandomEntry(); err != nil {
+		t.Errorf("RandomEntry() = %v, want the scripted success", err)
+	}
+	if got := fake.RandomProverb(); got != "Error loading proverbs: third call fails" {
+		t.Errorf("RandomProverb() = %q, want the scripted error", got)
+	}
+	if err := fake.LoadProverbs(); err != nil {
+		t.Errorf("LoadProverbs() = %v after the script ran out", err)
+	}
+
+	errAlways := errors.New("always fails")
+	fake.Err = errAlways
+	if _, err := fake.DailyProverb(time.Now()); err != errAlways {
+		t.Errorf("DailyProverb() = %v, want %v", err, errAlways)
+	}
+
+	want := []string{"LoadProverbs()", "RandomEntry()", "RandomProverb()", "LoadProverbs()"}
+	if got := fake.Calls()[:4]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+}
+
+func TestFakeService(t *testing.T) {
+	fake := NewFakeService()
+
+	if got := fake.Greet("TestUser"); got != "Hello, TestUser!" {
+		t.Errorf("Greet() = %q", got)
+	}
+	if got := fake.RandomProverb(); got != DefaultProverbs[0] {
+		t.Errorf("RandomProverb() = %q", got)
+	}
+	if err := fake.LoadProverbs(); err != nil {
+		t.Errorf("LoadProverbs() unexpected error: %v", err)
+	}
+
+	if n := len(fake.FakeGreeter.Calls()); n != 1 {
+		t.Errorf("greeter recorded %d calls, want 1", n)
+	}
+	if n := len(fake.FakeProverbProvider.Calls()); n != 2 {
+		t.Errorf("provider recorded %d calls, want 2", n)
+	}
+}
+
+func TestFakesConcurrentUse(t *testing.T) {
+	fake := NewFakeService()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fake.Greet("Gopher")
+			fake.RandomProverb()
+		}()
+	}
+	wg.Wait()
+
+	if n := len(fake.FakeGreeter.Calls()); n != 10 {
+		t.Errorf("greeter recorded %d calls, want 10", n)
+	}
+}
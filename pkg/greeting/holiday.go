@@ -0,0 +1,140 @@
+package greeting
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed holidays.txt
+var holidayData string
+
+// GlobalRegion is the region of holidays observed everywhere. Selecting it
+// leaves out every regional holiday.
+const GlobalRegion = "global"
+
+// Holiday is a yearly holiday on a fixed date
+type Holiday struct {
+	Region string     `json:"region"`
+	Month  time.Month `json:"month"`
+	Day    int        `json:"day"`
+	Name   string     `json:"name"`
+	// Greeting is the greeting template, with %s where the name goes
+	Greeting string `json:"greeting"`
+}
+
+// On reports whether the holiday falls on the date of t, in t's location
+func (h Holiday) On(t time.Time) bool {
+	return t.Month() == h.Month && t.Day() == h.Day
+}
+
+// holidays is the embedded holiday table in date order, parsed on first use
+var holidays = sync.OnceValue(func() []Holiday {
+	return parseHolidays(holidayData)
+})
+
+// parseHolidays parses holiday data in the
+// "<region> | <MM-DD> | <name> | <greeting>" line format and sorts it by
+// date. Blank lines, comments and malformed lines are ignored.
+func parseHolidays(data string) []Holiday {
+	var list []Holiday
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, tagSeparator)
+		if len(fields) != 4 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		date, err := time.Parse("01-02", fields[1])
+		region := strings.ToLower(fields[0])
+		if err != nil || region == "" || fields[2] == "" || strings.Count(fields[3], "%") != 1 || !strings.Contains(fields[3], "%s") {
+			continue
+		}
+		list = append(list, Holiday{Region: region, Month: date.Month(), Day: date.Day(), Name: fields[2], Greeting: fields[3]})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Month != list[j].Month {
+			return list[i].Month < list[j].Month
+		}
+		return list[i].Day < list[j].Day
+	})
+	return list
+}
+
+// Regions returns the regions of the holiday table in sorted order,
+// including GlobalRegion
+func Regions() []string {
+	seen := map[string]bool{GlobalRegion: true}
+	for _, h := range holidays() {
+		seen[h.Region] = true
+	}
+
+	regions := make([]string, 0, len(seen))
+	for region := range seen {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// ParseRegion validates a region code such as "us" or "DE". An empty region
+// selects GlobalRegion.
+func ParseRegion(region string) (string, error) {
+	r := strings.ToLower(strings.TrimSpace(region))
+	if r == "" {
+		return GlobalRegion, nil
+	}
+	for _, known := range Regions() {
+		if r == known {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported region %q (supported: %s)", region, strings.Join(Regions(), ", "))
+}
+
+// Holidays returns the holidays observed in region, which are the global
+// holidays and the region's own, in date order
+func Holidays(region string) []Holiday {
+	region = strings.ToLower(strings.TrimSpace(region))
+	var list []Holiday
+	for _, h := range holidays() {
+		if h.Region == GlobalRegion || h.Region == region {
+			list = append(list, h)
+		}
+	}
+	return list
+}
+
+// HolidayOn returns the holiday observed in region on the date of t. A
+// regional holiday wins over a global one on the same day.
+func HolidayOn(t time.Time, region string) (Holiday, bool) {
+	var found Holiday
+	ok := false
+	for _, h := range Holidays(region) {
+		if h.On(t) && (!ok || h.Region != GlobalRegion) {
+			found, ok = h, true
+		}
+	}
+	return found, ok
+}
+
+// WithFestive makes Greet use the holiday greeting of region, such as
+// "Happy New Year, Alice!", on holidays. The date is read from the service's
+// clock, so WithClock controls it. On other days greetings are unchanged.
+func WithFestive(region string) Option {
+	return func(s *Service) {
+		s.festive = true
+		s.region = strings.ToLower(strings.TrimSpace(region))
+	}
+}
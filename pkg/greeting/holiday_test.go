@@ -0,0 +1,108 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHolidays(t *testing.T) {
+	data := `# comment
+us | 07-04 | Independence Day | Happy Fourth of July, %s!
+GLOBAL | 01-01 | New Year's Day | Happy New Year, %s!
+global | 13-01 | Bad Month | Hi, %s!
+global | 02-30 | Bad Day | Hi, %s!
+global | 03-01 | No Name Slot | Hi there!
+global | 03-02 | Too | Many | Fields, %s!
+ | 03-03 | No Region | Hi, %s!`
+
+	got := parseHolidays(data)
+	if len(got) != 2 {
+		t.Fatalf("parseHolidays() returned %d holidays, want 2: %+v", len(got), got)
+	}
+	if got[0].Name != "New Year's Day" || got[0].Region != GlobalRegion || got[0].Month != time.January || got[0].Day != 1 {
+		t.Errorf("first holiday = %+v, want New Year's Day sorted first", got[0])
+	}
+}
+
+func TestRegions(t *testing.T) {
+	regions := Regions()
+	found := map[string]bool{}
+	for _, r := range regions {
+		found[r] = true
+	}
+	if !found[GlobalRegion] || !found["us"] {
+		t.Errorf("Regions() = %v, want global and us", regions)
+	}
+
+	for _, tt := range []struct{ in, want string }{{"", GlobalRegion}, {" US ", "us"}, {"de", "de"}} {
+		if got, err := ParseRegion(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParseRegion(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseRegion("atlantis"); err == nil {
+		t.Error("ParseRegion() should reject unknown regions")
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	// Every entry of the embedded table is well-formed
+	entries := 0
+	for _, line := range strings.Split(holidayData, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			entries++
+		}
+	}
+	if len(holidays()) != entries {
+		t.Errorf("parsed %d of %d embedded holidays", len(holidays()), entries)
+	}
+
+	for _, h := range Holidays(GlobalRegion) {
+		if h.Region != GlobalRegion {
+			t.Errorf("Holidays(global) includes regional holiday %+v", h)
+		}
+	}
+
+	us := Holidays("us")
+	if len(us) <= len(Holidays(GlobalRegion)) {
+		t.Errorf("Holidays(us) should add regional holidays to the global ones")
+	}
+	for i := 1; i < len(us); i++ {
+		if us[i].Month < us[i-1].Month || (us[i].Month == us[i-1].Month && us[i].Day < us[i-1].Day) {
+			t.Errorf("Holidays(us) not in date order at %d: %+v", i, us)
+		}
+	}
+}
+
+func TestWithFestive(t *testing.T) {
+	date := func(month time.Month, day int) Clock {
+		return fixedClock(time.Date(2024, month, day, 9, 0, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name   string
+		region string
+		clock  Clock
+		want   string
+	}{
+		{"new year", "", date(time.January, 1), "Happy New Year, Alice!"},
+		{"gopher day", "us", date(time.November, 10), "Happy Gopher Day, Alice!"},
+		{"regional holiday", "us", date(time.July, 4), "Happy Fourth of July, Alice!"},
+		{"holiday of another region", "de", date(time.July, 4), "Hello, Alice!"},
+		{"ordinary day", "us", date(time.March, 3), "Hello, Alice!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(WithFestive(tt.region), WithClock(tt.clock))
+			if got := s.Greet("Alice"); got != tt.want {
+				t.Errorf("Greet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Without WithFestive holidays don't change the greeting
+	if got := NewService(WithClock(date(time.January, 1))).Greet("Alice"); got != "Hello, Alice!" {
+		t.Errorf("Greet() without WithFestive = %q", got)
+	}
+}
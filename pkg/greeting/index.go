@@ -0,0 +1,145 @@
+package greeting
+
+import (
+	"strings"
+	"sync"
+)
+
+// gramSize is the number of runes in the tokens of a searchIndex
+const gramSize = 3
+
+// searchIndex is an inverted index from trigrams of the lower-cased proverb
+// texts to the positions of the proverbs containing them. It answers the
+// case-insensitive substring searches of Filter.Search without scanning the
+// whole collection: a text containing the query contains every trigram of
+// it, so only proverbs listed under all of them need to be checked.
+type searchIndex struct {
+	texts    []string
+	postings map[string][]int
+}
+
+// newSearchIndex returns an index of proverbs
+func newSearchIndex(proverbs []Proverb) *searchIndex {
+	ix := &searchIndex{postings: make(map[string][]int)}
+	for _, p := range proverbs {
+		ix.add(p)
+	}
+	return ix
+}
+
+// embeddedIndex is the index of the compiled-in proverbs, built once per
+// process and shared like the proverbs themselves
+var embeddedIndex = sync.OnceValue(func() *searchIndex {
+	proverbs, _ := embeddedData()
+	return newSearchIndex(proverbs)
+})
+
+// add indexes p as the proverb following the ones already indexed, so a
+// growing collection doesn't have to be indexed again
+func (ix *searchIndex) add(p Proverb) {
+	pos := len(ix.texts)
+	text := strings.ToLower(p.Text)
+	ix.texts = append(ix.texts, text)
+
+	for _, gram := range trigrams(text) {
+		list := ix.postings[gram]
+		// Positions only grow, so a repeated trigram is the last entry
+		if n := len(list); n == 0 || list[n-1] != pos {
+			ix.postings[gram] = append(list, pos)
+		}
+	}
+}
+
+// extend returns a copy of ix with proverbs added after the indexed ones,
+// leaving ix as it is. The copy shares the posting lists of ix, capped so
+// that adding to them allocates instead of writing into ix, which makes
+// extending the shared embeddedIndex cheaper than indexing it again.
+func (ix *searchIndex) extend(proverbs []Proverb) *searchIndex {
+	n := len(ix.texts)
+	ext := &searchIndex{
+		texts:    ix.texts[:n:n],
+		postings: make(map[string][]int, len(ix.postings)),
+	}
+	for gram, list := range ix.postings {
+		ext.postings[gram] = list[:len(list):len(list)]
+	}
+	for _, p := range proverbs {
+		ext.add(p)
+	}
+	return ext
+}
+
+// search returns the positions of the proverbs whose text contains query
+// (case-insensitive) in ascending order
+func (ix *searchIndex) search(query string) []int {
+	query = strings.ToLower(query)
+	grams := trigrams(query)
+
+	var candidates []int
+	if len(grams) == 0 {
+		// Too short to have a trigram; every proverb is a candidate
+		candidates = make([]int, len(ix.texts))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	} else {
+		// Start from the rarest trigram so the intersection stays small
+		candidates = ix.postings[grams[0]]
+		for _, gram := range grams[1:] {
+			if list := ix.postings[gram]; len(list) < len(candidates) {
+				candidates = list
+			}
+		}
+		for _, gram := range grams {
+			candidates = intersect(candidates, ix.postings[gram])
+			if len(candidates) == 0 {
+				return nil
+			}
+		}
+	}
+
+	matches := make([]int, 0, len(candidates))
+	for _, pos := range candidates {
+		if strings.Contains(ix.texts[pos], query) {
+			matches = append(matches, pos)
+		}
+	}
+	return matches
+}
+
+// trigrams returns the distinct rune trigrams of s
+func trigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < gramSize {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(runes))
+	grams := make([]string, 0, len(runes)-gramSize+1)
+	for i := 0; i+gramSize <= len(runes); i++ {
+		gram := string(runes[i : i+gramSize])
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// intersect returns the positions present in both ascending lists a and b
+func intersect(a, b []int) []int {
+	out := make([]int, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
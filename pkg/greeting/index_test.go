@@ -0,0 +1,180 @@
+package greeting
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearchIndex(t *testing.T) {
+	ix := newSearchIndex([]Proverb{
+		{ID: 1, Text: "Errors are values."},
+		{ID: 2, Text: "Don't just check errors, handle them gracefully."},
+		{ID: 3, Text: "Concurrency is not parallelism."},
+		{ID: 4, Text: "Ünïcödé proverbs are fine."},
+	})
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"errors", []int{0, 1}},
+		{"ERRORS", []int{0, 1}},
+		{"rors ar", []int{0}},
+		{"gracefully.", []int{1}},
+		{"ünï", []int{3}},
+		{"is", []int{2}},
+		{"", []int{0, 1, 2, 3}},
+		{"nothing like this", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := ix.search(tt.query); len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	// Proverbs added later are found without rebuilding the index
+	ix.add(Proverb{ID: 5, Text: "A little copying is better than a little dependency."})
+	if got := ix.search("copying"); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("search after add = %v, want [4]", got)
+	}
+}
+
+func TestSearchIndexExtend(t *testing.T) {
+	base := newSearchIndex([]Proverb{{ID: 1, Text: "Errors are values."}, {ID: 2, Text: "Don't panic."}})
+	ext := base.extend([]Proverb{{ID: 3, Text: "Handle errors gracefully."}})
+	// Adding to both must not let one see the other's proverbs
+	base.add(Proverb{ID: 3, Text: "Errors, errors everywhere."})
+	ext.add(Proverb{ID: 4, Text: "Panic is for programmer errors."})
+
+	if got := ext.search("errors"); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Errorf("extended search = %v, want [0 2 3]", got)
+	}
+	if got := ext.search("everywhere"); got != nil {
+		t.Errorf("extended index sees a proverb added to its base: %v", got)
+	}
+	if got := base.search("errors"); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("base search = %v, want [0 2]", got)
+	}
+	if got := base.search("gracefully"); got != nil {
+		t.Errorf("base index sees an extended proverb: %v", got)
+	}
+}
+
+func TestFilterProverbsExtraProverbs(t *testing.T) {
+	service := NewService(WithExtraProverbs([]Proverb{{Text: "Gophers love extra proverbs."}}))
+	all, err := service.Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []Filter{{Search: "extra proverbs"}, {Search: "errors"}} {
+		got, err := service.FilterProverbs(f)
+		if err != nil {
+			t.Fatalf("FilterProverbs(%+v) unexpected error: %v", f, err)
+		}
+		if want := f.Apply(all); len(want) == 0 || !reflect.DeepEqual(got, want) {
+			t.Errorf("FilterProverbs(%+v) = %v, want %v", f, got, want)
+		}
+	}
+	// The shared index of the embedded proverbs doesn't pick them up
+	if got := embeddedIndex().search("extra proverbs"); got != nil {
+		t.Errorf("embedded index = %v, want no match", got)
+	}
+}
+
+func TestFilterProverbsMatchesApply(t *testing.T) {
+	service := NewService()
+	all, err := service.Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []Filter{
+		{Search: "go"},
+		{Search: "Interface"},
+		{Search: "errors", Tag: "errors"},
+		{Search: "a"},
+		{Search: "no such proverb"},
+	} {
+		got, err := service.FilterProverbs(f)
+		if err != nil {
+			t.Fatalf("FilterProverbs(%+v) unexpected error: %v", f, err)
+		}
+		if want := f.Apply(all); !reflect.DeepEqual(got, want) {
+			t.Errorf("FilterProverbs(%+v) = %v, want %v", f, got, want)
+		}
+	}
+}
+
+func FuzzSearchIndex(f *testing.F) {
+	f.Add("Errors are values.\nDon't panic.", "err")
+	f.Add("ÄÖÜ straße", "SS")
+	f.Add("abcabc", "cab")
+
+	f.Fuzz(func(t *testing.T, data, query string) {
+		s := NewService()
+		if err := s.LoadProverbsFromReader(strings.NewReader(data)); err != nil {
+			return
+		}
+		all, _ := s.Proverbs()
+		got, _ := s.FilterProverbs(Filter{Search: query})
+		want := Filter{Search: query}.Apply(all)
+		if len(got) != len(want) || (len(got) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("FilterProverbs(%q) = %v, want %v", query, got, want)
+		}
+	})
+}
+
+// largeCollection returns a service loaded with n generated proverbs
+func largeCollection(b *testing.B, n int) *Service {
+	b.Helper()
+	words := []string{"channel", "interface", "error", "goroutine", "value", "mutex", "package", "clever", "simple", "clear"}
+
+	var data strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&data, "Proverb %d: %s beats %s, %s. | tag%d\n",
+			i, words[i%len(words)], words[(i/7)%len(words)], words[(i/3)%len(words)], i%20)
+	}
+
+	s := NewService()
+	if err := s.LoadProverbsFromReader(strings.NewReader(data.String())); err != nil {
+		b.Fatal(err)
+	}
+	return s
+}
+
+func BenchmarkSearch10k(b *testing.B) {
+	s := largeCollection(b, 10000)
+	all, _ := s.Proverbs()
+	f := Filter{Search: "Proverb 4242:"}
+
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.FilterProverbs(f); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.Apply(all)
+		}
+	})
+}
+
+func BenchmarkLoadExtraProverbs(b *testing.B) {
+	extra := make([]Proverb, 100)
+	for i := range extra {
+		extra[i] = Proverb{Text: fmt.Sprintf("Extra proverb %d keeps the index warm.", i)}
+	}
+	for i := 0; i < b.N; i++ {
+		s := NewService(WithExtraProverbs(extra))
+		if err := s.LoadProverbs(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Command proverbgen validates proverb.txt and converts it into the forms the
+// greeting package embeds: the parsed collection gob-encoded and compressed,
+// decoded on first use, the Go source of the collection used by builds with the proverbs_raw tag
+// and by TinyGo builds, and the checksum every build verifies the collection
+// against. Malformed lines fail generation with their line number instead of
+// being skipped silently. It runs through go generate in pkg/greeting:
+//
+//	go generate ./pkg/greeting
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func main() {
+	in := flag.String("in", "proverb.txt", "proverb data to convert")
+	out := flag.String("out", "proverbs_gen.go", "Go file to write")
+	gz := flag.String("gz", "proverbs.gob.gz", "compressed proverb data to write")
+	sum := flag.String("sum", "proverbs_sum.go", "Go file declaring the checksum to write")
+	flag.Parse()
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		fail(err)
+	}
+	src, err := generate(*in, data)
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fail(err)
+	}
+	src, err = generateChecksum(*in, data)
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(*sum, src, 0o644); err != nil {
+		fail(err)
+	}
+
+	proverbs, err := load(*in, data)
+	if err != nil {
+		fail(err)
+	}
+	compressed, err := compress(proverbs)
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(*gz, compressed, 0o644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "proverbgen:", err)
+	os.Exit(1)
+}
+
+// validate reports the first malformed line of data, which is named name in
+// error messages. The parser of the greeting package tolerates these lines;
+// the source data should not contain them.
+func validate(name string, data []byte) error {
+	seen := make(map[string]int)
+	for _, line := range greeting.ProverbLines(string(data)) {
+		n := line.Number
+		p, ok, err := greeting.ParseProverbLine(line.Text)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+		if !ok {
+			continue
+		}
+		if first, ok := seen[p.Text]; ok {
+			return fmt.Errorf("%s:%d: duplicate of line %d", name, n, first)
+		}
+		seen[p.Text] = n
+	}
+
+	if len(seen) == 0 {
+		return fmt.Errorf("%s: no proverbs", name)
+	}
+	return nil
+}
+
+// generate validates data and returns the formatted Go source declaring it
+// as the embeddedProverbs slice of proverbs_raw and TinyGo builds
+func generate(name string, data []byte) ([]byte, error) {
+	proverbs, err := load(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by proverbgen from %s; DO NOT EDIT.\n\n", name)
+	fmt.Fprintf(&buf, "//go:build proverbs_raw || tinygo\n\n")
+	fmt.Fprintf(&buf, "package greeting\n\n")
+	fmt.Fprintf(&buf, "// embeddedProverbs is the proverb collection of %s\n", name)
+	fmt.Fprintf(&buf, "var embeddedProverbs = []Proverb{\n")
+	for _, p := range proverbs {
+		fmt.Fprintf(&buf, "{ID: %d, Text: %q", p.ID, p.Text)
+		if len(p.Tags) > 0 {
+			fmt.Fprintf(&buf, ", Tags: %#v", p.Tags)
+		}
+		if p.Author != "" {
+			fmt.Fprintf(&buf, ", Author: %q", p.Author)
+		}
+		if p.Source != "" {
+			fmt.Fprintf(&buf, ", Source: %q", p.Source)
+		}
+		fmt.Fprintf(&buf, "},\n")
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// generateChecksum validates data and returns the formatted Go source
+// declaring the checksum of its proverbs as embeddedChecksum, for every build
+func generateChecksum(name string, data []byte) ([]byte, error) {
+	proverbs, err := load(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by proverbgen from %s; DO NOT EDIT.\n\n", name)
+	fmt.Fprintf(&buf, "package greeting\n\n")
+	fmt.Fprintf(&buf, "// embeddedChecksum is the checksum of the proverbs of %s, see\n// ProverbsChecksum\n", name)
+	fmt.Fprintf(&buf, "const embeddedChecksum = %q\n", greeting.ProverbsChecksum(proverbs))
+	return format.Source(buf.Bytes())
+}
+
+// load validates data and parses it like the embedded collection is parsed
+func load(name string, data []byte) ([]greeting.Proverb, error) {
+	if err := validate(name, data); err != nil {
+		return nil, err
+	}
+
+	service := greeting.NewService()
+	if err := service.LoadProverbsFromReader(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return service.Proverbs()
+}
+
+// compress returns proverbs gob-encoded and compressed with gzip, so the
+// greeting package decodes them without parsing the text format again. The
+// header carries no name or modification time, so the output only changes
+// when the proverbs do.
+func compress(proverbs []greeting.Proverb) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if err := gob.NewEncoder(zw).Encode(proverbs); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", "# comment\n\nDon't panic. | errors\r\nClear is better than clever.\n", ""},
+		{"invalid UTF-8", "Don't panic.\nBad \xff bytes\n", "in.txt:2: invalid UTF-8"},
+		{"byte order mark", "\ufeffDon't panic.\n", "in.txt:1: byte order mark"},
+		{"escape sequence", "\x1b[31mRed\x1b[0m proverb\n", "in.txt:1: control characters"},
+		{"empty text", "| errors\n", "in.txt:1: proverb text is empty"},
+		{"author", "Don't panic. | errors | Rob Pike\nErrors are values. | | Rob Pike\n", ""},
+		{"source", "Don't panic. | errors | Rob Pike | https://go-proverbs.github.io/\n", ""},
+		{"malformed source", "Don't panic. | errors | Rob Pike | style\n", `in.txt:1: source "style" is not an http or https URL`},
+		{"fourth separator", "Don't panic. | errors | Rob Pike | https://go.dev/ | style\n", "in.txt:1: more than three separators"},
+		{"empty author", "Don't panic. | errors |\n", "in.txt:1: author is empty"},
+		{"empty tag", "Don't panic. | errors,\n", "in.txt:1: empty tag"},
+		{"duplicate", "Don't panic.\n\nDon't panic. | errors\n", "in.txt:3: duplicate of line 1"},
+		{"continued", "Don't panic,\n  ever. | errors\n\t| Rob Pike\n", ""},
+		{"error after continued", "Don't panic,\n  ever.\n| errors\n", "in.txt:3: proverb text is empty"},
+		{"no proverbs", "# nothing here\n", "in.txt: no proverbs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate("in.txt", []byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("in.txt", []byte("Don't panic. | errors, Style | Rob Pike\nA \"quoted\" proverb.\n"))
+	if err != nil {
+		t.Fatalf("generate() unexpected error: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "proverbs_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source doesn't parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"// Code generated by proverbgen from in.txt; DO NOT EDIT.",
+		"//go:build proverbs_raw || tinygo",
+		`{ID: 1, Text: "Don't panic.", Tags: []string{"errors", "style"}, Author: "Rob Pike"},`,
+		`{ID: 2, Text: "A \"quoted\" proverb."},`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source missing %q:\n%s", want, src)
+		}
+	}
+
+	if _, err := generate("in.txt", []byte("| errors\n")); err == nil {
+		t.Error("generate() should reject malformed data")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	proverbs := []greeting.Proverb{{ID: 1, Text: "Don't panic.", Tags: []string{"errors"}, Author: "Rob Pike"}}
+	first, err := compress(proverbs)
+	if err != nil {
+		t.Fatalf("compress() unexpected error: %v", err)
+	}
+	if second, _ := compress(proverbs); !bytes.Equal(first, second) {
+		t.Error("compress() should be deterministic")
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(first))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []greeting.Proverb
+	if err := gob.NewDecoder(zr).Decode(&got); err != nil || !reflect.DeepEqual(got, proverbs) {
+		t.Errorf("round trip = %+v (err %v), want %+v", got, err, proverbs)
+	}
+}
+
+func TestGenerateChecksum(t *testing.T) {
+	src, err := generateChecksum("in.txt", []byte("Don't panic. | errors | Rob Pike\n"))
+	if err != nil {
+		t.Fatalf("generateChecksum() unexpected error: %v", err)
+	}
+
+	want := greeting.ProverbsChecksum([]greeting.Proverb{{ID: 1, Text: "Don't panic.", Tags: []string{"errors"}, Author: "Rob Pike"}})
+	if !strings.Contains(string(src), `const embeddedChecksum = "`+want+`"`) {
+		t.Errorf("generated source doesn't declare checksum %s:\n%s", want, src)
+	}
+	if strings.Contains(string(src), "//go:build") {
+		t.Errorf("the checksum is needed by every build:\n%s", src)
+	}
+
+	if _, err := generateChecksum("in.txt", []byte("| errors\n")); err == nil {
+		t.Error("generateChecksum() should reject malformed data")
+	}
+}
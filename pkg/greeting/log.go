@@ -0,0 +1,24 @@
+//go:build !tinygo
+
+package greeting
+
+import "log/slog"
+
+// serviceLogger is the type of the logger set WithLogger
+type serviceLogger = *slog.Logger
+
+// WithLogger makes the service log diagnostics, such as how long loading the
+// proverbs took, to logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// logInfo logs msg with the key-value pairs in args if the service has a
+// logger
+func (s *Service) logInfo(msg string, args ...any) {
+	if s.logger != nil {
+		s.logger.Info(msg, args...)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !tinygo
+
+package greeting
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestGreetLogsTruncation(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	s := NewService(WithLogger(logger), WithMaxNameLength(3))
+	s.Greet("Bob")
+	if buf.Len() != 0 {
+		t.Errorf("unexpected log output for a short name: %s", buf.String())
+	}
+	s.Greet("Alice")
+	if out := buf.String(); !strings.Contains(out, "truncated name") || !strings.Contains(out, "length=5") || !strings.Contains(out, "max=3") {
+		t.Errorf("expected a truncation log entry, got %q", out)
+	}
+}
+
+func TestLoadProverbsLogsTiming(t *testing.T) {
+	var buf bytes.Buffer
+	service := NewService(WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	if err := service.LoadProverbs(); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "loaded proverbs") || !strings.Contains(out, "duration=") {
+		t.Errorf("expected a timing record, got %q", out)
+	}
+}
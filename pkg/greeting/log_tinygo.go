@@ -0,0 +1,10 @@
+//go:build tinygo
+
+package greeting
+
+// serviceLogger is empty in TinyGo builds, which leave out log/slog and the
+// reflection behind it; WithLogger isn't available there
+type serviceLogger = struct{}
+
+// logInfo discards diagnostics in TinyGo builds
+func (s *Service) logInfo(msg string, args ...any) {}
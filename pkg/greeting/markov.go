@@ -0,0 +1,112 @@
+package greeting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// markovOrder is the number of words the next word of a generated
+	// proverb depends on
+	markovOrder = 2
+	// minGeneratedWords and maxGeneratedWords bound the length of a
+	// generated proverb; longer ones are cut off
+	minGeneratedWords = 4
+	maxGeneratedWords = 30
+	// generateAttempts is how often GenerateProverb tries to make up a
+	// proverb that isn't in the collection already
+	generateAttempts = 100
+)
+
+// markovChain maps runs of markovOrder words of a corpus to the words that
+// follow them
+type markovChain struct {
+	// starts holds the first words of every proverb
+	starts [][]string
+	// next maps a run of words, as returned by markovKey, to the words
+	// following it; "" ends the proverb
+	next map[string][]string
+}
+
+// newMarkovChain builds the chain over the words of proverbs. Proverbs of
+// fewer than markovOrder words are left out.
+func newMarkovChain(proverbs []Proverb) *markovChain {
+	c := &markovChain{next: make(map[string][]string)}
+	for _, p := range proverbs {
+		words := strings.Fields(p.Text)
+		if len(words) < markovOrder {
+			continue
+		}
+		c.starts = append(c.starts, words[:markovOrder])
+		for i := markovOrder; i <= len(words); i++ {
+			key := markovKey(words[i-markovOrder : i])
+			next := ""
+			if i < len(words) {
+				next = words[i]
+			}
+			c.next[key] = append(c.next[key], next)
+		}
+	}
+	return c
+}
+
+// markovKey returns the key of a run of words, ignoring case so proverbs
+// can continue each other where a word starts a sentence
+func markovKey(words []string) string {
+	return strings.ToLower(strings.Join(words, " "))
+}
+
+// generate walks the chain from a random start, drawing numbers from intn
+func (c *markovChain) generate(intn func(int) int) string {
+	start := c.starts[intn(len(c.starts))]
+	words := append([]string(nil), start...)
+	for len(words) < maxGeneratedWords {
+		candidates := c.next[markovKey(words[len(words)-markovOrder:])]
+		if len(candidates) == 0 {
+			break
+		}
+		next := candidates[intn(len(candidates))]
+		if next == "" {
+			break
+		}
+		words = append(words, next)
+	}
+
+	text := strings.Join(words, " ")
+	if !strings.ContainsAny(text[len(text)-1:], ".!?") {
+		text = strings.TrimRight(text, ",;:") + "."
+	}
+	first, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToUpper(first)) + text[size:]
+}
+
+// GenerateProverb makes up a parody proverb for fun with a Markov chain
+// over the words of the collection: every run of two words in it comes from
+// a real proverb, but the whole is not in the collection. It is marked
+// Generated, has no ID, and is drawn from the service's random numbers, so
+// WithSeed makes it reproducible.
+func (s *Service) GenerateProverb() (Proverb, error) {
+	proverbs, err := s.loaded()
+	if err != nil {
+		return Proverb{}, err
+	}
+	chain := newMarkovChain(proverbs)
+	if len(chain.starts) == 0 {
+		return Proverb{}, errors.New("no proverbs long enough to generate from")
+	}
+
+	known := make(map[string]bool, len(proverbs))
+	for _, p := range proverbs {
+		known[strings.ToLower(p.Text)] = true
+	}
+	for i := 0; i < generateAttempts; i++ {
+		text := chain.generate(s.intn)
+		if len(strings.Fields(text)) >= minGeneratedWords && !known[strings.ToLower(text)] {
+			return Proverb{Text: text, Generated: true}, nil
+		}
+	}
+	return Proverb{}, fmt.Errorf("no new proverb after %d attempts; the collection is too small", generateAttempts)
+}
@@ -0,0 +1,54 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateProverb(t *testing.T) {
+	all, err := NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool)
+	for _, p := range all {
+		known[strings.ToLower(p.Text)] = true
+	}
+
+	s := NewService(WithSeed(7))
+	for i := 0; i < 20; i++ {
+		p, err := s.GenerateProverb()
+		if err != nil {
+			t.Fatalf("GenerateProverb() error = %v", err)
+		}
+		if !p.Generated || p.ID != 0 {
+			t.Errorf("GenerateProverb() = %+v, want a generated proverb without an ID", p)
+		}
+		if known[strings.ToLower(p.Text)] {
+			t.Errorf("GenerateProverb() = %q, a real proverb", p.Text)
+		}
+		if words := len(strings.Fields(p.Text)); words < minGeneratedWords || words > maxGeneratedWords {
+			t.Errorf("GenerateProverb() = %q with %d words", p.Text, words)
+		}
+		if !strings.ContainsAny(p.Text[len(p.Text)-1:], ".!?") {
+			t.Errorf("GenerateProverb() = %q doesn't end a sentence", p.Text)
+		}
+	}
+
+	// The same seed makes up the same proverb
+	a, _ := NewService(WithSeed(42)).GenerateProverb()
+	b, _ := NewService(WithSeed(42)).GenerateProverb()
+	if a.Text != b.Text {
+		t.Errorf("GenerateProverb() with seed 42 = %q and %q", a.Text, b.Text)
+	}
+}
+
+func TestGenerateProverbSmallCollection(t *testing.T) {
+	s := NewService()
+	if err := s.LoadProverbsFromReader(strings.NewReader("Clear is better than clever.\n")); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := s.GenerateProverb(); err == nil {
+		t.Errorf("GenerateProverb() from a single proverb = %q, want an error", p.Text)
+	}
+}
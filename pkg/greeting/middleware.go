@@ -0,0 +1,63 @@
+package greeting
+
+import (
+	"strings"
+	"unicode"
+)
+
+// GreeterFunc adapts an ordinary function to the Greeter interface
+type GreeterFunc func(name string) string
+
+// Greet calls f(name)
+func (f GreeterFunc) Greet(name string) string {
+	return f(name)
+}
+
+// Middleware wraps a Greeter to transform the greetings it returns
+type Middleware func(Greeter) Greeter
+
+// Chain returns g wrapped in mws. Greetings pass through the middlewares in
+// the order given, so Chain(g, Shout(), Reverse()) reverses the shouted
+// greeting.
+func Chain(g Greeter, mws ...Middleware) Greeter {
+	for _, mw := range mws {
+		g = mw(g)
+	}
+	return g
+}
+
+// Transform returns a middleware applying fn to every greeting
+func Transform(fn func(greeting string) string) Middleware {
+	return func(next Greeter) Greeter {
+		return GreeterFunc(func(name string) string {
+			return fn(next.Greet(name))
+		})
+	}
+}
+
+// Shout returns a middleware that upper-cases greetings and ends them with
+// an exclamation mark: "Good day, Alice." becomes "GOOD DAY, ALICE!"
+func Shout() Middleware {
+	return Transform(func(greeting string) string {
+		greeting = strings.TrimRightFunc(strings.ToUpper(greeting), func(r rune) bool {
+			return r == '.' || r == '!' || r == '?' || unicode.IsSpace(r)
+		})
+		return greeting + "!"
+	})
+}
+
+// Whisper returns a middleware that lower-cases greetings
+func Whisper() Middleware {
+	return Transform(strings.ToLower)
+}
+
+// Reverse returns a middleware that reverses greetings rune by rune
+func Reverse() Middleware {
+	return Transform(func(greeting string) string {
+		runes := []rune(greeting)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes)
+	})
+}
@@ -0,0 +1,38 @@
+package greeting
+
+import "testing"
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		mws  []Middleware
+		want string
+	}{
+		{"no middleware", nil, nil, "Hello, Alice!"},
+		{"shout", nil, []Middleware{Shout()}, "HELLO, ALICE!"},
+		{"shout replaces a period", []Option{WithStyle(StyleFormal)}, []Middleware{Shout()}, "GOOD DAY, ALICE!"},
+		{"shout keeps inverted marks", []Option{WithLanguage("es")}, []Middleware{Shout()}, "¡HOLA, ALICE!"},
+		{"whisper", nil, []Middleware{Whisper()}, "hello, alice!"},
+		{"reverse", nil, []Middleware{Reverse()}, "!ecilA ,olleH"},
+		{"reverse multi-byte runes", []Option{WithLanguage("pt")}, []Middleware{Reverse()}, "!ecilA ,álO"},
+		{"composed in order", nil, []Middleware{Whisper(), Reverse()}, "!ecila ,olleh"},
+		{"shout after reverse", nil, []Middleware{Reverse(), Shout()}, "!ECILA ,OLLEH!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Chain(NewService(tt.opts...), tt.mws...)
+			if got := g.Greet("Alice"); got != tt.want {
+				t.Errorf("Greet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreeterFunc(t *testing.T) {
+	var g Greeter = GreeterFunc(func(name string) string { return "Hi " + name })
+	if got := Chain(g, Transform(func(s string) string { return s + "?" })).Greet("Bob"); got != "Hi Bob?" {
+		t.Errorf("Greet() = %q, want %q", got, "Hi Bob?")
+	}
+}
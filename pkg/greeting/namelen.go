@@ -0,0 +1,55 @@
+package greeting
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxNameLength is the number of characters of a name greeted in full
+// by services created without WithMaxNameLength. It is generous for real
+// names while keeping megabyte-sized input out of banners and HTTP responses.
+const DefaultMaxNameLength = 256
+
+// ellipsis replaces the cut-off tail of a truncated name
+const ellipsis = "…"
+
+// TruncateName shortens name to at most max characters, replacing the
+// cut-off tail with an ellipsis, and reports whether it was shortened. A max
+// of 0 or less leaves name unchanged.
+func TruncateName(name string, max int) (string, bool) {
+	if max <= 0 || utf8.RuneCountInString(name) <= max {
+		return name, false
+	}
+
+	cut := 0
+	for i := 0; i < max-1; i++ {
+		_, size := utf8.DecodeRuneInString(name[cut:])
+		cut += size
+	}
+	// Don't leave a dangling joiner or space in front of the ellipsis
+	return strings.TrimRight(name[:cut], " \u200d") + ellipsis, true
+}
+
+// WithMaxNameLength sets the number of characters after which Greet
+// truncates names. A length of 0 or less disables truncation.
+func WithMaxNameLength(n int) Option {
+	return func(s *Service) {
+		s.maxNameLen = n
+	}
+}
+
+// MaxNameLength returns the number of characters after which Greet truncates
+// names, or 0 or less if names are never truncated
+func (s *Service) MaxNameLength() int {
+	return s.maxNameLen
+}
+
+// truncateName shortens name to the service's maximum length, logging when
+// it does
+func (s *Service) truncateName(name string) string {
+	truncated, ok := TruncateName(name, s.maxNameLen)
+	if ok {
+		s.logInfo("truncated name", "length", utf8.RuneCountInString(name), "max", s.maxNameLen)
+	}
+	return truncated
+}
@@ -0,0 +1,51 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		want  string
+		cut   bool
+	}{
+		{"short", "Alice", 10, "Alice", false},
+		{"exact", "Alice", 5, "Alice", false},
+		{"long", "Alexandria", 5, "Alex…", true},
+		{"multi-byte", "山田太郎さん", 4, "山田太…", true},
+		{"trailing space dropped", "Ana Lu", 5, "Ana…", true},
+		{"dangling joiner dropped", "ab\u200dcd", 4, "ab…", true},
+		{"unlimited", strings.Repeat("x", 1000), 0, strings.Repeat("x", 1000), false},
+		{"one character", "Alice", 1, "…", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cut := TruncateName(tt.input, tt.max)
+			if got != tt.want || cut != tt.cut {
+				t.Errorf("TruncateName(%q, %d) = %q, %v; want %q, %v", tt.input, tt.max, got, cut, tt.want, tt.cut)
+			}
+		})
+	}
+}
+
+func TestGreetTruncatesLongNames(t *testing.T) {
+	huge := strings.Repeat("a", 1<<20)
+
+	got := NewService().Greet(huge)
+	want := "Hello, " + strings.Repeat("a", DefaultMaxNameLength-1) + "…!"
+	if got != want {
+		t.Errorf("Greet() returned %d bytes, want the name cut to %d characters", len(got), DefaultMaxNameLength)
+	}
+
+	if got := NewService(WithMaxNameLength(6), WithTitle("Dr.")).Greet("Alexandria"); got != "Hello, Dr. Alexa…!" {
+		t.Errorf("Greet() = %q, want %q", got, "Hello, Dr. Alexa…!")
+	}
+	if got := NewService(WithMaxNameLength(0)).Greet(huge); len(got) != len(huge)+len("Hello, !") {
+		t.Errorf("Greet() with no limit returned %d bytes, want %d", len(got), len(huge)+len("Hello, !"))
+	}
+}
@@ -0,0 +1,36 @@
+package greeting
+
+import "strings"
+
+// Bidirectional isolates keeping right-to-left names from reordering the
+// greeting around them
+const (
+	firstStrongIsolate = '\u2068'
+	popDirIsolate      = '\u2069'
+)
+
+// invisibleRunes are zero-width characters without any effect on how a name
+// renders; they are removed wherever they appear
+var invisibleRunes = strings.NewReplacer(
+	"\u200b", "", // zero width space
+	"\u2060", "", // word joiner
+	"\ufeff", "", // zero width no-break space (byte order mark)
+)
+
+// IsolateName wraps a name containing right-to-left script in bidirectional
+// isolates, so terminals and browsers lay out the surrounding greeting left
+// to right. Other names are returned unchanged.
+func IsolateName(name string) string {
+	if !ContainsRTL(name) {
+		return name
+	}
+	return string(firstStrongIsolate) + name + string(popDirIsolate)
+}
+
+// WithNormalizedNames makes Greet normalize names with NormalizeName and
+// isolate right-to-left names with IsolateName
+func WithNormalizedNames() Option {
+	return func(s *Service) {
+		s.normalizeNames = true
+	}
+}
@@ -0,0 +1,73 @@
+//go:build !tinygo
+
+package greeting
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"already normalized", "José", "José"},
+		{"combining accent", "Jose\u0301", "José"},
+		{"zero width space", "Ana\u200b Lu\u200bisa", "Ana Luisa"},
+		{"byte order mark", "\ufeffAlice", "Alice"},
+		{"word joiner", "Al\u2060ice", "Alice"},
+		{"leading and trailing joiners", "\u200dAlice\u200c", "Alice"},
+		{"emoji sequence kept", "\U0001F469\u200d\U0001F4BB", "\U0001F469\u200d\U0001F4BB"},
+		{"persian non-joiner kept", "می\u200cخواهم", "می\u200cخواهم"},
+		{"only invisible", "\u200b\u200d", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeName(tt.input); got != tt.want {
+				t.Errorf("NormalizeName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsolateName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Alice", "Alice"},
+		{"山田", "山田"},
+		{"שרה", "\u2068שרה\u2069"},         // Hebrew
+		{"علي", "\u2068علي\u2069"},         // Arabic
+		{"Ana علي", "\u2068Ana علي\u2069"}, // mixed
+	}
+
+	for _, tt := range tests {
+		if got := IsolateName(tt.input); got != tt.want {
+			t.Errorf("IsolateName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWithNormalizedNames(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		in   string
+		want string
+	}{
+		{"normalized", []Option{WithNormalizedNames()}, "Jose\u0301\u200b", "Hello, José!"},
+		{"isolated", []Option{WithNormalizedNames()}, "שרה", "Hello, \u2068שרה\u2069!"},
+		{"title stays outside the isolate", []Option{WithNormalizedNames(), WithTitle("Dr.")}, "שרה", "Hello, Dr. \u2068שרה\u2069!"},
+		{"invisible name", []Option{WithNormalizedNames()}, "\u200b", "Hello, Gopher!"},
+		{"off by default", nil, "Jose\u0301", "Hello, Jose\u0301!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewService(tt.opts...).Greet(tt.in); got != tt.want {
+				t.Errorf("Greet(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
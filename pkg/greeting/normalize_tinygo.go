@@ -0,0 +1,35 @@
+//go:build tinygo
+
+package greeting
+
+import (
+	"strings"
+	"unicode"
+)
+
+// rtlScripts are the right-to-left scripts recognized by TinyGo builds
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Hebrew, unicode.Mandaic, unicode.Nko,
+	unicode.Samaritan, unicode.Syriac, unicode.Thaana,
+}
+
+// NormalizeName removes invisible zero-width characters from name and trims
+// zero-width joiners from both ends. TinyGo builds leave out the Unicode
+// normalization tables of golang.org/x/text, so unlike other builds the name
+// is not put into normalization form C.
+func NormalizeName(name string) string {
+	name = invisibleRunes.Replace(name)
+	return strings.Trim(name, " \u200c\u200d")
+}
+
+// ContainsRTL reports whether s contains right-to-left script such as Arabic
+// or Hebrew. TinyGo builds check the script of every character instead of
+// its bidirectional class.
+func ContainsRTL(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, rtlScripts...) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+//go:build tinygo
+
+package greeting
+
+import "testing"
+
+func TestNormalizeNameTinyGo(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"combining accent kept", "Jose\u0301", "Jose\u0301"},
+		{"zero width space", "Ana\u200b Lu\u200bisa", "Ana Luisa"},
+		{"leading and trailing joiners", "\u200dAlice\u200c", "Alice"},
+		{"emoji sequence kept", "\U0001F469\u200d\U0001F4BB", "\U0001F469\u200d\U0001F4BB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeName(tt.input); got != tt.want {
+				t.Errorf("NormalizeName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsolateNameTinyGo(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Alice", "Alice"},
+		{"山田", "山田"},
+		{"שרה", "\u2068שרה\u2069"},         // Hebrew
+		{"علي", "\u2068علي\u2069"},         // Arabic
+		{"Ana علي", "\u2068Ana علي\u2069"}, // mixed
+	}
+
+	for _, tt := range tests {
+		if got := IsolateName(tt.input); got != tt.want {
+			t.Errorf("IsolateName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
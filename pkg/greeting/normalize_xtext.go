@@ -0,0 +1,33 @@
+//go:build !tinygo
+
+package greeting
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/bidi"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName puts name into Unicode normalization form C, so that "e"
+// followed by a combining acute accent and the precomposed "é" compare and
+// render alike. Invisible zero-width characters are removed and zero-width
+// joiners are trimmed from both ends; joiners inside the name are kept, as
+// emoji sequences and some scripts rely on them.
+func NormalizeName(name string) string {
+	name = invisibleRunes.Replace(name)
+	name = strings.Trim(name, " \u200c\u200d")
+	return norm.NFC.String(name)
+}
+
+// ContainsRTL reports whether s contains right-to-left script such as Arabic
+// or Hebrew
+func ContainsRTL(s string) bool {
+	for _, r := range s {
+		switch p, _ := bidi.LookupRune(r); p.Class() {
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
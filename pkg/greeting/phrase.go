@@ -0,0 +1,63 @@
+package greeting
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed phrases.txt
+var phraseData string
+
+// phrases maps a language to the greeting templates WithRandomPhrase picks
+// from. The embedded list is parsed on first use.
+var phrases = sync.OnceValue(func() map[string][]string {
+	return parsePhrases(phraseData)
+})
+
+// parsePhrases parses phrase data in the "<language> | <template>" line
+// format. Blank lines, comments and templates without exactly one %s are
+// ignored.
+func parsePhrases(data string) map[string][]string {
+	byLang := make(map[string][]string)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		lang, tmpl, ok := strings.Cut(line, tagSeparator)
+		lang = NormalizeLanguage(lang)
+		tmpl = strings.TrimSpace(tmpl)
+		if !ok || lang == "" || strings.Count(tmpl, "%") != 1 || !strings.Contains(tmpl, "%s") {
+			continue
+		}
+		byLang[lang] = append(byLang[lang], tmpl)
+	}
+	return byLang
+}
+
+// WithRandomPhrase makes Greet pick a random phrasing, such as "Hi" or
+// "Howdy", for every greeting instead of the template of the configured
+// style. The choice uses the service's random source, so WithSeed and
+// WithRand make it reproducible.
+func WithRandomPhrase() Option {
+	return func(s *Service) {
+		s.randomPhrase = true
+	}
+}
+
+// randomTemplate returns a random greeting template for lang, or the
+// template of style in lang if the language has no phrases, rather than a
+// phrase of another language. Unsupported languages fall back to
+// DefaultLanguage as they do for templates.
+func (s *Service) randomTemplate(lang string, style Style) string {
+	if _, ok := templates[lang]; !ok {
+		lang = DefaultLanguage
+	}
+	list := phrases()[lang]
+	if len(list) == 0 {
+		return templateFor(lang, style)
+	}
+	return list[s.intn(len(list))]
+}
@@ -0,0 +1,84 @@
+package greeting
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParsePhrases(t *testing.T) {
+	data := `# comment
+en | Hello, %s!
+EN-us | Hi, %s!
+
+de | Hallo!
+fr | Salut, %s et %d!
+| Orphan, %s!
+it Ciao, %s!`
+
+	got := parsePhrases(data)
+	if len(got) != 1 || len(got["en"]) != 2 || got["en"][1] != "Hi, %s!" {
+		t.Errorf("parsePhrases() = %v, want two English phrases", got)
+	}
+}
+
+func TestEmbeddedPhrases(t *testing.T) {
+	for _, lang := range Languages() {
+		list := phrases()[lang]
+		if len(list) < 2 {
+			t.Errorf("language %s has %d phrases, want at least 2", lang, len(list))
+		}
+	}
+}
+
+func TestWithRandomPhrase(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		s := NewService(WithRandomPhrase(), WithRand(fixedRand(i)))
+		seen[s.Greet("Alice")] = true
+	}
+	for _, want := range []string{"Hello, Alice!", "Hi, Alice!", "Hey there, Alice!", "Howdy, Alice!"} {
+		if !seen[want] {
+			t.Errorf("random phrases %v never produced %q", seen, want)
+		}
+	}
+
+	// The same seed picks the same phrasings
+	a := NewService(WithRandomPhrase(), WithSeed(7), WithLanguage("de"))
+	b := NewService(WithRandomPhrase(), WithSeed(7), WithLanguage("de"))
+	for i := 0; i < 5; i++ {
+		if x, y := a.Greet("Jörg"), b.Greet("Jörg"); x != y {
+			t.Fatalf("seeded services differ: %q vs %q", x, y)
+		}
+	}
+
+	// Unsupported languages fall back to English phrases
+	if got := NewService(WithRandomPhrase(), WithLanguage("tlh"), WithRand(fixedRand(3))).Greet(""); got != "Howdy, Gopher!" {
+		t.Errorf("Greet() = %q, want %q", got, "Howdy, Gopher!")
+	}
+}
+
+func TestRandomPhraseWithoutPhrases(t *testing.T) {
+	saved := phrases
+	t.Cleanup(func() { phrases = saved })
+	phrases = func() map[string][]string {
+		return map[string][]string{DefaultLanguage: {"Howdy, %s!"}}
+	}
+
+	// A language without phrases keeps its own greeting instead of
+	// switching to English
+	for _, style := range []Style{StyleFriendly, StyleFormal} {
+		s := NewService(WithRandomPhrase(), WithLanguage("de"), WithStyle(style))
+		if got, want := s.Greet("Jörg"), fmt.Sprintf(templateFor("de", style), "Jörg"); got != want {
+			t.Errorf("Greet() with style %v = %q, want %q", style, got, want)
+		}
+	}
+	if got := NewService(WithRandomPhrase()).Greet("Alice"); got != "Howdy, Alice!" {
+		t.Errorf("Greet() = %q, want %q", got, "Howdy, Alice!")
+	}
+}
+
+// fixedRand always picks its own value, reduced to the requested range
+type fixedRand int
+
+func (r fixedRand) Intn(n int) int       { return int(r) % n }
+func (r fixedRand) Int63n(n int64) int64 { return int64(r) % n }
@@ -1,56 +1,403 @@
 package greeting
 
 import (
-	_ "embed"
+	"errors"
 	"fmt"
-	"math/rand"
+	"io"
+	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
-//go:embed proverb.txt
-var proverbData string
+//go:generate go run -tags proverbs_raw ./internal/proverbgen -in proverb.txt -out proverbs_gen.go -gz proverbs.gob.gz -sum proverbs_sum.go
 
-// LoadProverbs loads proverbs from embedded data
-func (s *Service) LoadProverbs() error {
-	if proverbData == "" {
-		return fmt.Errorf("embedded proverb data is empty")
+// tagSeparator separates a proverb's text from its comma-separated tags, the
+// tags from the proverb's author, and the author from its source
+const tagSeparator = "|"
+
+// Proverb is a single entry of the proverb collection
+type Proverb struct {
+	// ID is the 1-based position of the proverb in the collection
+	ID   int      `json:"id"`
+	Text string   `json:"text"`
+	Tags []string `json:"tags,omitempty"`
+	// Author is who the proverb is attributed to, empty when unknown
+	Author string `json:"author,omitempty"`
+	// Source is the URL of the talk or post the proverb comes from, empty
+	// when unknown
+	Source string `json:"source,omitempty"`
+	// Added is the date the proverb was added to its collection as
+	// YYYY-MM-DD, and Translations maps language codes such as "de" to
+	// translations of the text. Only the v2 format (see ParseProverbsV2)
+	// carries them.
+	Added        string            `json:"added,omitempty"`
+	Translations map[string]string `json:"translations,omitempty"`
+	// Generated marks a parody made up by GenerateProverb, which has no ID
+	Generated bool `json:"generated,omitempty"`
+}
+
+// String returns the proverb text
+func (p Proverb) String() string {
+	return p.Text
+}
+
+// HasTag reports whether the proverb is tagged with tag (case-insensitive)
+func (p Proverb) HasTag(tag string) bool {
+	for _, t := range p.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Split the embedded data into individual proverbs
-	lines := strings.Split(strings.TrimSpace(proverbData), "\n")
-	s.proverbs = make([]string, 0, len(lines))
+// ProverbLine is a logical line of proverb data: a line together with the
+// continuation lines after it
+type ProverbLine struct {
+	// Number is the 1-based line number of the first line, and End the one
+	// of the last continuation line, or Number without any
+	Number int
+	End    int
+	Text   string
+}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+// ProverbLines splits proverb data into logical lines. A line starting with
+// a space or tab continues the line before it, so long proverbs can span
+// several lines:
+//
+//	There are two ways of constructing a software design: One way is to
+//	  make it so simple that there are obviously no deficiencies, ...
+//	  | quotes | Tony Hoare
+//
+// Continuation lines are joined with a single space. Blank lines end the
+// line before them and are dropped, and CR line endings are removed.
+func ProverbLines(data string) []ProverbLine {
+	var lines []ProverbLine
+	continued := false
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continued = false
+			continue
+		}
+		if continued && (line[0] == ' ' || line[0] == '\t') {
+			last := &lines[len(lines)-1]
+			last.Text = strings.TrimRight(last.Text, " \t") + " " + strings.TrimSpace(line)
+			last.End = i + 1
+			continue
+		}
+		lines = append(lines, ProverbLine{Number: i + 1, End: i + 1, Text: line})
+		continued = true
+	}
+	return lines
+}
+
+// parseProverbs parses proverb data in the
+// "<text> | <tag>, <tag> | <author> | <source>" line format. Blank lines and
+// lines starting with '#' are ignored, tags, author and source are optional, and indented lines continue the line before them
+// (see ProverbLines).
+// A leading byte order mark, CRLF line endings, invalid UTF-8 and terminal
+// control sequences are tolerated and never reach the parsed proverbs.
+func parseProverbs(data string) []Proverb {
+	data = strings.TrimPrefix(data, "\ufeff")
+	lines := ProverbLines(data)
+	proverbs := make([]Proverb, 0, len(lines))
+
+	for _, l := range lines {
+		line := sanitizeText(l.Text)
 		// Skip empty lines and comments
-		if line != "" && !strings.HasPrefix(line, "#") {
-			s.proverbs = append(s.proverbs, line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		text, rest, _ := strings.Cut(line, tagSeparator)
+		tagList, rest, _ := strings.Cut(rest, tagSeparator)
+		author, source, _ := strings.Cut(rest, tagSeparator)
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
 		}
+
+		proverbs = append(proverbs, Proverb{
+			ID:     len(proverbs) + 1,
+			Text:   text,
+			Tags:   parseTags(tagList),
+			Author: strings.TrimSpace(author),
+			Source: strings.TrimSpace(source),
+		})
 	}
 
-	if len(s.proverbs) == 0 {
-		return fmt.Errorf("no valid proverbs found in embedded data")
+	return proverbs
+}
+
+// ParseProverbLine strictly parses a single line of proverb data in the
+// "<text> | <tag>, <tag> | <author> | <source>" format, as found in
+// proverb.txt. Unlike the lenient loader it rejects invalid UTF-8, byte order
+// marks, control characters, empty text, empty tags, an empty author and a
+// source that isn't an http or https URL. Blank lines and
+// comments yield ok == false and no error. The returned proverb has no ID.
+func ParseProverbLine(line string) (p Proverb, ok bool, err error) {
+	line = strings.TrimSuffix(line, "\r")
+	if !utf8.ValidString(line) {
+		return Proverb{}, false, errors.New("invalid UTF-8")
+	}
+	if strings.ContainsRune(line, '\ufeff') {
+		return Proverb{}, false, errors.New("byte order mark")
+	}
+	line = strings.TrimSpace(line)
+	if sanitizeText(line) != line {
+		return Proverb{}, false, errors.New("control characters or escape sequences")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return Proverb{}, false, nil
+	}
+
+	text, tagList, hasTags := strings.Cut(line, tagSeparator)
+	tagList, author, hasAuthor := strings.Cut(tagList, tagSeparator)
+	author, source, hasSource := strings.Cut(author, tagSeparator)
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Proverb{}, false, errors.New("proverb text is empty")
+	}
+	if strings.Contains(source, tagSeparator) {
+		return Proverb{}, false, errors.New("more than three separators")
+	}
+	author, source = strings.TrimSpace(author), strings.TrimSpace(source)
+	// A source may follow an empty author: "<text> | <tags> | | <source>"
+	if hasAuthor && author == "" && !hasSource {
+		return Proverb{}, false, errors.New("author is empty")
+	}
+	if hasSource && !IsSourceURL(source) {
+		return Proverb{}, false, fmt.Errorf("source %q is not an http or https URL", source)
 	}
+	// An author may follow an empty tag list: "<text> | | <author>"
+	if hasTags && !(hasAuthor && strings.TrimSpace(tagList) == "") {
+		for _, tag := range strings.Split(tagList, ",") {
+			if strings.TrimSpace(tag) == "" {
+				return Proverb{}, false, errors.New("empty tag")
+			}
+		}
+	}
+
+	return Proverb{Text: text, Tags: parseTags(tagList), Author: author, Source: source}, true, nil
+}
+
+// IsSourceURL reports whether source is an http or https URL without white
+// space, as proverb sources must be
+func IsSourceURL(source string) bool {
+	rest, ok := strings.CutPrefix(source, "https://")
+	if !ok {
+		rest, ok = strings.CutPrefix(source, "http://")
+	}
+	return ok && rest != "" && !strings.ContainsAny(source, " \t")
+}
+
+// parseTags splits a comma-separated tag list into lower-cased tags
+func parseTags(list string) []string {
+	var tags []string
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// LoadProverbs loads the proverbs compiled in from proverb.txt. The embedded
+// data is decompressed on first use and shared by every Service.
+func (s *Service) LoadProverbs() error {
+	s.reload = nil
+	return s.loadEmbedded()
+}
+
+// loadEmbedded loads the embedded proverbs followed by the extra ones
+func (s *Service) loadEmbedded() error {
+	start := time.Now()
+	proverbs, err := embeddedData()
+	if err != nil {
+		return err
+	}
+	extra := s.extra
+	if s.extraFunc != nil {
+		if extra, err = s.extraFunc(); err != nil {
+			return fmt.Errorf("loading extra proverbs: %w", err)
+		}
+	}
+	s.proverbs = proverbs
+	s.index = embeddedIndex()
+	if len(extra) > 0 {
+		s.proverbs = appendProverbs(proverbs, extra)
+		s.index = s.index.extend(s.proverbs[len(proverbs):])
+	}
+	if err := s.excludeProverbs(); err != nil {
+		s.proverbs, s.index = nil, nil
+		return err
+	}
+
+	s.logInfo("loaded proverbs", "count", len(s.proverbs), "duration", time.Since(start))
 
 	return nil
 }
 
-// RandomProverb returns a random Go proverb
-func (s *Service) RandomProverb() string {
+// WithExtraProverbs adds proverbs, such as a user's own collection, after
+// the embedded ones. They are numbered on from the last embedded proverb;
+// their own IDs are ignored. LoadProverbsFromReader drops them.
+func WithExtraProverbs(proverbs []Proverb) Option {
+	return func(s *Service) {
+		s.extra, s.extraFunc = proverbs, nil
+	}
+}
+
+// WithExtraProverbsFunc is like WithExtraProverbs, but calls load every time
+// the embedded proverbs are loaded, so Reload picks up proverbs added to
+// the user's collection since.
+func WithExtraProverbsFunc(load func() ([]Proverb, error)) Option {
+	return func(s *Service) {
+		s.extra, s.extraFunc = nil, load
+	}
+}
+
+// appendProverbs returns a new collection of base followed by extra, with
+// the proverbs of extra numbered on from the last one of base
+func appendProverbs(base, extra []Proverb) []Proverb {
+	proverbs := make([]Proverb, 0, len(base)+len(extra))
+	proverbs = append(proverbs, base...)
+	for _, p := range extra {
+		p.ID = len(proverbs) + 1
+		proverbs = append(proverbs, p)
+	}
+	return proverbs
+}
+
+// LoadProverbsFromReader replaces the proverb collection with proverbs read
+// from r, which uses the same line format as the embedded data or the v2
+// format (see IsProverbsV2). Since r can't be read twice, Reload keeps
+// these proverbs; use LoadProverbsFromFile to have Reload read them again.
+// Like LoadProverbs it is not safe for concurrent use.
+func (s *Service) LoadProverbsFromReader(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading proverbs: %w", err)
+	}
+	if err := s.loadData(data); err != nil {
+		return err
+	}
+	s.reload = func() error { return s.loadData(data) }
+	return nil
+}
+
+// LoadProverbsFromFile replaces the proverb collection with the proverbs of
+// the file at path, in a format LoadProverbsFromReader accepts. Reload reads
+// the file again. Like LoadProverbs it is not safe for concurrent use.
+func (s *Service) LoadProverbsFromFile(path string) error {
+	if err := s.loadFile(path); err != nil {
+		return err
+	}
+	s.reload = func() error { return s.loadFile(path) }
+	return nil
+}
+
+// loadFile loads the proverbs of the file at path
+func (s *Service) loadFile(path string) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- the caller picks the file
+	if err != nil {
+		return fmt.Errorf("reading proverbs: %w", err)
+	}
+	return s.loadData(data)
+}
+
+// loadData loads the proverbs of data. The loaded collection is kept if
+// data holds no valid proverbs.
+func (s *Service) loadData(data []byte) error {
+	start := time.Now()
+	var err error
+	var proverbs []Proverb
+	if IsProverbsV2(string(data)) {
+		if proverbs, err = ParseProverbsV2(string(data)); err != nil {
+			return fmt.Errorf("parsing proverbs: %w", err)
+		}
+	} else {
+		proverbs = parseProverbs(string(data))
+	}
+	if len(proverbs) == 0 {
+		return fmt.Errorf("no valid proverbs found")
+	}
+	s.proverbs = proverbs
+	s.index = newSearchIndex(proverbs)
+	if err := s.excludeProverbs(); err != nil {
+		s.proverbs, s.index = nil, nil
+		return err
+	}
+
+	s.logInfo("loaded proverbs", "count", len(s.proverbs), "duration", time.Since(start))
+
+	return nil
+}
+
+// loaded returns the proverb collection, loading the embedded data on first
+// use. It is safe for concurrent use.
+func (s *Service) loaded() ([]Proverb, error) {
+	proverbs, _, err := s.loadedIndexed()
+	return proverbs, err
+}
+
+// loadedIndexed returns the proverb collection together with its search
+// index, loading the embedded data on first use. It is safe for concurrent
+// use.
+func (s *Service) loadedIndexed() ([]Proverb, *searchIndex, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if len(s.proverbs) == 0 {
-		// Try to load proverbs if not already loaded
 		if err := s.LoadProverbs(); err != nil {
-			return "Error loading proverbs: " + err.Error()
+			return nil, nil, err
 		}
 	}
+	return s.proverbs, s.index, nil
+}
 
-	if len(s.proverbs) == 0 {
-		return "No proverbs available"
+// Reload reads the proverb collection again from where it was last loaded
+// from, replacing the loaded proverbs: the file of LoadProverbsFromFile, or
+// the embedded data and the extra proverbs of WithExtraProverbsFunc.
+// Unlike LoadProverbs it is safe for concurrent use.
+func (s *Service) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reload != nil {
+		return s.reload()
 	}
+	return s.loadEmbedded()
+}
+
+// Proverbs returns every loaded proverb, loading the embedded data if needed
+func (s *Service) Proverbs() ([]Proverb, error) {
+	loaded, err := s.loaded()
+	if err != nil {
+		return nil, err
+	}
+
+	proverbs := make([]Proverb, len(loaded))
+	copy(proverbs, loaded)
+	return proverbs, nil
+}
+
+// RandomEntry returns a random proverb together with its ID and tags,
+// loading the embedded data if needed
+func (s *Service) RandomEntry() (Proverb, error) {
+	proverbs, err := s.loaded()
+	if err != nil {
+		return Proverb{}, err
+	}
+	return proverbs[s.intn(len(proverbs))], nil
+}
 
-	// Use current time as seed for randomness
-	rand.Seed(time.Now().UnixNano())
-	index := rand.Intn(len(s.proverbs))
-	return s.proverbs[index]
-}
\ No newline at end of file
+// RandomProverb returns a random Go proverb
+func (s *Service) RandomProverb() string {
+	proverb, err := s.RandomEntry()
+	if err != nil {
+		return "Error loading proverbs: " + err.Error()
+	}
+	return proverb.Text
+}
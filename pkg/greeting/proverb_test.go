@@ -2,7 +2,12 @@ package greeting
 
 import (
 	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -38,7 +43,7 @@ func TestLoadProverbs(t *testing.T) {
 
 				// Verify all proverbs are non-empty
 				for i, proverb := range service.proverbs {
-					if strings.TrimSpace(proverb) == "" {
+					if strings.TrimSpace(proverb.Text) == "" {
 						t.Errorf("LoadProverbs() proverb at index %d is empty", i)
 					}
 				}
@@ -79,7 +84,7 @@ func TestRandomProverb(t *testing.T) {
 			name: "handles empty proverb list gracefully",
 			setupService: func() *Service {
 				service := NewService()
-				service.proverbs = []string{} // Empty proverb list
+				service.proverbs = []Proverb{} // Empty proverb list
 				return service
 			},
 			expectContains: "", // Should auto-load
@@ -128,7 +133,7 @@ func TestRandomProverbConsistency(t *testing.T) {
 	// Test that all returned proverbs are from the loaded set
 	proverbSet := make(map[string]bool)
 	for _, proverb := range service.proverbs {
-		proverbSet[proverb] = true
+		proverbSet[proverb.Text] = true
 	}
 
 	for i := 0; i < 20; i++ {
@@ -139,13 +144,14 @@ func TestRandomProverbConsistency(t *testing.T) {
 	}
 }
 
-// TestProverbDataIntegrity verifies the embedded proverb data meets requirements
+// TestProverbDataIntegrity verifies the proverb data meets requirements
 func TestProverbDataIntegrity(t *testing.T) {
-	if proverbData == "" {
-		t.Fatal("Embedded proverb data is empty")
+	data, err := os.ReadFile("proverb.txt")
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(proverbData), "\n")
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
 	validProverbs := 0
 
 	for _, line := range lines {
@@ -156,7 +162,25 @@ func TestProverbDataIntegrity(t *testing.T) {
 	}
 
 	if validProverbs < 50 {
-		t.Errorf("Embedded data contains %d valid proverbs, expected at least 50", validProverbs)
+		t.Errorf("Proverb data contains %d valid proverbs, expected at least 50", validProverbs)
+	}
+}
+
+// TestGeneratedProverbsUpToDate fails when proverb.txt was edited without
+// running go generate. Run it with -tags proverbs_raw as well to check the
+// generated Go source.
+func TestGeneratedProverbsUpToDate(t *testing.T) {
+	data, err := os.ReadFile("proverb.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	embedded, err := embeddedData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := parseProverbs(string(data)); !reflect.DeepEqual(embedded, want) {
+		t.Error("embedded proverbs are out of date; run go generate ./pkg/greeting")
 	}
 }
 
@@ -176,7 +200,7 @@ func TestEmptyProverbDataHandling(t *testing.T) {
 	}
 	
 	// Now test RandomProverb with empty proverbs slice
-	service.proverbs = []string{}
+	service.proverbs = []Proverb{}
 	result := service.RandomProverb()
 	
 	// Should auto-load and return a valid proverb
@@ -252,4 +276,212 @@ func ExampleService_RandomProverb() {
 	proverb := service.RandomProverb()
 	fmt.Printf("Got a proverb: %t\n", len(proverb) > 0)
 	// Output: Got a proverb: true
-}
\ No newline at end of file
+}
+// TestRandomProverbWithSeed verifies seeded services are reproducible
+func TestRandomProverbWithSeed(t *testing.T) {
+	first := NewService(WithSeed(42))
+	second := NewService(WithSeed(42))
+
+	for i := 0; i < 20; i++ {
+		a, b := first.RandomProverb(), second.RandomProverb()
+		if a != b {
+			t.Fatalf("call %d: seeded services diverged: %q vs %q", i, a, b)
+		}
+	}
+}
+
+// TestRandomProverbWithRand verifies a caller-supplied source is used
+func TestRandomProverbWithRand(t *testing.T) {
+	service := NewService(WithRand(rand.New(rand.NewSource(1))))
+	reference := NewService(WithSeed(1))
+
+	if got, want := service.RandomProverb(), reference.RandomProverb(); got != want {
+		t.Errorf("WithRand() service returned %q, want %q", got, want)
+	}
+}
+
+// TestReloadConcurrentWithReads verifies reloading is safe while proverbs are read
+func TestReloadConcurrentWithReads(t *testing.T) {
+	service := NewService(WithSeed(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := service.Reload(); err != nil {
+				t.Errorf("Reload() unexpected error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := service.RandomEntry(); err != nil {
+				t.Errorf("RandomEntry() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLoadProverbsFromReader(t *testing.T) {
+	data := "\ufeff# house rules\r\nDon't panic. | Errors, \r\n\r\nClear is \x1b[1mbetter\x1b[0m than clever.\xff\r\nErrors are values. | | Rob Pike \r\n"
+
+	service := NewService()
+	if err := service.LoadProverbsFromReader(strings.NewReader(data)); err != nil {
+		t.Fatalf("LoadProverbsFromReader() error = %v", err)
+	}
+
+	want := []Proverb{
+		{ID: 1, Text: "Don't panic.", Tags: []string{"errors"}},
+		{ID: 2, Text: "Clear is better than clever."},
+		{ID: 3, Text: "Errors are values.", Author: "Rob Pike"},
+	}
+	if fmt.Sprint(service.proverbs) != fmt.Sprint(want) {
+		t.Errorf("LoadProverbsFromReader() loaded %+v, want %+v", service.proverbs, want)
+	}
+
+	if err := service.LoadProverbsFromReader(strings.NewReader("# nothing here\n")); err == nil {
+		t.Error("LoadProverbsFromReader() should fail without any proverbs")
+	}
+	if len(service.proverbs) != 3 {
+		t.Errorf("a failed load replaced the collection with %d proverbs", len(service.proverbs))
+	}
+}
+
+func TestReloadReadsSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proverbs.txt")
+	if err := os.WriteFile(path, []byte("Don't panic.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	service := NewService()
+	if err := service.LoadProverbsFromFile(path); err != nil {
+		t.Fatalf("LoadProverbsFromFile() error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("Don't panic.\nErrors are values.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if proverbs, _ := service.Proverbs(); len(proverbs) != 2 || proverbs[1].Text != "Errors are values." {
+		t.Errorf("Reload() loaded %+v, want the changed file", proverbs)
+	}
+
+	// A collection read from a reader is kept, not replaced by the embedded one
+	if err := service.LoadProverbsFromReader(strings.NewReader("A little copying is better than a little dependency.\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if proverbs, _ := service.Proverbs(); len(proverbs) != 1 {
+		t.Errorf("Reload() after LoadProverbsFromReader loaded %d proverbs, want 1", len(proverbs))
+	}
+}
+
+func TestReloadExtraProverbsFunc(t *testing.T) {
+	embedded, err := NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var extra []Proverb
+	service := NewService(WithExtraProverbsFunc(func() ([]Proverb, error) { return extra, nil }))
+	if proverbs, _ := service.Proverbs(); len(proverbs) != len(embedded) {
+		t.Fatalf("got %d proverbs, want %d", len(proverbs), len(embedded))
+	}
+
+	// Proverbs imported since the first load
+	extra = []Proverb{{Text: "Ship it on a Friday."}}
+	if err := service.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	proverbs, _ := service.Proverbs()
+	if len(proverbs) != len(embedded)+1 || proverbs[len(proverbs)-1].Text != "Ship it on a Friday." {
+		t.Errorf("Reload() loaded %d proverbs, want the imported one too", len(proverbs))
+	}
+}
+
+func TestParseProverbLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Proverb
+		wantOK  bool
+		wantErr string
+	}{
+		{line: "  # comment"},
+		{line: "\r"},
+		{line: "Don't panic. | Errors, style | Rob Pike\r", want: Proverb{Text: "Don't panic.", Tags: []string{"errors", "style"}, Author: "Rob Pike"}, wantOK: true},
+		{line: "Errors are values. | | Rob Pike", want: Proverb{Text: "Errors are values.", Author: "Rob Pike"}, wantOK: true},
+		{line: "Clear is better than clever.", want: Proverb{Text: "Clear is better than clever."}, wantOK: true},
+		{line: "Bad \xff bytes", wantErr: "invalid UTF-8"},
+		{line: "\x1b[31mRed\x1b[0m", wantErr: "control characters"},
+		{line: " | errors", wantErr: "proverb text is empty"},
+		{line: "Don't panic. | errors,", wantErr: "empty tag"},
+		{line: "Don't panic. | errors |", wantErr: "author is empty"},
+		{line: "Don't panic. | errors | Rob Pike | https://go-proverbs.github.io/", want: Proverb{Text: "Don't panic.", Tags: []string{"errors"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"}, wantOK: true},
+		{line: "Don't panic. | errors | | https://go-proverbs.github.io/", want: Proverb{Text: "Don't panic.", Tags: []string{"errors"}, Source: "https://go-proverbs.github.io/"}, wantOK: true},
+		{line: "Don't panic. | a | b | c", wantErr: `source "c" is not an http or https URL`},
+		{line: "Don't panic. | a | b | https://example.com/a b", wantErr: "is not an http or https URL"},
+		{line: "Don't panic. | a | b | https://example.com | d", wantErr: "more than three separators"},
+	}
+
+	for _, tt := range tests {
+		got, ok, err := ParseProverbLine(tt.line)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseProverbLine(%q) error = %v, want %q", tt.line, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || ok != tt.wantOK || fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("ParseProverbLine(%q) = %+v, %v, %v; want %+v, %v", tt.line, got, ok, err, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestProverbLines(t *testing.T) {
+	data := "# a comment\r\n  continued comment\nFirst line\n  continues,\r\n\tand ends. | quotes\n\n  Indented after a blank line.\nLast. | errors\n"
+	want := []ProverbLine{
+		{1, 2, "# a comment continued comment"},
+		{3, 5, "First line continues, and ends. | quotes"},
+		{7, 7, "  Indented after a blank line."},
+		{8, 8, "Last. | errors"},
+	}
+	if got := ProverbLines(data); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ProverbLines() = %q, want %q", got, want)
+	}
+
+	proverbs := parseProverbs("A long proverb\n  spans lines\n  | style | Someone\n")
+	if len(proverbs) != 1 || proverbs[0].Text != "A long proverb spans lines" || proverbs[0].Author != "Someone" {
+		t.Errorf("parseProverbs() of continued lines = %+v", proverbs)
+	}
+}
+
+func TestWithExtraProverbs(t *testing.T) {
+	embedded, err := NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewService(WithExtraProverbs([]Proverb{{ID: 1, Text: "Ship it on a Friday.", Tags: []string{"mine"}}}))
+	proverbs, err := service.Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proverbs) != len(embedded)+1 {
+		t.Fatalf("got %d proverbs, want %d", len(proverbs), len(embedded)+1)
+	}
+	last := proverbs[len(proverbs)-1]
+	if last.ID != len(embedded)+1 || last.Text != "Ship it on a Friday." {
+		t.Errorf("extra proverb = %+v, want ID %d", last, len(embedded)+1)
+	}
+
+	found, err := service.FilterProverbs(Filter{Search: "friday"})
+	if err != nil || len(found) != 1 {
+		t.Errorf("search for the extra proverb found %v (err %v)", found, err)
+	}
+	if again, _ := NewService().Proverbs(); len(again) != len(embedded) {
+		t.Error("extra proverbs leaked into the shared embedded collection")
+	}
+}
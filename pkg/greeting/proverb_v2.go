@@ -0,0 +1,378 @@
+package greeting
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// frontMatterDelimiter opens and closes the front matter of a v2 entry
+const frontMatterDelimiter = "---"
+
+// ParseError is an error at a line of proverb data
+type ParseError struct {
+	// Line is the 1-based line number
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// IsProverbsV2 reports whether data is in the v2 proverb format, that is
+// whether its first line that is neither blank nor a '#' comment is "---".
+// Anything else is the line format of proverb.txt.
+func IsProverbsV2(data string) bool {
+	for _, line := range strings.Split(strings.TrimPrefix(data, "\ufeff"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line == frontMatterDelimiter
+	}
+	return false
+}
+
+// ParseProverbsV2 strictly parses proverb data in the v2 format, where every
+// proverb is its text preceded by YAML front matter holding its metadata:
+//
+//	---
+//	id: 1
+//	tags: [concurrency, design]
+//	author: Rob Pike
+//	source: https://go-proverbs.github.io/
+//	added: 2015-11-18
+//	translations:
+//	  de: Kommuniziere nicht durch geteilten Speicher, ...
+//	---
+//	Don't communicate by sharing memory, share memory by communicating.
+//
+// Every key is optional. The id, when given, must be the position of the
+// proverb in the file; the source is an http or https URL and the added
+// date is YYYY-MM-DD. Strings may be
+// double-quoted with Go escapes. The text runs up to the next "---", with
+// its lines joined by a single space. Blank lines, and '#' comments before
+// the first entry and in front matter, are ignored.
+//
+// Like ParseProverbLine it rejects invalid UTF-8, byte order marks and
+// control characters; errors are *ParseError with the line number. The
+// proverbs are numbered by their position.
+func ParseProverbsV2(data string) ([]Proverb, error) {
+	data = strings.TrimPrefix(data, "\ufeff")
+	lines := strings.Split(data, "\n")
+
+	var (
+		proverbs []Proverb
+		entry    *v2Entry
+	)
+	finish := func() error {
+		if entry == nil {
+			return nil
+		}
+		p, err := entry.proverb(len(proverbs) + 1)
+		if err != nil {
+			return err
+		}
+		proverbs = append(proverbs, p)
+		entry = nil
+		return nil
+	}
+
+	for i, line := range lines {
+		n := i + 1
+		line = strings.TrimSuffix(line, "\r")
+		if err := checkV2Line(line); err != nil {
+			return nil, &ParseError{Line: n, Err: err}
+		}
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case entry != nil && entry.inFrontMatter:
+			if trimmed == frontMatterDelimiter {
+				entry.inFrontMatter = false
+				continue
+			}
+			if err := entry.parseField(line); err != nil {
+				return nil, &ParseError{Line: n, Err: err}
+			}
+		case trimmed == frontMatterDelimiter:
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			entry = &v2Entry{line: n, inFrontMatter: true, seen: make(map[string]bool)}
+		case trimmed == "":
+		case entry != nil:
+			entry.text = append(entry.text, trimmed)
+		case strings.HasPrefix(trimmed, "#"):
+		default:
+			return nil, &ParseError{Line: n, Err: errors.New(`text outside an entry; entries start with "---"`)}
+		}
+	}
+	if entry != nil && entry.inFrontMatter {
+		return nil, &ParseError{Line: entry.line, Err: errors.New("front matter is not closed")}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return proverbs, nil
+}
+
+// checkV2Line rejects the lines of v2 data that ParseProverbLine would
+func checkV2Line(line string) error {
+	if !utf8.ValidString(line) {
+		return errors.New("invalid UTF-8")
+	}
+	if strings.ContainsRune(line, '\ufeff') {
+		return errors.New("byte order mark")
+	}
+	if trimmed := strings.TrimSpace(line); sanitizeText(trimmed) != trimmed {
+		return errors.New("control characters or escape sequences")
+	}
+	return nil
+}
+
+// v2Entry collects a v2 entry while it is parsed
+type v2Entry struct {
+	// line is where the entry starts
+	line          int
+	inFrontMatter bool
+	// inTranslations is set after a "translations:" key
+	inTranslations bool
+	seen           map[string]bool
+
+	id           int
+	tags         []string
+	author       string
+	source       string
+	added        string
+	translations map[string]string
+	text         []string
+}
+
+// parseField parses a line of front matter
+func (e *v2Entry) parseField(line string) error {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return nil
+	}
+
+	key, value, ok := strings.Cut(trimmed, ":")
+	if !ok {
+		return fmt.Errorf("%q is not a key: value pair", trimmed)
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+	indented := line[0] == ' ' || line[0] == '\t'
+	if indented && e.inTranslations {
+		return e.parseTranslation(key, value)
+	}
+	if indented {
+		return fmt.Errorf("unexpected indentation of %q", key)
+	}
+	e.inTranslations = false
+
+	if e.seen[key] {
+		return fmt.Errorf("key %q is repeated", key)
+	}
+	e.seen[key] = true
+
+	switch key {
+	case "id":
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 1 {
+			return fmt.Errorf("id %q is not a positive number", value)
+		}
+		e.id = id
+	case "tags":
+		tags, err := parseV2List(value)
+		if err != nil {
+			return err
+		}
+		e.tags = tags
+	case "author":
+		author, err := parseV2String(value)
+		if err != nil {
+			return err
+		}
+		if author == "" {
+			return errors.New("author is empty")
+		}
+		e.author = author
+	case "source":
+		source, err := parseV2String(value)
+		if err != nil {
+			return err
+		}
+		if !IsSourceURL(source) {
+			return fmt.Errorf("source %q is not an http or https URL", source)
+		}
+		e.source = source
+	case "added":
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("added date %q is not YYYY-MM-DD", value)
+		}
+		e.added = value
+	case "translations":
+		if value != "" {
+			return errors.New("translations must be indented on the lines below")
+		}
+		e.inTranslations = true
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// parseTranslation parses an indented "<language>: <text>" line below the
+// translations key
+func (e *v2Entry) parseTranslation(lang, value string) error {
+	if lang == "" || strings.Trim(lang, "abcdefghijklmnopqrstuvwxyz-") != "" {
+		return fmt.Errorf("malformed language %q (use a language code such as de or pt-br)", lang)
+	}
+	if _, ok := e.translations[lang]; ok {
+		return fmt.Errorf("translation %q is repeated", lang)
+	}
+	text, err := parseV2String(value)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		return fmt.Errorf("translation %q is empty", lang)
+	}
+	if e.translations == nil {
+		e.translations = make(map[string]string)
+	}
+	e.translations[lang] = text
+	return nil
+}
+
+// proverb returns the parsed entry as the proverb at position id
+func (e *v2Entry) proverb(id int) (Proverb, error) {
+	if e.id != 0 && e.id != id {
+		return Proverb{}, &ParseError{Line: e.line, Err: fmt.Errorf("id %d out of order, want %d", e.id, id)}
+	}
+	text := strings.Join(e.text, " ")
+	if text == "" {
+		return Proverb{}, &ParseError{Line: e.line, Err: errors.New("proverb text is empty")}
+	}
+	return Proverb{
+		ID:           id,
+		Text:         text,
+		Tags:         e.tags,
+		Author:       e.author,
+		Source:       e.source,
+		Added:        e.added,
+		Translations: e.translations,
+	}, nil
+}
+
+// parseV2String parses a plain or double-quoted string
+func parseV2String(value string) (string, error) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, nil
+	}
+	s, err := strconv.Unquote(value)
+	if err != nil {
+		return "", fmt.Errorf("malformed quoted string %s", value)
+	}
+	return s, nil
+}
+
+// parseV2List parses a "[a, b]" list of lower-cased tags
+func parseV2List(value string) ([]string, error) {
+	inner, ok := strings.CutPrefix(value, "[")
+	if inner, ok = strings.CutSuffix(inner, "]"); !ok {
+		return nil, fmt.Errorf("tags %q are not a list like [a, b]", value)
+	}
+	if strings.TrimSpace(inner) == "" {
+		return nil, nil
+	}
+
+	var tags []string
+	for _, tag := range strings.Split(inner, ",") {
+		tag, err := parseV2String(strings.TrimSpace(tag))
+		if err != nil {
+			return nil, err
+		}
+		if tag == "" {
+			return nil, errors.New("empty tag")
+		}
+		tags = append(tags, strings.ToLower(tag))
+	}
+	return tags, nil
+}
+
+// WriteProverbsV2 writes proverbs in the v2 format read by ParseProverbsV2,
+// numbering them by their position. Metadata that is unknown is left out.
+func WriteProverbsV2(w io.Writer, proverbs []Proverb) error {
+	bw := bufio.NewWriter(w)
+	for i, p := range proverbs {
+		if p.Text == frontMatterDelimiter || strings.ContainsAny(p.Text, "\r\n") {
+			return fmt.Errorf("proverb %d: text %q can't be written", i+1, p.Text)
+		}
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+
+		fmt.Fprintf(bw, "%s\nid: %d\n", frontMatterDelimiter, i+1)
+		if len(p.Tags) > 0 {
+			tags := make([]string, len(p.Tags))
+			for i, tag := range p.Tags {
+				tags[i] = formatV2String(tag)
+			}
+			fmt.Fprintf(bw, "tags: [%s]\n", strings.Join(tags, ", "))
+		}
+		if p.Author != "" {
+			fmt.Fprintf(bw, "author: %s\n", formatV2String(p.Author))
+		}
+		if p.Source != "" {
+			fmt.Fprintf(bw, "source: %s\n", formatV2String(p.Source))
+		}
+		if p.Added != "" {
+			fmt.Fprintf(bw, "added: %s\n", p.Added)
+		}
+		if len(p.Translations) > 0 {
+			bw.WriteString("translations:\n")
+			langs := make([]string, 0, len(p.Translations))
+			for lang := range p.Translations {
+				langs = append(langs, lang)
+			}
+			sort.Strings(langs)
+			for _, lang := range langs {
+				fmt.Fprintf(bw, "  %s: %s\n", lang, formatV2String(p.Translations[lang]))
+			}
+		}
+		fmt.Fprintf(bw, "%s\n%s\n", frontMatterDelimiter, p.Text)
+	}
+	return bw.Flush()
+}
+
+// formatV2String returns s plain when YAML reads it back as the same string,
+// and double-quoted otherwise
+func formatV2String(s string) string {
+	if s == "" || s != strings.TrimSpace(s) || strings.ContainsAny(s, "\"[]{},\\") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") ||
+		strings.ContainsAny(s[:1], "#-?:&*!|>'%@`") {
+		return strconv.Quote(s)
+	}
+	// Numbers and words such as "yes" or "null" aren't strings to YAML
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	return s
+}
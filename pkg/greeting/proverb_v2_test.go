@@ -0,0 +1,151 @@
+package greeting
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const v2Sample = `# Team proverbs
+---
+id: 1
+tags: [concurrency, Design]
+author: Rob Pike
+source: https://go-proverbs.github.io/
+added: 2015-11-18
+translations:
+  de: "Kommuniziere nicht durch geteilten Speicher."
+  fr: Ne communiquez pas en partageant la mémoire.
+---
+Don't communicate by sharing memory,
+share memory by communicating.
+
+---
+---
+Clear is better than clever.
+`
+
+func TestParseProverbsV2(t *testing.T) {
+	proverbs, err := ParseProverbsV2(v2Sample)
+	if err != nil {
+		t.Fatalf("ParseProverbsV2() error = %v", err)
+	}
+	want := []Proverb{
+		{
+			ID:     1,
+			Text:   "Don't communicate by sharing memory, share memory by communicating.",
+			Tags:   []string{"concurrency", "design"},
+			Author: "Rob Pike",
+			Source: "https://go-proverbs.github.io/",
+			Added:  "2015-11-18",
+			Translations: map[string]string{
+				"de": "Kommuniziere nicht durch geteilten Speicher.",
+				"fr": "Ne communiquez pas en partageant la mémoire.",
+			},
+		},
+		{ID: 2, Text: "Clear is better than clever."},
+	}
+	if !reflect.DeepEqual(proverbs, want) {
+		t.Errorf("ParseProverbsV2() = %+v, want %+v", proverbs, want)
+	}
+}
+
+func TestParseProverbsV2Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		line int
+		want string
+	}{
+		{"---\nid: 2\n---\nText.\n", 1, "id 2 out of order, want 1"},
+		{"---\nauthor: Me\n---\n", 1, "proverb text is empty"},
+		{"---\nauthor: Me\n", 1, "front matter is not closed"},
+		{"---\ncolor: red\n---\nText.\n", 2, `unknown key "color"`},
+		{"---\nauthor: A\nauthor: B\n---\nText.\n", 3, `key "author" is repeated`},
+		{"---\nadded: 18.11.2015\n---\nText.\n", 2, "not YYYY-MM-DD"},
+		{"---\ntags: a, b\n---\nText.\n", 2, "not a list"},
+		{"---\nsource: go.dev\n---\nText.\n", 2, "not an http or https URL"},
+		{"---\ntags: [a, ]\n---\nText.\n", 2, "empty tag"},
+		{"---\ntranslations:\n  DE: Text.\n---\nText.\n", 3, "malformed language"},
+		{"---\n  de: Text.\n---\nText.\n", 2, "unexpected indentation"},
+		{"# c\nText.\n---\n---\nText.\n", 2, "text outside an entry"},
+		{"---\n---\nBad \xff.\n", 3, "invalid UTF-8"},
+		{"---\n---\nBad \x1b[31m.\n", 3, "control characters"},
+	}
+	for _, tt := range tests {
+		_, err := ParseProverbsV2(tt.data)
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Line != tt.line || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseProverbsV2(%q) error = %v, want line %d: %s", tt.data, err, tt.line, tt.want)
+		}
+	}
+}
+
+func TestIsProverbsV2(t *testing.T) {
+	tests := map[string]bool{
+		v2Sample:                   true,
+		"\ufeff---\n---\nText.\n":  true,
+		"Text. | tag\n---\n":       false,
+		"# only a comment\n":       false,
+		"":                         false,
+		"\n  ---  \n---\nText.\n":  true,
+		"--- not a delimiter\n---": false,
+	}
+	for data, want := range tests {
+		if got := IsProverbsV2(data); got != want {
+			t.Errorf("IsProverbsV2(%q) = %v, want %v", data, got, want)
+		}
+	}
+}
+
+func TestWriteProverbsV2RoundTrip(t *testing.T) {
+	data, err := os.ReadFile("proverb.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proverbs := parseProverbs(string(data))
+	proverbs = append(proverbs, Proverb{
+		ID:           len(proverbs) + 1,
+		Text:         "# is not a comment here",
+		Tags:         []string{"true", "-dash"},
+		Author:       "Someone: \"quoted\"",
+		Added:        "2024-02-29",
+		Translations: map[string]string{"pt-br": "yes", "de": " spaced "},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteProverbsV2(&buf, proverbs); err != nil {
+		t.Fatalf("WriteProverbsV2() error = %v", err)
+	}
+	if !IsProverbsV2(buf.String()) {
+		t.Fatalf("WriteProverbsV2() output isn't detected as v2:\n%s", buf.String())
+	}
+	got, err := ParseProverbsV2(buf.String())
+	if err != nil {
+		t.Fatalf("ParseProverbsV2() of written data error = %v", err)
+	}
+	if !reflect.DeepEqual(got, proverbs) {
+		t.Errorf("round trip changed the proverbs:\n got %+v\nwant %+v", got, proverbs)
+	}
+
+	if err := WriteProverbsV2(&buf, []Proverb{{Text: "---"}}); err == nil {
+		t.Error(`WriteProverbsV2() of the text "---" succeeded`)
+	}
+}
+
+func TestLoadProverbsFromReaderV2(t *testing.T) {
+	s := NewService()
+	if err := s.LoadProverbsFromReader(strings.NewReader(v2Sample)); err != nil {
+		t.Fatalf("LoadProverbsFromReader() error = %v", err)
+	}
+	proverbs, err := s.Proverbs()
+	if err != nil || len(proverbs) != 2 || proverbs[0].Translations["de"] == "" {
+		t.Errorf("Proverbs() = %+v, %v", proverbs, err)
+	}
+
+	if err := s.LoadProverbsFromReader(strings.NewReader("---\nid: x\n---\nText.\n")); err == nil {
+		t.Error("LoadProverbsFromReader() of malformed v2 data succeeded")
+	}
+}
@@ -0,0 +1,81 @@
+// Code generated by proverbgen from proverb.txt; DO NOT EDIT.
+
+//go:build proverbs_raw || tinygo
+
+package greeting
+
+// embeddedProverbs is the proverb collection of proverb.txt
+var embeddedProverbs = []Proverb{
+	{ID: 1, Text: "Don't communicate by sharing memory, share memory by communicating.", Tags: []string{"concurrency"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 2, Text: "Concurrency is not parallelism.", Tags: []string{"concurrency"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 3, Text: "Channels orchestrate; mutexes serialize.", Tags: []string{"concurrency"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 4, Text: "The bigger the interface, the weaker the abstraction.", Tags: []string{"interfaces", "design"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 5, Text: "Make the zero value useful.", Tags: []string{"design"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 6, Text: "interface{} says nothing.", Tags: []string{"interfaces"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 7, Text: "Gofmt's style is no one's favorite, yet gofmt is everyone's favorite.", Tags: []string{"style", "tooling"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 8, Text: "A little copying is better than a little dependency.", Tags: []string{"dependencies", "design"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 9, Text: "Syscalls must always be guarded with build tags.", Tags: []string{"syscall", "portability"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 10, Text: "Cgo must always be guarded with build tags.", Tags: []string{"cgo", "portability"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 11, Text: "Cgo is not Go.", Tags: []string{"cgo"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 12, Text: "With the unsafe package there are no guarantees.", Tags: []string{"unsafe"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 13, Text: "Clear is better than clever.", Tags: []string{"simplicity", "style"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 14, Text: "Reflection is never clear.", Tags: []string{"reflection"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 15, Text: "Errors are values.", Tags: []string{"errors"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 16, Text: "Don't just check errors, handle them gracefully.", Tags: []string{"errors"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 17, Text: "Design the architecture, name the components, document the details.", Tags: []string{"design", "documentation"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 18, Text: "Documentation is for users.", Tags: []string{"documentation"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 19, Text: "Don't panic.", Tags: []string{"errors"}, Author: "Rob Pike", Source: "https://go-proverbs.github.io/"},
+	{ID: 20, Text: "Make it work, make it right, make it fast.", Tags: []string{"performance"}, Author: "Kent Beck"},
+	{ID: 21, Text: "Build constraints are for files, not functions.", Tags: []string{"portability"}},
+	{ID: 22, Text: "The empty interface says nothing.", Tags: []string{"interfaces"}},
+	{ID: 23, Text: "Write tests to learn.", Tags: []string{"testing"}},
+	{ID: 24, Text: "The race detector is your friend.", Tags: []string{"testing", "concurrency"}},
+	{ID: 25, Text: "Prefer composition over inheritance.", Tags: []string{"design"}},
+	{ID: 26, Text: "Accept interfaces, return structs.", Tags: []string{"interfaces", "design"}},
+	{ID: 27, Text: "Don't use goroutines in libraries.", Tags: []string{"concurrency"}},
+	{ID: 28, Text: "Avoid package level state.", Tags: []string{"design"}},
+	{ID: 29, Text: "Simple is better than complex.", Tags: []string{"zen", "simplicity"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 30, Text: "Explicit is better than implicit.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 31, Text: "Flat is better than nested.", Tags: []string{"zen", "style"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 32, Text: "Sparse is better than dense.", Tags: []string{"zen", "style"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 33, Text: "Readability counts.", Tags: []string{"zen", "style"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 34, Text: "Special cases aren't special enough to break the rules.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 35, Text: "Although practicality beats purity.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 36, Text: "Errors should never pass silently.", Tags: []string{"zen", "errors"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 37, Text: "Unless explicitly silenced.", Tags: []string{"zen", "errors"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 38, Text: "In the face of ambiguity, refuse the temptation to guess.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 39, Text: "There should be one obvious way to do it.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 40, Text: "Although that way may not be obvious at first unless you're Dutch.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 41, Text: "Now is better than never.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 42, Text: "Although never is often better than right now.", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 43, Text: "If the implementation is hard to explain, it's a bad idea.", Tags: []string{"zen", "simplicity"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 44, Text: "If the implementation is easy to explain, it may be a good idea.", Tags: []string{"zen", "simplicity"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 45, Text: "Namespaces are one honking great idea -- let's do more of those!", Tags: []string{"zen"}, Author: "Tim Peters", Source: "https://peps.python.org/pep-0020/"},
+	{ID: 46, Text: "Go is about composition, not inheritance.", Tags: []string{"design"}},
+	{ID: 47, Text: "Goroutines are cheap, but not free.", Tags: []string{"concurrency", "performance"}},
+	{ID: 48, Text: "Don't start a goroutine without knowing how it will stop.", Tags: []string{"concurrency"}},
+	{ID: 49, Text: "Channel ownership transfers responsibility.", Tags: []string{"concurrency"}},
+	{ID: 50, Text: "Leave concurrency to the caller.", Tags: []string{"concurrency", "design"}, Author: "Dave Cheney", Source: "https://dave.cheney.net/practical-go/presentations/qcon-china.html"},
+	{ID: 51, Text: "Before you launch a goroutine, know how it will stop.", Tags: []string{"concurrency"}},
+	{ID: 52, Text: "Never start a goroutine without knowing when it will stop.", Tags: []string{"concurrency"}, Author: "Dave Cheney", Source: "https://dave.cheney.net/practical-go/presentations/qcon-china.html"},
+	{ID: 53, Text: "The best programs are written so that computing machines can perform them quickly and so that human beings can understand them clearly.", Tags: []string{"quotes"}, Author: "Donald Knuth"},
+	{ID: 54, Text: "Programs must be written for people to read, and only incidentally for machines to execute.", Tags: []string{"quotes"}, Author: "Harold Abelson"},
+	{ID: 55, Text: "Debugging is twice as hard as writing the code in the first place.", Tags: []string{"quotes"}, Author: "Brian Kernighan"},
+	{ID: 56, Text: "Everyone knows that debugging is twice as hard as writing a program in the first place.", Tags: []string{"quotes"}, Author: "Brian Kernighan"},
+	{ID: 57, Text: "So if you're as clever as you can be when you write it, how will you ever debug it?", Tags: []string{"quotes"}, Author: "Brian Kernighan"},
+	{ID: 58, Text: "The most important single aspect of software development is to be clear about what you are trying to build.", Tags: []string{"quotes"}, Author: "Bjarne Stroustrup"},
+	{ID: 59, Text: "Wirth's law: Software is getting slower more rapidly than hardware becomes faster.", Tags: []string{"quotes"}, Author: "Niklaus Wirth"},
+	{ID: 60, Text: "The cheapest, fastest, and most reliable components are those that aren't there.", Tags: []string{"quotes"}, Author: "Gordon Bell"},
+	{ID: 61, Text: "One of my most productive days was throwing away 1000 lines of code.", Tags: []string{"quotes"}, Author: "Ken Thompson"},
+	{ID: 62, Text: "Good code is its own best documentation.", Tags: []string{"quotes"}, Author: "Steve McConnell"},
+	{ID: 63, Text: "Code never lies, comments sometimes do.", Tags: []string{"quotes"}, Author: "Ron Jeffries"},
+	{ID: 64, Text: "Any fool can write code that a computer can understand. Good programmers write code that humans can understand.", Tags: []string{"quotes"}, Author: "Martin Fowler"},
+	{ID: 65, Text: "First, solve the problem. Then, write the code.", Tags: []string{"quotes"}, Author: "John Johnson"},
+	{ID: 66, Text: "Experience is the name everyone gives to their mistakes.", Tags: []string{"quotes"}, Author: "Oscar Wilde"},
+	{ID: 67, Text: "In order to understand recursion, one must first understand recursion.", Tags: []string{"quotes"}},
+	{ID: 68, Text: "There are two ways of constructing a software design: One way is to make it so simple that there are obviously no deficiencies, and the other way is to make it so complicated that there are no obvious deficiencies.", Tags: []string{"quotes"}, Author: "Tony Hoare"},
+	{ID: 69, Text: "The first 90% of the code accounts for the first 90% of the development time. The remaining 10% of the code accounts for the other 90% of the development time.", Tags: []string{"quotes"}, Author: "Tom Cargill"},
+	{ID: 70, Text: "Adding manpower to a late software project makes it later.", Tags: []string{"quotes"}, Author: "Fred Brooks"},
+	{ID: 71, Text: "A complex system that works is invariably found to have evolved from a simple system that worked.", Tags: []string{"quotes"}, Author: "John Gall"},
+	{ID: 72, Text: "If you want to set off and go develop some grand new thing, you don't need millions of dollars of capitalization. You need enough pizza and Diet Coke to stick in your refrigerator, a cheap PC to work on and the dedication to go through with it.", Tags: []string{"quotes"}, Author: "John Carmack"},
+}
@@ -0,0 +1,84 @@
+package greeting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BuiltinProvider is the name of the provider backed by NewService, which is
+// always registered
+const BuiltinProvider = "builtin"
+
+// ProviderFactory creates a provider configured with the options resolved
+// for a run, such as the language or a seed. The provider must implement
+// Greeter, ProverbProvider or both; factories wrapping a *Service can pass
+// opts on to NewService.
+type ProviderFactory func(opts ...Option) interface{}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]ProviderFactory{
+		BuiltinProvider: func(opts ...Option) interface{} { return NewService(opts...) },
+	}
+)
+
+// RegisterProvider makes a provider available under name, so programs can
+// select it by name, for example from a config file. It is meant to be
+// called from the init function of a compiled-in extension, such as a
+// proverb source backed by a company wiki:
+//
+//	func init() {
+//		greeting.RegisterProvider("wiki", func(opts ...greeting.Option) interface{} {
+//			return greeting.NewService(append(opts, greeting.WithExtraProverbs(wikiProverbs()))...)
+//		})
+//	}
+//
+// RegisterProvider panics if name is empty or already registered, or if
+// factory is nil.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if name == "" {
+		panic("greeting: RegisterProvider with an empty name")
+	}
+	if factory == nil {
+		panic("greeting: RegisterProvider factory is nil for " + name)
+	}
+	if _, dup := providers[name]; dup {
+		panic("greeting: RegisterProvider called twice for " + name)
+	}
+	providers[name] = factory
+}
+
+// LookupProvider returns the factory registered under name
+func LookupProvider(name string) (ProviderFactory, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	factory, ok := providers[name]
+	return factory, ok
+}
+
+// Providers returns the names of the registered providers in sorted order
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseProvider validates the name of a registered provider
+func ParseProvider(name string) (string, error) {
+	if _, ok := LookupProvider(name); !ok {
+		return "", fmt.Errorf("unknown provider %q (registered: %s)", name, strings.Join(Providers(), ", "))
+	}
+	return name, nil
+}
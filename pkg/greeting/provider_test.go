@@ -0,0 +1,65 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+)
+
+// shoutingGreeter is a greeter registered by the tests
+type shoutingGreeter struct{}
+
+func (shoutingGreeter) Greet(name string) string { return "HEY " + strings.ToUpper(name) + "!" }
+
+func TestRegisterProvider(t *testing.T) {
+	RegisterProvider("test-shouting", func(opts ...Option) interface{} { return shoutingGreeter{} })
+
+	factory, ok := LookupProvider("test-shouting")
+	if !ok {
+		t.Fatal("LookupProvider() didn't find the registered provider")
+	}
+	if got := factory().(Greeter).Greet("gopher"); got != "HEY GOPHER!" {
+		t.Errorf("Greet() = %q", got)
+	}
+
+	names := Providers()
+	if !strings.Contains(strings.Join(names, ","), "builtin,test-shouting") {
+		t.Errorf("Providers() = %v", names)
+	}
+	if _, err := ParseProvider("test-shouting"); err != nil {
+		t.Errorf("ParseProvider() error = %v", err)
+	}
+	if _, err := ParseProvider("wiki"); err == nil || !strings.Contains(err.Error(), "builtin") {
+		t.Errorf("ParseProvider(wiki) error = %v, want the registered names", err)
+	}
+}
+
+func TestBuiltinProvider(t *testing.T) {
+	factory, ok := LookupProvider(BuiltinProvider)
+	if !ok {
+		t.Fatal("the builtin provider isn't registered")
+	}
+	s, ok := factory(WithLanguage("de")).(*Service)
+	if !ok {
+		t.Fatalf("the builtin provider doesn't create a *Service")
+	}
+	if got := s.Greet("Gopher"); got != "Hallo, Gopher!" {
+		t.Errorf("Greet() = %q, want the options applied", got)
+	}
+}
+
+func TestRegisterProviderPanics(t *testing.T) {
+	for name, register := range map[string]func(){
+		"empty name": func() { RegisterProvider("", func(...Option) interface{} { return nil }) },
+		"nil":        func() { RegisterProvider("test-nil", nil) },
+		"duplicate":  func() { RegisterProvider(BuiltinProvider, func(...Option) interface{} { return nil }) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterProvider() didn't panic")
+				}
+			}()
+			register()
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package greeting
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SanitizeName makes an untrusted name safe to print to a terminal. Escape
+// sequences such as "\x1b[31m" are removed together with their parameters,
+// control characters and bidirectional overrides are dropped, invalid UTF-8
+// is discarded, and control whitespace such as tabs and newlines becomes a
+// plain space. Surrounding spaces are trimmed.
+func SanitizeName(name string) string {
+	return sanitizeText(name)
+}
+
+// sanitizeText implements SanitizeName for any single line of untrusted text
+func sanitizeText(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			// Invalid UTF-8 byte
+		case r == '\x1b':
+			size = escapeLen(text[i:])
+		case r == '\u009b':
+			// 8-bit CSI, followed by the same parameters as ESC [
+			size += csiLen(text[i+size:])
+		case unicode.IsControl(r) && unicode.IsSpace(r):
+			b.WriteByte(' ')
+		case unicode.IsControl(r), isBidiControl(r):
+			// Dropped
+		default:
+			b.WriteRune(r)
+		}
+		i += size
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// escapeLen returns the length of the escape sequence at the start of s,
+// which begins with ESC. Unterminated sequences extend to the end of s.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		return 2 + csiLen(s[2:])
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM and APC strings end with BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		// Two-character sequences with optional intermediate bytes
+		i := 1
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x30 && s[i] <= 0x7e {
+			i++
+		}
+		return i
+	}
+}
+
+// csiLen returns the length of the parameter, intermediate and final bytes
+// of a control sequence
+func csiLen(s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+		if s[i] < 0x20 || s[i] > 0x3f {
+			// Not part of a control sequence; stop before it
+			return i
+		}
+	}
+	return len(s)
+}
+
+// isBidiControl reports whether r changes the direction of the text that
+// follows it, which can make a name render differently than it reads
+func isBidiControl(r rune) bool {
+	return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069') || r == '\u200e' || r == '\u200f' || r == '\u061c'
+}
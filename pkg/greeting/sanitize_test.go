@@ -0,0 +1,83 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain name", "Alice", "Alice"},
+		{"non-ascii name", "José", "José"},
+		{"emoji sequence", "👩\u200d💻 Ada", "👩\u200d💻 Ada"},
+		{"color codes", "\x1b[31mEvil\x1b[0m", "Evil"},
+		{"cursor movement", "\x1b[2J\x1b[1;1HBob", "Bob"},
+		{"window title", "\x1b]0;pwned\aEve", "Eve"},
+		{"hyperlink", "\x1b]8;;http://evil.example\x1b\\Mallory\x1b]8;;\x1b\\", "Mallory"},
+		{"8-bit csi", "\u009b31mTrudy", "Trudy"},
+		{"two-character escape", "\x1bcReset", "Reset"},
+		{"unterminated escape", "Oscar\x1b[31", "Oscar"},
+		{"lone escape", "Peggy\x1b", "Peggy"},
+		{"bell and backspace", "Vic\a\btor", "Victor"},
+		{"newlines become spaces", "Ann\nMarie\r\n", "Ann Marie"},
+		{"tab becomes space", "John\tDoe", "John Doe"},
+		{"bidi override", "Ali\u202ecod.exe", "Alicod.exe"},
+		{"invalid utf-8", "Wal\xffter", "Walter"},
+		{"only control characters", "\x1b[31m\x00", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeName(tt.input); got != tt.want {
+				t.Errorf("SanitizeName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GreetSanitizesNames(t *testing.T) {
+	service := NewService()
+	if got := service.Greet("\x1b[31mEvil"); got != "Hello, Evil!" {
+		t.Errorf("Greet() = %q, want escape sequences removed", got)
+	}
+	if got := service.Greet("\x1b[31m"); got != "Hello, Gopher!" {
+		t.Errorf("Greet() = %q, want the default name when nothing printable is left", got)
+	}
+
+	raw := NewService(WithRawNames())
+	if got := raw.Greet("\x1b[31mRed\x1b[0m"); got != "Hello, \x1b[31mRed\x1b[0m!" {
+		t.Errorf("Greet() with WithRawNames = %q, want the name unchanged", got)
+	}
+}
+
+func FuzzSanitizeName(f *testing.F) {
+	for _, seed := range []string{"Alice", "José", "\x1b[31mEvil", "\x1b]0;title\a", "\u009b2J", "a\u202eb", "\xff\xfe", "\x1b"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, name string) {
+		got := SanitizeName(name)
+		if !utf8.ValidString(got) {
+			t.Fatalf("SanitizeName(%q) = %q, not valid UTF-8", name, got)
+		}
+		for _, r := range got {
+			if unicode.IsControl(r) || isBidiControl(r) {
+				t.Fatalf("SanitizeName(%q) = %q, contains %U", name, got, r)
+			}
+		}
+		if again := SanitizeName(got); again != got {
+			t.Fatalf("SanitizeName is not idempotent: %q then %q", got, again)
+		}
+
+		greeting := NewService().Greet(name)
+		if strings.ContainsRune(greeting, '\x1b') {
+			t.Fatalf("Greet(%q) = %q, contains an escape character", name, greeting)
+		}
+	})
+}
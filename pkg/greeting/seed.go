@@ -0,0 +1,9 @@
+//go:build !tinygo
+
+package greeting
+
+// defaultSeed seeds the random source of services created without WithSeed
+// or WithRand from the current time
+func (s *Service) defaultSeed() int64 {
+	return s.now().UnixNano()
+}
@@ -0,0 +1,21 @@
+//go:build tinygo
+
+package greeting
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// defaultSeed seeds the random source of services created without WithSeed
+// or WithRand. Microcontrollers usually boot without a real-time clock, so a
+// time seed would pick the same proverbs after every reset; the seed is read
+// from the hardware random number generator instead, falling back to the
+// time on chips without one.
+func (s *Service) defaultSeed() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return s.now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]))
+}
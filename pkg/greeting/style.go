@@ -0,0 +1,135 @@
+package greeting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Style selects the tone of a greeting
+type Style string
+
+// Supported greeting styles
+const (
+	StyleFriendly Style = "friendly"
+	StyleFormal   Style = "formal"
+	StyleCasual   Style = "casual"
+)
+
+// DefaultLanguage is used when no language is configured or the configured
+// language is not supported
+const DefaultLanguage = "en"
+
+// DefaultStyle is used when no style is configured
+const DefaultStyle = StyleFriendly
+
+// templates maps a language to the greeting template for each style
+var templates = map[string]map[Style]string{
+	"en": {StyleFriendly: "Hello, %s!", StyleFormal: "Good day, %s.", StyleCasual: "Hey, %s!"},
+	"es": {StyleFriendly: "¡Hola, %s!", StyleFormal: "Buenos días, %s.", StyleCasual: "¡Qué tal, %s!"},
+	"fr": {StyleFriendly: "Bonjour, %s!", StyleFormal: "Bonjour, %s.", StyleCasual: "Salut, %s!"},
+	"de": {StyleFriendly: "Hallo, %s!", StyleFormal: "Guten Tag, %s.", StyleCasual: "Servus, %s!"},
+	"pt": {StyleFriendly: "Olá, %s!", StyleFormal: "Bom dia, %s.", StyleCasual: "Oi, %s!"},
+	"it": {StyleFriendly: "Ciao, %s!", StyleFormal: "Buongiorno, %s.", StyleCasual: "Ehi, %s!"},
+}
+
+// Languages returns the supported language codes in sorted order
+func Languages() []string {
+	langs := make([]string, 0, len(templates))
+	for lang := range templates {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Styles returns the supported greeting styles
+func Styles() []Style {
+	return []Style{StyleFriendly, StyleFormal, StyleCasual}
+}
+
+// NormalizeLanguage reduces a language tag such as "de-AT" or "pt_BR.UTF-8"
+// to its lower-cased base language code ("de", "pt")
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_."); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// ParseLanguage validates a language tag and returns its base language code
+func ParseLanguage(lang string) (string, error) {
+	base := NormalizeLanguage(lang)
+	if _, ok := templates[base]; !ok {
+		return "", fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	return base, nil
+}
+
+// ParseStyle validates a style name
+func ParseStyle(style string) (Style, error) {
+	s := Style(strings.ToLower(strings.TrimSpace(style)))
+	for _, known := range Styles() {
+		if s == known {
+			return s, nil
+		}
+	}
+	names := make([]string, 0, len(Styles()))
+	for _, known := range Styles() {
+		names = append(names, string(known))
+	}
+	return "", fmt.Errorf("unsupported style %q (supported: %s)", style, strings.Join(names, ", "))
+}
+
+// WithLanguage selects the greeting language. Unsupported languages fall
+// back to DefaultLanguage.
+func WithLanguage(lang string) Option {
+	return func(s *Service) {
+		s.language = NormalizeLanguage(lang)
+	}
+}
+
+// WithStyle selects the greeting style. Unknown styles fall back to DefaultStyle.
+func WithStyle(style Style) Option {
+	return func(s *Service) {
+		s.style = style
+	}
+}
+
+// Language returns the language of the service's greetings: the language
+// set WithLanguage, or DefaultLanguage if it isn't supported
+func (s *Service) Language() string {
+	if _, ok := templates[s.language]; ok {
+		return s.language
+	}
+	return DefaultLanguage
+}
+
+// Style returns the style of the service's greetings: the style set
+// WithStyle, or DefaultStyle if it is unknown
+func (s *Service) Style() Style {
+	if _, ok := templates[DefaultLanguage][s.style]; ok {
+		return s.style
+	}
+	return DefaultStyle
+}
+
+// template returns the greeting template for the service's language and style
+func (s *Service) template() string {
+	return templateFor(s.language, s.style)
+}
+
+// templateFor returns the greeting template for lang and style, falling back
+// to DefaultLanguage and DefaultStyle
+func templateFor(lang string, style Style) string {
+	byStyle, ok := templates[lang]
+	if !ok {
+		byStyle = templates[DefaultLanguage]
+	}
+	tmpl, ok := byStyle[style]
+	if !ok {
+		tmpl = byStyle[DefaultStyle]
+	}
+	return tmpl
+}
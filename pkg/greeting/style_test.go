@@ -0,0 +1,102 @@
+package greeting
+
+import "testing"
+
+func TestService_GreetWithLanguageAndStyle(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		input string
+		want  string
+	}{
+		{"default is english friendly", nil, "Alice", "Hello, Alice!"},
+		{"formal english", []Option{WithStyle(StyleFormal)}, "Alice", "Good day, Alice."},
+		{"casual english", []Option{WithStyle(StyleCasual)}, "Alice", "Hey, Alice!"},
+		{"spanish", []Option{WithLanguage("es")}, "Ana", "¡Hola, Ana!"},
+		{"german formal", []Option{WithLanguage("de"), WithStyle(StyleFormal)}, "Jörg", "Guten Tag, Jörg."},
+		{"region tag is normalized", []Option{WithLanguage("pt-BR")}, "João", "Olá, João!"},
+		{"unsupported language falls back", []Option{WithLanguage("tlh")}, "Worf", "Hello, Worf!"},
+		{"unknown style falls back", []Option{WithLanguage("fr"), WithStyle("grumpy")}, "Zoé", "Bonjour, Zoé!"},
+		{"default name", []Option{WithLanguage("it")}, "", "Ciao, Gopher!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewService(tt.opts...).Greet(tt.input); got != tt.want {
+				t.Errorf("Greet(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"en", "en", false},
+		{"DE", "de", false},
+		{"pt_BR.UTF-8", "pt", false},
+		{" es-MX ", "es", false},
+		{"xx", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLanguage(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLanguage(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLanguage(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	for _, style := range Styles() {
+		if got, err := ParseStyle(string(style)); err != nil || got != style {
+			t.Errorf("ParseStyle(%q) = %q, %v", style, got, err)
+		}
+	}
+	if got, err := ParseStyle(" Formal "); err != nil || got != StyleFormal {
+		t.Errorf("ParseStyle is not case/space insensitive: %q, %v", got, err)
+	}
+	if _, err := ParseStyle("grumpy"); err == nil {
+		t.Error("ParseStyle(grumpy) expected error")
+	}
+}
+
+func TestService_GreetIn(t *testing.T) {
+	svc := NewService(WithLanguage("es"), WithStyle(StyleCasual), WithTitle("Dr."))
+
+	tests := []struct {
+		lang  string
+		style Style
+		want  string
+	}{
+		{"de", StyleFormal, "Guten Tag, Dr. Alice."},
+		{"fr-CA", StyleCasual, "Salut, Dr Alice!"},
+		{"xx", "grumpy", "Hello, Dr. Alice!"},
+	}
+	for _, tt := range tests {
+		if got := svc.GreetIn("Alice", tt.lang, tt.style); got != tt.want {
+			t.Errorf("GreetIn(Alice, %q, %q) = %q, want %q", tt.lang, tt.style, got, tt.want)
+		}
+	}
+	if got, want := svc.Greet("Alice"), "¡Qué tal, Dr. Alice!"; got != want {
+		t.Errorf("Greet(Alice) = %q after GreetIn, want %q", got, want)
+	}
+}
+
+func TestService_LanguageAndStyle(t *testing.T) {
+	svc := NewService(WithLanguage("pt-BR"), WithStyle(StyleFormal))
+	if svc.Language() != "pt" || svc.Style() != StyleFormal {
+		t.Errorf("Language(), Style() = %q, %q; want pt, formal", svc.Language(), svc.Style())
+	}
+	svc = NewService(WithLanguage("xx"), WithStyle("grumpy"))
+	if svc.Language() != DefaultLanguage || svc.Style() != DefaultStyle {
+		t.Errorf("Language(), Style() = %q, %q; want the defaults", svc.Language(), svc.Style())
+	}
+}
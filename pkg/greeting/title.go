@@ -0,0 +1,113 @@
+package greeting
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTitleLen is the longest title, in characters, accepted by ParseTitle
+const maxTitleLen = 20
+
+// localizedTitles maps the well-known English titles, keyed without their
+// trailing dot, to their form in each supported language
+var localizedTitles = map[string]map[string]string{
+	"dr":   {"en": "Dr.", "es": "Dr.", "fr": "Dr", "de": "Dr.", "pt": "Dr.", "it": "Dr."},
+	"prof": {"en": "Prof.", "es": "Prof.", "fr": "Pr", "de": "Prof.", "pt": "Prof.", "it": "Prof."},
+	"mx":   {"en": "Mx.", "es": "Mx.", "fr": "Mx", "de": "Mx.", "pt": "Mx.", "it": "Mx."},
+	"mr":   {"en": "Mr.", "es": "Sr.", "fr": "M.", "de": "Herr", "pt": "Sr.", "it": "Sig."},
+	"mrs":  {"en": "Mrs.", "es": "Sra.", "fr": "Mme", "de": "Frau", "pt": "Sra.", "it": "Sig.ra"},
+	"ms":   {"en": "Ms.", "es": "Sra.", "fr": "Mme", "de": "Frau", "pt": "Sra.", "it": "Sig.ra"},
+}
+
+// genderedTitles lists honorifics that imply a gender, keyed like
+// localizedTitles, in every supported language
+var genderedTitles = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "miss": true, "sir": true, "madam": true,
+	"sr": true, "sra": true, "srta": true, "señor": true, "señora": true, "señorita": true, "don": true, "doña": true,
+	"m": true, "mme": true, "mlle": true, "monsieur": true, "madame": true, "mademoiselle": true,
+	"herr": true, "frau": true,
+	"senhor": true, "senhora": true, "dona": true,
+	"sig": true, "sig.ra": true, "signor": true, "signora": true, "signorina": true,
+}
+
+// titleKey normalizes a title for the lookup tables
+func titleKey(title string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(title)), ".")
+}
+
+// ParseTitle validates an honorific such as "Dr." or "Prof." and returns it
+// trimmed. Titles may contain letters, dots, hyphens, apostrophes and spaces
+// and are at most 20 characters long. An empty title is valid and means no
+// title.
+func ParseTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(title) > maxTitleLen {
+		return "", fmt.Errorf("title %q is longer than %d characters", title, maxTitleLen)
+	}
+
+	hasLetter := false
+	for _, r := range title {
+		switch {
+		case unicode.IsLetter(r) || unicode.Is(unicode.Mn, r):
+			hasLetter = true
+		case r == '.' || r == '-' || r == '\'' || r == ' ':
+		default:
+			return "", fmt.Errorf("title %q contains %q (allowed: letters, dots, hyphens, apostrophes and spaces)", title, r)
+		}
+	}
+	if !hasLetter {
+		return "", fmt.Errorf("title %q contains no letters", title)
+	}
+	return title, nil
+}
+
+// IsGenderedTitle reports whether title, such as "Mr." or "Frau", implies a
+// gender
+func IsGenderedTitle(title string) bool {
+	return genderedTitles[titleKey(title)]
+}
+
+// WithTitle puts an honorific such as "Dr." before every greeted name. The
+// well-known titles Dr., Prof., Mx., Mr., Mrs. and Ms. are translated to the
+// greeting language; other titles are used as given. Use ParseTitle to
+// validate titles from untrusted input.
+func WithTitle(title string) Option {
+	return func(s *Service) {
+		s.title = strings.TrimSpace(title)
+	}
+}
+
+// WithNeutral keeps greetings free of gendered phrasing: titles implying a
+// gender, such as "Mrs." or "Herr", are left out.
+func WithNeutral() Option {
+	return func(s *Service) {
+		s.neutral = true
+	}
+}
+
+// titled returns name preceded by the service's title in lang
+func (s *Service) titled(name, lang string) string {
+	title := s.title
+	if !s.rawNames {
+		title = SanitizeName(title)
+	}
+	if title == "" {
+		return name
+	}
+
+	if byLang, ok := localizedTitles[titleKey(title)]; ok {
+		if _, ok := byLang[lang]; !ok {
+			lang = DefaultLanguage
+		}
+		title = byLang[lang]
+	}
+	if s.neutral && IsGenderedTitle(title) {
+		return name
+	}
+	return title + " " + name
+}
@@ -0,0 +1,82 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTitle(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"Dr.", "Dr.", false},
+		{"  Prof. ", "Prof.", false},
+		{"Sig.ra", "Sig.ra", false},
+		{"Señora", "Señora", false},
+		{"Lt.-Col.", "Lt.-Col.", false},
+		{"", "", false},
+		{"Dr.\x1b[31m", "", true},
+		{"Dr. #1", "", true},
+		{"...", "", true},
+		{strings.Repeat("x", maxTitleLen+1), "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTitle(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTitle(%q) = %q, %v; want %q, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestIsGenderedTitle(t *testing.T) {
+	for _, title := range []string{"Mr.", "mrs", "Ms", "Herr", "Frau", "Sra.", "Mme", "Sig.ra", "Sir"} {
+		if !IsGenderedTitle(title) {
+			t.Errorf("IsGenderedTitle(%q) = false, want true", title)
+		}
+	}
+	for _, title := range []string{"Dr.", "Prof.", "Mx.", "Capt.", ""} {
+		if IsGenderedTitle(title) {
+			t.Errorf("IsGenderedTitle(%q) = true, want false", title)
+		}
+	}
+}
+
+func TestWithTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"no title", nil, "Hello, Alice!"},
+		{"title", []Option{WithTitle("Dr.")}, "Hello, Dr. Alice!"},
+		{"unknown title", []Option{WithTitle("Capt.")}, "Hello, Capt. Alice!"},
+		{"localized", []Option{WithTitle("dr"), WithLanguage("fr")}, "Bonjour, Dr Alice!"},
+		{"localized gendered", []Option{WithTitle("Mr."), WithLanguage("de"), WithStyle(StyleFormal)}, "Guten Tag, Herr Alice."},
+		{"unsupported language", []Option{WithTitle("Prof."), WithLanguage("xx")}, "Hello, Prof. Alice!"},
+		{"neutral keeps neutral titles", []Option{WithTitle("Dr."), WithNeutral()}, "Hello, Dr. Alice!"},
+		{"neutral drops gendered titles", []Option{WithTitle("Mrs."), WithNeutral()}, "Hello, Alice!"},
+		{"neutral drops localized gendered titles", []Option{WithTitle("Ms."), WithLanguage("it"), WithNeutral()}, "Ciao, Alice!"},
+		{"sanitized", []Option{WithTitle("Dr.\x1b[31m")}, "Hello, Dr. Alice!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewService(tt.opts...).Greet("Alice"); got != tt.want {
+				t.Errorf("Greet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTitleAppliesToEveryTemplate(t *testing.T) {
+	s := NewService(WithTitle("Dr."), WithRandomPhrase(), WithRand(fixedRand(3)))
+	if got := s.Greet("Alice"); got != "Howdy, Dr. Alice!" {
+		t.Errorf("Greet() = %q, want %q", got, "Howdy, Dr. Alice!")
+	}
+	if got := s.Greet(""); got != "Howdy, Dr. Gopher!" {
+		t.Errorf("Greet(\"\") = %q, want %q", got, "Howdy, Dr. Gopher!")
+	}
+}
@@ -0,0 +1,99 @@
+package greeting
+
+import (
+	_ "embed"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed translations.txt
+var translationData string
+
+// translations maps proverb texts to their embedded translations by
+// language, parsed on first use
+var translations = sync.OnceValue(func() map[string]map[string]string {
+	return parseTranslations(translationData)
+})
+
+// parseTranslations parses translation data made of blank-line separated
+// entries: the proverb text on the first line, then one "<language>:
+// <translation>" line per language. Comments and malformed lines are
+// ignored.
+func parseTranslations(data string) map[string]map[string]string {
+	m := make(map[string]map[string]string)
+	key := ""
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "#"):
+		case line == "":
+			key = ""
+		case key == "":
+			key = line
+		default:
+			lang, text, ok := strings.Cut(line, ":")
+			lang, text = strings.ToLower(strings.TrimSpace(lang)), strings.TrimSpace(text)
+			if !ok || lang == "" || text == "" {
+				continue
+			}
+			if m[key] == nil {
+				m[key] = make(map[string]string)
+			}
+			m[key][lang] = text
+		}
+	}
+	return m
+}
+
+// Translate returns p with its text translated into lang, a language tag
+// such as "fr" or "pt_BR", and whether there is a translation. The proverb's
+// own translations are preferred over the embedded ones; a translation into
+// a regional variant such as "pt-br" serves the base language as well.
+// Proverbs are in English, so lang "en" and proverbs without a translation
+// are returned unchanged.
+func Translate(p Proverb, lang string) (Proverb, bool) {
+	if NormalizeLanguage(lang) == DefaultLanguage {
+		return p, false
+	}
+	for _, m := range []map[string]string{p.Translations, translations()[p.Text]} {
+		if text, ok := lookupTranslation(m, lang); ok {
+			p.Text = text
+			return p, true
+		}
+	}
+	return p, false
+}
+
+// lookupTranslation returns the translation of m for the language tag lang:
+// the one for the tag itself, then the one for its base language, then the
+// first of a regional variant of the base language
+func lookupTranslation(m map[string]string, lang string) (string, bool) {
+	if len(m) == 0 {
+		return "", false
+	}
+	tag, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(lang)), ".")
+	tag = strings.ReplaceAll(tag, "_", "-")
+	base := NormalizeLanguage(lang)
+	if base == "" {
+		return "", false
+	}
+	if text, ok := m[tag]; ok {
+		return text, true
+	}
+	if text, ok := m[base]; ok {
+		return text, true
+	}
+
+	variants := make([]string, 0, len(m))
+	for l := range m {
+		if NormalizeLanguage(l) == base {
+			variants = append(variants, l)
+		}
+	}
+	if len(variants) == 0 {
+		return "", false
+	}
+	sort.Strings(variants)
+	return m[variants[0]], true
+}
@@ -0,0 +1,60 @@
+package greeting
+
+import "testing"
+
+func TestTranslate(t *testing.T) {
+	p := Proverb{ID: 1, Text: "Errors are values."}
+	tests := []struct {
+		lang string
+		want string
+		ok   bool
+	}{
+		{"fr", "Les erreurs sont des valeurs.", true},
+		{"de_AT.UTF-8", "Fehler sind Werte.", true},
+		{"en", "Errors are values.", false},
+		{"ja", "Errors are values.", false},
+		{"", "Errors are values.", false},
+	}
+	for _, tt := range tests {
+		got, ok := Translate(p, tt.lang)
+		if got.Text != tt.want || ok != tt.ok || got.ID != 1 {
+			t.Errorf("Translate(%q) = %+v, %v; want %q, %v", tt.lang, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	own := Proverb{Text: "Errors are values.", Translations: map[string]string{"fr": "Les erreurs, ce sont des valeurs.", "pt-br": "Erros são valores!"}}
+	if got, _ := Translate(own, "fr"); got.Text != "Les erreurs, ce sont des valeurs." {
+		t.Errorf("Translate() preferred the embedded translation: %q", got.Text)
+	}
+	if got, _ := Translate(own, "pt"); got.Text != "Erros são valores!" {
+		t.Errorf("Translate() to pt = %q, want the pt-br translation", got.Text)
+	}
+	if got, _ := Translate(own, "pt_PT"); got.Text != "Erros são valores!" {
+		t.Errorf("Translate() to pt_PT = %q, want the pt-br translation", got.Text)
+	}
+}
+
+func TestTranslationsAreKnown(t *testing.T) {
+	proverbs, err := NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool, len(proverbs))
+	for _, p := range proverbs {
+		known[p.Text] = true
+	}
+
+	if len(translations()) == 0 {
+		t.Fatal("no embedded translations")
+	}
+	for text, byLang := range translations() {
+		if !known[text] {
+			t.Errorf("translation for unknown proverb %q", text)
+		}
+		for lang := range byLang {
+			if _, err := ParseLanguage(lang); err != nil {
+				t.Errorf("translation of %q into %v", text, err)
+			}
+		}
+	}
+}
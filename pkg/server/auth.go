@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// ReloadResponse is the body returned by POST /admin/reload
+type ReloadResponse struct {
+	Proverbs int `json:"proverbs"`
+}
+
+// LoadTokens reads API tokens from a file with one token per line. Blank
+// lines and lines starting with '#' are ignored.
+func LoadTokens(path string) ([]string, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is chosen by the operator
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+	return tokens, scanner.Err()
+}
+
+// tokenSet holds the SHA-256 digests of the accepted API tokens. Comparing
+// fixed-length digests in constant time hides both the tokens' contents and
+// their lengths from timing attacks.
+type tokenSet [][sha256.Size]byte
+
+// newTokenSet hashes the non-empty tokens
+func newTokenSet(tokens []string) tokenSet {
+	var set tokenSet
+	for _, t := range tokens {
+		if t = strings.TrimSpace(t); t != "" {
+			set = append(set, sha256.Sum256([]byte(t)))
+		}
+	}
+	return set
+}
+
+// valid reports whether token is one of the accepted tokens. Every token is
+// compared so the time taken does not depend on which one matched.
+func (s tokenSet) valid(token string) bool {
+	digest := sha256.Sum256([]byte(token))
+	match := 0
+	for _, d := range s {
+		match |= subtle.ConstantTimeCompare(digest[:], d[:])
+	}
+	return match == 1
+}
+
+// requireToken rejects requests without a valid "Authorization: Bearer"
+// header with 401 Unauthorized
+func (s tokenSet) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || !s.valid(strings.TrimSpace(token)) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="hello-gopher"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid API token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminRoutes registers the administrative endpoints for svc
+func adminRoutes(svc *greeting.Service) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/reload", handleReload(svc))
+	return mux
+}
+
+// handleReload reloads the proverb collection
+func handleReload(svc *greeting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := svc.Reload(); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to reload proverbs")
+			return
+		}
+		proverbs, err := svc.Proverbs()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to load proverbs")
+			return
+		}
+		writeJSON(w, http.StatusOK, ReloadResponse{Proverbs: len(proverbs)})
+	}
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestLoadTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	content := "# deploy tokens\nalpha\n\n  beta  \n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := LoadTokens(path)
+	if err != nil {
+		t.Fatalf("LoadTokens() unexpected error: %v", err)
+	}
+	if want := []string{"alpha", "beta"}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("LoadTokens() = %q, want %q", tokens, want)
+	}
+
+	if _, err := LoadTokens(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadTokens() expected error for a missing file")
+	}
+}
+
+func TestTokenSet(t *testing.T) {
+	set := newTokenSet([]string{"alpha", "", "beta"})
+	if len(set) != 2 {
+		t.Errorf("empty tokens should be ignored, got %d tokens", len(set))
+	}
+
+	for token, want := range map[string]bool{"alpha": true, "beta": true, "alph": false, "": false, "alphabet": false} {
+		if got := set.valid(token); got != want {
+			t.Errorf("valid(%q) = %v, want %v", token, got, want)
+		}
+	}
+}
+
+func TestAdminEndpointsRequireToken(t *testing.T) {
+	h := New(nil, nil, WithAuthTokens("s3cret")).Handler()
+
+	request := func(method, path, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		want   int
+	}{
+		{"no token", http.MethodPost, "/admin/reload", "", http.StatusUnauthorized},
+		{"wrong token", http.MethodPost, "/admin/reload", "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", http.MethodPost, "/admin/reload", "Basic s3cret", http.StatusUnauthorized},
+		{"valid token", http.MethodPost, "/admin/reload", "Bearer s3cret", http.StatusOK},
+		{"scheme is case-insensitive", http.MethodPost, "/admin/reload", "bearer s3cret", http.StatusOK},
+		{"wrong method", http.MethodGet, "/admin/reload", "Bearer s3cret", http.StatusMethodNotAllowed},
+		{"read endpoints stay public", http.MethodGet, "/greet", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(tt.method, tt.path, tt.auth)
+			if rec.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+			}
+			if rec.Code == http.StatusUnauthorized && !strings.HasPrefix(rec.Header().Get("WWW-Authenticate"), "Bearer") {
+				t.Error("401 responses should carry a WWW-Authenticate challenge")
+			}
+		})
+	}
+
+	if rec := request(http.MethodPost, "/admin/reload", "Bearer s3cret"); !strings.Contains(rec.Body.String(), `"proverbs":`) {
+		t.Errorf("reload response = %q", rec.Body.String())
+	}
+}
+
+func TestAdminEndpointsDisabledWithoutTokens(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(nil, nil).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reload", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("POST /admin/reload without tokens = %d, want 404", rec.Code)
+	}
+}
+
+func TestReloadReadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proverbs.txt")
+	if err := os.WriteFile(path, []byte("Don't panic.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	svc := greeting.NewService()
+	if err := svc.LoadProverbsFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	h := New(svc, nil, WithAuthTokens("s3cret")).Handler()
+
+	if err := os.WriteFile(path, []byte("Don't panic.\nErrors are values.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"proverbs":2`) {
+		t.Errorf("POST /admin/reload = %d %s, want the 2 proverbs of the changed file", rec.Code, rec.Body.String())
+	}
+}
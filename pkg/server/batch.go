@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// DefaultMaxBatchSize is the number of names POST /greet:batch accepts unless
+// WithMaxBatchSize sets another limit
+const DefaultMaxBatchSize = 100
+
+// maxBatchNameSize bounds the body of POST /greet:batch per accepted name
+const maxBatchNameSize = 2 << 10
+
+// CodeBatchTooLarge rejects POST /greet:batch requests with more names than
+// the server accepts
+const CodeBatchTooLarge = "batch_too_large"
+
+// GreetBatchRequest is the body accepted by POST /greet:batch. Every name is
+// greeted in the same language and style, which default to the server's.
+type GreetBatchRequest struct {
+	Names []string `json:"names"`
+	Lang  string   `json:"lang,omitempty"`
+	Style string   `json:"style,omitempty"`
+}
+
+// GreetBatchResult is the result for the name at Index of a
+// GreetBatchRequest: a greeting, or an error and its code if the name was
+// rejected
+type GreetBatchResult struct {
+	Index    int    `json:"index"`
+	Greeting string `json:"greeting,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+// WithMaxBatchSize limits POST /greet:batch to n names instead of
+// DefaultMaxBatchSize. Larger batches are rejected with 400 Bad Request. An
+// n of 0 or less keeps DefaultMaxBatchSize.
+func WithMaxBatchSize(n int) Option {
+	return func(s *Server) {
+		if n <= 0 {
+			n = DefaultMaxBatchSize
+		}
+		s.maxBatchSize = n
+	}
+}
+
+// handleGreetBatch greets every name of a GreetBatchRequest body, at most
+// maxSize, and answers with a JSON array of GreetBatchResult in the order of
+// the names. A rejected name fails only its own result; a bad language or
+// style fails the batch. With "stream=true" the results are streamed as
+// newline-delimited JSON, flushed one by one, so clients can process large
+// batches as they arrive.
+func handleGreetBatch(svc *greeting.Service, maxSize int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GreetBatchRequest
+		if err := decodeJSON(w, r, &req, int64(maxSize)*maxBatchNameSize); err != nil {
+			writeRequestError(w, err)
+			return
+		}
+		if len(req.Names) > maxSize {
+			writeRequestError(w, badRequest(CodeBatchTooLarge, "batch of %d names exceeds the limit of %d", len(req.Names), maxSize))
+			return
+		}
+		lang, style, err := greetingOptions(svc, req.Lang, req.Style)
+		if err != nil {
+			writeRequestError(w, err)
+			return
+		}
+
+		stream, _ := strconv.ParseBool(r.URL.Query().Get("stream"))
+		if !stream {
+			results := make([]GreetBatchResult, 0, len(req.Names))
+			for i, name := range req.Names {
+				results = append(results, greetBatchItem(r, svc, i, name, lang, style))
+			}
+			writeJSON(w, http.StatusOK, results)
+			return
+		}
+
+		naming := requestNaming(r.Context())
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		for i, name := range req.Names {
+			line, _ := json.Marshal(greetBatchItem(r, svc, i, name, lang, style))
+			if _, err := w.Write(append(renameEvent(line, naming), '\n')); err != nil {
+				// The client went away
+				return
+			}
+			_ = rc.Flush()
+		}
+	}
+}
+
+// greetBatchItem greets the name at index i of a batch
+func greetBatchItem(r *http.Request, svc *greeting.Service, i int, name, lang string, style greeting.Style) GreetBatchResult {
+	name, err := greetingName(svc, name)
+	if err != nil {
+		e := err.(*requestError)
+		return GreetBatchResult{Index: i, Error: e.message, Code: e.code}
+	}
+	_, span := startSpan(r.Context(), "greeting.render")
+	defer span.End()
+	return GreetBatchResult{Index: i, Greeting: svc.GreetIn(name, lang, style), Name: name}
+}
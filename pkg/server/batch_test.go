@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// postBatch posts body to POST /greet:batch with the query
+func postBatch(t *testing.T, url, query, body string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url+"/greet:batch"+query, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestGreetBatchEndpoint(t *testing.T) {
+	svc := greeting.NewService(greeting.WithMaxNameLength(8))
+	ts := httptest.NewServer(New(svc, nil, WithMaxBatchSize(3)).Handler())
+	t.Cleanup(ts.Close)
+
+	resp := postBatch(t, ts.URL, "", `{"names": ["Zoë", "Alexandra", ""], "lang": "fr"}`)
+	var got []GreetBatchResult
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /greet:batch = %d, %v", resp.StatusCode, err)
+	}
+	want := []GreetBatchResult{
+		{Index: 0, Greeting: "Bonjour, Zoë!", Name: "Zoë"},
+		{Index: 1, Error: "name exceeds 8 characters", Code: CodeNameTooLong},
+		{Index: 2, Greeting: "Bonjour, Gopher!", Name: "Gopher"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("POST /greet:batch returned %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	tests := []struct {
+		body string
+		code string
+	}{
+		{`{"names": ["a", "b", "c", "d"]}`, CodeBatchTooLarge},
+		{`{"names": ["a"], "style": "grumpy"}`, CodeUnsupportedStyle},
+		{`{"names": "a"}`, CodeInvalidJSON},
+	}
+	for _, tt := range tests {
+		resp := postBatch(t, ts.URL, "", tt.body)
+		var e ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || resp.StatusCode != http.StatusBadRequest || e.Code != tt.code {
+			t.Errorf("POST /greet:batch %s = %d %+v, want 400 with code %s", tt.body, resp.StatusCode, e, tt.code)
+		}
+	}
+}
+
+func TestGreetBatchDefaultLimit(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	names, _ := json.Marshal(map[string][]string{"names": make([]string, DefaultMaxBatchSize+1)})
+	if resp := postBatch(t, ts.URL, "", string(names)); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("POST /greet:batch with %d names status = %d, want 400", DefaultMaxBatchSize+1, resp.StatusCode)
+	}
+	empty := postBatch(t, ts.URL, "", `{"names": []}`)
+	var got []GreetBatchResult
+	if err := json.NewDecoder(empty.Body).Decode(&got); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("POST /greet:batch without names = %v, %v; want []", got, err)
+	}
+}
+
+func TestWithMaxBatchSizeNotPositive(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		ts := httptest.NewServer(New(nil, nil, WithMaxBatchSize(n)).Handler())
+		t.Cleanup(ts.Close)
+
+		if resp := postBatch(t, ts.URL, "", `{"names": ["Alice"]}`); resp.StatusCode != http.StatusOK {
+			t.Errorf("POST /greet:batch with WithMaxBatchSize(%d) = %d, want 200", n, resp.StatusCode)
+		}
+		names, _ := json.Marshal(map[string][]string{"names": make([]string, DefaultMaxBatchSize+1)})
+		if resp := postBatch(t, ts.URL, "", string(names)); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("WithMaxBatchSize(%d) accepted more than %d names", n, DefaultMaxBatchSize)
+		}
+	}
+}
+
+func TestGreetBatchStream(t *testing.T) {
+	ts := newStyledServer(t, StylePlain, NamingCamelCase)
+
+	resp := postBatch(t, ts.URL, "?stream=true", `{"names": ["Alice", "Bob"], "lang": "de", "style": "casual"}`)
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+	var lines []string
+	for sc := bufio.NewScanner(resp.Body); sc.Scan(); {
+		lines = append(lines, sc.Text())
+	}
+	want := []string{
+		`{"index":0,"greeting":"Servus, Alice!","name":"Alice"}`,
+		`{"index":1,"greeting":"Servus, Bob!","name":"Bob"}`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("streamed results = %q, want %q", lines, want)
+	}
+}
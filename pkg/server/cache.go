@@ -0,0 +1,60 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// revalidate lets clients and proxies store a response but makes them check
+// it with the ETag before every use
+const revalidate = "public, no-cache"
+
+// writeCachedJSON writes v like writeJSON with an ETag derived from the body
+// and the given Cache-Control header. Requests whose If-None-Match lists the
+// ETag get 304 Not Modified without a body.
+func writeCachedJSON(w http.ResponseWriter, r *http.Request, v interface{}, cacheControl string) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode the response")
+		return
+	}
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. As RFC 9110
+// requires for If-None-Match, weak tags match their strong counterparts.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// untilTomorrow returns the cache lifetime of the proverb of the day at now:
+// the time left until midnight in now's location, in whole seconds
+func untilTomorrow(now time.Time) int {
+	y, m, d := now.Date()
+	midnight := time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+	return int(midnight.Sub(now) / time.Second)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting/greetingtest"
+)
+
+func TestProverbsETag(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	resp := getJSON(t, ts.URL+"/proverbs", nil)
+	etag := resp.Header.Get("ETag")
+	if len(etag) < 3 || etag[0] != '"' {
+		t.Fatalf("GET /proverbs ETag = %q, want a quoted tag", etag)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != revalidate {
+		t.Errorf("GET /proverbs Cache-Control = %q, want %q", cc, revalidate)
+	}
+	if again := getJSON(t, ts.URL+"/proverbs", nil).Header.Get("ETag"); again != etag {
+		t.Errorf("ETag changed between requests: %q, then %q", etag, again)
+	}
+
+	tests := []struct {
+		ifNoneMatch string
+		want        int
+	}{
+		{etag, http.StatusNotModified},
+		{"W/" + etag, http.StatusNotModified},
+		{`"other", ` + etag, http.StatusNotModified},
+		{"*", http.StatusNotModified},
+		{`"other"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/proverbs", nil)
+		req.Header.Set("If-None-Match", tt.ifNoneMatch)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("If-None-Match %s: status = %d, want %d", tt.ifNoneMatch, resp.StatusCode, tt.want)
+		}
+		if resp.Header.Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: ETag = %q, want %q", tt.ifNoneMatch, resp.Header.Get("ETag"), etag)
+		}
+	}
+}
+
+func TestDailyProverbCaching(t *testing.T) {
+	svc := greeting.NewService()
+	now := time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)
+	h := handleProverb(svc, func() time.Time { return now })
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/proverb?daily=true", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /proverb?daily=true status = %d", rec.Code)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=21600" {
+		t.Errorf("Cache-Control = %q, want the 6 hours until midnight", cc)
+	}
+	if expires := rec.Header().Get("Expires"); expires != "Sun, 18 Oct 2026 00:00:00 GMT" {
+		t.Errorf("Expires = %q, want midnight", expires)
+	}
+	want, _ := svc.DailyProverb(now)
+	var got greeting.Proverb
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.ID != want.ID {
+		t.Errorf("body = %s, want the proverb of the day %q", rec.Body, want.Text)
+	}
+
+	etag := rec.Header().Get("ETag")
+	req := httptest.NewRequest(http.MethodGet, "/proverb?daily=true", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("conditional GET = %d with %d bytes, want 304 without a body", rec.Code, rec.Body.Len())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/proverb", nil))
+	if cc := rec.Header().Get("Cache-Control"); cc != "no-store" {
+		t.Errorf("random proverb Cache-Control = %q, want no-store", cc)
+	}
+}
+
+func TestDailyProverbLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// 20:00 UTC is already 05:00 the next day in Tokyo
+	now := time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)
+	svc := greeting.NewService(greeting.WithClock(greetingtest.NewFakeClock(now)), greeting.WithLocation(tokyo))
+
+	rec := httptest.NewRecorder()
+	NewHandler(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/proverb?daily=true", nil))
+	want, _ := svc.DailyProverb(now.In(tokyo))
+	var got greeting.Proverb
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got.ID != want.ID {
+		t.Errorf("body = %s, want the proverb of the day in Tokyo %q", rec.Body, want.Text)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=68400" {
+		t.Errorf("Cache-Control = %q, want the 19 hours until midnight in Tokyo", cc)
+	}
+}
+
+func TestUntilTomorrow(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), 24 * 60 * 60},
+		{time.Date(2026, 10, 17, 23, 59, 30, 0, time.UTC), 30},
+		{time.Date(2026, 12, 31, 12, 0, 0, 0, berlin), 12 * 60 * 60},
+	}
+	for _, tt := range tests {
+		if got := untilTomorrow(tt.now); got != tt.want {
+			t.Errorf("untilTomorrow(%v) = %d, want %d", tt.now, got, tt.want)
+		}
+	}
+}
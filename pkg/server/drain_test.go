@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestReadinessEndpoint(t *testing.T) {
+	srv := New(greeting.NewService(), nil, WithRateLimit(Rate{Requests: 1, Per: time.Hour}))
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	for i := 0; i < 3; i++ {
+		var ready HealthResponse
+		if resp := getJSON(t, ts.URL+"/readyz", &ready); resp.StatusCode != http.StatusOK || ready.Status != "ready" {
+			t.Fatalf("GET /readyz = %d %+v, want 200 ready", resp.StatusCode, ready)
+		}
+	}
+
+	srv.draining.Store(true)
+	var ready, health HealthResponse
+	if resp := getJSON(t, ts.URL+"/readyz", &ready); resp.StatusCode != http.StatusServiceUnavailable || ready.Status != "draining" {
+		t.Errorf("GET /readyz while draining = %d %+v, want 503 draining", resp.StatusCode, ready)
+	}
+	for _, path := range []string{"/livez", "/healthz"} {
+		if resp := getJSON(t, ts.URL+path, &health); resp.StatusCode != http.StatusOK || health.Status != "ok" {
+			t.Errorf("GET %s while draining = %d %+v, want 200 ok", path, resp.StatusCode, health)
+		}
+	}
+}
+
+func TestReadinessDelay(t *testing.T) {
+	srv := New(greeting.NewService(), nil, WithReadinessDelay(300*time.Millisecond))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := "http://" + ln.Addr().String()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	var ready, live HealthResponse
+	if resp := getJSON(t, addr+"/readyz", &ready); resp.StatusCode != http.StatusServiceUnavailable || ready.Status != "starting" {
+		t.Errorf("GET /readyz right after start = %d %+v, want 503 starting", resp.StatusCode, ready)
+	}
+	if resp := getJSON(t, addr+"/livez", &live); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /livez during the readiness delay = %d, want 200", resp.StatusCode)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp := getJSON(t, addr+"/readyz", &ready)
+		if resp.StatusCode == http.StatusOK && ready.Status == "ready" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GET /readyz = %d %+v long after the readiness delay", resp.StatusCode, ready)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestReadinessReflectsData(t *testing.T) {
+	var everything greeting.Exclusion
+	for id := 1; id <= 1000; id++ {
+		everything.IDs = append(everything.IDs, id)
+	}
+	ts := httptest.NewServer(New(greeting.NewService(greeting.WithExclusion(everything)), nil).Handler())
+	t.Cleanup(ts.Close)
+
+	var ready, live HealthResponse
+	if resp := getJSON(t, ts.URL+"/readyz", &ready); resp.StatusCode != http.StatusServiceUnavailable || ready.Status != "unavailable" {
+		t.Errorf("GET /readyz without proverbs = %d %+v, want 503 unavailable", resp.StatusCode, ready)
+	}
+	if resp := getJSON(t, ts.URL+"/livez", &live); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /livez without proverbs = %d, want 200", resp.StatusCode)
+	}
+}
+
+// slowHandler wraps the API with GET /slow, which answers once release is
+// closed and signals started when it begins
+func slowHandler(h http.Handler, started chan<- struct{}, release <-chan struct{}) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", h)
+	mux.HandleFunc("GET /slow", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte("done"))
+	})
+	return mux
+}
+
+// serveDraining serves srv with a slow endpoint and starts a request to it.
+// It returns the server address, the cancel function, the result of serve
+// and the result of the slow request.
+func serveDraining(t *testing.T, srv *Server, release <-chan struct{}) (string, context.CancelFunc, <-chan error, <-chan error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := "http://" + ln.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	started := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.serve(ctx, ln, slowHandler(srv.Handler(), started, release))
+	}()
+
+	slow := make(chan error, 1)
+	go func() {
+		resp, err := http.Get(addr + "/slow")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = &statusError{resp.StatusCode}
+			}
+		}
+		slow <- err
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow request never started")
+	}
+	return addr, cancel, done, slow
+}
+
+type statusError struct{ code int }
+
+func (e *statusError) Error() string { return http.StatusText(e.code) }
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	release := make(chan struct{})
+	srv := New(greeting.NewService(), nil, WithDrainDelay(300*time.Millisecond), WithDrainTimeout(5*time.Second))
+	addr, cancel, done, slow := serveDraining(t, srv, release)
+
+	cancel()
+
+	// During the drain delay new requests are still answered, but the
+	// readiness check fails
+	deadline := time.Now().Add(time.Second)
+	for {
+		var ready HealthResponse
+		resp := getJSON(t, addr+"/readyz", &ready)
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GET /readyz = %d after shutdown started, want 503", resp.StatusCode)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if resp := getJSON(t, addr+"/proverb", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /proverb during the drain delay = %d, want 200", resp.StatusCode)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Serve() returned %v before the in-flight request finished", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Errorf("in-flight request failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Serve() = %v, want nil", err)
+	}
+	if _, err := http.Get(addr + "/healthz"); err == nil {
+		t.Error("server still accepts connections after draining")
+	}
+}
+
+func TestServeDrainTimeoutCutsOffRequests(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	srv := New(greeting.NewService(), nil, WithDrainTimeout(50*time.Millisecond))
+	_, cancel, done, slow := serveDraining(t, srv, release)
+
+	start := time.Now()
+	cancel()
+	err := <-done
+	if err == nil || !strings.Contains(err.Error(), "drain timeout of 50ms") {
+		t.Errorf("Serve() = %v, want a drain timeout error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Serve() took %s to give up on the in-flight request", elapsed)
+	}
+	if err := <-slow; err == nil {
+		t.Error("in-flight request succeeded after being cut off")
+	}
+}
@@ -0,0 +1,24 @@
+package server_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/server"
+)
+
+// Mount the greeting API under /gopher/ on an existing mux
+func ExampleNewHandler() {
+	mux := http.NewServeMux()
+	mux.Handle("/gopher/", http.StripPrefix("/gopher", server.NewHandler(greeting.NewService())))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gopher/greet?name=Alice", nil))
+
+	body, _ := io.ReadAll(rec.Body)
+	fmt.Print(string(body))
+	// Output: {"greeting":"Hello, Alice!","name":"Alice"}
+}
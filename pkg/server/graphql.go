@@ -0,0 +1,168 @@
+//go:build graphql
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// GraphQLSchema is the schema served at POST /graphql
+const GraphQLSchema = `
+schema {
+	query: Query
+}
+
+type Query {
+	"Proverbs with the tag and containing the text, like proverb list"
+	proverbs(tag: String, search: String, first: Int): [Proverb!]!
+	"The proverb with the ID, or null"
+	proverb(id: Int!): Proverb
+	randomProverb: Proverb!
+	"The proverb of the day in the server's time zone"
+	dailyProverb: Proverb!
+	"Every tag used by the proverbs, sorted"
+	tags: [String!]!
+	"A greeting in the server's language and style; name defaults to Gopher"
+	greet(name: String): Greeting!
+}
+
+type Proverb {
+	id: Int!
+	text: String!
+	tags: [String!]!
+}
+
+type Greeting {
+	greeting: String!
+	name: String!
+}
+`
+
+func init() {
+	graphQLHandler = func(svc *greeting.Service) http.Handler {
+		schema := graphql.MustParseSchema(GraphQLSchema, &graphQLResolver{svc: svc})
+		return &relay.Handler{Schema: schema}
+	}
+}
+
+// graphQLResolver resolves the Query type
+type graphQLResolver struct {
+	svc *greeting.Service
+}
+
+func (r *graphQLResolver) Proverbs(args struct {
+	Tag    *string
+	Search *string
+	First  *int32
+}) ([]*proverbResolver, error) {
+	var f greeting.Filter
+	if args.Tag != nil {
+		f.Tag = *args.Tag
+	}
+	if args.Search != nil {
+		f.Search = *args.Search
+	}
+	proverbs, err := r.svc.FilterProverbs(f)
+	if err != nil {
+		return nil, err
+	}
+	if args.First != nil && *args.First >= 0 && int(*args.First) < len(proverbs) {
+		proverbs = proverbs[:*args.First]
+	}
+
+	resolvers := make([]*proverbResolver, len(proverbs))
+	for i, p := range proverbs {
+		resolvers[i] = &proverbResolver{p}
+	}
+	return resolvers, nil
+}
+
+func (r *graphQLResolver) Proverb(args struct{ ID int32 }) (*proverbResolver, error) {
+	proverbs, err := r.svc.Proverbs()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range proverbs {
+		if p.ID == int(args.ID) {
+			return &proverbResolver{p}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *graphQLResolver) RandomProverb(ctx context.Context) (*proverbResolver, error) {
+	p, err := selectProverb(ctx, r.svc)
+	if err != nil {
+		return nil, err
+	}
+	return &proverbResolver{p}, nil
+}
+
+func (r *graphQLResolver) DailyProverb() (*proverbResolver, error) {
+	p, err := r.svc.TodaysProverb()
+	if err != nil {
+		return nil, err
+	}
+	return &proverbResolver{p}, nil
+}
+
+func (r *graphQLResolver) Tags() ([]string, error) {
+	proverbs, err := r.svc.Proverbs()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	tags := []string{}
+	for _, p := range proverbs {
+		for _, tag := range p.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+func (r *graphQLResolver) Greet(ctx context.Context, args struct{ Name *string }) *greetResolver {
+	var name string
+	if args.Name != nil {
+		name, _ = greeting.TruncateName(*args.Name, r.svc.MaxNameLength())
+	}
+	if name == "" {
+		name = "Gopher"
+	}
+	_, span := startSpan(ctx, "greeting.render")
+	defer span.End()
+	return &greetResolver{GreetResponse{Greeting: r.svc.Greet(name), Name: name}}
+}
+
+// proverbResolver resolves the Proverb type
+type proverbResolver struct {
+	p greeting.Proverb
+}
+
+func (r *proverbResolver) ID() int32    { return int32(r.p.ID) }
+func (r *proverbResolver) Text() string { return r.p.Text }
+
+func (r *proverbResolver) Tags() []string {
+	if r.p.Tags == nil {
+		return []string{}
+	}
+	return r.p.Tags
+}
+
+// greetResolver resolves the Greeting type
+type greetResolver struct {
+	g GreetResponse
+}
+
+func (r *greetResolver) Greeting() string { return r.g.Greeting }
+func (r *greetResolver) Name() string     { return r.g.Name }
@@ -0,0 +1,120 @@
+//go:build graphql
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// graphQLResponse is the response to a GraphQL request
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// postGraphQL runs query against the server and decodes its data into v
+func postGraphQL(t *testing.T, url, query string, variables map[string]interface{}, v interface{}) graphQLResponse {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	resp, err := http.Post(url+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var result graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("invalid GraphQL response: %v", err)
+	}
+	if v != nil && len(result.Data) > 0 {
+		if err := json.Unmarshal(result.Data, v); err != nil {
+			t.Fatalf("invalid GraphQL data %s: %v", result.Data, err)
+		}
+	}
+	return result
+}
+
+func TestGraphQLProverbs(t *testing.T) {
+	ts := newTestServer(t, nil)
+	want, _ := greeting.NewService().FilterProverbs(greeting.Filter{Tag: "concurrency", Search: "share"})
+
+	var data struct {
+		Proverbs []greeting.Proverb `json:"proverbs"`
+		First    []greeting.Proverb `json:"first"`
+		Proverb  *greeting.Proverb  `json:"proverb"`
+		Missing  *greeting.Proverb  `json:"missing"`
+		Tags     []string           `json:"tags"`
+	}
+	resp := postGraphQL(t, ts.URL, `query($tag: String) {
+		proverbs(tag: $tag, search: "share") { id text tags }
+		first: proverbs(first: 2) { id }
+		proverb(id: 1) { id text }
+		missing: proverb(id: 100000) { id }
+		tags
+	}`, map[string]interface{}{"tag": "concurrency"}, &data)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors: %+v", resp.Errors)
+	}
+
+	if len(data.Proverbs) != len(want) || len(want) == 0 || data.Proverbs[0].Text != want[0].Text {
+		t.Errorf("proverbs = %+v, want %+v", data.Proverbs, want)
+	}
+	if len(data.First) != 2 {
+		t.Errorf("proverbs(first: 2) returned %d proverbs", len(data.First))
+	}
+	if data.Proverb == nil || data.Proverb.ID != 1 || data.Proverb.Text == "" {
+		t.Errorf("proverb(id: 1) = %+v", data.Proverb)
+	}
+	if data.Missing != nil {
+		t.Errorf("proverb(id: 100000) = %+v, want null", data.Missing)
+	}
+	if len(data.Tags) == 0 {
+		t.Error("tags is empty")
+	}
+}
+
+func TestGraphQLGreetAndRandom(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	var data struct {
+		Greet        GreetResponse    `json:"greet"`
+		Anonymous    GreetResponse    `json:"anonymous"`
+		Random       greeting.Proverb `json:"randomProverb"`
+		DailyProverb greeting.Proverb `json:"dailyProverb"`
+	}
+	resp := postGraphQL(t, ts.URL, `{
+		greet(name: "Alice") { greeting name }
+		anonymous: greet { greeting name }
+		randomProverb { id text }
+		dailyProverb { id }
+	}`, nil, &data)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors: %+v", resp.Errors)
+	}
+
+	if data.Greet != (GreetResponse{Greeting: "Hello, Alice!", Name: "Alice"}) {
+		t.Errorf("greet = %+v", data.Greet)
+	}
+	if data.Anonymous.Name != "Gopher" {
+		t.Errorf("greet without name = %+v", data.Anonymous)
+	}
+	if data.Random.ID == 0 || data.DailyProverb.ID == 0 {
+		t.Errorf("randomProverb = %+v, dailyProverb = %+v", data.Random, data.DailyProverb)
+	}
+}
+
+func TestGraphQLInvalidQuery(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	resp := postGraphQL(t, ts.URL, `{ proverbs { author } }`, nil, nil)
+	if len(resp.Errors) == 0 {
+		t.Error("expected an error for an unknown field")
+	}
+}
@@ -0,0 +1,345 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// GreetResponse is the body returned by GET /greet and POST /greet. Only
+// POST /greet reports the language and style.
+type GreetResponse struct {
+	Greeting string `json:"greeting"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang,omitempty"`
+	Style    string `json:"style,omitempty"`
+}
+
+// GreetRequest is the body accepted by POST /greet. The name defaults to
+// Gopher, and the language and style to the server's.
+type GreetRequest struct {
+	Name  string `json:"name"`
+	Lang  string `json:"lang,omitempty"`
+	Style string `json:"style,omitempty"`
+}
+
+// maxGreetRequestSize bounds the body of POST /greet
+const maxGreetRequestSize = 4 << 10
+
+// HealthResponse is the body returned by GET /livez, GET /healthz and
+// GET /readyz
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// BadgeResponse is the shields.io endpoint badge returned by GET /badge.
+// See https://shields.io/badges/endpoint-badge for the schema.
+type BadgeResponse struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color,omitempty"`
+	CacheSeconds  int    `json:"cacheSeconds,omitempty"`
+}
+
+// Badge defaults; the color is the Go gopher blue
+const (
+	badgeLabel        = "go proverb"
+	badgeColor        = "00ADD8"
+	badgeCacheSeconds = 300
+)
+
+// ErrorResponse is the body returned when a request fails. Failures clients
+// can fix come with one of the Code constants.
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// Error codes of rejected POST /greet requests
+const (
+	CodeInvalidJSON         = "invalid_json"
+	CodeUnknownField        = "unknown_field"
+	CodeBodyTooLarge        = "body_too_large"
+	CodeNameTooLong         = "name_too_long"
+	CodeUnsupportedLanguage = "unsupported_language"
+	CodeUnsupportedStyle    = "unsupported_style"
+)
+
+// routes registers the API endpoints for svc. The read endpoints may be
+// called from scripts on any site.
+func routes(svc *greeting.Service) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("GET /greet", allowAnyOrigin(handleGreet(svc)))
+	mux.Handle("POST /greet", allowAnyOrigin(handleGreetPost(svc)))
+	mux.Handle("POST /greet:batch", allowAnyOrigin(handleGreetBatch(svc, DefaultMaxBatchSize)))
+	mux.Handle("GET /proverb", allowAnyOrigin(handleProverb(svc, svc.Now)))
+	mux.Handle("GET /proverbs", allowAnyOrigin(handleProverbs(svc)))
+	mux.Handle("GET /stream", allowAnyOrigin(handleStream(svc)))
+	mux.Handle("GET /badge", allowAnyOrigin(handleBadge(svc)))
+	mux.HandleFunc("GET /livez", handleHealth)
+	mux.HandleFunc("GET /healthz", handleHealth)
+	mux.HandleFunc("GET /{$}", handlePage(svc, defaultPage, svc.Now))
+	mux.HandleFunc("GET /widget.js", handleWidget(false))
+	mux.HandleFunc("GET /widget-"+WidgetVersion+".js", handleWidget(true))
+	if graphQLHandler != nil {
+		mux.Handle("POST /graphql", graphQLHandler(svc))
+	}
+	return mux
+}
+
+// graphQLHandler serves POST /graphql. It is only set in binaries built with
+// the graphql build tag, which keeps the GraphQL library out of the default
+// build; see graphql.go.
+var graphQLHandler func(svc *greeting.Service) http.Handler
+
+// handleGreet greets the name given in the "name" query parameter. Names
+// are sanitized, and those longer than the service's maximum length
+// truncated, before they are greeted or echoed back.
+func handleGreet(svc *greeting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, _ := greeting.TruncateName(greeting.SanitizeName(r.URL.Query().Get("name")), svc.MaxNameLength())
+		if name == "" {
+			name = "Gopher"
+		}
+		_, span := startSpan(r.Context(), "greeting.render")
+		text := svc.Greet(name)
+		span.End()
+		writeJSON(w, http.StatusOK, GreetResponse{Greeting: text, Name: name})
+	}
+}
+
+// handleGreetPost greets the person described by a GreetRequest body. Unlike
+// GET /greet it carries names of any script without URL encoding, takes the
+// language and style per request and rejects invalid requests with 400 Bad
+// Request and an error code instead of truncating or ignoring their fields.
+func handleGreetPost(svc *greeting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GreetRequest
+		if err := decodeJSON(w, r, &req, maxGreetRequestSize); err != nil {
+			writeRequestError(w, err)
+			return
+		}
+		lang, style, err := greetingOptions(svc, req.Lang, req.Style)
+		if err != nil {
+			writeRequestError(w, err)
+			return
+		}
+		name, err := greetingName(svc, req.Name)
+		if err != nil {
+			writeRequestError(w, err)
+			return
+		}
+
+		_, span := startSpan(r.Context(), "greeting.render")
+		text := svc.GreetIn(name, lang, style)
+		span.End()
+		writeJSON(w, http.StatusOK, GreetResponse{Greeting: text, Name: name, Lang: lang, Style: string(style)})
+	}
+}
+
+// requestError is a problem with a request that the client has to fix,
+// answered with status and an error code
+type requestError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *requestError) Error() string { return e.message }
+
+// badRequest returns a requestError answered with 400 Bad Request
+func badRequest(code, format string, args ...interface{}) *requestError {
+	return &requestError{status: http.StatusBadRequest, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// decodeJSON decodes the JSON body of r, at most limit bytes, into v.
+// Unknown fields and data after the JSON value are rejected.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}, limit int64) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("unexpected data after the JSON object")
+	}
+	var tooLarge *http.MaxBytesError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &tooLarge):
+		return &requestError{
+			status:  http.StatusRequestEntityTooLarge,
+			code:    CodeBodyTooLarge,
+			message: fmt.Sprintf("request body exceeds %d bytes", limit),
+		}
+	case strings.HasPrefix(err.Error(), "json: unknown field"):
+		return badRequest(CodeUnknownField, "%s", strings.TrimPrefix(err.Error(), "json: "))
+	default:
+		return badRequest(CodeInvalidJSON, "invalid JSON body: %s", strings.TrimPrefix(err.Error(), "json: "))
+	}
+}
+
+// greetingOptions validates the language and style of a request. Empty
+// values select the service's.
+func greetingOptions(svc *greeting.Service, lang, style string) (string, greeting.Style, error) {
+	parsedLang, parsedStyle := svc.Language(), svc.Style()
+	var err error
+	if lang != "" {
+		if parsedLang, err = greeting.ParseLanguage(lang); err != nil {
+			return "", "", badRequest(CodeUnsupportedLanguage, "%v", err)
+		}
+	}
+	if style != "" {
+		if parsedStyle, err = greeting.ParseStyle(style); err != nil {
+			return "", "", badRequest(CodeUnsupportedStyle, "%v", err)
+		}
+	}
+	return parsedLang, parsedStyle, nil
+}
+
+// greetingName sanitizes and validates the name of a request, which
+// defaults to Gopher
+func greetingName(svc *greeting.Service, name string) (string, error) {
+	name, truncated := greeting.TruncateName(greeting.SanitizeName(name), svc.MaxNameLength())
+	if truncated {
+		return "", badRequest(CodeNameTooLong, "name exceeds %d characters", svc.MaxNameLength())
+	}
+	if name == "" {
+		name = "Gopher"
+	}
+	return name, nil
+}
+
+// handleProverb returns a random proverb, or with "daily=true" the proverb
+// of the day for the date now returns. The proverb of the day may be cached
+// until midnight.
+func handleProverb(svc *greeting.Service, now func() time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if daily, _ := strconv.ParseBool(r.URL.Query().Get("daily")); daily {
+			today := now()
+			proverb, err := svc.DailyProverb(today)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to load proverbs")
+				return
+			}
+			maxAge := untilTomorrow(today)
+			w.Header().Set("Expires", today.Add(time.Duration(maxAge)*time.Second).UTC().Format(http.TimeFormat))
+			writeCachedJSON(w, r, proverb, "public, max-age="+strconv.Itoa(maxAge))
+			return
+		}
+
+		proverb, err := selectProverb(r.Context(), svc)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to load proverbs")
+			return
+		}
+		// Every request gets another proverb
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(w, http.StatusOK, proverb)
+	}
+}
+
+// selectProverb picks a random proverb from svc inside a span recording
+// the chosen proverb
+func selectProverb(ctx context.Context, svc *greeting.Service) (greeting.Proverb, error) {
+	_, span := startSpan(ctx, "proverb.select")
+	defer span.End()
+
+	proverb, err := svc.RandomEntry()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to load proverbs")
+		return proverb, err
+	}
+	span.SetAttributes(attribute.Int("proverb.id", proverb.ID))
+	return proverb, nil
+}
+
+// handleBadge returns a random proverb as a shields.io endpoint badge. The
+// optional "label" query parameter replaces the default label.
+func handleBadge(svc *greeting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		proverb, err := selectProverb(r.Context(), svc)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to load proverbs")
+			return
+		}
+
+		label := r.URL.Query().Get("label")
+		if label == "" {
+			label = badgeLabel
+		}
+		writeJSON(w, http.StatusOK, BadgeResponse{
+			SchemaVersion: 1,
+			Label:         label,
+			Message:       proverb.Text,
+			Color:         badgeColor,
+			CacheSeconds:  badgeCacheSeconds,
+		})
+	}
+}
+
+// handleHealth reports that the server is able to answer requests. It serves
+// the liveness check, so it doesn't depend on the proverbs or on draining: a
+// failing liveness check means "restart me".
+func handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// handleReady reports whether the server accepts new requests. It answers
+// 503 Service Unavailable with the status "starting" during the readiness
+// delay, "unavailable" while the proverbs can't be loaded and "draining"
+// once shutdown starts. Unlike /livez, a failing readiness check means
+// "send traffic elsewhere", not "restart me".
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	status := "ready"
+	switch {
+	case s.draining.Load():
+		status = "draining"
+	case time.Now().UnixNano() < s.readyAt.Load():
+		status = "starting"
+	default:
+		// Loads the proverbs on first use, and again after a failed reload
+		if _, err := s.svc.Proverbs(); err != nil {
+			status = "unavailable"
+		}
+	}
+	if status != "ready" {
+		writeJSON(w, http.StatusServiceUnavailable, HealthResponse{Status: status})
+		return
+	}
+	writeJSON(w, http.StatusOK, HealthResponse{Status: status})
+}
+
+// writeJSON writes v as the JSON response body with the given status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an ErrorResponse with the given status
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Error: message})
+}
+
+// writeRequestError writes err, a *requestError, as an ErrorResponse with its
+// status and error code
+func writeRequestError(w http.ResponseWriter, err error) {
+	var e *requestError
+	if !errors.As(err, &e) {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, e.status, ErrorResponse{Error: e.message, Code: e.code})
+}
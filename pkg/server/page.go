@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// PageTemplateName is the file a template directory must contain to replace
+// the page served at GET /
+const PageTemplateName = "index.html"
+
+//go:embed static/index.html
+var defaultPageSource string
+
+// defaultPage is the built-in template of the page served at GET /
+var defaultPage = template.Must(template.New(PageTemplateName).Parse(defaultPageSource))
+
+// PageData is passed to the template of the page served at GET /
+type PageData struct {
+	// Proverb is the proverb of the day
+	Proverb greeting.Proverb
+	// Date is the current time; the proverb changes at midnight
+	Date time.Time
+}
+
+// ParsePageTemplate parses the template of the page served at GET / from the
+// PageTemplateName file in fsys, such as os.DirFS of a --template-dir. The
+// template is executed with a PageData and may include other templates in
+// fsys with names ending in ".html".
+func ParsePageTemplate(fsys fs.FS) (*template.Template, error) {
+	if _, err := fs.Stat(fsys, PageTemplateName); err != nil {
+		return nil, err
+	}
+	return template.ParseFS(fsys, "*.html")
+}
+
+// WithPageTemplate replaces the built-in template of the page served at
+// GET /; see ParsePageTemplate
+func WithPageTemplate(tmpl *template.Template) Option {
+	return func(s *Server) {
+		s.page = tmpl
+	}
+}
+
+// handlePage renders the proverb of the day as an HTML page with tmpl. The
+// page may be cached until midnight.
+func handlePage(svc *greeting.Service, tmpl *template.Template, now func() time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		today := now()
+		proverb, err := svc.DailyProverb(today)
+		if err != nil {
+			http.Error(w, "failed to load proverbs", http.StatusInternalServerError)
+			return
+		}
+
+		// Render into a buffer, so a failing template yields a clean error
+		var page bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&page, PageTemplateName, PageData{Proverb: proverb, Date: today}); err != nil {
+			http.Error(w, "failed to render the page", http.StatusInternalServerError)
+			return
+		}
+
+		maxAge := untilTomorrow(today)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+		w.Header().Set("Expires", today.Add(time.Duration(maxAge)*time.Second).UTC().Format(http.TimeFormat))
+		_, _ = w.Write(page.Bytes())
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestPage(t *testing.T) {
+	svc := greeting.NewService()
+	now := time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)
+	want, _ := svc.DailyProverb(now)
+
+	rec := httptest.NewRecorder()
+	handlePage(svc, defaultPage, func() time.Time { return now }).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("GET / = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	for _, s := range []string{html.EscapeString(want.Text), "Saturday, October 17, 2026", "prefers-color-scheme: dark"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("page doesn't contain %q", s)
+		}
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=14400" {
+		t.Errorf("Cache-Control = %q, want the 4 hours until midnight", cc)
+	}
+}
+
+func TestPageServed(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "<blockquote>") {
+		t.Errorf("GET / = %d:\n%.200s", resp.StatusCode, body)
+	}
+
+	// Only the root is the page; other unknown paths stay 404
+	if resp := getJSON(t, ts.URL+"/nope", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /nope = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestPageTemplate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":  {Data: []byte(`{{template "title.html" .}}: {{.Proverb.Text}}`)},
+		"title.html":  {Data: []byte(`#{{.Proverb.ID}}`)},
+		"ignored.txt": {Data: []byte(`{{`)},
+	}
+	tmpl, err := ParsePageTemplate(fsys)
+	if err != nil {
+		t.Fatalf("ParsePageTemplate() error = %v", err)
+	}
+
+	svc := greeting.NewService()
+	ts := httptest.NewServer(New(svc, nil, WithPageTemplate(tmpl)).Handler())
+	t.Cleanup(ts.Close)
+
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	today, _ := svc.TodaysProverb()
+	if want := "#" + strconv.Itoa(today.ID) + ": " + html.EscapeString(today.Text); string(body) != want {
+		t.Errorf("GET / = %q, want %q", body, want)
+	}
+}
+
+func TestParsePageTemplateErrors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing index": {"page.html": {Data: []byte("hi")}},
+		"syntax error":  {"index.html": {Data: []byte("{{.Proverb")}},
+	}
+	for name, fsys := range tests {
+		if _, err := ParsePageTemplate(fsys); err == nil {
+			t.Errorf("%s: ParsePageTemplate() succeeded", name)
+		}
+	}
+}
+
+func TestPageTemplateExecutionError(t *testing.T) {
+	tmpl, err := ParsePageTemplate(fstest.MapFS{"index.html": {Data: []byte("{{.Nope}}")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	handlePage(greeting.NewService(), tmpl, time.Now).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "Nope") {
+		t.Errorf("broken template = %d %q, want a clean 500", rec.Code, rec.Body.String())
+	}
+}
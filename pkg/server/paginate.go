@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// Page sizes of GET /proverbs
+const (
+	DefaultPerPage = 50
+	MaxPerPage     = 100
+)
+
+// ProverbPage is the body returned by GET /proverbs: one page of the
+// proverbs matching the request's filters
+type ProverbPage struct {
+	Proverbs []greeting.Proverb `json:"proverbs"`
+	// Total is the number of matching proverbs on all pages
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	// Next is the relative URL of the next page, empty on the last page
+	Next string `json:"next,omitempty"`
+}
+
+// proverbQuery is the parsed query of GET /proverbs
+type proverbQuery struct {
+	filter  greeting.Filter
+	page    int
+	perPage int
+}
+
+// parseProverbQuery reads the tag, q, page and per_page parameters of a
+// GET /proverbs request
+func parseProverbQuery(query url.Values) (proverbQuery, error) {
+	pq := proverbQuery{
+		filter:  greeting.Filter{Tag: query.Get("tag"), Search: query.Get("q")},
+		page:    1,
+		perPage: DefaultPerPage,
+	}
+
+	if value := query.Get("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return pq, fmt.Errorf("invalid page %q: use a number from 1", value)
+		}
+		pq.page = page
+	}
+	if value := query.Get("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > MaxPerPage {
+			return pq, fmt.Errorf("invalid per_page %q: use a number from 1 to %d", value, MaxPerPage)
+		}
+		pq.perPage = perPage
+	}
+	return pq, nil
+}
+
+// paginate returns the page of proverbs selected by pq. The link to the next
+// page keeps the other parameters of query and is relative, so it also works
+// when the API is mounted under a prefix.
+func paginate(proverbs []greeting.Proverb, pq proverbQuery, query url.Values) ProverbPage {
+	page := ProverbPage{
+		Proverbs: []greeting.Proverb{},
+		Total:    len(proverbs),
+		Page:     pq.page,
+		PerPage:  pq.perPage,
+	}
+
+	// Pages past the end are empty; compare before multiplying so huge page
+	// numbers can't overflow
+	if pq.page-1 < (len(proverbs)+pq.perPage-1)/pq.perPage {
+		start := (pq.page - 1) * pq.perPage
+		end := min(start+pq.perPage, len(proverbs))
+		page.Proverbs = proverbs[start:end]
+		if end < len(proverbs) {
+			next := url.Values{}
+			for key, values := range query {
+				next[key] = values
+			}
+			next.Set("page", strconv.Itoa(pq.page+1))
+			page.Next = "?" + next.Encode()
+		}
+	}
+	return page
+}
+
+// handleProverbs returns a page of the proverbs matching the "tag" and "q"
+// (text search) parameters, with an ETag so clients can revalidate their
+// copy instead of downloading it again. The filters are the ones of the
+// proverb list command.
+func handleProverbs(svc *greeting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pq, err := parseProverbQuery(query)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proverbs, err := svc.FilterProverbs(pq.filter)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to load proverbs")
+			return
+		}
+
+		page := paginate(proverbs, pq, query)
+		if page.Next != "" {
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", page.Next))
+		}
+		writeCachedJSON(w, r, page, revalidate)
+	}
+}
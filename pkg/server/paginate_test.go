@@ -0,0 +1,95 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestProverbsPagination(t *testing.T) {
+	ts := newTestServer(t, nil)
+	all, err := greeting.NewService().Proverbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var first ProverbPage
+	resp := getJSON(t, ts.URL+"/proverbs?per_page=10", &first)
+	if first.Total != len(all) || first.Page != 1 || first.PerPage != 10 || len(first.Proverbs) != 10 {
+		t.Fatalf("first page = total %d, page %d, per_page %d, %d proverbs", first.Total, first.Page, first.PerPage, len(first.Proverbs))
+	}
+	if first.Next != "?page=2&per_page=10" {
+		t.Errorf("next = %q, want ?page=2&per_page=10", first.Next)
+	}
+	if link := resp.Header.Get("Link"); link != `<?page=2&per_page=10>; rel="next"` {
+		t.Errorf("Link = %q", link)
+	}
+
+	// Following the next links visits every proverb once, in order
+	var seen []greeting.Proverb
+	next := "?per_page=10"
+	for pages := 0; next != ""; pages++ {
+		if pages > len(all) {
+			t.Fatal("next links never end")
+		}
+		var page ProverbPage
+		getJSON(t, ts.URL+"/proverbs"+next, &page)
+		seen = append(seen, page.Proverbs...)
+		next = page.Next
+	}
+	if len(seen) != len(all) || seen[0].ID != all[0].ID || seen[len(seen)-1].ID != all[len(all)-1].ID {
+		t.Errorf("pages returned %d proverbs, want all %d in order", len(seen), len(all))
+	}
+
+	var past ProverbPage
+	getJSON(t, ts.URL+"/proverbs?page=1000000000000", &past)
+	if past.Proverbs == nil || len(past.Proverbs) != 0 || past.Next != "" || past.Total != len(all) {
+		t.Errorf("page past the end = %+v, want an empty page", past)
+	}
+}
+
+func TestProverbsFilters(t *testing.T) {
+	ts := newTestServer(t, nil)
+	svc := greeting.NewService()
+
+	tests := []struct {
+		query  string
+		filter greeting.Filter
+	}{
+		{"tag=concurrency", greeting.Filter{Tag: "concurrency"}},
+		{"q=interface", greeting.Filter{Search: "interface"}},
+		{"tag=concurrency&q=share", greeting.Filter{Tag: "concurrency", Search: "share"}},
+		{"tag=no-such-tag", greeting.Filter{Tag: "no-such-tag"}},
+	}
+	for _, tt := range tests {
+		want, err := svc.FilterProverbs(tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var page ProverbPage
+		getJSON(t, ts.URL+"/proverbs?"+tt.query, &page)
+		if page.Total != len(want) || len(page.Proverbs) != len(want) {
+			t.Errorf("GET /proverbs?%s = %d proverbs, want the %d of proverb list", tt.query, page.Total, len(want))
+		}
+	}
+
+	var page ProverbPage
+	getJSON(t, ts.URL+"/proverbs?tag=concurrency&per_page=1", &page)
+	if next, _ := url.ParseQuery(page.Next[1:]); next.Get("tag") != "concurrency" || next.Get("page") != "2" {
+		t.Errorf("next = %q, want it to keep the tag filter", page.Next)
+	}
+}
+
+func TestProverbsInvalidQuery(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	for _, query := range []string{"page=0", "page=x", "per_page=0", "per_page=101", "per_page=-5"} {
+		var body ErrorResponse
+		resp := getJSON(t, ts.URL+"/proverbs?"+query, &body)
+		if resp.StatusCode != http.StatusBadRequest || body.Error == "" {
+			t.Errorf("GET /proverbs?%s = %d %+v, want 400 with an error", query, resp.StatusCode, body)
+		}
+	}
+}
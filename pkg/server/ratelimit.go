@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate is a number of requests allowed per time period
+type Rate struct {
+	Requests int
+	Per      time.Duration
+}
+
+// String formats the rate the way ParseRate reads it
+func (r Rate) String() string {
+	switch r.Per {
+	case time.Second:
+		return fmt.Sprintf("%d/s", r.Requests)
+	case time.Minute:
+		return fmt.Sprintf("%d/m", r.Requests)
+	case time.Hour:
+		return fmt.Sprintf("%d/h", r.Requests)
+	}
+	return fmt.Sprintf("%d/%s", r.Requests, r.Per)
+}
+
+// ParseRate reads a rate such as "10/s", "100/m", "1000/h" or "5/30s"
+func ParseRate(s string) (Rate, error) {
+	count, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("invalid rate %q: use <requests>/<period>, for example 10/s", s)
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return Rate{}, fmt.Errorf("invalid rate %q: request count must be a positive number", s)
+	}
+
+	var per time.Duration
+	switch period {
+	case "s", "sec", "second":
+		per = time.Second
+	case "m", "min", "minute":
+		per = time.Minute
+	case "h", "hour":
+		per = time.Hour
+	default:
+		per, err = time.ParseDuration(period)
+		if err != nil || per <= 0 {
+			return Rate{}, fmt.Errorf("invalid rate %q: unknown period %q", s, period)
+		}
+	}
+	return Rate{Requests: n, Per: per}, nil
+}
+
+// bucket is the token bucket of a single client
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps one token bucket per client IP. Each bucket holds up to
+// rate.Requests tokens and refills continuously at rate.Requests per rate.Per.
+type rateLimiter struct {
+	rate Rate
+	now  func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// newRateLimiter returns a limiter allowing rate per client
+func newRateLimiter(rate Rate) *rateLimiter {
+	return &rateLimiter{rate: rate, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// allow takes a token from the bucket of key. If the bucket is empty it
+// reports how long the client has to wait for the next token.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	capacity := float64(l.rate.Requests)
+	perToken := l.rate.Per / time.Duration(l.rate.Requests)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.last))/float64(perToken))
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) * float64(perToken))
+}
+
+// sweep forgets clients whose buckets have refilled completely, at most
+// once per rate period, so the map does not grow without bound
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.rate.Per {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= l.rate.Per {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// limit rejects requests from clients that exceed the limiter's rate with
+// 429 Too Many Requests and a Retry-After header. Health checks are never
+// limited.
+func (l *rateLimiter) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/livez" || r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ok, wait := l.allow(clientIP(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, fmt.Sprintf("rate limit of %s exceeded", l.rate))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the IP address of the connection the request arrived on.
+// Forwarding headers are ignored because any client can set them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Rate
+		wantErr bool
+	}{
+		{"10/s", Rate{10, time.Second}, false},
+		{"100/m", Rate{100, time.Minute}, false},
+		{"1000/hour", Rate{1000, time.Hour}, false},
+		{"5/30s", Rate{5, 30 * time.Second}, false},
+		{"10", Rate{}, true},
+		{"0/s", Rate{}, true},
+		{"x/s", Rate{}, true},
+		{"10/fortnight", Rate{}, true},
+		{"10/-1s", Rate{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRate(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseRate(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	if s := (Rate{5, 30 * time.Second}).String(); s != "5/30s" {
+		t.Errorf("Rate.String() = %q", s)
+	}
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(Rate{Requests: 2, Per: time.Second})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allow("a"); !ok {
+			t.Fatalf("request %d within the burst was rejected", i+1)
+		}
+	}
+	ok, wait := l.allow("a")
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("third request = %v, wait %s; want rejected with 500ms wait", ok, wait)
+	}
+	if ok, _ := l.allow("b"); !ok {
+		t.Error("another client should have its own bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.allow("a"); !ok {
+		t.Error("bucket should refill one token after 500ms")
+	}
+
+	now = now.Add(time.Hour)
+	l.allow("c")
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("idle buckets should be swept")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	h := New(greeting.NewService(), nil, WithRateLimit(Rate{Requests: 1, Per: time.Minute})).Handler()
+
+	request := func(path, remote string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remote
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := request("/greet", "192.0.2.1:1234"); rec.Code != http.StatusOK {
+		t.Fatalf("first request status = %d", rec.Code)
+	}
+
+	rec := request("/proverb", "192.0.2.1:5678")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("second request status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+
+	if rec := request("/healthz", "192.0.2.1:1234"); rec.Code != http.StatusOK {
+		t.Errorf("health checks should not be limited, status = %d", rec.Code)
+	}
+	if rec := request("/greet", "198.51.100.7:1234"); rec.Code != http.StatusOK {
+		t.Errorf("other clients should not be limited, status = %d", rec.Code)
+	}
+}
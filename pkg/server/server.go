@@ -0,0 +1,263 @@
+// Package server exposes the greeting service over HTTP with JSON responses.
+//
+// Endpoints:
+//
+//	GET /                   the proverb of the day as an HTML page
+//	GET /greet?name=Alice   {"greeting": "Hello, Alice!", "name": "Alice"}
+//	POST /greet             the same for a {"name": "Alice", "lang": "de",
+//	                        "style": "formal"} body, reporting the language and
+//	                        style; invalid requests get 400 with an error code
+//	POST /greet:batch       an array of greetings for {"names": [...]}, with
+//	                        per-name errors; ?stream=true for NDJSON
+//	GET /proverb            a random proverb, ?daily=true for the proverb of the day
+//	GET /proverbs           the proverbs, filtered by ?tag and ?q, paginated by
+//	                        ?page and ?per_page, with an ETag
+//	GET /stream?interval=5s a random proverb every interval (Server-Sent Events)
+//	GET /badge              a random proverb as a shields.io endpoint badge
+//	GET /widget.js          a script embedding a rotating proverb in any page
+//	GET /livez              {"status": "ok"} while the process can answer requests
+//	GET /healthz            the same as /livez, for existing probes
+//	GET /readyz             {"status": "ready"}, or 503 while the server starts,
+//	                        can't load its proverbs or drains
+//	POST /admin/reload      reload the proverbs (requires a bearer token)
+//	POST /graphql           a GraphQL API (binaries built with -tags graphql)
+//
+// Example usage:
+//
+//	srv := server.New(greeting.NewService(), log.Default())
+//	err := srv.ListenAndServe(ctx, ":8080")
+//
+// Services that already run an HTTP server can mount the API with NewHandler.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShutdownTimeout is the default drain timeout, which bounds how long
+// in-flight requests may take to finish once the server is asked to stop
+const ShutdownTimeout = 10 * time.Second
+
+// readHeaderTimeout protects against clients that never finish their headers
+const readHeaderTimeout = 5 * time.Second
+
+// Server serves the greeting API
+type Server struct {
+	svc     *greeting.Service
+	logger  *log.Logger
+	limiter *rateLimiter
+	tokens  tokenSet
+
+	page           *template.Template
+	accessLog      *slog.Logger
+	tracerProvider trace.TracerProvider
+	apiStyle       APIStyle
+	naming         FieldNaming
+	maxBatchSize   int
+
+	drainDelay     time.Duration
+	drainTimeout   time.Duration
+	readinessDelay time.Duration
+	// readyAt is when the readiness delay ends, in Unix nanoseconds; zero
+	// when there is none
+	readyAt atomic.Int64
+	// draining is set once shutdown starts, failing the readiness check
+	draining atomic.Bool
+}
+
+// Option configures a Server created by New
+type Option func(*Server)
+
+// WithRateLimit limits every client IP to rate requests, answering excess
+// requests with 429 Too Many Requests
+func WithRateLimit(rate Rate) Option {
+	return func(s *Server) {
+		s.limiter = newRateLimiter(rate)
+	}
+}
+
+// WithAuthTokens enables the administrative endpoints under /admin/, which
+// require an "Authorization: Bearer <token>" header carrying one of tokens.
+// Read endpoints stay public. Without tokens the admin endpoints are not served.
+func WithAuthTokens(tokens ...string) Option {
+	return func(s *Server) {
+		s.tokens = append(s.tokens, newTokenSet(tokens)...)
+	}
+}
+
+// WithDrainDelay keeps serving requests for delay after the server is asked
+// to stop, while GET /readyz already answers 503 Service Unavailable. That
+// gives load balancers polling the readiness check time to stop sending new
+// requests before the listener closes.
+func WithDrainDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.drainDelay = delay
+	}
+}
+
+// WithDrainTimeout bounds how long in-flight requests may take to finish
+// once the listener is closed, instead of ShutdownTimeout. Requests still
+// running afterwards are cut off.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.drainTimeout = timeout
+	}
+}
+
+// WithReadinessDelay makes GET /readyz answer 503 Service Unavailable for
+// delay after the server starts serving, while GET /livez already succeeds.
+// During a rolling deployment that keeps traffic away from a new instance
+// until it has warmed up.
+func WithReadinessDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.readinessDelay = delay
+	}
+}
+
+// New returns a server answering requests with svc, or greeting.Default if
+// svc is nil. Requests are logged to logger; a nil logger disables request
+// logging.
+func New(svc *greeting.Service, logger *log.Logger, opts ...Option) *Server {
+	if svc == nil {
+		svc = greeting.Default()
+	}
+	s := &Server{svc: svc, logger: logger, drainTimeout: ShutdownTimeout, apiStyle: StylePlain, naming: NamingSnakeCase, maxBatchSize: DefaultMaxBatchSize}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewHandler returns the API endpoints for svc as a plain http.Handler
+// without request logging, so other services can mount the API on their own
+// mux. Mount it under a prefix with http.StripPrefix:
+//
+//	mux.Handle("/gopher/", http.StripPrefix("/gopher", server.NewHandler(svc)))
+//
+// A nil svc uses greeting.Default. If the service traces its requests with
+// OpenTelemetry, proverb selection and greeting rendering show up as child
+// spans of the request span.
+func NewHandler(svc *greeting.Service) http.Handler {
+	if svc == nil {
+		svc = greeting.Default()
+	}
+	return routes(svc)
+}
+
+// Handler returns the HTTP handler for the API, including request logging,
+// tracing, rate limiting, the API style, the readiness check and the
+// token-protected admin endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", NewHandler(s.svc))
+	mux.HandleFunc("GET /readyz", s.handleReady)
+	mux.Handle("POST /greet:batch", allowAnyOrigin(handleGreetBatch(s.svc, s.maxBatchSize)))
+	if s.page != nil {
+		mux.HandleFunc("GET /{$}", handlePage(s.svc, s.page, s.svc.Now))
+	}
+	if len(s.tokens) > 0 {
+		mux.Handle("/admin/", s.tokens.requireToken(adminRoutes(s.svc)))
+	}
+	var h http.Handler = mux
+	if s.limiter != nil {
+		h = s.limiter.limit(h)
+	}
+	if s.apiStyle != StylePlain || s.naming != NamingSnakeCase {
+		h = s.styleResponses(h)
+	}
+	switch {
+	case s.accessLog != nil:
+		h = logAccess(s.accessLog, h)
+	case s.logger != nil:
+		h = logRequests(s.logger, h)
+	}
+	// Tracing comes first, so the access log can record the trace ID
+	if s.tracerProvider != nil {
+		h = traceRequests(s.tracerProvider, h)
+	}
+	return h
+}
+
+// ListenAndServe listens on addr and serves the API until ctx is canceled,
+// then shuts down gracefully
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves the API on ln until ctx is canceled, then drains: the
+// readiness check fails at once, requests are still served for the drain
+// delay, and after the listener closes in-flight requests are given the
+// drain timeout to complete. Serve returns an error if requests had to be
+// cut off.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	return s.serve(ctx, ln, s.Handler())
+}
+
+// serve implements Serve with the handler h
+func (s *Server) serve(ctx context.Context, ln net.Listener, h http.Handler) error {
+	// Long-lived requests such as event streams watch the base context,
+	// which is canceled as soon as the listener closes
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
+	srv := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+	srv.RegisterOnShutdown(cancelBase)
+	if s.readinessDelay > 0 {
+		s.readyAt.Store(time.Now().Add(s.readinessDelay).UnixNano())
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.draining.Store(true)
+	srv.SetKeepAlivesEnabled(false)
+	if s.drainDelay > 0 {
+		select {
+		case err := <-errc:
+			return err
+		case <-time.After(s.drainDelay):
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("requests still running after the drain timeout of %s were cut off", s.drainTimeout)
+		}
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func newTestServer(t *testing.T, logger *log.Logger) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(New(greeting.NewService(greeting.WithSeed(1)), logger).Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func getJSON(t *testing.T, url string, v interface{}) *http.Response {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s: invalid JSON: %v", url, err)
+		}
+	}
+	return resp
+}
+
+func TestGreetEndpoint(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	tests := []struct {
+		query string
+		want  GreetResponse
+	}{
+		{"", GreetResponse{Greeting: "Hello, Gopher!", Name: "Gopher"}},
+		{"?name=Alice", GreetResponse{Greeting: "Hello, Alice!", Name: "Alice"}},
+		{"?name=Jos%C3%A9", GreetResponse{Greeting: "Hello, José!", Name: "José"}},
+		{"?name=%1B%5B31mAl%1B%5B0m%07%0Aice", GreetResponse{Greeting: "Hello, Al ice!", Name: "Al ice"}},
+	}
+
+	for _, tt := range tests {
+		var got GreetResponse
+		resp := getJSON(t, ts.URL+"/greet"+tt.query, &got)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET /greet%s status = %d", tt.query, resp.StatusCode)
+		}
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			t.Errorf("GET /greet%s Content-Type = %q", tt.query, ct)
+		}
+		if got != tt.want {
+			t.Errorf("GET /greet%s = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestGreetEndpointTruncatesLongNames(t *testing.T) {
+	ts := httptest.NewServer(New(greeting.NewService(greeting.WithMaxNameLength(5)), nil).Handler())
+	t.Cleanup(ts.Close)
+
+	var got GreetResponse
+	getJSON(t, ts.URL+"/greet?name="+strings.Repeat("a", 10000), &got)
+	if want := (GreetResponse{Greeting: "Hello, aaaa…!", Name: "aaaa…"}); got != want {
+		t.Errorf("GET /greet with a long name = %+v, want %+v", got, want)
+	}
+}
+
+func TestGreetPostEndpoint(t *testing.T) {
+	ts := httptest.NewServer(New(greeting.NewService(greeting.WithMaxNameLength(8)), nil).Handler())
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		body   string
+		status int
+		want   GreetResponse
+		code   string
+	}{
+		{`{"name":"Zoë","lang":"de","style":"formal"}`, http.StatusOK, GreetResponse{Greeting: "Guten Tag, Zoë.", Name: "Zoë", Lang: "de", Style: "formal"}, ""},
+		{`{"name":"李雷","lang":"pt-BR"}`, http.StatusOK, GreetResponse{Greeting: "Olá, 李雷!", Name: "李雷", Lang: "pt", Style: "friendly"}, ""},
+		{`{"name":"\u001b[2JAl\u0000"}`, http.StatusOK, GreetResponse{Greeting: "Hello, Al!", Name: "Al", Lang: "en", Style: "friendly"}, ""},
+		{`{}`, http.StatusOK, GreetResponse{Greeting: "Hello, Gopher!", Name: "Gopher", Lang: "en", Style: "friendly"}, ""},
+		{`{"name":`, http.StatusBadRequest, GreetResponse{}, CodeInvalidJSON},
+		{`{"name":42}`, http.StatusBadRequest, GreetResponse{}, CodeInvalidJSON},
+		{`{"name":"Al"} {}`, http.StatusBadRequest, GreetResponse{}, CodeInvalidJSON},
+		{`{"nmae":"Al"}`, http.StatusBadRequest, GreetResponse{}, CodeUnknownField},
+		{`{"name":"Alexandra"}`, http.StatusBadRequest, GreetResponse{}, CodeNameTooLong},
+		{`{"lang":"xx"}`, http.StatusBadRequest, GreetResponse{}, CodeUnsupportedLanguage},
+		{`{"style":"grumpy"}`, http.StatusBadRequest, GreetResponse{}, CodeUnsupportedStyle},
+		{`{"name":"` + strings.Repeat("a", 5000) + `"}`, http.StatusRequestEntityTooLarge, GreetResponse{}, CodeBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Post(ts.URL+"/greet", "application/json", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("POST /greet %.40s status = %d, want %d", tt.body, resp.StatusCode, tt.status)
+			continue
+		}
+		if tt.code != "" {
+			var e ErrorResponse
+			if err := json.Unmarshal(body, &e); err != nil || e.Code != tt.code || e.Error == "" {
+				t.Errorf("POST /greet %.40s = %s, want error code %s", tt.body, body, tt.code)
+			}
+			continue
+		}
+		var got GreetResponse
+		if err := json.Unmarshal(body, &got); err != nil || got != tt.want {
+			t.Errorf("POST /greet %s = %s, want %+v", tt.body, body, tt.want)
+		}
+	}
+}
+
+func TestProverbEndpoints(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	var proverb greeting.Proverb
+	getJSON(t, ts.URL+"/proverb", &proverb)
+	if proverb.ID == 0 || proverb.Text == "" {
+		t.Errorf("GET /proverb = %+v, want a proverb", proverb)
+	}
+
+	var page ProverbPage
+	getJSON(t, ts.URL+"/proverbs", &page)
+	if len(page.Proverbs) < 10 || page.Total < len(page.Proverbs) {
+		t.Errorf("GET /proverbs returned %d of %d proverbs", len(page.Proverbs), page.Total)
+	}
+
+	var health HealthResponse
+	getJSON(t, ts.URL+"/healthz", &health)
+	if health.Status != "ok" {
+		t.Errorf("GET /healthz = %+v", health)
+	}
+}
+
+func TestBadgeEndpoint(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	var badge BadgeResponse
+	getJSON(t, ts.URL+"/badge", &badge)
+	if badge.SchemaVersion != 1 || badge.Label != "go proverb" || badge.Message == "" {
+		t.Errorf("GET /badge = %+v, want a schema version 1 proverb badge", badge)
+	}
+
+	getJSON(t, ts.URL+"/badge?label=wisdom", &badge)
+	if badge.Label != "wisdom" {
+		t.Errorf("GET /badge?label=wisdom label = %q", badge.Label)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	req, _ := http.NewRequest(http.MethodPut, ts.URL+"/greet", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("PUT /greet status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestRequestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	ts := newTestServer(t, log.New(&buf, "", 0))
+
+	getJSON(t, ts.URL+"/greet?name=Log", nil)
+	resp := getJSON(t, ts.URL+"/missing", nil)
+
+	logs := buf.String()
+	if !strings.Contains(logs, "GET /greet?name=Log 200") {
+		t.Errorf("missing greet request in log:\n%s", logs)
+	}
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(logs, "GET /missing 404") {
+		t.Errorf("missing 404 request in log:\n%s", logs)
+	}
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- New(greeting.NewService(), log.New(io.Discard, "", 0)).Serve(ctx, ln)
+	}()
+
+	var health HealthResponse
+	getJSON(t, "http://"+ln.Addr().String()+"/healthz", &health)
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() returned %v after shutdown, want nil", err)
+		}
+	case <-time.After(ShutdownTimeout):
+		t.Fatal("Serve() did not return after the context was canceled")
+	}
+}
+
+func TestNewHandlerMountedUnderPrefix(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", NewHandler(greeting.NewService(greeting.WithLanguage("de")))))
+	mux.HandleFunc("/other", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "host route")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var greet GreetResponse
+	getJSON(t, ts.URL+"/api/greet?name=Ada", &greet)
+	if greet.Greeting != "Hallo, Ada!" {
+		t.Errorf("GET /api/greet = %+v, want the German greeting", greet)
+	}
+
+	var health HealthResponse
+	getJSON(t, ts.URL+"/api/healthz", &health)
+	if health.Status != "ok" {
+		t.Errorf("GET /api/healthz = %+v", health)
+	}
+
+	resp, err := http.Get(ts.URL + "/other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "host route" {
+		t.Errorf("host mux route = %q", body)
+	}
+}
+
+func TestNewHandlerNilService(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/greet", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Hello, Gopher!") {
+		t.Errorf("NewHandler(nil) /greet = %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * hello-gopher proverb widget
+ *
+ * Renders a rotating Go proverb into an element of the page:
+ *
+ *   <div id="go-proverb"></div>
+ *   <script src="https://gopher.example.com/widget.js" data-target="#go-proverb" async></script>
+ *
+ * Options, as attributes of the script tag:
+ *   data-target    CSS selector of the element to fill (default: insert after the script)
+ *   data-interval  seconds between proverbs, 0 to show just one (default: 30)
+ *   data-daily     "true" to show the proverb of the day instead
+ *
+ * The proverbs come from the JSON API next to this script, so the widget
+ * works wherever the API is mounted.
+ */
+(function () {
+  "use strict";
+
+  var script = document.currentScript;
+  if (!script) {
+    return;
+  }
+  var api = new URL(".", script.src);
+  var daily = script.getAttribute("data-daily") === "true";
+  var interval = parseInt(script.getAttribute("data-interval") || "30", 10);
+  var selector = script.getAttribute("data-target");
+
+  var target = selector ? document.querySelector(selector) : null;
+  if (!target) {
+    target = document.createElement("div");
+    script.parentNode.insertBefore(target, script.nextSibling);
+  }
+
+  var quote = document.createElement("blockquote");
+  quote.className = "go-proverb";
+  var text = document.createElement("p");
+  text.className = "go-proverb-text";
+  var footer = document.createElement("footer");
+  footer.className = "go-proverb-footer";
+  quote.appendChild(text);
+  quote.appendChild(footer);
+  target.appendChild(quote);
+
+  function show() {
+    fetch(new URL(daily ? "proverb?daily=true" : "proverb", api))
+      .then(function (resp) {
+        if (!resp.ok) {
+          throw new Error("HTTP " + resp.status);
+        }
+        return resp.json();
+      })
+      .then(function (proverb) {
+        // textContent keeps the proverb from being parsed as HTML
+        text.textContent = proverb.text;
+        footer.textContent = "Go Proverb #" + proverb.id;
+      })
+      .catch(function () {
+        // Keep the last proverb; the next rotation tries again
+      });
+  }
+
+  show();
+  if (!daily && interval > 0) {
+    setInterval(show, interval * 1000);
+  }
+})();
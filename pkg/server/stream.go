@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+// Limits for the interval query parameter of GET /stream
+const (
+	DefaultStreamInterval = 10 * time.Second
+	MinStreamInterval     = time.Second
+	MaxStreamInterval     = time.Hour
+)
+
+// handleStream pushes a random proverb to the client as a Server-Sent Event
+// right away and then once per interval until the client disconnects
+func handleStream(svc *greeting.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		interval, err := parseInterval(r.URL.Query().Get("interval"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		naming := requestNaming(r.Context())
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for n := 1; ; n++ {
+			proverb, err := selectProverb(r.Context(), svc)
+			if err != nil {
+				writeEvent(w, naming, n, "error", ErrorResponse{Error: "failed to load proverbs"})
+				_ = rc.Flush()
+				return
+			}
+			writeEvent(w, naming, n, "proverb", proverb)
+			if err := rc.Flush(); err != nil {
+				return
+			}
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// parseInterval reads a stream interval given as a Go duration ("30s") or a
+// number of seconds ("30"). An empty value selects DefaultStreamInterval.
+func parseInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultStreamInterval, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid interval %q: use a duration such as 30s", value)
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	if interval < MinStreamInterval || interval > MaxStreamInterval {
+		return 0, fmt.Errorf("interval must be between %s and %s", MinStreamInterval, MaxStreamInterval)
+	}
+	return interval, nil
+}
+
+// writeEvent writes v as a JSON-encoded Server-Sent Event with fields spelled
+// as naming asks
+func writeEvent(w http.ResponseWriter, naming FieldNaming, id int, event string, v interface{}) {
+	data, _ := json.Marshal(v)
+	data = renameEvent(data, naming)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data)
+}
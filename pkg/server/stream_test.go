@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", DefaultStreamInterval, false},
+		{"5s", 5 * time.Second, false},
+		{"2m", 2 * time.Minute, false},
+		{"30", 30 * time.Second, false},
+		{"500ms", 0, true},
+		{"0", 0, true},
+		{"2h", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseInterval(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseInterval(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseInterval(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// readEvent reads one Server-Sent Event and returns its fields
+func readEvent(t *testing.T, r *bufio.Reader) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading event: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return fields
+		}
+		key, value, _ := strings.Cut(line, ": ")
+		fields[key] = value
+	}
+}
+
+func TestStreamEndpoint(t *testing.T) {
+	ts := newTestServer(t, log.New(io.Discard, "", 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/stream?interval=1s", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	r := bufio.NewReader(resp.Body)
+	for _, wantID := range []string{"1", "2"} {
+		event := readEvent(t, r)
+		if event["id"] != wantID || event["event"] != "proverb" {
+			t.Errorf("event = %v, want proverb event %s", event, wantID)
+		}
+		var p greeting.Proverb
+		if err := json.Unmarshal([]byte(event["data"]), &p); err != nil || p.Text == "" {
+			t.Errorf("event data %q is not a proverb: %v", event["data"], err)
+		}
+	}
+}
+
+func TestStreamInvalidInterval(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	var body ErrorResponse
+	resp := getJSON(t, ts.URL+"/stream?interval=fast", &body)
+	if resp.StatusCode != http.StatusBadRequest || body.Error == "" {
+		t.Errorf("GET /stream?interval=fast = %d %+v, want 400 with an error", resp.StatusCode, body)
+	}
+}
+
+func TestShutdownEndsStreams(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- New(greeting.NewService(), nil).Serve(ctx, ln)
+	}()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/stream?interval=1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	readEvent(t, bufio.NewReader(resp.Body))
+
+	start := time.Now()
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Serve() = %v, want nil", err)
+	}
+	if elapsed := time.Since(start); elapsed > ShutdownTimeout/2 {
+		t.Errorf("shutdown waited %s for an open stream", elapsed)
+	}
+}
@@ -0,0 +1,289 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// APIStyle selects the shape of JSON response bodies
+type APIStyle string
+
+// Supported API styles
+const (
+	// StylePlain returns the payload as the body, as in {"greeting": ...}
+	StylePlain APIStyle = "plain"
+	// StyleEnveloped wraps every payload in {"data", "error", "meta"}:
+	//
+	//	{"data": {"greeting": "Hello, Alice!", "name": "Alice"}, "error": null, "meta": {"status": 200}}
+	//	{"data": null, "error": {"message": "rate limit of 10/s exceeded"}, "meta": {"status": 429}}
+	StyleEnveloped APIStyle = "enveloped"
+)
+
+// FieldNaming selects how the fields of JSON response bodies are spelled
+type FieldNaming string
+
+// Supported field namings
+const (
+	// NamingSnakeCase spells fields like per_page
+	NamingSnakeCase FieldNaming = "snake_case"
+	// NamingCamelCase spells fields like perPage
+	NamingCamelCase FieldNaming = "camelCase"
+)
+
+// ParseAPIStyle parses an API style name, "plain" or "enveloped"
+func ParseAPIStyle(s string) (APIStyle, error) {
+	switch style := APIStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case StylePlain, StyleEnveloped:
+		return style, nil
+	}
+	return "", fmt.Errorf("unsupported API style %q (supported: %s, %s)", s, StylePlain, StyleEnveloped)
+}
+
+// ParseFieldNaming parses a field naming, "snake_case" or "camelCase". The
+// short forms "snake" and "camel" are accepted, in any case.
+func ParseFieldNaming(s string) (FieldNaming, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "snake_case", "snake":
+		return NamingSnakeCase, nil
+	case "camelcase", "camel":
+		return NamingCamelCase, nil
+	}
+	return "", fmt.Errorf("unsupported field naming %q (supported: %s, %s)", s, NamingSnakeCase, NamingCamelCase)
+}
+
+// WithAPIStyle shapes the JSON responses of the API with style and spells
+// their fields with naming, so the API can follow the conventions of its
+// consumers. Server-Sent Events of GET /stream follow the naming but are
+// not enveloped. GET /badge and POST /graphql keep the formats their
+// clients expect. The default is StylePlain with NamingSnakeCase.
+func WithAPIStyle(style APIStyle, naming FieldNaming) Option {
+	return func(s *Server) {
+		s.apiStyle, s.naming = style, naming
+	}
+}
+
+// namingKey is the context key of the field naming of a request
+type namingKey struct{}
+
+// requestNaming returns the field naming of the request with ctx
+func requestNaming(ctx context.Context) FieldNaming {
+	if naming, ok := ctx.Value(namingKey{}).(FieldNaming); ok {
+		return naming
+	}
+	return NamingSnakeCase
+}
+
+// styleResponses reshapes the JSON responses of next as the server's API
+// style and field naming ask
+func (s *Server) styleResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/badge" || r.URL.Path == "/graphql" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &styleRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), namingKey{}, s.naming)))
+		if !rec.buffered {
+			return
+		}
+
+		body := rec.body.Bytes()
+		if s.naming == NamingCamelCase {
+			if renamed, err := renameFields(body, camelCase); err == nil {
+				body = renamed
+			}
+		}
+		if s.apiStyle == StyleEnveloped {
+			body = envelope(body, rec.status)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(body)
+	})
+}
+
+// styleRecorder holds back JSON responses so styleResponses can reshape
+// them. Other responses, such as pages and event streams, pass through.
+type styleRecorder struct {
+	http.ResponseWriter
+	wroteHeader bool
+	buffered    bool
+	status      int
+	body        bytes.Buffer
+}
+
+// WriteHeader holds back the status of JSON responses
+func (r *styleRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	if strings.HasPrefix(r.Header().Get("Content-Type"), "application/json") {
+		r.buffered, r.status = true, status
+		return
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write holds back the body of JSON responses
+func (r *styleRecorder) Write(p []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	if r.buffered {
+		return r.body.Write(p)
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController, so
+// streaming handlers can still flush
+func (r *styleRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// envelopeError is the error of an enveloped response
+type envelopeError struct {
+	Message json.RawMessage `json:"message"`
+	Code    json.RawMessage `json:"code,omitempty"`
+}
+
+// envelope wraps the JSON body of a response with status in
+// {"data", "error", "meta"}. An ErrorResponse becomes the error.
+func envelope(body []byte, status int) []byte {
+	data, errorValue := bytes.TrimSpace(body), []byte("null")
+	var e map[string]json.RawMessage
+	if status >= 400 && json.Unmarshal(data, &e) == nil && e["error"] != nil &&
+		(len(e) == 1 || len(e) == 2 && e["code"] != nil) {
+		errorValue, _ = json.Marshal(envelopeError{Message: e["error"], Code: e["code"]})
+		data = []byte("null")
+	}
+	return fmt.Appendf(nil, `{"data":%s,"error":%s,"meta":{"status":%d}}`+"\n", data, errorValue, status)
+}
+
+// camelCase spells a snake_case field such as per_page as perPage
+func camelCase(field string) string {
+	if !strings.Contains(field, "_") {
+		return field
+	}
+	var b strings.Builder
+	upper := false
+	for _, c := range field {
+		switch {
+		case c == '_':
+			upper = b.Len() > 0
+		case upper:
+			b.WriteRune(unicode.ToUpper(c))
+			upper = false
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// renameFields returns the JSON document data with every object key renamed
+// by rename, keeping the order of the keys
+func renameFields(data []byte, rename func(string) string) ([]byte, error) {
+	type container struct {
+		object bool
+		// first is set until the first element is written
+		first bool
+		// key is set when the next token of an object is a key
+		key bool
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out bytes.Buffer
+	var stack []container
+
+	// separate writes the comma before an array element or object member
+	separate := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := &stack[len(stack)-1]
+		if top.object && !top.key {
+			return
+		}
+		if !top.first {
+			out.WriteByte(',')
+		}
+		top.first = false
+	}
+	// valueDone expects the next key after an object member's value
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].key = true
+		}
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				separate()
+				out.WriteRune(rune(t))
+				stack = append(stack, container{object: t == '{', first: true, key: t == '{'})
+			default:
+				out.WriteRune(rune(t))
+				stack = stack[:len(stack)-1]
+				valueDone()
+			}
+			continue
+		case string:
+			if len(stack) > 0 && stack[len(stack)-1].object && stack[len(stack)-1].key {
+				separate()
+				key, _ := json.Marshal(rename(t))
+				out.Write(key)
+				out.WriteByte(':')
+				stack[len(stack)-1].key = false
+				continue
+			}
+		}
+
+		separate()
+		value, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(value)
+		valueDone()
+	}
+	if len(stack) > 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if bytes.HasSuffix(data, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// renameEvent returns the JSON data of an event spelled with naming
+func renameEvent(data []byte, naming FieldNaming) []byte {
+	if naming != NamingCamelCase {
+		return data
+	}
+	if renamed, err := renameFields(data, camelCase); err == nil {
+		return renamed
+	}
+	return data
+}
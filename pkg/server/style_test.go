@@ -0,0 +1,179 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func TestParseAPIStyle(t *testing.T) {
+	for value, want := range map[string]APIStyle{"plain": StylePlain, "Enveloped": StyleEnveloped, " plain ": StylePlain} {
+		if got, err := ParseAPIStyle(value); err != nil || got != want {
+			t.Errorf("ParseAPIStyle(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseAPIStyle("jsonapi"); err == nil {
+		t.Error("ParseAPIStyle(jsonapi) succeeded, want an error")
+	}
+}
+
+func TestParseFieldNaming(t *testing.T) {
+	tests := map[string]FieldNaming{
+		"snake_case": NamingSnakeCase,
+		"snake":      NamingSnakeCase,
+		"camelCase":  NamingCamelCase,
+		"camelcase":  NamingCamelCase,
+		"CAMEL":      NamingCamelCase,
+	}
+	for value, want := range tests {
+		if got, err := ParseFieldNaming(value); err != nil || got != want {
+			t.Errorf("ParseFieldNaming(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseFieldNaming("kebab-case"); err == nil {
+		t.Error("ParseFieldNaming(kebab-case) succeeded, want an error")
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"per_page":       "perPage",
+		"name":           "name",
+		"retry_after_ms": "retryAfterMs",
+		"_private":       "private",
+	}
+	for field, want := range tests {
+		if got := camelCase(field); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestRenameFields(t *testing.T) {
+	in := `{"per_page":20,"items":[{"tag_list":["a_b"],"ok":true},null,1.5e3],"next_page":{"page_no":2}}` + "\n"
+	want := `{"perPage":20,"items":[{"tagList":["a_b"],"ok":true},null,1.5e3],"nextPage":{"pageNo":2}}` + "\n"
+	got, err := renameFields([]byte(in), camelCase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("renameFields() = %s, want %s", got, want)
+	}
+	if _, err := renameFields([]byte(`{"broken":`), camelCase); err == nil {
+		t.Error("renameFields() of truncated JSON succeeded, want an error")
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	tests := []struct {
+		body   string
+		status int
+		want   string
+	}{
+		{`{"status": "ok"}`, 200, `{"data":{"status": "ok"},"error":null,"meta":{"status":200}}`},
+		{`[1,2]`, 200, `{"data":[1,2],"error":null,"meta":{"status":200}}`},
+		{`{"error":"not found"}`, 404, `{"data":null,"error":{"message":"not found"},"meta":{"status":404}}`},
+		{`{"error":"name exceeds 5 characters","code":"name_too_long"}`, 400, `{"data":null,"error":{"message":"name exceeds 5 characters","code":"name_too_long"},"meta":{"status":400}}`},
+		{`{"status":"draining"}`, 503, `{"data":{"status":"draining"},"error":null,"meta":{"status":503}}`},
+	}
+	for _, tt := range tests {
+		if got := strings.TrimSpace(string(envelope([]byte(tt.body+"\n"), tt.status))); got != tt.want {
+			t.Errorf("envelope(%s, %d) = %s, want %s", tt.body, tt.status, got, tt.want)
+		}
+	}
+}
+
+// newStyledServer serves the API with the given style and naming
+func newStyledServer(t *testing.T, style APIStyle, naming FieldNaming, opts ...Option) *httptest.Server {
+	t.Helper()
+	srv := New(greeting.NewService(), nil, append(opts, WithAPIStyle(style, naming))...)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestEnvelopedResponses(t *testing.T) {
+	ts := newStyledServer(t, StyleEnveloped, NamingSnakeCase, WithRateLimit(Rate{Requests: 1, Per: time.Minute}))
+
+	var ok struct {
+		Data  GreetResponse `json:"data"`
+		Error *struct{}     `json:"error"`
+		Meta  struct {
+			Status int `json:"status"`
+		} `json:"meta"`
+	}
+	resp := getJSON(t, ts.URL+"/greet?name=Alice", &ok)
+	if ok.Data.Greeting != "Hello, Alice!" || ok.Error != nil || ok.Meta.Status != http.StatusOK {
+		t.Errorf("GET /greet = %+v, want an enveloped greeting", ok)
+	}
+	if resp.Header.Get("Content-Length") == "" {
+		t.Error("GET /greet has no Content-Length")
+	}
+
+	var limited struct {
+		Data  json.RawMessage `json:"data"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Meta struct {
+			Status int `json:"status"`
+		} `json:"meta"`
+	}
+	resp = getJSON(t, ts.URL+"/greet?name=Bob", &limited)
+	if resp.StatusCode != http.StatusTooManyRequests || string(limited.Data) != "null" ||
+		limited.Error.Message == "" || limited.Meta.Status != http.StatusTooManyRequests {
+		t.Errorf("rate-limited GET /greet = %d %+v, want an enveloped 429 error", resp.StatusCode, limited)
+	}
+}
+
+func TestCamelCaseResponses(t *testing.T) {
+	ts := newStyledServer(t, StylePlain, NamingCamelCase)
+
+	var page map[string]json.RawMessage
+	resp := getJSON(t, ts.URL+"/proverbs?per_page=2", &page)
+	if _, ok := page["perPage"]; !ok {
+		t.Errorf("GET /proverbs fields = %v, want perPage", page)
+	}
+	if _, ok := page["per_page"]; ok {
+		t.Error("GET /proverbs still spells per_page")
+	}
+
+	// Revalidation still works, and 304 responses stay empty
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/proverbs?per_page=2", nil)
+	req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified || len(body) != 0 {
+		t.Errorf("revalidating GET /proverbs = %d with %q, want an empty 304", resp.StatusCode, body)
+	}
+}
+
+func TestStyleKeepsBadge(t *testing.T) {
+	ts := newStyledServer(t, StyleEnveloped, NamingCamelCase)
+
+	var badge map[string]interface{}
+	getJSON(t, ts.URL+"/badge", &badge)
+	if badge["schemaVersion"] != 1.0 || badge["data"] != nil {
+		t.Errorf("GET /badge = %v, want a shields.io badge", badge)
+	}
+}
+
+func TestRenameEvent(t *testing.T) {
+	data := []byte(`{"per_page":1}`)
+	if got := string(renameEvent(data, NamingCamelCase)); got != `{"perPage":1}` {
+		t.Errorf("renameEvent(camelCase) = %s", got)
+	}
+	if got := string(renameEvent(data, NamingSnakeCase)); got != string(data) {
+		t.Errorf("renameEvent(snake_case) = %s", got)
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans created by the server
+const tracerName = "github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/server"
+
+// WithTracerProvider traces every request with spans from tp, such as an
+// OpenTelemetry SDK provider exporting to a collector. Requests carrying a
+// W3C traceparent header continue the caller's trace. Proverb selection and
+// greeting rendering get child spans of their own.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(s *Server) {
+		s.tracerProvider = tp
+	}
+}
+
+// traceRequests wraps next with a server span for every request
+func traceRequests(tp trace.TracerProvider, next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "hello-gopher",
+		otelhttp.WithTracerProvider(tp),
+		otelhttp.WithPropagators(propagation.TraceContext{}),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+}
+
+// startSpan starts a span named name as a child of the request span in ctx.
+// Without tracing the span does nothing.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name)
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracedServer(t *testing.T, opts ...Option) (*httptest.Server, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { tp.Shutdown(t.Context()) })
+
+	srv := New(greeting.NewService(greeting.WithSeed(1)), nil, append(opts, WithTracerProvider(tp))...)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return ts, recorder
+}
+
+// spansByName indexes the ended spans of recorder by name
+func spansByName(recorder *tracetest.SpanRecorder) map[string]sdktrace.ReadOnlySpan {
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	return spans
+}
+
+func TestTracingProverbSelection(t *testing.T) {
+	ts, recorder := newTracedServer(t)
+
+	var proverb greeting.Proverb
+	getJSON(t, ts.URL+"/proverb", &proverb)
+
+	spans := spansByName(recorder)
+	request, ok := spans["GET /proverb"]
+	if !ok {
+		t.Fatalf("no request span, got %v", spans)
+	}
+	selection, ok := spans["proverb.select"]
+	if !ok {
+		t.Fatalf("no proverb.select span, got %v", spans)
+	}
+	if selection.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Error("proverb.select is not a child of the request span")
+	}
+	var id int64
+	for _, attr := range selection.Attributes() {
+		if attr.Key == "proverb.id" {
+			id = attr.Value.AsInt64()
+		}
+	}
+	if id != int64(proverb.ID) {
+		t.Errorf("proverb.id = %d, want %d", id, proverb.ID)
+	}
+}
+
+func TestTracingGreetingContinuesCallerTrace(t *testing.T) {
+	ts, recorder := newTracedServer(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/greet?name=Trace", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	spans := spansByName(recorder)
+	for _, name := range []string{"GET /greet", "greeting.render"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("no %s span, got %v", name, spans)
+			continue
+		}
+		if got := span.SpanContext().TraceID().String(); got != traceID {
+			t.Errorf("%s trace ID = %s, want the caller's %s", name, got, traceID)
+		}
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	ts, recorder := newTracedServer(t, WithAccessLog(slog.New(slog.NewJSONHandler(&buf, nil))))
+
+	getJSON(t, ts.URL+"/greet?name=Log", nil)
+	getJSON(t, ts.URL+"/missing", nil)
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid access log line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d access log records, want 2:\n%s", len(records), buf.String())
+	}
+
+	greet := records[0]
+	if greet["msg"] != "request" || greet["method"] != "GET" || greet["path"] != "/greet" ||
+		greet["query"] != "name=Log" || greet["status"] != float64(200) || greet["remote"] != "127.0.0.1" {
+		t.Errorf("unexpected greet record %v", greet)
+	}
+	if n, _ := greet["bytes"].(float64); n == 0 {
+		t.Errorf("greet record has no response size: %v", greet)
+	}
+	if want := spansByName(recorder)["GET /greet"].SpanContext().TraceID().String(); greet["trace_id"] != want {
+		t.Errorf("greet trace_id = %v, want %s", greet["trace_id"], want)
+	}
+	if records[1]["status"] != float64(404) {
+		t.Errorf("unexpected 404 record %v", records[1])
+	}
+}
+
+func TestStreamTraced(t *testing.T) {
+	ts, _ := newTracedServer(t)
+
+	resp, err := http.Get(ts.URL + "/stream?interval=1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	readEvent(t, bufio.NewReader(resp.Body))
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+// widgetJS is the proverb widget served at /widget.js
+//
+//go:embed static/widget.js
+var widgetJS []byte
+
+// WidgetVersion identifies the embedded widget: the start of its SHA-256
+// hash. The widget is also served at /widget-<version>.js, a URL that never
+// changes content and may therefore be cached forever.
+var WidgetVersion = func() string {
+	sum := sha256.Sum256(widgetJS)
+	return hex.EncodeToString(sum[:])[:12]
+}()
+
+// handleWidget serves the widget script. /widget.js is cached for an hour
+// and revalidated with its ETag; the versioned URL is immutable.
+func handleWidget(immutable bool) http.HandlerFunc {
+	etag := `"` + WidgetVersion + `"`
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("ETag", etag)
+		if immutable {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+			w.Header().Set("Content-Location", "widget-"+WidgetVersion+".js")
+		}
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(widgetJS)))
+		_, _ = w.Write(widgetJS)
+	}
+}
+
+// allowAnyOrigin lets scripts on other sites, such as the widget, read the
+// responses of next
+func allowAnyOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWidget(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	resp, err := http.Get(ts.URL + "/widget.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/javascript") {
+		t.Fatalf("GET /widget.js = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(string(body), "document.currentScript") {
+		t.Errorf("GET /widget.js returned an unexpected script:\n%.200s", body)
+	}
+
+	versioned := resp.Header.Get("Content-Location")
+	if versioned != "widget-"+WidgetVersion+".js" || len(WidgetVersion) != 12 {
+		t.Errorf("Content-Location = %q, want the versioned URL", versioned)
+	}
+	resp, err = http.Get(ts.URL + "/" + versioned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(pinned) != string(body) || !strings.Contains(resp.Header.Get("Cache-Control"), "immutable") {
+		t.Errorf("GET /%s = %q, want the same script cached forever", versioned, resp.Header.Get("Cache-Control"))
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/widget.js", nil)
+	req.Header.Set("If-None-Match", `"`+WidgetVersion+`"`)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("conditional GET /widget.js = %d, want 304", resp.StatusCode)
+	}
+}
+
+func TestReadEndpointsAllowAnyOrigin(t *testing.T) {
+	ts := newTestServer(t, nil)
+
+	for _, path := range []string{"/proverb", "/proverb?daily=true", "/proverbs", "/greet", "/badge"} {
+		resp := getJSON(t, ts.URL+path, nil)
+		if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("GET %s Access-Control-Allow-Origin = %q, want *", path, got)
+		}
+	}
+}
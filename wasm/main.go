@@ -0,0 +1,41 @@
+//go:build js && wasm
+
+// Command wasm exposes pkg/greeting to JavaScript for a browser demo of the
+// library. It registers two global functions:
+//
+//	greet(name)      returns a greeting, such as "Hello, Alice!"
+//	randomProverb()  returns a random Go proverb
+//
+// Build it and copy the JavaScript support file of your Go installation next
+// to index.html:
+//
+//	GOOS=js GOARCH=wasm go build -o wasm/hello-gopher.wasm ./wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" wasm/
+//
+// Then serve the wasm directory over HTTP, for example with
+// 'python3 -m http.server -d wasm', and open index.html.
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/louiellywton/go-portfolio/01-hello-gopher/pkg/greeting"
+)
+
+func main() {
+	service := greeting.NewService()
+
+	js.Global().Set("greet", js.FuncOf(func(this js.Value, args []js.Value) any {
+		name := ""
+		if len(args) > 0 && args[0].Type() == js.TypeString {
+			name = args[0].String()
+		}
+		return service.Greet(name)
+	}))
+	js.Global().Set("randomProverb", js.FuncOf(func(this js.Value, args []js.Value) any {
+		return service.RandomProverb()
+	}))
+
+	// The functions are only callable while the program runs
+	select {}
+}